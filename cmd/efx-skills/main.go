@@ -3,7 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/provider"
 	"github.com/lmarques/efx-skills/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -11,11 +15,33 @@ import (
 var version = "0.2.1"
 
 func main() {
+	flushOutput := func() {}
 	rootCmd := &cobra.Command{
 		Use:     "efx-skills",
 		Short:   "Unified AI agent skills manager",
 		Long:    `efx-skills is a TUI tool for discovering, previewing, installing, and managing AI agent skills across multiple providers.`,
 		Version: version,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+				// Only errors, on stderr, are left
+				tui.EnableQuiet()
+			}
+			if ascii, _ := cmd.Flags().GetBool("ascii"); tui.ASCIIMode(ascii) && !machineReadable(cmd) {
+				flushOutput = tui.EnableASCII()
+			}
+			workspace, _ := cmd.Flags().GetString("workspace")
+			if err := tui.LoadWorkspace(workspace); err != nil {
+				return err
+			}
+			// config validate reports problems itself
+			if cmd.Name() != "validate" {
+				if err := tui.CheckConfig(); err != nil {
+					return err
+				}
+			}
+			tui.LoadIgnoreRules()
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return tui.Run()
 		},
@@ -39,9 +65,13 @@ func main() {
 		Use:   "status",
 		Short: "Show provider status panel",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if plain, _ := cmd.Flags().GetBool("plain"); plain {
+				return tui.RunStatusPlain()
+			}
 			return tui.RunStatus()
 		},
 	}
+	statusCmd.Flags().Bool("plain", false, "Print the provider status and last sync results instead of opening the TUI")
 
 	// Preview command
 	previewCmd := &cobra.Command{
@@ -49,40 +79,381 @@ func main() {
 		Short: "Preview skill SKILL.md content",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Flags().GetBool("raw")
+			out, _ := cmd.Flags().GetString("out")
+			if raw || out != "" {
+				return tui.RunPreviewExport(args[0], out)
+			}
 			return tui.RunPreview(args[0])
 		},
 	}
+	previewCmd.Flags().Bool("raw", false, "Print the raw SKILL.md instead of opening the viewer")
+	previewCmd.Flags().String("out", "", "Write the raw SKILL.md to this file")
 
 	// Install command
 	installCmd := &cobra.Command{
-		Use:   "install <skill>",
-		Short: "Install skill to selected providers",
-		Args:  cobra.ExactArgs(1),
+		Use:   "install <owner/repo/skill[@version]>... | - | --tag <tag> <owner/repo>... | --pack <pack>",
+		Short: "Install skills to selected providers (- reads them from stdin)",
+		Args: func(cmd *cobra.Command, args []string) error {
+			pack, _ := cmd.Flags().GetString("pack")
+			listPacks, _ := cmd.Flags().GetBool("list-packs")
+			if pack != "" || listPacks {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			providers, _ := cmd.Flags().GetStringSlice("provider")
-			return tui.RunInstall(args[0], providers)
+			chooseVersion, _ := cmd.Flags().GetBool("choose-version")
+			branch, _ := cmd.Flags().GetString("branch")
+			if listPacks, _ := cmd.Flags().GetBool("list-packs"); listPacks {
+				return tui.RunListPacks()
+			}
+			if pack, _ := cmd.Flags().GetString("pack"); pack != "" {
+				if chooseVersion || branch != "" {
+					return fmt.Errorf("--choose-version and --branch cannot be used with --pack")
+				}
+				return tui.RunInstallPack(pack, providers)
+			}
+			if tag, _ := cmd.Flags().GetString("tag"); tag != "" {
+				if chooseVersion {
+					return fmt.Errorf("--choose-version cannot be used with --tag")
+				}
+				return tui.RunInstallTagged(args, tag, providers, branch)
+			}
+			return tui.RunInstallBatch(args, providers, chooseVersion, branch)
 		},
 	}
 	installCmd.Flags().StringSliceP("provider", "p", []string{}, "Target providers (claude, cursor, qoder, etc.)")
+	installCmd.Flags().Bool("choose-version", false, "Pick from the repository's tagged versions")
+	installCmd.Flags().String("branch", "", "Follow a branch (e.g. next, beta) instead of the default one")
+	installCmd.Flags().String("tag", "", "Install every skill with this frontmatter tag from the given repositories")
+	installCmd.Flags().String("pack", "", "Install a starter pack (go, frontend, docs, ...)")
+	installCmd.Flags().Bool("list-packs", false, "List the starter packs")
+
+	// Collections command
+	collectionsCmd := &cobra.Command{
+		Use:   "collections",
+		Short: "List curated skill collections published by the registries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunCollectionsList()
+		},
+	}
+	collectionInstallCmd := &cobra.Command{
+		Use:   "install <collection>",
+		Short: "Install every skill of a collection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			return tui.RunCollectionInstall(args[0], providers)
+		},
+	}
+	collectionInstallCmd.Flags().StringSliceP("provider", "p", []string{}, "Target providers (every configured provider when omitted)")
+	collectionsCmd.AddCommand(collectionInstallCmd)
+
+	// Topics command
+	topicsCmd := &cobra.Command{
+		Use:   "topics [topic]",
+		Short: "List skill topics from frontmatter tags and registry metadata, or the skills of one",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topic := ""
+			if len(args) > 0 {
+				topic = args[0]
+			}
+			return tui.RunTopics(topic)
+		},
+	}
+
+	// Enable and disable commands
+	enableCmd := &cobra.Command{
+		Use:   "enable [skill...]",
+		Short: "Link stored skills, or all those with a tag, into providers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tag, _ := cmd.Flags().GetString("tag")
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			return tui.RunEnable(args, tag, providers, true)
+		},
+	}
+	disableCmd := &cobra.Command{
+		Use:   "disable [skill...]",
+		Short: "Unlink skills, or all those with a tag, from providers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tag, _ := cmd.Flags().GetString("tag")
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			return tui.RunEnable(args, tag, providers, false)
+		},
+	}
+	for _, c := range []*cobra.Command{enableCmd, disableCmd} {
+		c.Flags().String("tag", "", "Act on every stored skill with this frontmatter tag")
+		c.Flags().StringSliceP("provider", "p", []string{}, "Target providers (every configured provider when omitted)")
+	}
+
+	// Link and unlink commands
+	linkCmd := &cobra.Command{
+		Use:   "link <skill>...",
+		Short: "Link stored skills into providers, as the manage view does",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			return tui.RunLink(args, providers, true)
+		},
+	}
+	unlinkCmd := &cobra.Command{
+		Use:   "unlink <skill>...",
+		Short: "Unlink skills from providers, as the manage view does",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			return tui.RunLink(args, providers, false)
+		},
+	}
+	for _, c := range []*cobra.Command{linkCmd, unlinkCmd} {
+		c.Flags().StringSliceP("provider", "p", []string{}, "Target providers, enabled first if needed (every configured provider when omitted)")
+	}
+
+	// Update command
+	updateCmd := &cobra.Command{
+		Use:   "update [skill...]",
+		Short: "Update skills from their tracked branch",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, _ := cmd.Flags().GetBool("all")
+			branch, _ := cmd.Flags().GetString("branch")
+			strategy, _ := cmd.Flags().GetString("strategy")
+			return tui.RunUpdate(args, all, branch, strategy)
+		},
+	}
+	updateCmd.Flags().Bool("all", false, "Update every skill with upstream changes")
+	updateCmd.Flags().String("branch", "", "Switch the named skills to this branch")
+	updateCmd.Flags().String("strategy", "", "For skills edited in the store: keep, upstream or save-local (asked when interactive)")
+
+	// Hold commands
+	holdCmd := &cobra.Command{
+		Use:   "hold <skill...>",
+		Short: "Freeze skills at their installed version so updates skip them",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunHold(args, true)
+		},
+	}
+	unholdCmd := &cobra.Command{
+		Use:   "unhold <skill...>",
+		Short: "Let held skills follow their branch again",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunHold(args, false)
+		},
+	}
+
+	// Remove command
+	removeCmd := &cobra.Command{
+		Use:   "remove <skill>",
+		Short: "Remove a skill from all providers, the store and the lock file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRemove(args[0])
+		},
+	}
+
+	// Dev link commands
+	linkDevCmd := &cobra.Command{
+		Use:   "link-dev <dir>",
+		Short: "Symlink a skill working directory into the store so edits are live in every provider",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			name, _ := cmd.Flags().GetString("name")
+			return tui.RunLinkDev(args[0], name, providers)
+		},
+	}
+	linkDevCmd.Flags().StringSliceP("provider", "p", []string{}, "Target providers (claude, cursor, qoder, etc.)")
+	linkDevCmd.Flags().String("name", "", "Skill name (default: the directory name)")
+
+	unlinkDevCmd := &cobra.Command{
+		Use:   "unlink-dev <skill>",
+		Short: "Detach a skill linked with link-dev, keeping its working directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunUnlinkDev(args[0])
+		},
+	}
+
+	devCmd := &cobra.Command{
+		Use:   "dev <dir>",
+		Short: "Watch a skill working directory, validating it and re-syncing its outputs on save",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			interval, _ := cmd.Flags().GetDuration("interval")
+			return tui.RunDev(args[0], providers, interval)
+		},
+	}
+	devCmd.Flags().StringSliceP("provider", "p", []string{}, "Target providers (claude, cursor, qoder, etc.)")
+	devCmd.Flags().Duration("interval", 500*time.Millisecond, "How often to check for changes")
+
+	// Prune command
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Review and delete dangling links, unmanaged provider entries and stale lock entries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			yes, _ := cmd.Flags().GetBool("yes")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			return tui.RunPrune(yes, dryRun)
+		},
+	}
+	pruneCmd.Flags().BoolP("yes", "y", false, "Delete everything found without asking")
+	pruneCmd.Flags().Bool("dry-run", false, "Only list what would be removed")
+
+	// Adopt command
+	adoptCmd := &cobra.Command{
+		Use:   "adopt [provider...]",
+		Short: "Move real skill directories from providers into the central store and link them back",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			return tui.RunAdopt(args, dryRun)
+		},
+	}
+	adoptCmd.Flags().Bool("dry-run", false, "Only list what would be adopted")
+
+	// Migrate command
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Import a setup created by the npx skills CLI (lock file, store, config)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lock, _ := cmd.Flags().GetString("lock")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			return tui.RunMigrate(lock, dryRun)
+		},
+	}
+	migrateCmd.Flags().String("lock", "", "Path to the skills CLI lock file (default ~/.agents/.skill-lock.json)")
+	migrateCmd.Flags().Bool("dry-run", false, "Show what would change without writing")
 
 	// List command
 	listCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List installed skills",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return tui.RunList()
+			if recent, _ := cmd.Flags().GetBool("recent"); recent {
+				return tui.RunListRecent()
+			}
+			if interactive, _ := cmd.Flags().GetBool("interactive"); interactive {
+				return tui.RunInstalled()
+			}
+			refresh, _ := cmd.Flags().GetBool("refresh-stats")
+			return tui.RunList(refresh)
+		},
+	}
+	listCmd.Flags().Bool("recent", false, "Order skills by their latest install or update")
+	listCmd.Flags().BoolP("interactive", "i", false, "Browse installed skills in the TUI to preview, update, unlink or uninstall them")
+	listCmd.Flags().Bool("refresh-stats", false, "Fetch installs and stars from the registries even if cached recently")
+
+	// Info command
+	infoCmd := &cobra.Command{
+		Use:   "info <skill>",
+		Short: "Show everything known about an installed skill",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asJSON, _ := cmd.Flags().GetBool("json")
+			return tui.RunInfo(args[0], asJSON)
+		},
+	}
+	infoCmd.Flags().Bool("json", false, "Print the details as JSON")
+
+	// SBOM command
+	sbomCmd := &cobra.Command{
+		Use:   "sbom",
+		Short: "Print an inventory of installed skills with sources, refs, hashes and licenses",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			out, _ := cmd.Flags().GetString("out")
+			return tui.RunSBOM(format, out, version)
 		},
 	}
+	sbomCmd.Flags().String("format", "cyclonedx", "Document format: cyclonedx or spdx")
+	sbomCmd.Flags().String("out", "", "Write the document to this file")
+
+	// Handler of efx-skills:// links
+	openURLCmd := &cobra.Command{
+		Use:   "open-url <efx-skills://install?source=owner/repo&skill=name>",
+		Short: "Confirm and install the skill an efx-skills:// link names",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunOpenURL(args[0])
+		},
+	}
+
+	// Stats command
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show estimated token usage and registry stats of skills per provider",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			refresh, _ := cmd.Flags().GetBool("refresh-stats")
+			return tui.RunStats(refresh)
+		},
+	}
+	statsCmd.Flags().Bool("refresh-stats", false, "Fetch installs and stars from the registries even if cached recently")
 
 	// Sync command
 	syncCmd := &cobra.Command{
 		Use:   "sync",
-		Short: "Sync skills across all providers",
+		Short: "Link stored skills, commands and agents into all enabled providers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if check, _ := cmd.Flags().GetBool("check"); check {
+				return tui.RunSyncCheck()
+			}
+			yes, _ := cmd.Flags().GetBool("yes")
+			return tui.RunSync(yes)
+		},
+	}
+	syncCmd.Flags().Bool("check", false, "Only report what is out of sync (exit code 3) without changing anything")
+	syncCmd.Flags().BoolP("yes", "y", false, "Apply the plan without showing it for confirmation")
+
+	// Diff command
+	diffCmd := &cobra.Command{
+		Use:   "diff <provider> <provider>",
+		Short: "Show skills one provider has and the other lacks, and copies that differ",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunDiff(args[0], args[1])
+		},
+	}
+
+	// Explain command
+	explainCmd := &cobra.Command{
+		Use:   "explain <provider>",
+		Short: "Explain why a provider is out of sync and how to fix each problem",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return tui.RunSync()
+			return tui.RunExplain(args[0])
 		},
 	}
 
+	// Parity command
+	parityCmd := &cobra.Command{
+		Use:   "parity",
+		Short: "List skills linked into some providers but not others",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			link, _ := cmd.Flags().GetBool("link")
+			return tui.RunParity(providers, link)
+		},
+	}
+	parityCmd.Flags().StringSliceP("provider", "p", []string{}, "Providers to compare (every configured provider when omitted)")
+	parityCmd.Flags().Bool("link", false, "Link each skill into the providers lacking it")
+
+	// Clone-provider command
+	cloneProviderCmd := &cobra.Command{
+		Use:   "clone-provider <from> <to>",
+		Short: "Link into a provider every skill linked in another one",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			prune, _ := cmd.Flags().GetBool("prune")
+			return tui.RunCloneProvider(args[0], args[1], prune)
+		},
+	}
+	cloneProviderCmd.Flags().Bool("prune", false, "Also remove skills the source provider does not have")
+
 	// Config command
 	configCmd := &cobra.Command{
 		Use:   "config",
@@ -91,6 +462,42 @@ func main() {
 			return tui.RunConfig()
 		},
 	}
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "validate",
+		Short: "Check config.json for unknown fields, wrong types and invalid values",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunConfigValidate()
+		},
+	})
+	discoverCmd := &cobra.Command{
+		Use:   "discover [topic...]",
+		Short: "Suggest skill repos from GitHub topics (claude-skills, agent-skills by default)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			add, _ := cmd.Flags().GetStringArray("add")
+			return tui.RunDiscoverRepos(args, add)
+		},
+	}
+	discoverCmd.Flags().StringArray("add", nil, "Add an owner/repo as a custom repo source after checking it has skills")
+	configCmd.AddCommand(discoverCmd)
+	restoreBackupCmd := &cobra.Command{
+		Use:   "restore-backup [n]",
+		Short: "List the timestamped backups of config.json, or roll back to the nth newest",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n := 0
+			if len(args) == 1 {
+				var err error
+				if n, err = strconv.Atoi(args[0]); err != nil {
+					return fmt.Errorf("expected a backup number, got %s", args[0])
+				}
+			}
+			lock, _ := cmd.Flags().GetBool("lock")
+			return tui.RunConfigRestoreBackup(n, lock)
+		},
+	}
+	restoreBackupCmd.Flags().Bool("lock", false, "Use the backups of the lock file instead")
+	configCmd.AddCommand(restoreBackupCmd)
 
 	// Doctor command
 	doctorCmd := &cobra.Command{
@@ -103,10 +510,494 @@ func main() {
 	}
 	doctorCmd.Flags().Bool("fix", false, "Automatically backfill legacy skill metadata")
 
-	rootCmd.AddCommand(searchCmd, statusCmd, previewCmd, installCmd, listCmd, syncCmd, configCmd, doctorCmd)
+	// Backup and restore commands
+	backupCmd := &cobra.Command{
+		Use:   "backup [file.tar.gz]",
+		Short: "Snapshot ~/.agents, config and provider links into a tar.gz",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output := ""
+			if len(args) > 0 {
+				output = args[0]
+			}
+			return tui.RunBackup(output)
+		},
+	}
 
-	if err := rootCmd.Execute(); err != nil {
+	restoreCmd := &cobra.Command{
+		Use:   "restore <file.tar.gz>",
+		Short: "Restore a backup and recreate its provider links",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			force, _ := cmd.Flags().GetBool("force")
+			return tui.RunRestore(args[0], force)
+		},
+	}
+	restoreCmd.Flags().Bool("force", false, "Restore over an existing, non-empty store")
+
+	// Compose command
+	composeCmd := &cobra.Command{
+		Use:   "compose",
+		Short: "Regenerate single-file instructions from the skills listed under \"compose\" in config",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunCompose()
+		},
+	}
+
+	// Slash commands
+	commandsCmd := newAssetCommand(provider.AssetCommands, "Manage slash commands (~/.claude/commands, .claude/commands)")
+
+	// Subagent definitions
+	agentsCmd := newAssetCommand(provider.AssetAgents, "Manage subagent definitions (~/.claude/agents, .claude/agents)")
+
+	rootCmd.AddCommand(searchCmd, statusCmd, previewCmd, installCmd, collectionsCmd, topicsCmd, enableCmd, disableCmd, linkCmd, unlinkCmd, updateCmd, holdCmd, unholdCmd, removeCmd, pruneCmd, adoptCmd, migrateCmd, listCmd, infoCmd, sbomCmd, statsCmd, syncCmd, diffCmd, explainCmd, parityCmd, cloneProviderCmd, configCmd, doctorCmd)
+	rootCmd.AddCommand(backupCmd, restoreCmd, composeCmd, commandsCmd, agentsCmd, newMCPCommand(), newProviderCommand(), newRegistryCommand(), newAuthCommand(), newRepoCommand(), newWorkspaceCommand())
+	rootCmd.AddCommand(openURLCmd, newURLHandlerCommand())
+	rootCmd.AddCommand(linkDevCmd, unlinkDevCmd, devCmd, newSkillCommand())
+	rootCmd.PersistentFlags().StringP("workspace", "w", "", "Workspace to use (default: the last one chosen with \"workspace use\")")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Print nothing but errors")
+	rootCmd.PersistentFlags().Bool("ascii", false, "Use ASCII symbols and basic colors (default when TERM is dumb)")
+
+	err := rootCmd.Execute()
+	flushOutput()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		if hint := errs.Hint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, "hint: "+hint)
+		}
+		os.Exit(tui.ExitCode(err))
+	}
+}
+
+// newAssetCommand builds the list/install/link/unlink command group for a
+// file-based asset type such as slash commands.
+func newAssetCommand(t provider.AssetType, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   string(t),
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunAssetList(t)
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List installed " + string(t),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunAssetList(t)
+		},
+	}
+
+	installCmd := &cobra.Command{
+		Use:   "install <owner/repo> [name...]",
+		Short: "Install " + string(t) + " published in a GitHub repo",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			project, _ := cmd.Flags().GetBool("project")
+			return tui.RunAssetInstall(t, args[0], args[1:], providers, project)
+		},
+	}
+
+	linkCmd := &cobra.Command{
+		Use:   "link <name>",
+		Short: "Link an installed entry into providers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			project, _ := cmd.Flags().GetBool("project")
+			return tui.RunAssetLink(t, args[0], providers, project)
+		},
+	}
+
+	unlinkCmd := &cobra.Command{
+		Use:   "unlink <name>",
+		Short: "Remove an entry from providers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			project, _ := cmd.Flags().GetBool("project")
+			return tui.RunAssetUnlink(t, args[0], providers, project)
+		},
+	}
+
+	for _, c := range []*cobra.Command{installCmd, linkCmd, unlinkCmd} {
+		c.Flags().StringSliceP("provider", "p", []string{}, "Target providers (default: all configured that support "+string(t)+")")
+		c.Flags().Bool("project", false, "Use the project folder in the current directory (e.g. .claude/"+string(t)+")")
+	}
+
+	cmd.AddCommand(listCmd, installCmd, linkCmd, unlinkCmd)
+	return cmd
+}
+
+// newWorkspaceCommand builds the command group managing named workspaces,
+// each with its own store, lock file and enabled providers.
+func newWorkspaceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "List, create and switch between separate skill stores",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunWorkspaceList()
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List workspaces and their stores",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunWorkspaceList()
+		},
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a workspace with its own store and config",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, _ := cmd.Flags().GetString("store")
+			return tui.RunWorkspaceCreate(args[0], store)
+		},
+	}
+	createCmd.Flags().String("store", "", "Store directory (default ~/.agents/workspaces/<name>/skills)")
+
+	useCmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Switch to a workspace for later runs (\"default\" is the original setup)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunWorkspaceUse(args[0])
+		},
+	}
+
+	cmd.AddCommand(listCmd, createCmd, useCmd)
+	return cmd
+}
+
+// newProviderCommand builds the command group enabling, disabling and
+// registering providers without the config view.
+func newProviderCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider",
+		Short: "List, enable, disable and register providers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunStatusPlain()
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List providers and their status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunStatusPlain()
+		},
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show a provider's path, link mode, skills and last sync",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunProviderShow(args[0])
+		},
+	}
+
+	enableCmd := &cobra.Command{
+		Use:   "enable <name>...",
+		Short: "Enable providers, creating their skills folder",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunProviderEnable(args, true)
+		},
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable <name>...",
+		Short: "Disable providers, leaving their skills in place",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunProviderEnable(args, false)
+		},
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Register a custom provider, or override a built-in one's path or link mode, and enable it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("path")
+			hook, _ := cmd.Flags().GetString("hook")
+			linkMode, _ := cmd.Flags().GetString("link-mode")
+			return tui.RunProviderAdd(args[0], path, hook, linkMode)
+		},
+	}
+	addCmd.Flags().String("path", "", "Skills folder, may use ~/, {home}, {xdg_config} and {project}")
+	addCmd.Flags().String("hook", "", "External command handling link, unlink and list instead of a folder")
+	addCmd.Flags().String("link-mode", "", "symlink (default), copy, hardlink or portable")
+
+	cmd.AddCommand(listCmd, showCmd, enableCmd, disableCmd, addCmd)
+	return cmd
+}
+
+// newRegistryCommand builds the command group listing, adding and
+// toggling the registries searched.
+func newRegistryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "List, add, enable and disable skill registries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRegistryList()
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List registries, whether each is searched, and registry plugins",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRegistryList()
+		},
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <name> <url>",
+		Short: "Add a custom registry endpoint serving the skills.sh or playbooks.com API",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiName, _ := cmd.Flags().GetString("api")
+			return tui.RunRegistryAdd(args[0], args[1], apiName)
+		},
+	}
+	addCmd.Flags().String("api", "", "API the endpoint serves: skills.sh (default) or playbooks.com")
+
+	enableCmd := &cobra.Command{
+		Use:   "enable <name>...",
+		Short: "Search registries again",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRegistryEnable(args, true)
+		},
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable <name>...",
+		Short: "Leave registries out of searches",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRegistryEnable(args, false)
+		},
+	}
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <name>...",
+		Short: "Remove registries from config.json",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRegistryRemove(args)
+		},
+	}
+
+	cmd.AddCommand(listCmd, addCmd, enableCmd, disableCmd, removeCmd)
+	return cmd
+}
+
+// newAuthCommand builds the command group keeping the GitHub and registry
+// tokens in the system keychain instead of config.json.
+func newAuthCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Keep GitHub and registry tokens in the system keychain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunAuthStatus()
+		},
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show where the GitHub and registry tokens are read from",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunAuthStatus()
+		},
+	}
+
+	setCmd := &cobra.Command{
+		Use:   "set <github|registry>",
+		Short: "Save a token in the keychain, read from a prompt or standard input",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunAuthSet(args[0], os.Stdin)
+		},
+	}
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <github|registry>",
+		Short: "Forget a token saved in the keychain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunAuthRemove(args[0])
+		},
+	}
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move plaintext tokens from config.json to the keychain",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunAuthMigrate()
+		},
+	}
+
+	cmd.AddCommand(statusCmd, setCmd, removeCmd, migrateCmd)
+	return cmd
+}
+
+// newRepoCommand builds the command group editing the custom GitHub repo
+// sources searched next to the registries.
+func newRepoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repo",
+		Short: "List, add and remove custom GitHub repo sources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRepoList()
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List custom repo sources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRepoList()
+		},
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <owner/repo>...",
+		Short: "Add repo sources after checking they exist and hold skills",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noCheck, _ := cmd.Flags().GetBool("no-check")
+			return tui.RunRepoAdd(args, !noCheck)
+		},
+	}
+	addCmd.Flags().Bool("no-check", false, "Add without checking GitHub, e.g. for private repos or offline")
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <owner/repo>...",
+		Short: "Remove repo sources",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunRepoRemove(args)
+		},
+	}
+
+	cmd.AddCommand(listCmd, addCmd, removeCmd)
+	return cmd
+}
+
+// newURLHandlerCommand builds the command group registering efx-skills
+// as the handler of efx-skills:// links, so web registries can offer
+// one-click installs.
+func newURLHandlerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "url-handler",
+		Short: "Register or remove the efx-skills:// link handler",
+	}
+
+	registerCmd := &cobra.Command{
+		Use:   "register",
+		Short: "Open efx-skills:// links with this binary (Linux and Windows)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunURLHandlerRegister()
+		},
+	}
+
+	unregisterCmd := &cobra.Command{
+		Use:   "unregister",
+		Short: "Stop handling efx-skills:// links",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunURLHandlerUnregister()
+		},
+	}
+
+	cmd.AddCommand(registerCmd, unregisterCmd)
+	return cmd
+}
+
+// newMCPCommand builds the command group managing MCP server definitions,
+// which are merged into provider config files rather than linked.
+func newMCPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Manage MCP servers across providers (~/.claude.json, ~/.cursor/mcp.json, ...)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunMCPList()
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored MCP servers and where they are enabled",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunMCPList()
+		},
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <name> -- <command> [args...]",
+		Short: "Store an MCP server and enable it in providers",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			env, _ := cmd.Flags().GetStringArray("env")
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			return tui.RunMCPAdd(args[0], args[1], args[2:], env, providers)
+		},
+	}
+	addCmd.Flags().StringArrayP("env", "e", []string{}, "Environment variable for the server (KEY=VALUE, repeatable)")
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Disable an MCP server (and delete it when no provider is given)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			providers, _ := cmd.Flags().GetStringSlice("provider")
+			return tui.RunMCPRemove(args[0], providers)
+		},
+	}
+
+	for _, c := range []*cobra.Command{addCmd, removeCmd} {
+		c.Flags().StringSliceP("provider", "p", []string{}, "Target providers (default: all configured that support MCP)")
+	}
+
+	cmd.AddCommand(listCmd, addCmd, removeCmd)
+	return cmd
+}
+
+// newSkillCommand builds the "skill" tools for skill authors.
+func newSkillCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skill",
+		Short: "Tools for checking skill content",
+	}
+
+	checkLinksCmd := &cobra.Command{
+		Use:   "check-links <dir|skill|owner/repo/skill>",
+		Short: "Report dead URLs and missing file references in a skill's markdown",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.RunCheckLinks(args[0])
+		},
+	}
+
+	cmd.AddCommand(checkLinksCmd)
+	return cmd
+}
+
+// machineReadable reports whether cmd prints JSON or a document meant for
+// other tools, which the ASCII filter must leave as written.
+func machineReadable(cmd *cobra.Command) bool {
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		return true
 	}
+	return cmd.Name() == "sbom"
 }
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/lmarques/efx-skills/internal/secrets"
+)
+
+// Requests to a registry and its mirrors carry a bearer token when one is
+// set for it, from the environment variable its "token" config setting
+// names or else the keychain (`efx-skills auth set <registry>`).
+var (
+	tokenMu          sync.Mutex
+	registryTokens   = make(map[string]string) // configured, by registry name
+	savedTokens      = make(map[string]string) // read from the keychain, "" when none
+	savedTokenLoaded = make(map[string]bool)
+)
+
+// savedRegistryToken reads the token saved for a registry in the keychain
+// or its encrypted file fallback. Tests replace it.
+var savedRegistryToken = func(registry string) string {
+	token, _, _ := secrets.Get(secrets.Registry(registry))
+	return token
+}
+
+// SetRegistryToken sets the token configured for registry from "$VAR", the
+// environment variable holding it. Other values are ignored: registry
+// tokens are never read as plaintext from config.json.
+func SetRegistryToken(registry, token string) {
+	if strings.HasPrefix(token, "$") {
+		token = os.Getenv(token[1:])
+	} else {
+		token = ""
+	}
+	tokenMu.Lock()
+	registryTokens[registry] = strings.TrimSpace(token)
+	tokenMu.Unlock()
+}
+
+// registryToken returns the token sent to registry, or "".
+func registryToken(registry string) string {
+	if registry == "" {
+		return ""
+	}
+	tokenMu.Lock()
+	defer tokenMu.Unlock()
+	if token := registryTokens[registry]; token != "" {
+		return token
+	}
+	// The keychain is only read once per registry and run
+	if !savedTokenLoaded[registry] {
+		savedTokens[registry], savedTokenLoaded[registry] = savedRegistryToken(registry), true
+	}
+	return savedTokens[registry]
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// TestMain keeps the tests away from the keychain of the machine running them.
+func TestMain(m *testing.M) {
+	keyring.MockInit()
+	os.Exit(m.Run())
+}
+
+func TestRegistryRequestsCarryToken(t *testing.T) {
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"skills":[]}`))
+	}))
+	defer srv.Close()
+	SetRegistries([]RegistryEndpoint{{Name: "corp", URL: srv.URL, Enabled: true}})
+	defer SetRegistries(nil)
+	orig := savedRegistryToken
+	savedRegistryToken = func(registry string) string {
+		if registry == "corp" {
+			return "saved"
+		}
+		return ""
+	}
+	defer func() { savedRegistryToken = orig }()
+	t.Setenv("CORP_TOKEN", "from-env")
+	defer func() {
+		SetRegistryToken("corp", "")
+		tokenMu.Lock()
+		clear(savedTokenLoaded)
+		tokenMu.Unlock()
+	}()
+
+	searchSkillsShAt(srv.URL, "corp", "lint", 5)
+	SetRegistryToken("corp", "$CORP_TOKEN")
+	searchSkillsShAt(srv.URL, "corp", "lint", 5)
+	SetRegistryToken("corp", "plaintext")
+	searchSkillsShAt(srv.URL, "corp", "lint", 5)
+
+	want := []string{"Bearer saved", "Bearer from-env", "Bearer saved"}
+	if len(auth) != 3 || auth[0] != want[0] || auth[1] != want[1] || auth[2] != want[2] {
+		t.Errorf("Authorization headers = %q, want %q", auth, want)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"sort"
+	"strings"
+)
+
+// catalogLimit is how many skills each registry is asked for when
+// gathering the topic directory.
+const catalogLimit = 200
+
+// Category is a topic with the skills tagged with it.
+type Category struct {
+	Name   string
+	Skills []Skill
+}
+
+// NormalizeTags lowercases tags and drops blanks and duplicates, keeping
+// the first occurrence order.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// GroupByTag builds the topic directory of skills: one category per tag,
+// largest first, then by name. Untagged skills are left out.
+func GroupByTag(skills []Skill) []Category {
+	byTag := make(map[string][]Skill)
+	for _, s := range skills {
+		for _, t := range NormalizeTags(s.Tags) {
+			byTag[t] = append(byTag[t], s)
+		}
+	}
+
+	cats := make([]Category, 0, len(byTag))
+	for name, list := range byTag {
+		cats = append(cats, Category{Name: name, Skills: list})
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		if len(cats[i].Skills) != len(cats[j].Skills) {
+			return len(cats[i].Skills) > len(cats[j].Skills)
+		}
+		return cats[i].Name < cats[j].Name
+	})
+	return cats
+}
+
+// TaggedCatalog lists the skills the registries describe with topics:
+// playbooks.com's catalog and whatever registry plugins return for an
+// empty query. skills.sh publishes no topics and is not asked. An error is
+// only returned when no registry answered.
+func TaggedCatalog() ([]Skill, error) {
+	skills, err := GetPlaybooksTrending(catalogLimit)
+	for _, plugin := range DiscoverRegistryPlugins() {
+		if found, perr := plugin.Search("", catalogLimit); perr == nil {
+			skills = append(skills, found...)
+			err = nil
+		}
+	}
+	if len(skills) == 0 && err != nil {
+		return nil, err
+	}
+	for i := range skills {
+		skills[i].Verified = IsVerifiedOwner(skills[i].Source)
+	}
+	return skills, nil
+}
@@ -0,0 +1,29 @@
+package api
+
+import "testing"
+
+func TestGroupByTag(t *testing.T) {
+	skills := []Skill{
+		{Name: "go-test", Tags: []string{"Testing", "golang"}},
+		{Name: "py-test", Tags: []string{"testing", " "}},
+		{Name: "docs", Tags: []string{"docs", "docs"}},
+		{Name: "untagged"},
+	}
+	cats := GroupByTag(skills)
+	if len(cats) != 3 {
+		t.Fatalf("GroupByTag = %+v, want 3 topics", cats)
+	}
+	if cats[0].Name != "testing" || len(cats[0].Skills) != 2 {
+		t.Errorf("first topic = %s with %d skills, want testing with 2", cats[0].Name, len(cats[0].Skills))
+	}
+	if cats[1].Name != "docs" || cats[2].Name != "golang" || len(cats[1].Skills) != 1 {
+		t.Errorf("topics = %s, %s; want docs then golang with one skill each", cats[1].Name, cats[2].Name)
+	}
+}
+
+func TestPlaybooksSkillTags(t *testing.T) {
+	s := PlaybooksSkill{Name: "lint", RepoOwner: "acme", RepoName: "tools", Category: "DevOps", Tags: []string{"ci", "devops"}}.toSkill()
+	if len(s.Tags) != 2 || s.Tags[0] != "devops" || s.Tags[1] != "ci" {
+		t.Errorf("Tags = %v, want [devops ci]", s.Tags)
+	}
+}
@@ -2,11 +2,17 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/skill"
 )
 
 // Client is the base HTTP client for API calls
@@ -25,27 +31,66 @@ func NewClient(baseURL string) *Client {
 	}
 }
 
-// Get performs a GET request
+// Get performs a GET request. A registry that cannot be reached, fails or
+// rate limits is retried on its mirrors in order; when all of them fail the
+// copy kept in the local mirror directory, if any, is returned instead.
+// Requests to registries count towards SessionStats.
 func (c *Client) Get(path string, params map[string]string) ([]byte, error) {
-	u, err := url.Parse(c.baseURL + path)
-	if err != nil {
-		return nil, err
-	}
-
-	q := u.Query()
+	q := url.Values{}
 	for k, v := range params {
 		q.Set(k, v)
 	}
+	mirror := mirrorPath(c.baseURL, path, q.Encode())
+
+	var firstErr error
+	for _, base := range baseURLs(c.baseURL) {
+		// Only the request that answers is timed, not the mirrors failing before it
+		start := time.Now()
+		data, err := c.get(base, path, q)
+		if err == nil {
+			recordRequest(registryName(c.baseURL), time.Since(start), nil)
+			saveMirror(mirror, data)
+			return data, nil
+		}
+		if !retriable(err) {
+			// The registry answered, e.g. that a skill does not exist
+			recordRequest(registryName(c.baseURL), time.Since(start), nil)
+			return nil, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	recordRequest(registryName(c.baseURL), 0, firstErr)
+	if data, ok := loadMirror(mirror); ok {
+		return data, nil
+	}
+	return nil, firstErr
+}
+
+// get requests path with query from one base URL.
+func (c *Client) get(base, path string, q url.Values) ([]byte, error) {
+	u, err := url.Parse(base + path)
+	if err != nil {
+		return nil, err
+	}
 	u.RawQuery = q.Encode()
 
-	resp, err := c.httpClient.Get(u.String())
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := registryToken(registryName(c.baseURL)); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %d %s", resp.StatusCode, resp.Status)
+		return nil, errs.FromResponse(resp, "API error: %s", resp.Status)
 	}
 
 	return io.ReadAll(resp.Body)
@@ -53,57 +98,146 @@ func (c *Client) Get(path string, params map[string]string) ([]byte, error) {
 
 // Skill represents a unified skill from any registry
 type Skill struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Source      string `json:"source"`
-	Description string `json:"description"`
-	Installs    int    `json:"installs"`
-	Stars       int    `json:"stars"`
-	Registry    string `json:"registry"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Source      string   `json:"source"`
+	Description string   `json:"description"`
+	Installs    int      `json:"installs"`
+	Stars       int      `json:"stars"`
+	Registry    string   `json:"registry"`
+	Path        string   `json:"path,omitempty"`     // folder inside the source repository, when known
+	Official    bool     `json:"official,omitempty"` // flagged official by the registry
+	Verified    bool     `json:"verified,omitempty"` // published by a well-known vendor account
+	Tags        []string `json:"tags,omitempty"`     // topics from registry metadata or SKILL.md frontmatter
+}
+
+// RegistryError is a registry that failed while searching; the other
+// registries' results are still returned.
+type RegistryError struct {
+	Registry string
+	Err      error
+}
+
+// Error reads like "playbooks.com: timeout".
+func (e RegistryError) Error() string {
+	var netErr net.Error
+	if errors.As(e.Err, &netErr) && netErr.Timeout() {
+		return e.Registry + ": timeout"
+	}
+	var urlErr *url.Error
+	if errors.As(e.Err, &urlErr) {
+		return e.Registry + ": " + urlErr.Err.Error()
+	}
+	return e.Registry + ": " + e.Err.Error()
 }
 
-// SearchAll searches all configured registries
+func (e RegistryError) Unwrap() error {
+	return e.Err
+}
+
+// SearchAll searches all configured registries, skipping the ones that fail
 func SearchAll(query string, limit int) ([]Skill, error) {
+	skills, _ := SearchRegistries(query, limit)
+	return skills, nil
+}
+
+// SearchRegistries searches all configured registries and reports each
+// registry that failed alongside the results of the others.
+func SearchRegistries(query string, limit int) ([]Skill, []RegistryError) {
 	var allSkills []Skill
+	var failures []RegistryError
 
-	// Search skills.sh
-	skillsShResults, err := SearchSkillsSh(query, limit)
-	if err == nil {
-		allSkills = append(allSkills, skillsShResults...)
+	// Search the enabled registries, skills.sh and playbooks.com by default
+	for _, r := range configuredRegistries() {
+		if !r.Enabled {
+			continue
+		}
+		results, err := r.search(query, limit)
+		if err != nil {
+			failures = append(failures, RegistryError{Registry: r.Name, Err: err})
+		}
+		allSkills = append(allSkills, results...)
 	}
 
-	// Search playbooks.com
-	playbooksResults, err := SearchPlaybooks(query, limit)
-	if err == nil {
-		allSkills = append(allSkills, playbooksResults...)
+	// Search external registry plugins found on PATH
+	for _, plugin := range DiscoverRegistryPlugins() {
+		start := time.Now()
+		pluginResults, err := plugin.Search(query, limit)
+		recordRequest(plugin.Name, time.Since(start), err)
+		if err != nil {
+			failures = append(failures, RegistryError{Registry: plugin.Name, Err: err})
+		}
+		allSkills = append(allSkills, pluginResults...)
 	}
 
-	// Deduplicate by name (prefer skills.sh for duplicates)
-	seen := make(map[string]bool)
+	// Deduplicate by name (prefer earlier registries), keeping an
+	// official flag reported by any registry
+	seen := make(map[string]int)
 	var unique []Skill
 	for _, s := range allSkills {
-		if !seen[s.Name] {
-			seen[s.Name] = true
-			unique = append(unique, s)
+		if i, ok := seen[s.Name]; ok {
+			if s.Official && s.Source == unique[i].Source {
+				unique[i].Official = true
+			}
+			continue
 		}
+		seen[s.Name] = len(unique)
+		s.Verified = IsVerifiedOwner(s.Source)
+		unique = append(unique, s)
+	}
+
+	return unique, failures
+}
+
+// LookupSkill finds a skill listed by registry under name, published from
+// source when it is known, to read its current installs and stars. An empty
+// registry tries skills.sh, then playbooks.com. A skill no registry lists
+// returns nil without an error.
+func LookupSkill(registry, name, source string) (*Skill, error) {
+	searches := map[string]func(string, int) ([]Skill, error){
+		"skills.sh":     SearchSkillsSh,
+		"playbooks.com": SearchPlaybooks,
+	}
+	registries := []string{registry}
+	if registry == "" {
+		registries = []string{"skills.sh", "playbooks.com"}
 	}
 
-	return unique, nil
+	for _, reg := range registries {
+		search, ok := searches[reg]
+		if !ok {
+			return nil, fmt.Errorf("no stats available from %s", reg)
+		}
+		results, err := search(name, 20)
+		if err != nil {
+			return nil, RegistryError{Registry: reg, Err: err}
+		}
+		for _, s := range results {
+			if s.Name == name && (source == "" || strings.EqualFold(s.Source, source)) {
+				return &s, nil
+			}
+		}
+	}
+	return nil, nil
 }
 
 // FetchSkillContent fetches SKILL.md content from GitHub
 func FetchSkillContent(owner, repo, skillPath string) (string, error) {
 	// Try common paths
 	paths := []string{
-		fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/%s/SKILL.md", owner, repo, skillPath),
-		fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/skills/%s/SKILL.md", owner, repo, skillPath),
-		fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/master/%s/SKILL.md", owner, repo, skillPath),
+		skill.RawURL(owner, repo, "main", skillPath+"/SKILL.md"),
+		skill.RawURL(owner, repo, "main", "skills/"+skillPath+"/SKILL.md"),
+		skill.RawURL(owner, repo, "master", skillPath+"/SKILL.md"),
 	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
 
 	for _, path := range paths {
-		resp, err := client.Get(path)
+		req, err := skill.NewGitHubRequest(path)
+		if err != nil {
+			continue
+		}
+		resp, err := client.Do(req)
 		if err != nil {
 			continue
 		}
@@ -118,7 +252,7 @@ func FetchSkillContent(owner, repo, skillPath string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("SKILL.md not found for %s/%s/%s", owner, repo, skillPath)
+	return "", errs.New(errs.NotFound, "SKILL.md not found for %s/%s/%s", owner, repo, skillPath)
 }
 
 // parseJSON is a helper to unmarshal JSON responses
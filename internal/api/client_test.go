@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "deadline exceeded" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+func TestRegistryErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&url.Error{Op: "Get", URL: "https://playbooks.com/api", Err: timeoutErr{}}, "playbooks.com: timeout"},
+		{&url.Error{Op: "Get", URL: "https://playbooks.com/api", Err: errors.New("connection refused")}, "playbooks.com: connection refused"},
+		{errors.New("API error: 503"), "playbooks.com: API error: 503"},
+	}
+	for _, tt := range tests {
+		if got := (RegistryError{Registry: "playbooks.com", Err: tt.err}).Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestLookupSkillMatchesSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"skills":[
+			{"id":"fork/lint","name":"lint","source":"fork/skills","installs":5},
+			{"id":"acme/lint","name":"lint","source":"acme/skills","installs":900}]}`))
+	}))
+	defer srv.Close()
+	old := skillsShBaseURL
+	skillsShBaseURL = srv.URL
+	defer func() { skillsShBaseURL = old }()
+
+	s, err := LookupSkill("skills.sh", "lint", "Acme/Skills")
+	if err != nil {
+		t.Fatalf("LookupSkill error: %v", err)
+	}
+	if s == nil || s.Installs != 900 {
+		t.Errorf("LookupSkill = %+v, want the acme/skills listing", s)
+	}
+
+	if s, err := LookupSkill("skills.sh", "lint", "other/repo"); err != nil || s != nil {
+		t.Errorf("LookupSkill for an unlisted source = %+v, %v; want nil", s, err)
+	}
+}
+
+func TestGetFallsBackToMirrors(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"skills":[{"id":"acme/lint","name":"lint","source":"acme/skills"}]}`))
+	}))
+	old := skillsShBaseURL
+	skillsShBaseURL = down.URL
+	defer func() { skillsShBaseURL = old }()
+	SetMirrors("skills.sh", []string{mirror.URL + "/"})
+	SetMirrorDir(t.TempDir())
+	defer SetMirrors("skills.sh", nil)
+	defer SetMirrorDir("")
+
+	if skills, err := SearchSkillsSh("lint", 5); err != nil || len(skills) != 1 {
+		t.Fatalf("search through the mirror = %+v, %v", skills, err)
+	}
+
+	// With every server down, the local mirror answers the same query
+	mirror.Close()
+	if skills, err := SearchSkillsSh("lint", 5); err != nil || len(skills) != 1 {
+		t.Fatalf("search from the local mirror = %+v, %v", skills, err)
+	}
+	if _, err := SearchSkillsSh("other", 5); err == nil {
+		t.Error("a query never answered should fail offline")
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Collection is a curated set of skills published by a registry, such as a
+// playbooks.com kit.
+type Collection struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Registry    string  `json:"registry"`
+	Skills      []Skill `json:"skills"`
+}
+
+// playbooksCollectionsResponse is the response of playbooks.com's
+// collections endpoint.
+type playbooksCollectionsResponse struct {
+	Success bool `json:"success"`
+	Data    []struct {
+		Slug        string           `json:"slug"`
+		Name        string           `json:"name"`
+		Description string           `json:"description"`
+		Skills      []PlaybooksSkill `json:"skills"`
+	} `json:"data"`
+}
+
+// toSkill converts a playbooks.com skill to the unified form.
+func (s PlaybooksSkill) toSkill() Skill {
+	source := s.RepoOwner
+	if s.RepoName != "" {
+		source = fmt.Sprintf("%s/%s", s.RepoOwner, s.RepoName)
+	}
+	return Skill{
+		ID:          s.SkillSlug,
+		Name:        s.Name,
+		Source:      source,
+		Description: s.ShortDescription,
+		Stars:       s.Stars,
+		Registry:    "playbooks.com",
+		Official:    s.IsOfficial,
+		Tags:        NormalizeTags(append([]string{s.Category}, s.Tags...)),
+	}
+}
+
+// GetPlaybooksCollections lists the collections published on playbooks.com.
+func GetPlaybooksCollections() ([]Collection, error) {
+	data, err := NewClient(playbooksBaseURL).Get("/api/collections", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response playbooksCollectionsResponse
+	if err := parseJSON(data, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("playbooks API returned success=false")
+	}
+
+	collections := make([]Collection, 0, len(response.Data))
+	for _, c := range response.Data {
+		col := Collection{ID: c.Slug, Name: c.Name, Description: c.Description, Registry: "playbooks.com"}
+		for _, s := range c.Skills {
+			col.Skills = append(col.Skills, s.toSkill())
+		}
+		collections = append(collections, col)
+	}
+	return collections, nil
+}
+
+// ListCollections gathers the starter packs and the collections of
+// playbooks.com and of registry plugins that publish some, ordered by
+// registry then name. An error is only returned when no registry answered.
+func ListCollections() ([]Collection, error) {
+	all := ListPacks()
+	var errs []error
+
+	if cols, err := GetPlaybooksCollections(); err == nil {
+		all = append(all, cols...)
+	} else {
+		errs = append(errs, err)
+	}
+	for _, plugin := range DiscoverRegistryPlugins() {
+		if cols, err := plugin.Collections(); err == nil {
+			all = append(all, cols...)
+		}
+	}
+
+	if len(all) == 0 && len(errs) > 0 {
+		return nil, errs[0]
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Registry != all[j].Registry {
+			return all[i].Registry < all[j].Registry
+		}
+		return all[i].Name < all[j].Name
+	})
+	return all, nil
+}
+
+// FindCollection returns the collection with the given ID, or one named
+// "registry/id" to tell apart collections of different registries.
+func FindCollection(collections []Collection, id string) (Collection, bool) {
+	for _, c := range collections {
+		if c.ID == id || c.Registry+"/"+c.ID == id {
+			return c, true
+		}
+	}
+	return Collection{}, false
+}
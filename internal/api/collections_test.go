@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+func TestGetPlaybooksCollections(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":[{"slug":"go-kit","name":"Go kit","description":"Go essentials",
+			"skills":[{"name":"go-style","repoOwner":"acme","repoName":"skills","skillSlug":"acme/skills/go-style"},
+			{"name":"go-test","repoOwner":"acme","repoName":"skills"}]}]}`))
+	}))
+	defer srv.Close()
+	old := playbooksBaseURL
+	playbooksBaseURL = srv.URL
+	defer func() { playbooksBaseURL = old }()
+
+	cols, err := GetPlaybooksCollections()
+	if err != nil {
+		t.Fatalf("GetPlaybooksCollections error: %v", err)
+	}
+	if len(cols) != 1 || cols[0].ID != "go-kit" || cols[0].Registry != "playbooks.com" || len(cols[0].Skills) != 2 {
+		t.Fatalf("collections = %+v", cols)
+	}
+	if s := cols[0].Skills[0]; s.Source != "acme/skills" || s.Name != "go-style" || s.Registry != "playbooks.com" {
+		t.Errorf("first skill = %+v", s)
+	}
+
+	if c, ok := FindCollection(cols, "playbooks.com/go-kit"); !ok || c.Name != "Go kit" {
+		t.Errorf("FindCollection(playbooks.com/go-kit) = %+v, %v", c, ok)
+	}
+	if _, ok := FindCollection(cols, "nope"); ok {
+		t.Error("FindCollection found an unknown collection")
+	}
+}
+
+func TestRegistryPluginCollections(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell plugins not supported on windows")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "acme", `read -r request
+echo '{"collections":[{"id":"starter","name":"Starter","skills":[{"name":"lint","source":"acme/tools"}]}]}'
+`)
+	t.Setenv("PATH", dir)
+
+	p := FindRegistryPlugin("acme")
+	if p == nil {
+		t.Fatal("FindRegistryPlugin(acme) = nil")
+	}
+	cols, err := p.Collections()
+	if err != nil {
+		t.Fatalf("Collections error: %v", err)
+	}
+	if len(cols) != 1 || cols[0].Registry != "acme" || cols[0].Skills[0].Registry != "acme" {
+		t.Fatalf("collections = %+v, want one tagged with the acme registry", cols)
+	}
+}
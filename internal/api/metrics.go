@@ -0,0 +1,87 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RegistryStats is what requests to one registry did during this session.
+type RegistryStats struct {
+	Registry string
+	Requests int
+	Failures int           // requests the registry and its mirrors did not answer
+	Latency  time.Duration // summed over the answered requests
+}
+
+// Average returns the mean latency of the answered requests.
+func (s RegistryStats) Average() time.Duration {
+	if answered := s.Requests - s.Failures; answered > 0 {
+		return s.Latency / time.Duration(answered)
+	}
+	return 0
+}
+
+// Summary reads like "180ms avg" or "180ms avg, 2 failures".
+func (s RegistryStats) Summary() string {
+	var parts []string
+	if s.Requests > s.Failures {
+		parts = append(parts, fmt.Sprintf("%dms avg", s.Average().Milliseconds()))
+	}
+	switch s.Failures {
+	case 0:
+	case 1:
+		parts = append(parts, "1 failure")
+	default:
+		parts = append(parts, fmt.Sprintf("%d failures", s.Failures))
+	}
+	return strings.Join(parts, ", ")
+}
+
+var (
+	statsMu sync.Mutex
+	stats   = make(map[string]*RegistryStats)
+)
+
+// recordRequest adds one request to a registry's session stats. Requests
+// to other APIs, with no registry name, are not tracked.
+func recordRequest(registry string, latency time.Duration, err error) {
+	if registry == "" {
+		return
+	}
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	s, ok := stats[registry]
+	if !ok {
+		s = &RegistryStats{Registry: registry}
+		stats[registry] = s
+	}
+	s.Requests++
+	if err != nil {
+		s.Failures++
+		return
+	}
+	s.Latency += latency
+}
+
+// SessionStats returns the stats of every registry requested during this
+// session, by name.
+func SessionStats() []RegistryStats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	list := make([]RegistryStats, 0, len(stats))
+	for _, s := range stats {
+		list = append(list, *s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Registry < list[j].Registry })
+	return list
+}
+
+// resetSessionStats forgets the stats recorded so far.
+func resetSessionStats() {
+	statsMu.Lock()
+	stats = make(map[string]*RegistryStats)
+	statsMu.Unlock()
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionStatsRecordsRequests(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"skills":[]}`))
+	}))
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	old := skillsShBaseURL
+	defer func() { skillsShBaseURL = old }()
+	resetSessionStats()
+	defer resetSessionStats()
+
+	skillsShBaseURL = up.URL
+	if _, err := SearchSkillsSh("lint", 5); err != nil {
+		t.Fatal(err)
+	}
+	skillsShBaseURL = down.URL
+	if _, err := SearchSkillsSh("lint", 5); err == nil {
+		t.Fatal("search against a down registry should fail")
+	}
+
+	stats := SessionStats()
+	if len(stats) != 1 || stats[0].Registry != "skills.sh" || stats[0].Requests != 2 || stats[0].Failures != 1 {
+		t.Fatalf("SessionStats = %+v, want 2 skills.sh requests with 1 failure", stats)
+	}
+	if summary := stats[0].Summary(); !strings.HasSuffix(summary, "ms avg, 1 failure") {
+		t.Errorf("Summary = %q", summary)
+	}
+}
+
+func TestRegistryStatsSummary(t *testing.T) {
+	cases := []struct {
+		stats RegistryStats
+		want  string
+	}{
+		{RegistryStats{Requests: 3, Latency: 540 * time.Millisecond}, "180ms avg"},
+		{RegistryStats{Requests: 2, Failures: 2}, "2 failures"},
+		{RegistryStats{Requests: 2, Failures: 1, Latency: 90 * time.Millisecond}, "90ms avg, 1 failure"},
+	}
+	for _, c := range cases {
+		if got := c.stats.Summary(); got != c.want {
+			t.Errorf("Summary(%+v) = %q, want %q", c.stats, got, c.want)
+		}
+	}
+}
+
+func TestSessionStatsTimeTheAnsweringMirror(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"skills":[]}`))
+	}))
+	defer mirror.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer slow.Close()
+	old := skillsShBaseURL
+	defer func() { skillsShBaseURL = old }()
+	skillsShBaseURL = slow.URL
+	SetMirrors("skills.sh", []string{mirror.URL})
+	defer SetMirrors("skills.sh", nil)
+	resetSessionStats()
+	defer resetSessionStats()
+
+	if _, err := SearchSkillsSh("lint", 5); err != nil {
+		t.Fatal(err)
+	}
+	stats := SessionStats()
+	if len(stats) != 1 || stats[0].Failures != 0 || stats[0].Latency >= delay {
+		t.Fatalf("SessionStats = %+v, want one answered request timed without the failed registry", stats)
+	}
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// Registries can have mirrors, base URLs serving the same API that are
+// tried in order when the registry fails, and every response can be kept in
+// a local mirror directory that answers when the registry and its mirrors
+// are all unreachable, e.g. on an air-gapped machine fed with a copy of
+// the directory.
+var (
+	mirrorMu  sync.RWMutex
+	mirrors   map[string][]string // base URLs by registry name
+	mirrorDir string              // "" keeps no local mirror
+)
+
+// SetMirrors sets the mirrors of registry ("skills.sh", "playbooks.com" or
+// a custom registry's name), tried in order when it fails.
+func SetMirrors(registry string, urls []string) {
+	mirrorMu.Lock()
+	defer mirrorMu.Unlock()
+	if mirrors == nil {
+		mirrors = make(map[string][]string)
+	}
+	var trimmed []string
+	for _, u := range urls {
+		if u = strings.TrimRight(strings.TrimSpace(u), "/"); u != "" {
+			trimmed = append(trimmed, u)
+		}
+	}
+	mirrors[registry] = trimmed
+}
+
+// SetMirrorDir sets the local mirror directory; "" turns it off.
+func SetMirrorDir(dir string) {
+	mirrorMu.Lock()
+	mirrorDir = dir
+	mirrorMu.Unlock()
+}
+
+// registryName returns the name of the registry served at baseURL, or ""
+// for other APIs.
+func registryName(baseURL string) string {
+	switch baseURL {
+	case skillsShBaseURL:
+		return "skills.sh"
+	case playbooksBaseURL:
+		return "playbooks.com"
+	}
+	return customRegistryName(baseURL)
+}
+
+// baseURLs lists baseURL followed by the mirrors of its registry.
+func baseURLs(baseURL string) []string {
+	mirrorMu.RLock()
+	defer mirrorMu.RUnlock()
+	return append([]string{baseURL}, mirrors[registryName(baseURL)]...)
+}
+
+// mirrorPath returns where the response to path with query is kept in the
+// local mirror, "" when there is none. Files are grouped by registry host
+// and path, so a mirror filled on one machine works on another.
+func mirrorPath(baseURL, path, query string) string {
+	mirrorMu.RLock()
+	dir := mirrorDir
+	mirrorMu.RUnlock()
+	if dir == "" {
+		return ""
+	}
+	host := registryName(baseURL)
+	if host == "" {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return ""
+		}
+		host = u.Host
+	}
+	name := "index"
+	if query != "" {
+		h := fnv.New64a()
+		h.Write([]byte(query))
+		name = fmt.Sprintf("%016x", h.Sum64())
+	}
+	return filepath.Join(dir, host, filepath.FromSlash(strings.Trim(path, "/")), name+".json")
+}
+
+// saveMirror keeps a response in the local mirror, renaming it into place
+// so readers never see half a file. Failing to is not an error of the
+// request.
+func saveMirror(file string, data []byte) {
+	if file == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		os.Remove(tmp)
+	}
+}
+
+// loadMirror reads a response kept in the local mirror.
+func loadMirror(file string) ([]byte, bool) {
+	if file == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(file)
+	return data, err == nil
+}
+
+// retriable reports whether a failed request should be tried on the next
+// mirror: the server could not be reached, failed or is rate limiting.
+// Answers like 404 are the same on every mirror.
+func retriable(err error) bool {
+	switch errs.KindOf(err) {
+	case errs.Network, errs.RateLimit:
+		return true
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
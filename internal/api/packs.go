@@ -0,0 +1,105 @@
+package api
+
+import (
+	"strings"
+	"sync"
+)
+
+// PacksRegistry is the registry name starter packs are listed under.
+const PacksRegistry = "efx-skills"
+
+// packsBaseURL serves packs.json, the maintained index of starter packs.
+var packsBaseURL = "https://raw.githubusercontent.com/lmarques/efx-skills/main"
+
+// packIndex is the format of packs.json. Skills are written as
+// "owner/repo/skill".
+type packIndex struct {
+	Packs []struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Skills      []string `json:"skills"`
+	} `json:"packs"`
+}
+
+// builtinPacks are used when the index cannot be fetched.
+var builtinPacks = []Collection{
+	newPack("go", "Go developer", "Test-driven development, debugging and planning for Go services",
+		"obra/superpowers/test-driven-development",
+		"obra/superpowers/systematic-debugging",
+		"obra/superpowers/writing-plans",
+		"anthropics/skills/mcp-builder",
+	),
+	newPack("frontend", "Frontend", "Interface design, browser testing and React practices",
+		"anthropics/skills/frontend-design",
+		"anthropics/skills/webapp-testing",
+		"vercel-labs/agent-skills/react-best-practices",
+		"vercel-labs/agent-skills/web-design-guidelines",
+	),
+	newPack("docs", "Docs & writing", "Documents, co-authoring and internal communication",
+		"anthropics/skills/doc-coauthoring",
+		"anthropics/skills/internal-comms",
+		"anthropics/skills/docx",
+		"anthropics/skills/pdf",
+	),
+}
+
+// newPack builds a starter pack from "owner/repo/skill" references.
+// References that do not name a skill are skipped.
+func newPack(id, name, description string, refs ...string) Collection {
+	c := Collection{ID: id, Name: name, Description: description, Registry: PacksRegistry}
+	for _, ref := range refs {
+		i := strings.LastIndex(ref, "/")
+		if i < 0 || strings.Count(ref, "/") != 2 {
+			continue
+		}
+		c.Skills = append(c.Skills, Skill{
+			Name:     ref[i+1:],
+			Source:   ref[:i],
+			Registry: "github",
+			Verified: IsVerifiedOwner(ref[:i]),
+		})
+	}
+	return c
+}
+
+// fetchedPacks holds the packs read from packsBaseURL, so a run asks for
+// the index once.
+var fetchedPacks struct {
+	sync.Mutex
+	url   string
+	packs []Collection
+}
+
+// ListPacks returns the starter packs from the maintained index, or the
+// built-in ones when it cannot be fetched.
+func ListPacks() []Collection {
+	fetchedPacks.Lock()
+	defer fetchedPacks.Unlock()
+	if fetchedPacks.packs == nil || fetchedPacks.url != packsBaseURL {
+		fetchedPacks.url, fetchedPacks.packs = packsBaseURL, fetchPacks()
+	}
+	return fetchedPacks.packs
+}
+
+// fetchPacks reads the index of starter packs.
+func fetchPacks() []Collection {
+	data, err := NewClient(packsBaseURL).Get("/packs.json", nil)
+	if err != nil {
+		return builtinPacks
+	}
+	var index packIndex
+	if err := parseJSON(data, &index); err != nil {
+		return builtinPacks
+	}
+	var packs []Collection
+	for _, p := range index.Packs {
+		if pack := newPack(p.ID, p.Name, p.Description, p.Skills...); p.ID != "" && len(pack.Skills) > 0 {
+			packs = append(packs, pack)
+		}
+	}
+	if len(packs) == 0 {
+		return builtinPacks
+	}
+	return packs
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListPacksFromIndex(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/packs.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"packs":[{"id":"rust","name":"Rust","skills":["acme/skills/clippy","not-a-ref"]},
+			{"id":"empty","name":"Empty","skills":[]}]}`))
+	}))
+	defer srv.Close()
+	old := packsBaseURL
+	packsBaseURL = srv.URL
+	defer func() { packsBaseURL = old }()
+
+	packs := ListPacks()
+	if len(packs) != 1 || packs[0].ID != "rust" || packs[0].Registry != PacksRegistry {
+		t.Fatalf("packs = %+v, want only the rust pack", packs)
+	}
+	if s := packs[0].Skills; len(s) != 1 || s[0].Name != "clippy" || s[0].Source != "acme/skills" {
+		t.Errorf("rust skills = %+v", s)
+	}
+	if ListPacks(); requests != 1 {
+		t.Errorf("index fetched %d times, want once per run", requests)
+	}
+}
+
+func TestListPacksFallsBackToBuiltIn(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusNotFound)
+	}))
+	defer srv.Close()
+	old := packsBaseURL
+	packsBaseURL = srv.URL
+	defer func() { packsBaseURL = old }()
+
+	packs := ListPacks()
+	for _, id := range []string{"go", "frontend", "docs"} {
+		if c, ok := FindCollection(packs, PacksRegistry+"/"+id); !ok || len(c.Skills) == 0 {
+			t.Errorf("built-in pack %s = %+v, %v", id, c, ok)
+		}
+	}
+}
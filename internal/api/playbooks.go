@@ -2,9 +2,11 @@ package api
 
 import (
 	"fmt"
+	"strings"
 )
 
-const playbooksBaseURL = "https://playbooks.com"
+// playbooksBaseURL is a variable so tests can point it at a local server.
+var playbooksBaseURL = "https://playbooks.com"
 
 // PlaybooksResponse represents the response from playbooks.com API
 type PlaybooksResponse struct {
@@ -14,21 +16,29 @@ type PlaybooksResponse struct {
 
 // PlaybooksSkill represents a skill from playbooks.com
 type PlaybooksSkill struct {
-	ID               int    `json:"id"`
-	Name             string `json:"name"`
-	Description      string `json:"description"`
-	ShortDescription string `json:"shortDescription"`
-	RepoOwner        string `json:"repoOwner"`
-	RepoName         string `json:"repoName"`
-	Path             string `json:"path"`
-	SkillSlug        string `json:"skillSlug"`
-	Stars            int    `json:"stars"`
-	IsOfficial       bool   `json:"isOfficial"`
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	ShortDescription string   `json:"shortDescription"`
+	RepoOwner        string   `json:"repoOwner"`
+	RepoName         string   `json:"repoName"`
+	Path             string   `json:"path"`
+	SkillSlug        string   `json:"skillSlug"`
+	Stars            int      `json:"stars"`
+	IsOfficial       bool     `json:"isOfficial"`
+	Category         string   `json:"category"`
+	Tags             []string `json:"tags"`
 }
 
 // SearchPlaybooks searches playbooks.com API
 func SearchPlaybooks(query string, limit int) ([]Skill, error) {
-	client := NewClient(playbooksBaseURL)
+	return searchPlaybooksAt(playbooksBaseURL, "playbooks.com", query, limit)
+}
+
+// searchPlaybooksAt searches a registry serving the playbooks.com API at
+// baseURL, tagging results with registry.
+func searchPlaybooksAt(baseURL, registry, query string, limit int) ([]Skill, error) {
+	client := NewClient(baseURL)
 
 	params := map[string]string{
 		"search": query,
@@ -51,19 +61,9 @@ func SearchPlaybooks(query string, limit int) ([]Skill, error) {
 
 	var skills []Skill
 	for _, s := range response.Data {
-		source := s.RepoOwner
-		if s.RepoName != "" {
-			source = fmt.Sprintf("%s/%s", s.RepoOwner, s.RepoName)
-		}
-
-		skills = append(skills, Skill{
-			ID:          s.SkillSlug,
-			Name:        s.Name,
-			Source:      source,
-			Description: s.ShortDescription,
-			Stars:       s.Stars,
-			Registry:    "playbooks.com",
-		})
+		skill := s.toSkill()
+		skill.Registry = registry
+		skills = append(skills, skill)
 	}
 
 	return skills, nil
@@ -89,20 +89,94 @@ func GetPlaybooksTrending(limit int) ([]Skill, error) {
 
 	var skills []Skill
 	for _, s := range response.Data {
-		source := s.RepoOwner
-		if s.RepoName != "" {
-			source = fmt.Sprintf("%s/%s", s.RepoOwner, s.RepoName)
-		}
-
-		skills = append(skills, Skill{
-			ID:          s.SkillSlug,
-			Name:        s.Name,
-			Source:      source,
-			Description: s.ShortDescription,
-			Stars:       s.Stars,
-			Registry:    "playbooks.com",
-		})
+		skills = append(skills, s.toSkill())
 	}
 
 	return skills, nil
 }
+
+// SkillDetails is the full record of one registry skill, with the long
+// description and metadata the list endpoints leave out.
+type SkillDetails struct {
+	Skill
+	License   string
+	UpdatedAt string
+	Sources   []SkillSource // every repository publishing the skill, when known
+}
+
+// SkillSource is one repository publishing a skill.
+type SkillSource struct {
+	Source   string
+	Path     string
+	Installs int
+}
+
+// PlaybooksSkillDetail is a skill from the playbooks.com detail endpoint.
+type PlaybooksSkillDetail struct {
+	PlaybooksSkill
+	License   string `json:"license"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+type playbooksDetailResponse struct {
+	Success bool                 `json:"success"`
+	Data    PlaybooksSkillDetail `json:"data"`
+}
+
+// GetPlaybooksSkill fetches the details of one playbooks.com skill by its
+// slug, e.g. "owner/repo/skill".
+func GetPlaybooksSkill(slug string) (*SkillDetails, error) {
+	data, err := NewClient(playbooksBaseURL).Get("/api/skills/"+strings.Trim(slug, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response playbooksDetailResponse
+	if err := parseJSON(data, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("playbooks API returned success=false")
+	}
+
+	d := response.Data
+	details := &SkillDetails{Skill: d.toSkill(), License: d.License, UpdatedAt: d.UpdatedAt}
+	if d.Description != "" {
+		details.Description = d.Description
+	}
+	details.Path = skillFolder(d.Path)
+	return details, nil
+}
+
+// skillFolder turns a registry path, which may name the SKILL.md itself,
+// into the skill's folder within its repository.
+func skillFolder(path string) string {
+	path = strings.TrimSuffix(strings.Trim(path, "/"), "SKILL.md")
+	path = strings.Trim(path, "/")
+	if path == "." {
+		return ""
+	}
+	return path
+}
+
+// FetchSkillDetails returns the full details of s from playbooks.com or
+// skills.sh, or the source and folder a registry plugin resolves it to.
+// Skills of other registries are returned as listed.
+func FetchSkillDetails(s Skill) (*SkillDetails, error) {
+	if s.ID != "" {
+		switch s.Registry {
+		case "playbooks.com":
+			return GetPlaybooksSkill(s.ID)
+		case "skills.sh":
+			return GetSkillsShSkill(s.ID)
+		}
+		if p := FindRegistryPlugin(s.Registry); p != nil {
+			source, path, err := p.Resolve(s.ID)
+			if err != nil {
+				return nil, err
+			}
+			s.Source, s.Path = source, path
+		}
+	}
+	return &SkillDetails{Skill: s}, nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPlaybooksSkill(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/skills/acme/skills/go-style" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"name":"go-style","repoOwner":"acme","repoName":"skills",
+			"skillSlug":"acme/skills/go-style","shortDescription":"Go style","description":"Go style rules, in full.",
+			"path":"tools/go-style/SKILL.md","stars":12,"license":"MIT","updatedAt":"2026-09-01"}}`))
+	}))
+	defer srv.Close()
+	old := playbooksBaseURL
+	playbooksBaseURL = srv.URL
+	defer func() { playbooksBaseURL = old }()
+
+	d, err := FetchSkillDetails(Skill{ID: "acme/skills/go-style", Name: "go-style", Registry: "playbooks.com"})
+	if err != nil {
+		t.Fatalf("FetchSkillDetails error: %v", err)
+	}
+	if d.Description != "Go style rules, in full." || d.Path != "tools/go-style" || d.Source != "acme/skills" {
+		t.Errorf("details = %+v", d.Skill)
+	}
+	if d.License != "MIT" || d.UpdatedAt != "2026-09-01" || d.Stars != 12 {
+		t.Errorf("metadata = %+v", d)
+	}
+}
+
+func TestFetchSkillDetailsOtherRegistries(t *testing.T) {
+	s := Skill{Name: "demo", Registry: "skills.sh", Description: "short"}
+	d, err := FetchSkillDetails(s)
+	if err != nil || d.Skill.Name != "demo" || d.Description != "short" {
+		t.Errorf("FetchSkillDetails = %+v, %v; want the skill as listed", d, err)
+	}
+}
+
+func TestSkillFolder(t *testing.T) {
+	for in, want := range map[string]string{
+		"skills/demo":          "skills/demo",
+		"/skills/demo/":        "skills/demo",
+		"skills/demo/SKILL.md": "skills/demo",
+		"SKILL.md":             "",
+		"":                     "",
+	} {
+		if got := skillFolder(in); got != want {
+			t.Errorf("skillFolder(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
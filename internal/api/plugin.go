@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// registryPluginPrefix is the executable name prefix for external registry plugins.
+// An executable named efx-skills-registry-acme on PATH becomes the "acme" registry.
+const registryPluginPrefix = "efx-skills-registry-"
+
+// pluginTimeout bounds a single plugin invocation, matching the HTTP client timeout.
+var pluginTimeout = 10 * time.Second
+
+// RegistryPlugin is an external registry implemented by an executable that
+// speaks a JSON protocol over stdio: one PluginRequest on stdin, one
+// PluginResponse on stdout.
+type RegistryPlugin struct {
+	Name string
+	Path string
+}
+
+// PluginRequest is written as JSON to a plugin's stdin.
+type PluginRequest struct {
+	Action string `json:"action"` // "search" | "resolve" | "collections"
+	Query  string `json:"query,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	ID     string `json:"id,omitempty"`
+}
+
+// PluginResponse is read as JSON from a plugin's stdout.
+type PluginResponse struct {
+	Skills      []Skill      `json:"skills,omitempty"`
+	Collections []Collection `json:"collections,omitempty"`
+	Source      string       `json:"source,omitempty"`
+	SkillPath   string       `json:"skillPath,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// DiscoverRegistryPlugins scans PATH for executables named
+// efx-skills-registry-<name>. The first match for a given name wins,
+// following normal PATH precedence.
+func DiscoverRegistryPlugins() []RegistryPlugin {
+	seen := make(map[string]bool)
+	var plugins []RegistryPlugin
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasPrefix(e.Name(), registryPluginPrefix) {
+				continue
+			}
+			name := strings.TrimPrefix(e.Name(), registryPluginPrefix)
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+			if name == "" || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			info, err := os.Stat(path)
+			if err != nil || info.Mode()&0111 == 0 {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, RegistryPlugin{Name: name, Path: path})
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// call runs the plugin with a single request and decodes its response.
+func (p RegistryPlugin) call(req PluginRequest) (*PluginResponse, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("registry plugin %s: %w: %s", p.Name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp PluginResponse
+	if err := parseJSON(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("registry plugin %s: invalid response: %w", p.Name, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("registry plugin %s: %s", p.Name, resp.Error)
+	}
+	return &resp, nil
+}
+
+// Search asks the plugin for skills matching query. Results are tagged with
+// the plugin's name as their registry.
+func (p RegistryPlugin) Search(query string, limit int) ([]Skill, error) {
+	resp, err := p.call(PluginRequest{Action: "search", Query: query, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	skills := make([]Skill, 0, len(resp.Skills))
+	for _, s := range resp.Skills {
+		s.Registry = p.Name
+		skills = append(skills, s)
+	}
+	return skills, nil
+}
+
+// Resolve asks the plugin to turn a skill ID into an installable source
+// (owner/repo) and the skill's path within that source.
+func (p RegistryPlugin) Resolve(id string) (source, skillPath string, err error) {
+	resp, err := p.call(PluginRequest{Action: "resolve", ID: id})
+	if err != nil {
+		return "", "", err
+	}
+	if resp.Source == "" {
+		return "", "", fmt.Errorf("registry plugin %s: no source for %q", p.Name, id)
+	}
+	return resp.Source, resp.SkillPath, nil
+}
+
+// Collections asks the plugin for the skill collections it publishes.
+// Plugins without collections answer with none.
+func (p RegistryPlugin) Collections() ([]Collection, error) {
+	resp, err := p.call(PluginRequest{Action: "collections"})
+	if err != nil {
+		return nil, err
+	}
+	cols := make([]Collection, 0, len(resp.Collections))
+	for _, c := range resp.Collections {
+		c.Registry = p.Name
+		for i := range c.Skills {
+			c.Skills[i].Registry = p.Name
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+// FindRegistryPlugin returns the discovered plugin with the given name, or nil.
+func FindRegistryPlugin(name string) *RegistryPlugin {
+	for _, p := range DiscoverRegistryPlugins() {
+		if p.Name == name {
+			p := p
+			return &p
+		}
+	}
+	return nil
+}
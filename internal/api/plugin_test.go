@@ -0,0 +1,100 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// writePlugin installs a shell-script registry plugin into dir.
+func writePlugin(t *testing.T, dir, name, script string) {
+	t.Helper()
+	path := filepath.Join(dir, registryPluginPrefix+name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("write plugin: %v", err)
+	}
+}
+
+func TestDiscoverRegistryPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell plugins not supported on windows")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "acme", "exit 0\n")
+	// Non-executable files are ignored
+	os.WriteFile(filepath.Join(dir, registryPluginPrefix+"broken"), []byte("x"), 0644)
+	t.Setenv("PATH", dir)
+
+	plugins := DiscoverRegistryPlugins()
+	if len(plugins) != 1 {
+		t.Fatalf("DiscoverRegistryPlugins() = %v, want 1 plugin", plugins)
+	}
+	if plugins[0].Name != "acme" {
+		t.Errorf("plugin Name = %q, want %q", plugins[0].Name, "acme")
+	}
+}
+
+func TestRegistryPluginSearch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell plugins not supported on windows")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "acme", `read -r request
+echo '{"skills":[{"id":"acme/tools/lint","name":"lint","source":"acme/tools","installs":3}]}'
+`)
+	t.Setenv("PATH", dir)
+
+	p := FindRegistryPlugin("acme")
+	if p == nil {
+		t.Fatal("FindRegistryPlugin(acme) = nil")
+	}
+	skills, err := p.Search("lint", 10)
+	if err != nil {
+		t.Fatalf("Search error: %v", err)
+	}
+	if len(skills) != 1 {
+		t.Fatalf("Search returned %d skills, want 1", len(skills))
+	}
+	if skills[0].Registry != "acme" {
+		t.Errorf("Registry = %q, want %q", skills[0].Registry, "acme")
+	}
+	if skills[0].Source != "acme/tools" {
+		t.Errorf("Source = %q, want %q", skills[0].Source, "acme/tools")
+	}
+}
+
+func TestRegistryPluginResolveError(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell plugins not supported on windows")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "acme", `read -r request
+echo '{"error":"unknown skill"}'
+`)
+	t.Setenv("PATH", dir)
+
+	p := FindRegistryPlugin("acme")
+	if _, _, err := p.Resolve("missing"); err == nil {
+		t.Fatal("Resolve() error = nil, want plugin error")
+	}
+}
+
+func TestFetchSkillDetailsResolvesPluginSkills(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell plugins not supported on windows")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "acme", `read -r request
+echo '{"source":"acme/internal-skills","skillPath":"skills/lint"}'
+`)
+	t.Setenv("PATH", dir)
+
+	d, err := FetchSkillDetails(Skill{ID: "lint", Name: "lint", Registry: "acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Source != "acme/internal-skills" || d.Path != "skills/lint" {
+		t.Errorf("details = %q %q, want the resolved source and folder", d.Source, d.Path)
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// RegistryAPIs lists the APIs a registry endpoint can speak: custom
+// endpoints serve the same API as skills.sh or playbooks.com, e.g. a
+// self-hosted copy.
+var RegistryAPIs = []string{"skills.sh", "playbooks.com"}
+
+// RegistryEndpoint is a registry searched by SearchRegistries.
+type RegistryEndpoint struct {
+	Name    string
+	URL     string // base URL; ignored for skills.sh and playbooks.com
+	API     string // one of RegistryAPIs; custom registries default to skills.sh
+	Enabled bool
+}
+
+var (
+	registryMu sync.RWMutex
+	registries []RegistryEndpoint // nil searches skills.sh and playbooks.com
+)
+
+// SetRegistries sets the registries searched, in order; results from
+// earlier ones win when several list a skill. nil restores the built-in
+// registries.
+func SetRegistries(list []RegistryEndpoint) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if list == nil {
+		registries = nil
+		return
+	}
+	registries = make([]RegistryEndpoint, 0, len(list))
+	for _, r := range list {
+		r.URL = RegistryBaseURL(r.URL)
+		registries = append(registries, r)
+	}
+}
+
+// RegistryBaseURL trims a registry URL down to its API base, so the search
+// endpoint URLs config.json has always listed, like
+// "https://skills.sh/api/search", work as well.
+func RegistryBaseURL(u string) string {
+	u = strings.TrimRight(strings.TrimSpace(u), "/")
+	for _, endpoint := range []string{"/api/search", "/api/skills"} {
+		u = strings.TrimSuffix(u, endpoint)
+	}
+	return u
+}
+
+// configuredRegistries returns the registries searched.
+func configuredRegistries() []RegistryEndpoint {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if registries == nil {
+		return []RegistryEndpoint{
+			{Name: "skills.sh", Enabled: true},
+			{Name: "playbooks.com", Enabled: true},
+		}
+	}
+	return append([]RegistryEndpoint(nil), registries...)
+}
+
+// customRegistryName returns the name of the custom registry served at
+// baseURL, or "".
+func customRegistryName(baseURL string) string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, r := range registries {
+		if r.URL == baseURL && r.Name != "skills.sh" && r.Name != "playbooks.com" {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+// search queries the registry with the API it speaks.
+func (r RegistryEndpoint) search(query string, limit int) ([]Skill, error) {
+	switch r.Name {
+	case "skills.sh":
+		return SearchSkillsSh(query, limit)
+	case "playbooks.com":
+		return SearchPlaybooks(query, limit)
+	}
+	switch r.API {
+	case "", "skills.sh":
+		return searchSkillsShAt(r.URL, r.Name, query, limit)
+	case "playbooks.com":
+		return searchPlaybooksAt(r.URL, r.Name, query, limit)
+	}
+	return nil, fmt.Errorf("unknown registry API %q (expected %s)", r.API, strings.Join(RegistryAPIs, " or "))
+}
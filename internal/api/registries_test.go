@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchRegistriesUsesConfiguredEndpoints(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"skills":[{"id":"corp/lint","name":"lint","source":"corp/skills","installs":3}]}`))
+	}))
+	defer srv.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("disabled registry was searched: %s", r.URL)
+	}))
+	defer down.Close()
+	old := skillsShBaseURL
+	skillsShBaseURL = down.URL
+	defer func() { skillsShBaseURL = old }()
+
+	t.Cleanup(func() { SetRegistries(nil) })
+	SetRegistries([]RegistryEndpoint{
+		{Name: "skills.sh", Enabled: false},
+		{Name: "corp", URL: srv.URL + "/api/search/", Enabled: true},
+	})
+
+	results, failures := SearchRegistries("lint", 10)
+	if len(failures) != 0 {
+		t.Fatalf("failures = %v", failures)
+	}
+	if len(results) != 1 || results[0].Registry != "corp" || results[0].Source != "corp/skills" {
+		t.Errorf("results = %+v, want lint from corp", results)
+	}
+	if path != "/api/search" {
+		t.Errorf("requested %s, want the skills.sh search endpoint", path)
+	}
+
+	SetRegistries([]RegistryEndpoint{{Name: "odd", URL: srv.URL, API: "npm", Enabled: true}})
+	if _, failures := SearchRegistries("lint", 10); len(failures) != 1 || failures[0].Registry != "odd" {
+		t.Errorf("failures = %v, want odd failing on its unknown API", failures)
+	}
+}
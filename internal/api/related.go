@@ -0,0 +1,112 @@
+package api
+
+import (
+	"sort"
+	"strings"
+)
+
+// relatedStopWords are name fragments too common to relate skills by.
+var relatedStopWords = map[string]bool{
+	"skill": true, "skills": true, "agent": true, "the": true, "and": true, "for": true, "with": true,
+}
+
+// nameTerms splits a skill name into lower-case keywords.
+func nameTerms(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' ' || r == '/'
+	})
+	var terms []string
+	for _, f := range fields {
+		if len(f) >= 3 && !relatedStopWords[f] {
+			terms = append(terms, f)
+		}
+	}
+	return terms
+}
+
+// sourceOwner returns the owner part of an owner/repo source.
+func sourceOwner(source string) string {
+	owner, _, _ := strings.Cut(source, "/")
+	return owner
+}
+
+// Related searches the registries for skills close to s: from the same
+// repository or author first, then sharing keywords with its name.
+func Related(s Skill, limit int) ([]Skill, error) {
+	var candidates []Skill
+	queries := []string{}
+	if owner := sourceOwner(s.Source); owner != "" {
+		queries = append(queries, owner)
+	}
+	queries = append(queries, nameTerms(s.Name)...)
+
+	var lastErr error
+	for _, q := range queries {
+		results, err := SearchAll(q, 50)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		candidates = append(candidates, results...)
+	}
+	if len(candidates) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return rankRelated(s, candidates, limit), nil
+}
+
+// rankRelated scores candidates against target and returns the best ones,
+// skipping target itself, duplicates and skills with nothing in common.
+func rankRelated(target Skill, candidates []Skill, limit int) []Skill {
+	terms := make(map[string]bool)
+	for _, t := range nameTerms(target.Name) {
+		terms[t] = true
+	}
+	owner := sourceOwner(target.Source)
+
+	type scored struct {
+		skill Skill
+		score int
+	}
+	seen := map[string]bool{target.Source + "/" + target.Name: true}
+	var ranked []scored
+	for _, c := range candidates {
+		key := c.Source + "/" + c.Name
+		if seen[key] || c.Name == target.Name {
+			continue
+		}
+		seen[key] = true
+
+		score := 0
+		switch {
+		case c.Source == target.Source:
+			score += 10
+		case owner != "" && sourceOwner(c.Source) == owner:
+			score += 5
+		}
+		for _, t := range nameTerms(c.Name) {
+			if terms[t] {
+				score += 3
+			}
+		}
+		if score > 0 {
+			ranked = append(ranked, scored{c, score})
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].skill.Installs > ranked[j].skill.Installs
+	})
+
+	var out []Skill
+	for _, r := range ranked {
+		if len(out) == limit {
+			break
+		}
+		out = append(out, r.skill)
+	}
+	return out
+}
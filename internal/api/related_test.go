@@ -0,0 +1,39 @@
+package api
+
+import "testing"
+
+func TestRankRelated(t *testing.T) {
+	target := Skill{Name: "react-hooks", Source: "acme/frontend"}
+	candidates := []Skill{
+		{Name: "react-hooks", Source: "acme/frontend"},           // the target itself
+		{Name: "vue-router", Source: "other/vue", Installs: 900}, // nothing in common
+		{Name: "react-testing", Source: "other/tests", Installs: 50},
+		{Name: "css-modules", Source: "acme/frontend"},
+		{Name: "deploy", Source: "acme/ops"},
+		{Name: "react-testing", Source: "other/tests"}, // duplicate
+		{Name: "hooks-lint", Source: "acme/frontend"},
+	}
+
+	got := rankRelated(target, candidates, 10)
+	want := []string{"hooks-lint", "css-modules", "deploy", "react-testing"}
+	if len(got) != len(want) {
+		t.Fatalf("rankRelated = %+v, want %v", got, want)
+	}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Errorf("rank %d = %s, want %s", i, got[i].Name, name)
+		}
+	}
+
+	if limited := rankRelated(target, candidates, 2); len(limited) != 2 {
+		t.Errorf("limit ignored: got %d results", len(limited))
+	}
+}
+
+func TestNameTerms(t *testing.T) {
+	got := nameTerms("The-React_skill.Hooks ui")
+	want := []string{"react", "hooks"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("nameTerms = %v, want %v", got, want)
+	}
+}
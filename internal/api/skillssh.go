@@ -2,9 +2,12 @@ package api
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 )
 
-const skillsShBaseURL = "https://skills.sh"
+// skillsShBaseURL is a variable so tests can point it at a local server.
+var skillsShBaseURL = "https://skills.sh"
 
 // SkillsShResponse represents the response from skills.sh API
 type SkillsShResponse struct {
@@ -19,9 +22,32 @@ type SkillsShSkill struct {
 	Source   string `json:"source"`
 }
 
+// SkillsShDetail is a skill from the skills.sh per-skill endpoint, with
+// every repository publishing it.
+type SkillsShDetail struct {
+	ID       string           `json:"id"`
+	Name     string           `json:"name"`
+	Installs int              `json:"installs"`
+	Sources  []SkillsShSource `json:"sources"`
+}
+
+// SkillsShSource is one repository publishing a skills.sh skill.
+type SkillsShSource struct {
+	Source    string `json:"source"`
+	Path      string `json:"path"`
+	Installs  int    `json:"installs"`
+	Canonical bool   `json:"canonical"`
+}
+
 // SearchSkillsSh searches skills.sh API
 func SearchSkillsSh(query string, limit int) ([]Skill, error) {
-	client := NewClient(skillsShBaseURL)
+	return searchSkillsShAt(skillsShBaseURL, "skills.sh", query, limit)
+}
+
+// searchSkillsShAt searches a registry serving the skills.sh API at
+// baseURL, tagging results with registry.
+func searchSkillsShAt(baseURL, registry, query string, limit int) ([]Skill, error) {
+	client := NewClient(baseURL)
 
 	params := map[string]string{
 		"q":     query,
@@ -45,7 +71,7 @@ func SearchSkillsSh(query string, limit int) ([]Skill, error) {
 			Name:     s.Name,
 			Source:   s.Source,
 			Installs: s.Installs,
-			Registry: "skills.sh",
+			Registry: registry,
 		})
 	}
 
@@ -83,3 +109,67 @@ func GetSkillsShTrending(limit int) ([]Skill, error) {
 
 	return skills, nil
 }
+
+// GetSkillsShSkill fetches the details of one skills.sh skill by its id.
+// The source of a search result is only the most installed one, so the
+// details name the canonical repository and the skill's exact path.
+func GetSkillsShSkill(id string) (*SkillDetails, error) {
+	data, err := NewClient(skillsShBaseURL).Get("/api/skills/"+strings.Trim(id, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var detail SkillsShDetail
+	if err := parseJSON(data, &detail); err != nil {
+		return nil, err
+	}
+	if len(detail.Sources) == 0 {
+		return nil, fmt.Errorf("skills.sh lists no source for %s", id)
+	}
+
+	sources := make([]SkillSource, 0, len(detail.Sources))
+	for _, src := range detail.Sources {
+		sources = append(sources, SkillSource{Source: src.Source, Path: skillFolder(src.Path), Installs: src.Installs})
+	}
+	best := canonicalSource(detail.Sources)
+	details := &SkillDetails{
+		Skill: Skill{
+			ID:       detail.ID,
+			Name:     detail.Name,
+			Source:   detail.Sources[best].Source,
+			Path:     skillFolder(detail.Sources[best].Path),
+			Installs: detail.Installs,
+			Registry: "skills.sh",
+		},
+		Sources: sources,
+	}
+	details.Verified = IsVerifiedOwner(details.Source)
+	return details, nil
+}
+
+// canonicalSource picks the repository a skill is installed from: the one
+// skills.sh flags as canonical, else one from a verified owner, else the
+// most installed.
+func canonicalSource(sources []SkillsShSource) int {
+	order := make([]int, len(sources))
+	for i := range order {
+		order[i] = i
+	}
+	rank := func(s SkillsShSource) int {
+		switch {
+		case s.Canonical:
+			return 0
+		case IsVerifiedOwner(s.Source):
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		sa, sb := sources[order[a]], sources[order[b]]
+		if rank(sa) != rank(sb) {
+			return rank(sa) < rank(sb)
+		}
+		return sa.Installs > sb.Installs
+	})
+	return order[0]
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetSkillsShSkillPicksCanonicalSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/skills/lint" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"id":"lint","name":"lint","installs":900,"sources":[
+			{"source":"fork/skills","path":"lint","installs":600},
+			{"source":"acme/skills","path":"skills/lint/SKILL.md","installs":300,"canonical":true}]}`))
+	}))
+	defer srv.Close()
+	old := skillsShBaseURL
+	skillsShBaseURL = srv.URL
+	defer func() { skillsShBaseURL = old }()
+
+	d, err := FetchSkillDetails(Skill{ID: "lint", Name: "lint", Source: "fork/skills", Registry: "skills.sh"})
+	if err != nil {
+		t.Fatalf("FetchSkillDetails error: %v", err)
+	}
+	if d.Source != "acme/skills" || d.Path != "skills/lint" || d.Installs != 900 {
+		t.Errorf("details = %+v, want the canonical source", d.Skill)
+	}
+	if len(d.Sources) != 2 || d.Sources[0].Source != "fork/skills" {
+		t.Errorf("sources = %+v, want both repositories", d.Sources)
+	}
+}
+
+func TestCanonicalSourceFallsBackToInstalls(t *testing.T) {
+	sources := []SkillsShSource{{Source: "a/x", Installs: 5}, {Source: "b/x", Installs: 50}}
+	if got := canonicalSource(sources); got != 1 {
+		t.Errorf("canonicalSource = %d, want the most installed", got)
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import "strings"
+
+// verifiedOwners are GitHub accounts of vendors publishing skills for their
+// own products. Skills from them get a "verified owner" badge.
+var verifiedOwners = map[string]bool{
+	"anthropics":   true,
+	"openai":       true,
+	"vercel":       true,
+	"vercel-labs":  true,
+	"microsoft":    true,
+	"google":       true,
+	"github":       true,
+	"cloudflare":   true,
+	"supabase":     true,
+	"stripe":       true,
+	"huggingface":  true,
+	"expo":         true,
+	"remotion-dev": true,
+	"getsentry":    true,
+	"prisma":       true,
+}
+
+// IsVerifiedOwner reports whether an owner/repo source belongs to a known
+// vendor account. It is a heuristic, not a registry guarantee.
+func IsVerifiedOwner(source string) bool {
+	return verifiedOwners[strings.ToLower(sourceOwner(source))]
+}
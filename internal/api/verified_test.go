@@ -0,0 +1,17 @@
+package api
+
+import "testing"
+
+func TestIsVerifiedOwner(t *testing.T) {
+	for source, want := range map[string]bool{
+		"anthropics/skills":   true,
+		"Vercel-Labs/agent":   true,
+		"someone/anthropics":  false,
+		"yoanbernabeu/grepai": false,
+		"":                    false,
+	} {
+		if got := IsVerifiedOwner(source); got != want {
+			t.Errorf("IsVerifiedOwner(%q) = %v, want %v", source, got, want)
+		}
+	}
+}
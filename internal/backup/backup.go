@@ -0,0 +1,278 @@
+// Package backup snapshots the skills environment into a tar.gz archive:
+// the central ~/.agents directory, the efx-skills config file and a manifest
+// recording which entries each provider links.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Archive member names.
+const (
+	manifestName = "manifest.json"
+	configName   = "config.json"
+	agentsPrefix = "agents/"
+)
+
+// Manifest describes a backup and the provider links to recreate on restore.
+type Manifest struct {
+	Version   int                 `json:"version"`
+	CreatedAt string              `json:"createdAt"`
+	AgentsDir string              `json:"agentsDir"` // source directory, informational
+	Links     map[string][]string `json:"links"`     // "provider/assetType" -> linked names
+}
+
+// Write creates a gzipped tarball at dest holding agentsDir, configFile (if
+// it exists) and the manifest.
+func Write(dest, agentsDir, configFile string, m Manifest) error {
+	m.Version = 1
+	m.AgentsDir = agentsDir
+	if m.CreatedAt == "" {
+		m.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(tw, manifestName, data, 0644); err != nil {
+		return err
+	}
+
+	if data, err := os.ReadFile(configFile); err == nil {
+		if err := writeFile(tw, configName, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := addTree(tw, agentsDir); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// addTree stores every file, directory and symlink under root as agents/...
+// Symlinks leading out of root, such as dev skills linked to a working
+// directory, are left out: Extract refuses them.
+func addTree(tw *tar.Writer, root string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == root {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		name := agentsPrefix + filepath.ToSlash(rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+			if linkEscapes(rel, link) {
+				return nil
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, mode int64) error {
+	hdr := &tar.Header{Name: name, Mode: mode, Size: int64(len(data)), ModTime: time.Now(), Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// Extract restores an archive: agents/ entries go under agentsDir and the
+// config file to configFile. It returns the archive manifest.
+func Extract(archive, agentsDir, configFile string) (Manifest, error) {
+	var m Manifest
+
+	f, err := os.Open(archive)
+	if err != nil {
+		return m, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return m, fmt.Errorf("reading %s: %w", archive, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return m, err
+		}
+
+		switch {
+		case hdr.Name == manifestName:
+			if err := json.NewDecoder(tr).Decode(&m); err != nil {
+				return m, fmt.Errorf("reading manifest: %w", err)
+			}
+		case hdr.Name == configName:
+			if err := writeTarFile(tr, configFile, 0644); err != nil {
+				return m, err
+			}
+		case strings.HasPrefix(hdr.Name, agentsPrefix):
+			if err := extractEntry(tr, hdr, agentsDir); err != nil {
+				return m, err
+			}
+		}
+	}
+
+	if m.Version == 0 {
+		return m, fmt.Errorf("%s is not an efx-skills backup (no manifest)", archive)
+	}
+	return m, nil
+}
+
+// extractEntry writes one agents/ member below root, refusing paths and
+// symlinks that would escape it and writes through symlinks already there.
+func extractEntry(tr *tar.Reader, hdr *tar.Header, root string) error {
+	rel := path.Clean(strings.TrimPrefix(hdr.Name, agentsPrefix))
+	if rel == "." || rel == "" {
+		return nil
+	}
+	if escapes(rel) {
+		return fmt.Errorf("unsafe path in archive: %s", hdr.Name)
+	}
+	if err := checkNoSymlinks(root, rel, hdr.Typeflag != tar.TypeSymlink); err != nil {
+		return fmt.Errorf("unsafe path in archive: %s: %w", hdr.Name, err)
+	}
+	target := filepath.Join(root, filepath.FromSlash(rel))
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, 0755)
+	case tar.TypeSymlink:
+		if linkEscapes(rel, hdr.Linkname) {
+			return fmt.Errorf("unsafe symlink in archive: %s -> %s", hdr.Name, hdr.Linkname)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		os.Remove(target)
+		return os.Symlink(hdr.Linkname, target)
+	case tar.TypeReg:
+		return writeTarFile(tr, target, os.FileMode(hdr.Mode).Perm())
+	}
+	return nil
+}
+
+// escapes reports whether the slash-separated rel leaves its root.
+func escapes(rel string) bool {
+	rel = path.Clean(rel)
+	return rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel)
+}
+
+// linkEscapes reports whether a symlink at rel, below a root, pointing to
+// dest resolves outside that root.
+func linkEscapes(rel, dest string) bool {
+	dest = filepath.ToSlash(dest)
+	if path.IsAbs(dest) || filepath.IsAbs(dest) {
+		return true
+	}
+	return escapes(path.Join(path.Dir(filepath.ToSlash(rel)), dest))
+}
+
+// checkNoSymlinks fails when a folder on the way from root to rel, or rel
+// itself when last is set, is a symlink, which a write would follow.
+func checkNoSymlinks(root, rel string, last bool) error {
+	parts := strings.Split(rel, "/")
+	if !last {
+		parts = parts[:len(parts)-1]
+	}
+	p := root
+	for _, part := range parts {
+		p = filepath.Join(p, part)
+		info, err := os.Lstat(p)
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", p)
+		}
+	}
+	return nil
+}
+
+func writeTarFile(r io.Reader, target string, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
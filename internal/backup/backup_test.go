@@ -0,0 +1,132 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteAndExtractRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	agents := filepath.Join(src, ".agents")
+	os.MkdirAll(filepath.Join(agents, "skills", "demo"), 0755)
+	os.WriteFile(filepath.Join(agents, "skills", "demo", "SKILL.md"), []byte("# Demo"), 0644)
+	os.WriteFile(filepath.Join(agents, ".skill-lock.json"), []byte(`{"version":3}`), 0644)
+	os.Symlink("skills/demo", filepath.Join(agents, "alias"))
+	config := filepath.Join(src, "config.json")
+	os.WriteFile(config, []byte(`{"skills":[]}`), 0644)
+
+	archive := filepath.Join(src, "backup.tar.gz")
+	links := map[string][]string{"claude/skills": {"demo"}}
+	if err := Write(archive, agents, config, Manifest{Links: links}); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+
+	dst := t.TempDir()
+	m, err := Extract(archive, filepath.Join(dst, ".agents"), filepath.Join(dst, "config.json"))
+	if err != nil {
+		t.Fatalf("Extract error: %v", err)
+	}
+	if got := m.Links["claude/skills"]; len(got) != 1 || got[0] != "demo" {
+		t.Fatalf("manifest links = %v, want claude/skills: [demo]", m.Links)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, ".agents", "skills", "demo", "SKILL.md"))
+	if err != nil || string(data) != "# Demo" {
+		t.Fatalf("restored SKILL.md = %q, %v", data, err)
+	}
+	if dest, err := os.Readlink(filepath.Join(dst, ".agents", "alias")); err != nil || dest != "skills/demo" {
+		t.Fatalf("restored symlink = %q, %v", dest, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "config.json")); err != nil {
+		t.Fatalf("config not restored: %v", err)
+	}
+}
+
+func TestExtractEmptyAndMissingArchives(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "empty.tar.gz")
+	agents := filepath.Join(t.TempDir(), "missing")
+	if err := Write(archive, agents, "", Manifest{}); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if _, err := Extract(archive, t.TempDir(), filepath.Join(t.TempDir(), "c.json")); err != nil {
+		t.Fatalf("Extract of a valid empty backup failed: %v", err)
+	}
+	if _, err := Extract(filepath.Join(t.TempDir(), "nope.tar.gz"), t.TempDir(), ""); err == nil {
+		t.Fatal("Extract of a missing archive should fail")
+	}
+}
+
+// writeArchive builds a backup archive from raw tar headers, for archives
+// Write would never produce.
+func writeArchive(t *testing.T, headers ...*tar.Header) string {
+	t.Helper()
+	archive := filepath.Join(t.TempDir(), "crafted.tar.gz")
+	f, _ := os.Create(archive)
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	manifest := []byte(`{"version":1}`)
+	tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0644, Size: int64(len(manifest)), Typeflag: tar.TypeReg})
+	tw.Write(manifest)
+	for _, hdr := range headers {
+		tw.WriteHeader(hdr)
+		if hdr.Typeflag == tar.TypeReg {
+			tw.Write(make([]byte, hdr.Size))
+		}
+	}
+	tw.Close()
+	gz.Close()
+	f.Close()
+	return archive
+}
+
+func TestExtractRefusesEscapingSymlinks(t *testing.T) {
+	dst := t.TempDir()
+	agents := filepath.Join(dst, ".agents")
+	outside := filepath.Join(dst, "outside")
+	os.MkdirAll(outside, 0755)
+
+	for _, link := range []string{"../../outside", outside} {
+		archive := writeArchive(t,
+			&tar.Header{Name: "agents/skills/evil", Linkname: link, Typeflag: tar.TypeSymlink},
+			&tar.Header{Name: "agents/skills/evil/planted", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+		if _, err := Extract(archive, agents, filepath.Join(dst, "config.json")); err == nil {
+			t.Errorf("Extract with a symlink to %s succeeded", link)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outside, "planted")); !os.IsNotExist(err) {
+		t.Fatal("a file was written outside the agents directory")
+	}
+
+	// Links already in the restored tree are not written through either
+	os.MkdirAll(filepath.Join(agents, "skills"), 0755)
+	os.Symlink(outside, filepath.Join(agents, "skills", "dev"))
+	archive := writeArchive(t, &tar.Header{Name: "agents/skills/dev/planted", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	if _, err := Extract(archive, agents, filepath.Join(dst, "config.json")); err == nil {
+		t.Error("Extract wrote through an existing symlink")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "planted")); !os.IsNotExist(err) {
+		t.Fatal("a file was written through an existing symlink")
+	}
+}
+
+func TestWriteLeavesOutEscapingSymlinks(t *testing.T) {
+	src := t.TempDir()
+	agents := filepath.Join(src, ".agents")
+	os.MkdirAll(filepath.Join(agents, "skills"), 0755)
+	os.Symlink(t.TempDir(), filepath.Join(agents, "skills", "dev"))
+	archive := filepath.Join(src, "backup.tar.gz")
+	if err := Write(archive, agents, "", Manifest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(t.TempDir(), ".agents")
+	if _, err := Extract(archive, dst, filepath.Join(t.TempDir(), "config.json")); err != nil {
+		t.Fatalf("Extract error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(dst, "skills", "dev")); !os.IsNotExist(err) {
+		t.Errorf("dev link restored: %v", err)
+	}
+}
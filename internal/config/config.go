@@ -2,9 +2,11 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/lmarques/efx-skills/internal/fsutil"
 	"github.com/lmarques/efx-skills/internal/provider"
 )
 
@@ -79,21 +81,42 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// Save saves configuration to file
+// Save writes the configuration atomically, keeping the previous file as
+// config.json.bak.
 func (c *Config) Save() error {
-	path := ConfigPath()
-	dir := filepath.Dir(path)
+	return WriteJSON(ConfigPath(), c)
+}
 
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
+// WriteJSON writes v as indented JSON to path, creating its directory. The
+// write is atomic and the previous file is kept with a .bak suffix. Every
+// writer of config.json goes through it.
+func WriteJSON(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(c, "", "  ")
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		return err
+		return fmt.Errorf("marshaling config: %w", err)
+	}
+
+	if err := fsutil.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
 	}
+	return nil
+}
 
-	return os.WriteFile(path, data, 0644)
+// WriteCache writes v as indented JSON to path atomically, without the
+// backups WriteJSON keeps: caches are rebuilt on every run.
+func WriteCache(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.Replace(path, data, 0644)
 }
 
 // AddRepo adds a custom repository
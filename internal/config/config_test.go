@@ -1,7 +1,9 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -48,3 +50,20 @@ func TestDefaultConfigProviderDefaults(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteJSONKeepsBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.json")
+	if err := WriteJSON(path, map[string]int{"v": 1}); err != nil {
+		t.Fatalf("first WriteJSON error: %v", err)
+	}
+	if err := WriteJSON(path, map[string]int{"v": 2}); err != nil {
+		t.Fatalf("second WriteJSON error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), `"v": 2`) {
+		t.Errorf("config = %q, %v; want the second write", data, err)
+	}
+	if bak, err := os.ReadFile(path + ".bak"); err != nil || !strings.Contains(string(bak), `"v": 1`) {
+		t.Errorf("backup = %q, %v; want the first write", bak, err)
+	}
+}
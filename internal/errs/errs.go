@@ -0,0 +1,146 @@
+// Package errs classifies failures so the CLI and TUI can tell users how to
+// fix them instead of only showing the wrapped error text.
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// Kind is the class of a failure.
+type Kind int
+
+const (
+	Unknown    Kind = iota
+	Network         // the server could not be reached or failed
+	RateLimit       // the GitHub API rate limit is exhausted
+	Permission      // a file, folder or repository is not accessible
+	NotFound        // a skill, repository or path does not exist
+	Conflict        // something of that name already exists
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Network:
+		return "network"
+	case RateLimit:
+		return "rate limit"
+	case Permission:
+		return "permission"
+	case NotFound:
+		return "not found"
+	case Conflict:
+		return "conflict"
+	}
+	return "unknown"
+}
+
+// Error is a failure of a known kind. Its message is the wrapped error's;
+// Hint, when set, replaces the kind's default remediation.
+type Error struct {
+	Kind Kind
+	Err  error
+	Hint string
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+func (e *Error) Unwrap() error { return e.Err }
+
+// New returns an error of kind with a formatted message.
+func New(kind Kind, format string, args ...any) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// WithHint returns an error of kind with a formatted message and a specific
+// remediation, e.g. the flag that overrides a conflict.
+func WithHint(kind Kind, hint, format string, args ...any) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...), Hint: hint}
+}
+
+// Status classifies an HTTP response status. GitHub reports an exhausted
+// rate limit as 403 with no requests remaining, or as 429.
+func Status(status int, header http.Header) Kind {
+	switch {
+	case status == http.StatusTooManyRequests,
+		status == http.StatusForbidden && header.Get("X-RateLimit-Remaining") == "0":
+		return RateLimit
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return Permission
+	case status == http.StatusNotFound:
+		return NotFound
+	case status == http.StatusConflict:
+		return Conflict
+	case status >= 500:
+		return Network
+	}
+	return Unknown
+}
+
+// FromResponse returns an error classified by resp's status, with a
+// formatted message. Access denied by a server calls for a token rather
+// than file permissions.
+func FromResponse(resp *http.Response, format string, args ...any) error {
+	e := &Error{Kind: Status(resp.StatusCode, resp.Header), Err: fmt.Errorf(format, args...)}
+	if e.Kind == Permission {
+		e.Hint = tokenHint
+	}
+	return e
+}
+
+// KindOf classifies err: by the kind it was marked with, else by its cause
+// when that is a well-known file system or network error.
+func KindOf(err error) Kind {
+	if err == nil {
+		return Unknown
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, fs.ErrPermission), errors.Is(err, syscall.EROFS):
+		return Permission
+	case errors.Is(err, fs.ErrExist), errors.Is(err, syscall.ENOTEMPTY):
+		return Conflict
+	case errors.Is(err, fs.ErrNotExist):
+		return NotFound
+	case errors.As(err, &netErr):
+		return Network
+	}
+	return Unknown
+}
+
+const tokenHint = "run efx-skills auth set github, set $GITHUB_TOKEN, or run gh auth login with an account that can read the repository"
+
+// hints are the default remediation of each kind.
+var hints = map[Kind]string{
+	Network:    "check your internet connection and proxy settings, then try again",
+	RateLimit:  "GitHub's rate limit is exhausted; " + tokenHint + " to raise it, or wait for it to reset",
+	Permission: "check the owner and permissions of the folder, or run with a user that can write to it; read-only mounts cannot be changed",
+	NotFound:   "check the name and source; for a private repository, " + tokenHint,
+	Conflict:   "remove or rename the existing one first",
+}
+
+// missingHint is the remediation of a local file or folder that does not
+// exist, which no token helps with.
+const missingHint = "check the path; it may have been moved or deleted, or not created yet"
+
+// Hint returns how to fix err, or "" when its kind is unknown.
+func Hint(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		if e.Hint != "" {
+			return e.Hint
+		}
+		return hints[e.Kind]
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return missingHint
+	}
+	return hints[KindOf(err)]
+}
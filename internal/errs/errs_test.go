@@ -0,0 +1,78 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	exhausted := http.Header{}
+	exhausted.Set("X-RateLimit-Remaining", "0")
+	tests := []struct {
+		status int
+		header http.Header
+		want   Kind
+	}{
+		{http.StatusTooManyRequests, nil, RateLimit},
+		{http.StatusForbidden, exhausted, RateLimit},
+		{http.StatusForbidden, http.Header{}, Permission},
+		{http.StatusUnauthorized, http.Header{}, Permission},
+		{http.StatusNotFound, nil, NotFound},
+		{http.StatusConflict, nil, Conflict},
+		{http.StatusBadGateway, nil, Network},
+		{http.StatusTeapot, nil, Unknown},
+	}
+	for _, tt := range tests {
+		if got := Status(tt.status, tt.header); got != tt.want {
+			t.Errorf("Status(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	_, notExist := os.Stat("/nonexistent/efx-skills")
+	tests := []struct {
+		err  error
+		want Kind
+	}{
+		{nil, Unknown},
+		{errors.New("boom"), Unknown},
+		{fmt.Errorf("wrapped: %w", New(RateLimit, "limited")), RateLimit},
+		{&fs.PathError{Op: "open", Path: "x", Err: fs.ErrPermission}, Permission},
+		{fmt.Errorf("replacing x: %w", fs.ErrExist), Conflict},
+		{notExist, NotFound},
+	}
+	for _, tt := range tests {
+		if got := KindOf(tt.err); got != tt.want {
+			t.Errorf("KindOf(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	if Hint(errors.New("boom")) != "" {
+		t.Error("unclassified error has a hint")
+	}
+	if Hint(New(Conflict, "exists")) != hints[Conflict] {
+		t.Errorf("Hint = %q, want the default conflict hint", Hint(New(Conflict, "exists")))
+	}
+	err := fmt.Errorf("restore: %w", WithHint(Conflict, "use --force", "exists"))
+	if Hint(err) != "use --force" {
+		t.Errorf("Hint = %q, want the specific hint", Hint(err))
+	}
+	_, notExist := os.Stat("/nonexistent/efx-skills")
+	if Hint(notExist) != missingHint {
+		t.Errorf("Hint(missing file) = %q, want the local hint", Hint(notExist))
+	}
+	if Hint(New(NotFound, "repository not found")) != hints[NotFound] {
+		t.Error("a remote not found should keep the token hint")
+	}
+	resp := &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	if err := FromResponse(resp, "status %d", resp.StatusCode); Hint(err) != tokenHint {
+		t.Errorf("Hint(401) = %q, want the token hint", Hint(err))
+	}
+}
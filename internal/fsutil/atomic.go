@@ -0,0 +1,60 @@
+// Package fsutil holds small file helpers shared by the store and config
+// code.
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix is appended to a file's name for the copy of its previous
+// version kept by WriteFile.
+const BackupSuffix = ".bak"
+
+// WriteFile replaces path with data without ever leaving it half-written:
+// the data goes to a temporary file in the same directory that is renamed
+// over path. The previous version, if any, is kept as path + BackupSuffix,
+// replacing an older backup, and as a timestamped backup when
+// SetBackupsKept asks for some.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if prev, err := os.ReadFile(path); err == nil {
+		if err := Replace(path+BackupSuffix, prev, perm); err != nil {
+			return err
+		}
+		if err := rotate(path, prev, perm); err != nil {
+			return err
+		}
+	}
+	return Replace(path, data, perm)
+}
+
+// Replace writes data to a temporary file and renames it to path, keeping
+// no backup, for caches and files other tools own. When path is a symlink,
+// as with configs kept in a dotfiles repository, the file it points to is
+// replaced and the link kept.
+func Replace(path string, data []byte, perm os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	// Flush to disk so a crash after the rename cannot leave an empty file
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
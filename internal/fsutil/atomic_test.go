@@ -0,0 +1,63 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileKeepsOneBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	for _, content := range []string{"v1", "v2", "v3"} {
+		if err := WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%s) error: %v", content, err)
+		}
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "v3" {
+		t.Errorf("file = %q, want v3", data)
+	}
+	if data, _ := os.ReadFile(path + BackupSuffix); string(data) != "v2" {
+		t.Errorf("backup = %q, want the previous version v2", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("dir has %d files, want the file and its backup only", len(entries))
+	}
+}
+
+func TestWriteFileWithoutPreviousVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lock.json")
+	if err := WriteFile(path, []byte("{}"), 0600); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if _, err := os.Stat(path + BackupSuffix); !os.IsNotExist(err) {
+		t.Errorf("backup exists for a new file: %v", err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestWriteFileKeepsSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "config.json")
+	os.MkdirAll(filepath.Dir(target), 0755)
+	os.WriteFile(target, []byte("v1"), 0644)
+	link := filepath.Join(dir, "config.json")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skip("symlinks not supported:", err)
+	}
+
+	if err := WriteFile(link, []byte("v2"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("the symlink was replaced: %v", err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "v2" {
+		t.Errorf("target = %q, want v2", data)
+	}
+}
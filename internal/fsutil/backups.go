@@ -0,0 +1,94 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BackupDirName is the folder, next to a file, holding its timestamped
+// backups.
+const BackupDirName = "backups"
+
+// backupTimeFormat sorts lexically in time order.
+const backupTimeFormat = "20060102-150405.000000"
+
+var (
+	backupsMu   sync.Mutex
+	backupsKept int
+)
+
+// SetBackupsKept sets how many timestamped backups WriteFile keeps of each
+// file, on top of the .bak copy. Zero, the default, keeps none.
+func SetBackupsKept(n int) {
+	backupsMu.Lock()
+	backupsKept = max(n, 0)
+	backupsMu.Unlock()
+}
+
+// Backup is a timestamped copy of a file's previous version.
+type Backup struct {
+	Path string
+	Time time.Time // when the version was replaced
+}
+
+// Backups lists the timestamped backups of path, newest first.
+func Backups(path string) ([]Backup, error) {
+	dir := filepath.Join(filepath.Dir(path), BackupDirName)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefix := filepath.Base(path) + "."
+	var backups []Backup
+	for _, e := range entries {
+		stamp, ok := strings.CutPrefix(e.Name(), prefix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{Path: filepath.Join(dir, e.Name()), Time: t})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Time.After(backups[j].Time) })
+	return backups, nil
+}
+
+// rotate saves prev, the version of path about to be replaced, as a
+// timestamped backup and drops the oldest ones beyond the kept count.
+func rotate(path string, prev []byte, perm os.FileMode) error {
+	backupsMu.Lock()
+	keep := backupsKept
+	backupsMu.Unlock()
+	if keep == 0 {
+		return nil
+	}
+
+	dir := filepath.Join(filepath.Dir(path), BackupDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	name := filepath.Base(path) + "." + time.Now().Format(backupTimeFormat)
+	if err := Replace(filepath.Join(dir, name), prev, perm); err != nil {
+		return err
+	}
+
+	backups, err := Backups(path)
+	if err != nil {
+		return err
+	}
+	for _, b := range backups[min(keep, len(backups)):] {
+		if err := os.Remove(b.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileRotatesBackups(t *testing.T) {
+	SetBackupsKept(2)
+	defer SetBackupsKept(0)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	for _, content := range []string{"v1", "v2", "v3", "v4"} {
+		if err := WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%s) error: %v", content, err)
+		}
+	}
+
+	backups, err := Backups(path)
+	if err != nil {
+		t.Fatalf("Backups error: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("backups = %+v, want the 2 newest", backups)
+	}
+	for i, want := range []string{"v3", "v2"} {
+		if data, _ := os.ReadFile(backups[i].Path); string(data) != want {
+			t.Errorf("backup %d = %q, want %q", i, data, want)
+		}
+	}
+	if data, _ := os.ReadFile(path + BackupSuffix); string(data) != "v3" {
+		t.Errorf(".bak = %q, want v3", data)
+	}
+}
+
+func TestBackupsIgnoresOtherFiles(t *testing.T) {
+	SetBackupsKept(3)
+	defer SetBackupsKept(0)
+
+	dir := t.TempDir()
+	config, lock := filepath.Join(dir, "config.json"), filepath.Join(dir, "lock.json")
+	for _, p := range []string{config, config, lock, lock} {
+		if err := WriteFile(p, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(dir, BackupDirName, "config.json.garbage"), []byte("x"), 0644)
+
+	if backups, _ := Backups(config); len(backups) != 1 {
+		t.Errorf("config backups = %+v, want 1", backups)
+	}
+	if backups, _ := Backups(filepath.Join(dir, "missing.json")); len(backups) != 0 {
+		t.Errorf("backups of a missing file = %+v", backups)
+	}
+}
@@ -0,0 +1,17 @@
+package fsutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidName checks that name is a single entry of a folder, such as a
+// skill in the store or an MCP server definition: not empty, "." or "..",
+// and free of path separators. Joining anything else to a folder could
+// reach the folder itself or its parents.
+func ValidName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid name %q: expected a single folder or file name", name)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package fsutil
+
+import "testing"
+
+func TestValidName(t *testing.T) {
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`, "../x"} {
+		if ValidName(name) == nil {
+			t.Errorf("ValidName(%q) = nil, want an error", name)
+		}
+	}
+	for _, name := range []string{"go-test", ".hidden", "a..b"} {
+		if err := ValidName(name); err != nil {
+			t.Errorf("ValidName(%q) = %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Package mcp manages MCP server definitions kept in central storage
+// (~/.agents/mcp/<name>.json) and merges them into each provider's native
+// config file under the "mcpServers" key.
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/fsutil"
+)
+
+// serversKey is the top-level key every supported provider config
+// (Claude ~/.claude.json, Cursor mcp.json, Windsurf mcp_config.json) uses.
+const serversKey = "mcpServers"
+
+// Server is a single MCP server definition.
+type Server struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+// Equal reports whether two definitions launch the same server.
+func (s Server) Equal(o Server) bool {
+	if len(s.Args) == 0 && len(o.Args) == 0 && len(s.Env) == 0 && len(o.Env) == 0 {
+		return s.Command == o.Command
+	}
+	return reflect.DeepEqual(s, o)
+}
+
+// LoadServers reads every <name>.json definition from dir. A missing
+// directory yields no servers.
+func LoadServers(dir string) (map[string]Server, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Server{}, nil
+		}
+		return nil, err
+	}
+
+	servers := make(map[string]Server)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var s Server
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", e.Name(), err)
+		}
+		servers[strings.TrimSuffix(e.Name(), ".json")] = s
+	}
+	return servers, nil
+}
+
+// SaveServer writes a definition to dir/<name>.json.
+func SaveServer(dir, name string, s Server) error {
+	if err := fsutil.ValidName(name); err != nil {
+		return err
+	}
+	if s.Command == "" {
+		return fmt.Errorf("MCP server %s needs a command", name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(filepath.Join(dir, name+".json"), data, 0644)
+}
+
+// ReadProviderServers returns the MCP servers configured in a provider
+// config file. A missing file yields no servers.
+func ReadProviderServers(path string) (map[string]Server, error) {
+	doc, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	servers := make(map[string]Server)
+	if raw, ok := doc[serversKey]; ok {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return nil, fmt.Errorf("parsing %s in %s: %w", serversKey, path, err)
+		}
+	}
+	return servers, nil
+}
+
+// MergeIntoProvider adds or replaces the given servers in a provider config
+// file, leaving every other key and server untouched.
+func MergeIntoProvider(path string, servers map[string]Server) error {
+	return updateProviderServers(path, func(existing map[string]json.RawMessage) error {
+		for name, s := range servers {
+			data, err := json.Marshal(s)
+			if err != nil {
+				return err
+			}
+			existing[name] = data
+		}
+		return nil
+	})
+}
+
+// RemoveFromProvider deletes the named servers from a provider config file.
+func RemoveFromProvider(path string, names ...string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	return updateProviderServers(path, func(existing map[string]json.RawMessage) error {
+		for _, name := range names {
+			delete(existing, name)
+		}
+		return nil
+	})
+}
+
+// Missing returns the sorted names of servers in want that are absent from,
+// or differ in, have.
+func Missing(want, have map[string]Server) []string {
+	var names []string
+	for name, s := range want {
+		if current, ok := have[name]; !ok || !current.Equal(s) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// updateProviderServers rewrites the mcpServers object of a provider config.
+// Servers are kept as raw JSON so fields this package does not model (type,
+// url, headers, ...) survive the round trip, and only the mcpServers member
+// of the file is replaced, leaving the rest of a file another tool owns as
+// it was.
+func updateProviderServers(path string, fn func(map[string]json.RawMessage) error) error {
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	existing := make(map[string]json.RawMessage)
+	if raw, ok := doc[serversKey]; ok {
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			return fmt.Errorf("parsing %s in %s: %w", serversKey, path, err)
+		}
+	}
+	if err := fn(existing); err != nil {
+		return err
+	}
+
+	data, err = patchServers(data, existing)
+	if err != nil {
+		return fmt.Errorf("updating %s: %w", path, err)
+	}
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return fsutil.Replace(path, data, perm)
+}
+
+// patchServers replaces the value of the top-level mcpServers member of
+// the JSON object in data with servers, or appends the member when there
+// is none, keeping every other byte of data.
+func patchServers(data []byte, servers map[string]json.RawMessage) ([]byte, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		data = []byte("{}\n")
+	}
+	start, end, found, err := memberValue(data, serversKey)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		raw, err := json.MarshalIndent(servers, lineIndent(data, start), "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(append(slices.Clip(data[:start]), raw...), data[end:]...), nil
+	}
+
+	raw, err := json.MarshalIndent(servers, "  ", "  ")
+	if err != nil {
+		return nil, err
+	}
+	closing := bytes.LastIndexByte(data, '}')
+	body := bytes.TrimRight(data[:closing], " \t\r\n")
+	member := fmt.Sprintf("\n  %q: %s\n", serversKey, raw)
+	if !bytes.HasSuffix(body, []byte("{")) {
+		member = "," + member
+	}
+	return append(append(slices.Clip(body), member...), data[closing:]...), nil
+}
+
+// memberValue finds the byte range of the value of the top-level member
+// key of the JSON object in data.
+func memberValue(data []byte, key string) (start, end int, found bool, err error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return 0, 0, false, fmt.Errorf("not a JSON object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, 0, false, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return 0, 0, false, err
+		}
+		if tok == key {
+			end := int(dec.InputOffset())
+			return end - len(value), end, true, nil
+		}
+	}
+	return 0, 0, false, nil
+}
+
+// lineIndent returns the whitespace starting the line holding offset.
+func lineIndent(data []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	line := data[lineStart:offset]
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+// readDocument loads a JSON object, returning an empty one for missing files.
+func readDocument(path string) (map[string]json.RawMessage, error) {
+	doc := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return doc, nil
+		}
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return doc, nil
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return doc, nil
+}
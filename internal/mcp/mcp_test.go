@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoadServers(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mcp")
+	s := Server{Command: "npx", Args: []string{"-y", "@modelcontextprotocol/server-github"}, Env: map[string]string{"GITHUB_TOKEN": "x"}}
+	if err := SaveServer(dir, "github", s); err != nil {
+		t.Fatalf("SaveServer error: %v", err)
+	}
+
+	servers, err := LoadServers(dir)
+	if err != nil {
+		t.Fatalf("LoadServers error: %v", err)
+	}
+	if got, ok := servers["github"]; !ok || !got.Equal(s) {
+		t.Fatalf("LoadServers = %+v, want github server", servers)
+	}
+
+	if err := SaveServer(dir, "broken", Server{}); err == nil {
+		t.Fatal("SaveServer without command should fail")
+	}
+	if err := SaveServer(dir, "../escape", s); err == nil {
+		t.Fatal("SaveServer should refuse a name outside dir")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "escape.json")); !os.IsNotExist(err) {
+		t.Errorf("escape.json was written next to dir: %v", err)
+	}
+}
+
+func TestMergeIntoProviderPreservesOtherKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".claude.json")
+	os.WriteFile(path, []byte(`{"theme":"dark","mcpServers":{"remote":{"type":"http","url":"https://example.com"}}}`), 0644)
+
+	if err := MergeIntoProvider(path, map[string]Server{"fs": {Command: "mcp-fs"}}); err != nil {
+		t.Fatalf("MergeIntoProvider error: %v", err)
+	}
+
+	var doc map[string]json.RawMessage
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON written: %v", err)
+	}
+	if string(doc["theme"]) != `"dark"` {
+		t.Fatalf("theme = %s, want preserved", doc["theme"])
+	}
+	var servers map[string]map[string]any
+	json.Unmarshal(doc["mcpServers"], &servers)
+	if servers["remote"]["url"] != "https://example.com" {
+		t.Fatalf("remote server lost its url: %+v", servers["remote"])
+	}
+	if servers["fs"]["command"] != "mcp-fs" {
+		t.Fatalf("fs server not merged: %+v", servers)
+	}
+
+	if err := RemoveFromProvider(path, "fs"); err != nil {
+		t.Fatalf("RemoveFromProvider error: %v", err)
+	}
+	have, _ := ReadProviderServers(path)
+	if _, ok := have["fs"]; ok {
+		t.Fatal("fs still present after RemoveFromProvider")
+	}
+	if _, ok := have["remote"]; !ok {
+		t.Fatal("remote removed by RemoveFromProvider")
+	}
+}
+
+func TestMissing(t *testing.T) {
+	want := map[string]Server{"a": {Command: "a"}, "b": {Command: "b"}}
+	have := map[string]Server{"a": {Command: "a"}, "b": {Command: "old"}}
+	if got := Missing(want, have); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("Missing = %v, want [b]", got)
+	}
+}
+
+func TestMergeIntoProviderKeepsTheRestOfTheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".claude.json")
+	original := "{\n  \"theme\": \"dark\",\n  \"mcpServers\": {},\n  \"autoUpdates\": false,\n  \"a\": 1\n}\n"
+	os.WriteFile(path, []byte(original), 0600)
+
+	if err := MergeIntoProvider(path, map[string]Server{"fs": {Command: "mcp-fs"}}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "{\n  \"theme\": \"dark\",\n  \"mcpServers\": {\n    \"fs\": {\n      \"command\": \"mcp-fs\"\n    }\n  },\n  \"autoUpdates\": false,\n  \"a\": 1\n}\n"
+	if string(data) != want {
+		t.Errorf("file = %s\nwant %s", data, want)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600 kept", info.Mode().Perm())
+	}
+
+	// Files without servers get the member appended
+	os.WriteFile(path, []byte("{\n  \"theme\": \"dark\"\n}\n"), 0644)
+	if err := MergeIntoProvider(path, map[string]Server{"fs": {Command: "mcp-fs"}}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	want = "{\n  \"theme\": \"dark\",\n  \"mcpServers\": {\n    \"fs\": {\n      \"command\": \"mcp-fs\"\n    }\n  }\n}\n"
+	if string(data) != want {
+		t.Errorf("file = %s\nwant %s", data, want)
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import "path/filepath"
+
+// AssetType identifies a kind of agent asset. Skills are directories; commands
+// and agents are single markdown files; MCP entries are server definitions
+// merged into a provider's native config file.
+type AssetType string
+
+const (
+	AssetSkills   AssetType = "skills"
+	AssetCommands AssetType = "commands"
+	AssetAgents   AssetType = "agents"
+	AssetMCP      AssetType = "mcp"
+)
+
+// AssetTypes returns every asset type in display order.
+func AssetTypes() []AssetType {
+	return []AssetType{AssetSkills, AssetCommands, AssetAgents, AssetMCP}
+}
+
+// IsFile reports whether assets of this type are single files rather than directories.
+func (t AssetType) IsFile() bool {
+	return t == AssetCommands || t == AssetAgents
+}
+
+// assetPaths maps provider name to the target locations of non-skill assets.
+// Providers missing from an entry do not support that asset type.
+var assetPaths = map[AssetType]map[string]func(home string) string{
+	AssetCommands: {
+		"claude":   func(h string) string { return filepath.Join(h, ".claude", "commands") },
+		"cursor":   func(h string) string { return filepath.Join(h, ".cursor", "commands") },
+		"opencode": func(h string) string { return filepath.Join(h, ".config", "opencode", "command") },
+		"codex":    func(h string) string { return filepath.Join(h, ".codex", "prompts") },
+	},
+	AssetAgents: {
+		"claude":   func(h string) string { return filepath.Join(h, ".claude", "agents") },
+		"opencode": func(h string) string { return filepath.Join(h, ".config", "opencode", "agent") },
+	},
+	AssetMCP: {
+		"claude":   func(h string) string { return filepath.Join(h, ".claude.json") },
+		"cursor":   func(h string) string { return filepath.Join(h, ".cursor", "mcp.json") },
+		"windsurf": func(h string) string { return filepath.Join(h, ".codeium", "windsurf", "mcp_config.json") },
+	},
+}
+
+// AssetPath returns where a provider keeps assets of the given type, or ""
+// when the provider does not support it. For AssetMCP this is a config file.
+func (d Definition) AssetPath(home string, t AssetType) string {
+	if t == AssetSkills {
+		return d.Path(home)
+	}
+	if fn, ok := assetPaths[t][d.Name]; ok {
+		return fn(home)
+	}
+	return ""
+}
+
+// projectAssetDirs maps provider name to the project-relative folder used for
+// project-scoped assets (e.g. .claude/commands inside a repository).
+var projectAssetDirs = map[AssetType]map[string]string{
+	AssetCommands: {
+		"claude": filepath.Join(".claude", "commands"),
+		"cursor": filepath.Join(".cursor", "commands"),
+	},
+	AssetAgents: {
+		"claude": filepath.Join(".claude", "agents"),
+	},
+}
+
+// ProjectAssetPath returns where a provider reads project-scoped assets of
+// the given type inside project, or "" when it has no project scope.
+func (d Definition) ProjectAssetPath(project string, t AssetType) string {
+	if rel, ok := projectAssetDirs[t][d.Name]; ok {
+		return filepath.Join(project, rel)
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Hook is an external command that manages a provider's skills in place of
+// symlinks, for agents that live on remote boxes, inside containers, or use
+// proprietary formats. The command is invoked as:
+//
+//	<command> link <skill> <source-dir>
+//	<command> unlink <skill>
+//	<command> list            (prints one skill name per line)
+//
+// The provider name is exported as EFX_SKILLS_PROVIDER.
+type Hook struct {
+	Provider string
+	Command  string
+}
+
+// CommandOutput receives hook diagnostics (stderr) as they are written, e.g.
+// a log pane in the TUI. Stdout is always kept for the hook protocol.
+var CommandOutput io.Writer
+
+// run executes the hook command with the given action arguments.
+func (h Hook) run(args ...string) (string, error) {
+	fields := strings.Fields(h.Command)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("provider %s: empty hook command", h.Provider)
+	}
+
+	cmd := exec.Command(fields[0], append(fields[1:], args...)...)
+	cmd.Env = append(os.Environ(), "EFX_SKILLS_PROVIDER="+h.Provider)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if CommandOutput != nil {
+		cmd.Stderr = io.MultiWriter(&stderr, CommandOutput)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("provider %s hook %s: %w: %s", h.Provider, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// Link asks the hook to make skillName (stored at sourceDir) available to the provider.
+func (h Hook) Link(skillName, sourceDir string) error {
+	_, err := h.run("link", skillName, sourceDir)
+	return err
+}
+
+// Unlink asks the hook to remove skillName from the provider.
+func (h Hook) Unlink(skillName string) error {
+	_, err := h.run("unlink", skillName)
+	return err
+}
+
+// List returns the skill names the hook reports as installed.
+func (h Hook) List() ([]string, error) {
+	out, err := h.run("list")
+	if err != nil {
+		return nil, err
+	}
+
+	var skills []string
+	for _, line := range strings.Split(out, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			skills = append(skills, name)
+		}
+	}
+	return skills, nil
+}
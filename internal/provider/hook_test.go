@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestHookLinkListUnlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell hooks not supported on windows")
+	}
+	dir := t.TempDir()
+	state := filepath.Join(dir, "state")
+	script := filepath.Join(dir, "hook.sh")
+	body := `#!/bin/sh
+case "$1" in
+  link) echo "$2" >> "` + state + `" ;;
+  unlink) grep -v "^$2\$" "` + state + `" > "` + state + `.tmp"; mv "` + state + `.tmp" "` + state + `" ;;
+  list) [ -f "` + state + `" ] && cat "` + state + `" ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("write hook: %v", err)
+	}
+
+	h := Hook{Provider: "remote", Command: script}
+	if err := h.Link("lint", "/store/lint"); err != nil {
+		t.Fatalf("Link error: %v", err)
+	}
+	if err := h.Link("deploy", "/store/deploy"); err != nil {
+		t.Fatalf("Link error: %v", err)
+	}
+	if err := h.Unlink("lint"); err != nil {
+		t.Fatalf("Unlink error: %v", err)
+	}
+
+	skills, err := h.List()
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if strings.Join(skills, ",") != "deploy" {
+		t.Fatalf("List() = %v, want [deploy]", skills)
+	}
+}
+
+func TestHookFailureIncludesStderr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell hooks not supported on windows")
+	}
+	script := filepath.Join(t.TempDir(), "hook.sh")
+	os.WriteFile(script, []byte("#!/bin/sh\necho boom >&2\nexit 1\n"), 0755)
+
+	err := Hook{Provider: "remote", Command: script}.Unlink("lint")
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("Unlink error = %v, want stderr included", err)
+	}
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"path"
+	"sync"
+)
+
+// DefaultIgnore lists the directory entries never treated as skills or
+// assets when scanning the store and provider folders: OS and VCS clutter,
+// dependency folders, a provider's exclusion list, and the staging folders
+// of interrupted installs.
+var DefaultIgnore = []string{
+	".DS_Store",
+	".git",
+	".skillignore",
+	"node_modules",
+	"*.tmp",
+	".*.install-*",
+	".*.link-*",
+}
+
+var (
+	ignoreMu       sync.RWMutex
+	ignorePatterns = DefaultIgnore
+)
+
+// SetIgnore adds user glob patterns, such as the "ignore" list in
+// config.json, to DefaultIgnore. Invalid patterns never match.
+func SetIgnore(extra []string) {
+	patterns := append(append([]string(nil), DefaultIgnore...), extra...)
+	ignoreMu.Lock()
+	ignorePatterns = patterns
+	ignoreMu.Unlock()
+}
+
+// Ignored reports whether a directory entry name matches an ignore pattern.
+func Ignored(name string) bool {
+	ignoreMu.RLock()
+	defer ignoreMu.RUnlock()
+	for _, pattern := range ignorePatterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package provider
+
+import "testing"
+
+func TestIgnored(t *testing.T) {
+	t.Cleanup(func() { SetIgnore(nil) })
+	SetIgnore([]string{"drafts-*", "[bad"})
+
+	tests := map[string]bool{
+		".DS_Store":           true,
+		".git":                true,
+		"node_modules":        true,
+		"notes.tmp":           true,
+		".demo.install-12345": true,
+		".demo.link-678":      true,
+		"drafts-old":          true,
+		"react-hooks":         false,
+		".claude":             false,
+		"tmp":                 false,
+		"drafts":              false,
+	}
+	for name, want := range tests {
+		if got := Ignored(name); got != want {
+			t.Errorf("Ignored(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
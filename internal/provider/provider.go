@@ -53,7 +53,7 @@ func DetectAll() []Provider {
 
 			if entries, err := os.ReadDir(p.SkillsPath); err == nil {
 				for _, e := range entries {
-					if e.Name() != ".DS_Store" {
+					if !Ignored(e.Name()) {
 						p.SkillCount++
 					}
 				}
@@ -82,7 +82,7 @@ func Get(name string) *Provider {
 
 				if entries, err := os.ReadDir(p.SkillsPath); err == nil {
 					for _, e := range entries {
-						if e.Name() != ".DS_Store" {
+						if !Ignored(e.Name()) {
 							p.SkillCount++
 						}
 					}
@@ -123,7 +123,7 @@ func (p *Provider) ListSkills() ([]string, error) {
 
 	var skills []string
 	for _, e := range entries {
-		if e.Name() != ".DS_Store" {
+		if !Ignored(e.Name()) {
 			skills = append(skills, e.Name())
 		}
 	}
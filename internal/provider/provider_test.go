@@ -37,3 +37,25 @@ func TestDefinitionsReturnCopy(t *testing.T) {
 		t.Fatalf("Definitions() returned mutable shared backing array")
 	}
 }
+
+func TestAssetPath(t *testing.T) {
+	var claude, qoder Definition
+	for _, def := range Definitions() {
+		switch def.Name {
+		case "claude":
+			claude = def
+		case "qoder":
+			qoder = def
+		}
+	}
+
+	if got, want := claude.AssetPath("/home/alice", AssetSkills), claude.Path("/home/alice"); got != want {
+		t.Fatalf("claude skills AssetPath = %q, want %q", got, want)
+	}
+	if got, want := claude.AssetPath("/home/alice", AssetCommands), filepath.Join("/home/alice", ".claude", "commands"); got != want {
+		t.Fatalf("claude commands AssetPath = %q, want %q", got, want)
+	}
+	if got := qoder.AssetPath("/home/alice", AssetAgents); got != "" {
+		t.Fatalf("qoder agents AssetPath = %q, want unsupported", got)
+	}
+}
@@ -0,0 +1,205 @@
+// Package secrets keeps the GitHub and registry tokens out of config.json:
+// in the system keychain (macOS Keychain, the Secret Service through
+// libsecret, Windows Credential Manager) or, where none is available such
+// as on headless Linux, in an encrypted file next to the config.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+// service groups the efx-skills entries in the keychain.
+const service = "efx-skills"
+
+// GitHub is the account of the token sent to GitHub.
+const GitHub = "github"
+
+// Registry returns the account of the token sent to a registry.
+func Registry(name string) string {
+	return "registry:" + name
+}
+
+// Backend is where a secret is kept.
+type Backend string
+
+const (
+	Keychain Backend = "keychain"
+	File     Backend = "encrypted file"
+)
+
+// ErrNotFound is returned for an account with no secret saved.
+var ErrNotFound = errors.New("no secret saved")
+
+// fileMu serialises reads and writes of the encrypted file.
+var fileMu sync.Mutex
+
+// Dir holds the encrypted file and its key.
+func Dir() string {
+	return filepath.Join(os.Getenv("HOME"), ".config", "efx-skills")
+}
+
+// FilePath returns the encrypted file used without a keychain.
+func FilePath() string {
+	return filepath.Join(Dir(), "credentials.enc")
+}
+
+// keyPath returns the file holding the key of FilePath. It keeps the
+// tokens out of config.json, dotfile repos and screenshots, not from
+// someone able to read the home directory.
+func keyPath() string {
+	return filepath.Join(Dir(), "credentials.key")
+}
+
+// Get returns the secret of account and where it was found, looking in the
+// keychain first.
+func Get(account string) (string, Backend, error) {
+	secret, err := keyring.Get(service, account)
+	if err == nil {
+		return secret, Keychain, nil
+	}
+	fileMu.Lock()
+	defer fileMu.Unlock()
+	stored, ferr := readFile()
+	if ferr != nil {
+		return "", "", ferr
+	}
+	if secret, ok := stored[account]; ok {
+		return secret, File, nil
+	}
+	return "", "", ErrNotFound
+}
+
+// Set saves the secret of account in the keychain, or in the encrypted file
+// when no keychain can be used, and returns where it went.
+func Set(account, secret string) (Backend, error) {
+	if err := keyring.Set(service, account, secret); err == nil {
+		// Drop a copy saved while the keychain was unavailable
+		removeFromFile(account)
+		return Keychain, nil
+	}
+	fileMu.Lock()
+	defer fileMu.Unlock()
+	stored, err := readFile()
+	if err != nil {
+		return "", err
+	}
+	stored[account] = secret
+	return File, writeFile(stored)
+}
+
+// Delete forgets the secret of account wherever it is kept.
+func Delete(account string) error {
+	err := keyring.Delete(service, account)
+	found := err == nil
+	if removed, ferr := removeFromFile(account); ferr != nil {
+		return ferr
+	} else if removed {
+		found = true
+	}
+	if !found {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// removeFromFile drops account from the encrypted file, reporting whether
+// it was there.
+func removeFromFile(account string) (bool, error) {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+	stored, err := readFile()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := stored[account]; !ok {
+		return false, nil
+	}
+	delete(stored, account)
+	return true, writeFile(stored)
+}
+
+// readFile decrypts the encrypted file; a missing one holds nothing.
+func readFile() (map[string]string, error) {
+	stored := make(map[string]string)
+	data, err := os.ReadFile(FilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return stored, nil
+	} else if err != nil {
+		return nil, err
+	}
+	gcm, err := fileCipher(false)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("%s is corrupted", FilePath())
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt %s: %w", FilePath(), err)
+	}
+	if err := json.Unmarshal(plain, &stored); err != nil {
+		return nil, fmt.Errorf("%s is corrupted: %w", FilePath(), err)
+	}
+	return stored, nil
+}
+
+// writeFile encrypts stored into the encrypted file, removing it once
+// empty.
+func writeFile(stored map[string]string) error {
+	if len(stored) == 0 {
+		if err := os.Remove(FilePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	gcm, err := fileCipher(true)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	return os.WriteFile(FilePath(), gcm.Seal(nonce, nonce, plain, nil), 0600)
+}
+
+// fileCipher returns the AES-GCM cipher of the encrypted file, creating
+// its key when create is set and there is none yet.
+func fileCipher(create bool) (cipher.AEAD, error) {
+	key, err := os.ReadFile(keyPath())
+	if errors.Is(err, os.ErrNotExist) && create {
+		key = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(Dir(), 0700); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(keyPath(), key, 0600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("cannot read the key of %s: %w", FilePath(), err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key in %s: %w", keyPath(), err)
+	}
+	return cipher.NewGCM(block)
+}
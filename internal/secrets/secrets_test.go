@@ -0,0 +1,81 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestKeychain(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	keyring.MockInit()
+
+	if backend, err := Set(GitHub, "ghp_secret"); err != nil || backend != Keychain {
+		t.Fatalf("Set = %q, %v; want the keychain", backend, err)
+	}
+	if secret, backend, err := Get(GitHub); err != nil || secret != "ghp_secret" || backend != Keychain {
+		t.Fatalf("Get = %q, %q, %v", secret, backend, err)
+	}
+	if _, err := os.Stat(FilePath()); !os.IsNotExist(err) {
+		t.Error("the encrypted file was written although the keychain works")
+	}
+	if err := Delete(GitHub); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Get(GitHub); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFallsBackToEncryptedFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	keyring.MockInitWithError(errors.New("no secret service"))
+	defer keyring.MockInit()
+
+	if backend, err := Set(Registry("corp"), "corp-secret"); err != nil || backend != File {
+		t.Fatalf("Set = %q, %v; want the encrypted file", backend, err)
+	}
+	data, err := os.ReadFile(FilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("corp-secret")) {
+		t.Error("the token is stored in plaintext")
+	}
+	if info, _ := os.Stat(FilePath()); info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+	if secret, backend, err := Get(Registry("corp")); err != nil || secret != "corp-secret" || backend != File {
+		t.Fatalf("Get = %q, %q, %v", secret, backend, err)
+	}
+	if _, _, err := Get(GitHub); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of an unsaved account = %v, want ErrNotFound", err)
+	}
+
+	if err := Delete(Registry("corp")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(FilePath()); !os.IsNotExist(err) {
+		t.Error("the emptied encrypted file was kept")
+	}
+	if err := Delete(Registry("corp")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestKeychainTakesOverFromFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	keyring.MockInitWithError(errors.New("locked"))
+	Set(GitHub, "old")
+	keyring.MockInit()
+
+	if backend, err := Set(GitHub, "new"); err != nil || backend != Keychain {
+		t.Fatalf("Set = %q, %v; want the keychain", backend, err)
+	}
+	if _, err := os.Stat(FilePath()); !os.IsNotExist(err) {
+		t.Error("the copy in the encrypted file was kept")
+	}
+}
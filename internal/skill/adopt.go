@@ -0,0 +1,151 @@
+package skill
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+)
+
+// SourceTypeLocal marks lock entries for skills with no known upstream.
+// Update checks skip them.
+const SourceTypeLocal = "local"
+
+// gitHubRemotePattern extracts owner/repo from https and ssh GitHub remotes.
+var gitHubRemotePattern = regexp.MustCompile(`github\.com[:/]([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// DetectGitSource returns the GitHub owner/repo and HEAD commit of the git
+// checkout containing dir, or empty strings when dir is not in a GitHub clone.
+func DetectGitSource(dir string) (source, commit string) {
+	out, err := exec.Command("git", "-C", dir, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", ""
+	}
+	m := gitHubRemotePattern.FindStringSubmatch(strings.TrimSpace(string(out)))
+	if m == nil {
+		return "", ""
+	}
+	if head, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output(); err == nil {
+		commit = strings.TrimSpace(string(head))
+	}
+	return m[1] + "/" + m[2], commit
+}
+
+// Adopt moves a real skill directory from a provider into the store and
+// leaves a symlink in its place. It refuses to overwrite an existing skill.
+func (s *Store) Adopt(providerPath, skillName string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
+	}
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	src := filepath.Join(providerPath, skillName)
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", src)
+	}
+
+	dst := filepath.Join(s.BaseDir, skillName)
+	if _, err := os.Lstat(dst); err == nil {
+		return errs.New(errs.Conflict, "%s already exists in the store", skillName)
+	}
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return err
+	}
+
+	if err := os.Rename(src, dst); err != nil {
+		// Different filesystems: copy then delete the original
+		if err := copyTree(src, dst); err != nil {
+			os.RemoveAll(dst)
+			return err
+		}
+		if err := os.RemoveAll(src); err != nil {
+			return err
+		}
+	}
+	return s.linkToProvider(skillName, providerPath)
+}
+
+// AddLocalToLock records a skill that has no upstream source.
+func (s *Store) AddLocalToLock(skillName string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
+	}
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	lock.Skills[skillName] = LockEntry{
+		Source:      SourceTypeLocal,
+		SourceType:  SourceTypeLocal,
+		InstalledAt: now,
+		UpdatedAt:   now,
+	}
+	return s.WriteLockFile(lock)
+}
+
+// copyTree copies a directory recursively, recreating symlinks as-is.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		}
+
+		in, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
@@ -0,0 +1,56 @@
+package skill
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestAdoptMovesDirectoryAndLinksBack(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, ".agents", "skills"))
+	providerDir := filepath.Join(tmp, ".claude", "skills")
+	os.MkdirAll(filepath.Join(providerDir, "mine"), 0755)
+	os.WriteFile(filepath.Join(providerDir, "mine", "SKILL.md"), []byte("# Mine"), 0644)
+
+	if err := store.Adopt(providerDir, "mine"); err != nil {
+		t.Fatalf("Adopt error: %v", err)
+	}
+	if !store.IsInstalled("mine") {
+		t.Fatal("skill not moved into the store")
+	}
+	info, err := os.Lstat(filepath.Join(providerDir, "mine"))
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("provider entry is not a symlink: %v", err)
+	}
+	if err := store.Adopt(providerDir, "mine"); err == nil {
+		t.Fatal("adopting a symlink should fail")
+	}
+
+	if err := store.AddLocalToLock("mine"); err != nil {
+		t.Fatalf("AddLocalToLock error: %v", err)
+	}
+	if hasUpdate, _, _, err := store.CheckForUpdate("mine"); hasUpdate || err != nil {
+		t.Fatalf("CheckForUpdate on local skill = %v, %v; want no update, nil", hasUpdate, err)
+	}
+}
+
+func TestDetectGitSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	if err := exec.Command("git", "-C", dir, "init", "-q").Run(); err != nil {
+		t.Skipf("git init failed: %v", err)
+	}
+	exec.Command("git", "-C", dir, "remote", "add", "origin", "git@github.com:acme/agent-skills.git").Run()
+
+	source, _ := DetectGitSource(dir)
+	if source != "acme/agent-skills" {
+		t.Fatalf("DetectGitSource = %q, want acme/agent-skills", source)
+	}
+	if source, _ := DetectGitSource(t.TempDir()); source != "" {
+		t.Fatalf("DetectGitSource outside a repo = %q, want empty", source)
+	}
+}
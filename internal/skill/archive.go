@@ -0,0 +1,274 @@
+package skill
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+)
+
+// SourceTypeArchive marks lock entries installed from a .zip or .tar.gz
+// URL, for skills distributed outside git. Their "commit" is the SHA-256 of
+// the archive, so a changed download counts as an update.
+const SourceTypeArchive = "archive"
+
+// archiveExts lists the archive formats accepted as sources.
+var archiveExts = []string{".zip", ".tar.gz", ".tgz"}
+
+// IsArchiveURL reports whether source is an http(s) URL of a skill archive.
+// A "#sha256=<hex>" fragment pins the expected checksum.
+func IsArchiveURL(source string) bool {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		return false
+	}
+	return archiveExt(source) != ""
+}
+
+// archiveExt returns the archive extension of u, ignoring any query or
+// fragment, or "" when u is not an archive.
+func archiveExt(u string) string {
+	u, _, _ = strings.Cut(u, "#")
+	u, _, _ = strings.Cut(u, "?")
+	for _, ext := range archiveExts {
+		if strings.HasSuffix(strings.ToLower(u), ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// ArchiveSkillName derives a skill name from an archive URL:
+// https://example.com/dl/deploy-1.2.zip names "deploy-1.2". URLs whose file
+// name leaves no usable name, such as https://example.com/.zip, fail.
+func ArchiveSkillName(u string) (string, error) {
+	u, _, _ = strings.Cut(u, "#")
+	u, _, _ = strings.Cut(u, "?")
+	base := path.Base(u)
+	name := base[:len(base)-len(archiveExt(base))]
+	if err := fsutil.ValidName(name); err != nil {
+		return "", fmt.Errorf("%s names no skill: %w", u, err)
+	}
+	return name, nil
+}
+
+// splitChecksum separates the "#sha256=" fragment from an archive URL.
+func splitChecksum(source string) (u, sum string) {
+	u, frag, _ := strings.Cut(source, "#")
+	if v, ok := strings.CutPrefix(frag, "sha256="); ok {
+		sum = strings.ToLower(v)
+	}
+	return u, sum
+}
+
+// fetchArchive downloads an archive and returns it with its SHA-256,
+// failing when it does not match the checksum pinned in source.
+func fetchArchive(source string) ([]byte, string, error) {
+	u, want := splitChecksum(source)
+	resp, err := GitHubGet(u)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", errs.FromResponse(resp, "downloading %s: %s", u, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading %s: %w", u, err)
+	}
+
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	if want != "" && got != want {
+		return nil, "", fmt.Errorf("checksum mismatch for %s: got sha256 %s, want %s", u, got, want)
+	}
+	return data, got, nil
+}
+
+// archiveCommitHash returns the SHA-256 of the archive currently served.
+func archiveCommitHash(source string) (string, error) {
+	_, sum, err := fetchArchive(source)
+	return sum, err
+}
+
+// installArchive downloads and extracts an archive, then moves the folder
+// holding SKILL.md into the store as skillName.
+func (s *Store) installArchive(source, skillName string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
+	}
+	data, _, err := fetchArchive(source)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(s.BaseDir, "."+skillName+".install-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	extracted := filepath.Join(tmp, "archive")
+	if archiveExt(source) == ".zip" {
+		err = extractZip(data, extracted)
+	} else {
+		err = extractTarGz(data, extracted)
+	}
+	if err != nil {
+		return fmt.Errorf("extracting %s: %w", source, err)
+	}
+
+	dir, err := findArchiveSkill(extracted, skillName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.moveIntoStore(filepath.Join(extracted, filepath.FromSlash(dir)), skillName); err != nil {
+		return err
+	}
+	return s.recordInstall(skillName, source, dir, "", false)
+}
+
+// findArchiveSkill returns the folder of an extracted archive holding
+// SKILL.md: the root, its only folder (as GitHub release archives have), or
+// any of the usual spots for skillName.
+func findArchiveSkill(root, skillName string) (string, error) {
+	candidates := []string{""}
+	if entries, err := os.ReadDir(root); err == nil && len(entries) == 1 && entries[0].IsDir() {
+		only := entries[0].Name()
+		candidates = append(candidates, only)
+		for _, c := range skillDirCandidates(skillName) {
+			candidates = append(candidates, path.Join(only, c))
+		}
+	}
+	candidates = append(candidates, skillDirCandidates(skillName)...)
+
+	for _, dir := range candidates {
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(dir), "SKILL.md")); err == nil {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("no SKILL.md found in archive for %s", skillName)
+}
+
+// archiveTarget returns where an archive entry is extracted, refusing names
+// that would escape dest.
+func archiveTarget(dest, name string) (string, error) {
+	name = strings.ReplaceAll(name, `\`, "/")
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return "", fmt.Errorf("unsafe path in archive: %s", name)
+		}
+	}
+	clean := path.Clean(strings.TrimLeft(name, "/"))
+	if clean == "." {
+		return "", nil
+	}
+	return filepath.Join(dest, filepath.FromSlash(clean)), nil
+}
+
+// writeArchiveFile writes one extracted file, keeping its execute bits.
+func writeArchiveFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	perm := os.FileMode(0644)
+	if mode&0111 != 0 {
+		perm = 0755
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// extractZip extracts the directories and regular files of a zip archive.
+// Symlinks and other special entries are skipped.
+func extractZip(data []byte, dest string) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		target, err := archiveTarget(dest, f.Name)
+		if err != nil {
+			return err
+		}
+		mode := f.Mode()
+		switch {
+		case target == "":
+		case mode.IsDir():
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			err = writeArchiveFile(target, rc, mode)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return os.MkdirAll(dest, 0755)
+}
+
+// extractTarGz extracts the directories and regular files of a gzipped
+// tarball. Symlinks and other special entries are skipped.
+func extractTarGz(data []byte, dest string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		target, err := archiveTarget(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch {
+		case target == "":
+		case hdr.Typeflag == tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case hdr.Typeflag == tar.TypeReg:
+			if err := writeArchiveFile(target, tr, os.FileMode(hdr.Mode)); err != nil {
+				return err
+			}
+		}
+	}
+	return os.MkdirAll(dest, 0755)
+}
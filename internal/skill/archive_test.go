@@ -0,0 +1,170 @@
+package skill
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	return buf.Bytes()
+}
+
+func tarGzArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func serveArchives(t *testing.T, archives map[string][]byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := archives[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestIsArchiveURL(t *testing.T) {
+	for source, want := range map[string]bool{
+		"https://example.com/dl/deploy.zip":               true,
+		"https://example.com/dl/deploy.tar.gz#sha256=abc": true,
+		"http://example.com/deploy.tgz?token=1":           true,
+		"https://example.com/deploy":                      false,
+		"owner/repo":                                      false,
+	} {
+		if got := IsArchiveURL(source); got != want {
+			t.Errorf("IsArchiveURL(%q) = %v, want %v", source, got, want)
+		}
+	}
+	if got, err := ArchiveSkillName("https://example.com/dl/deploy-1.2.tar.gz#sha256=abc"); err != nil || got != "deploy-1.2" {
+		t.Errorf("ArchiveSkillName = %q, %v, want deploy-1.2", got, err)
+	}
+	for _, u := range []string{"https://x/.zip", "https://x/..tar.gz", "https://x/...zip"} {
+		if name, err := ArchiveSkillName(u); err == nil {
+			t.Errorf("ArchiveSkillName(%q) = %q, want an error", u, name)
+		}
+		if _, err := ParseSource(u); err == nil {
+			t.Errorf("ParseSource(%q) should fail", u)
+		}
+	}
+}
+
+func TestInstallArchiveExtractsSkill(t *testing.T) {
+	server := serveArchives(t, map[string][]byte{
+		"/deploy.zip":    zipArchive(t, map[string]string{"deploy-main/SKILL.md": "# Deploy", "deploy-main/run.sh": "echo"}),
+		"/deploy.tar.gz": tarGzArchive(t, map[string]string{"SKILL.md": "# Deploy", "scripts/run.sh": "echo"}),
+	})
+
+	for _, file := range []string{"/deploy.zip", "/deploy.tar.gz"} {
+		store := NewStore(filepath.Join(t.TempDir(), "skills"))
+		source := server.URL + file
+		if err := store.installNative(source, "deploy", "", "", false); err != nil {
+			t.Fatalf("%s: install error: %v", file, err)
+		}
+		if data, err := os.ReadFile(filepath.Join(store.BaseDir, "deploy", "SKILL.md")); err != nil || string(data) != "# Deploy" {
+			t.Errorf("%s: SKILL.md = %q, %v", file, data, err)
+		}
+		lock, _ := store.ReadLockFile()
+		if entry := lock.Skills["deploy"]; entry.SourceType != SourceTypeArchive || entry.SourceURL != source {
+			t.Errorf("%s: lock entry = %+v", file, entry)
+		}
+	}
+}
+
+func TestVetFailureKeepsInstalledSkill(t *testing.T) {
+	server := serveArchives(t, map[string][]byte{
+		"/deploy.zip": zipArchive(t, map[string]string{"SKILL.md": "# Deploy v2"}),
+	})
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "deploy"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "deploy", "SKILL.md"), []byte("# Deploy v1"), 0644)
+	store.AddToLock("deploy", "team/skills", "abc")
+
+	store.Vet = func(dir string) error {
+		if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err != nil {
+			t.Errorf("vetted %s without its SKILL.md", dir)
+		}
+		return fmt.Errorf("blocked")
+	}
+	if err := store.installNative(server.URL+"/deploy.zip", "deploy", "", "", false); err == nil {
+		t.Fatal("install succeeded past a failed vet")
+	}
+	if data, _ := os.ReadFile(filepath.Join(store.BaseDir, "deploy", "SKILL.md")); string(data) != "# Deploy v1" {
+		t.Errorf("SKILL.md = %q, want the installed copy kept", data)
+	}
+	lock, _ := store.ReadLockFile()
+	if entry := lock.Skills["deploy"]; entry.Source != "team/skills" {
+		t.Errorf("lock entry = %+v, want it untouched", entry)
+	}
+	if entries, _ := os.ReadDir(store.BaseDir); len(entries) != 1 {
+		t.Errorf("store holds %d entries, want only deploy", len(entries))
+	}
+}
+
+func TestInstallArchiveVerifiesChecksum(t *testing.T) {
+	data := zipArchive(t, map[string]string{"SKILL.md": "# Deploy"})
+	server := serveArchives(t, map[string][]byte{"/deploy.zip": data})
+	sum := sha256.Sum256(data)
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+
+	err := store.installNative(server.URL+"/deploy.zip#sha256="+strings.Repeat("0", 64), "deploy", "", "", false)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+	if store.IsInstalled("deploy") {
+		t.Error("skill installed despite a bad checksum")
+	}
+
+	source := server.URL + "/deploy.zip#sha256=" + hex.EncodeToString(sum[:])
+	if err := store.installNative(source, "deploy", "", "", false); err != nil {
+		t.Fatalf("install with matching checksum: %v", err)
+	}
+	if got, err := SourceCommit(source, ""); err != nil || got != hex.EncodeToString(sum[:]) {
+		t.Errorf("SourceCommit = %q, %v", got, err)
+	}
+}
+
+func TestExtractRejectsEscapingPaths(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "out")
+	if err := extractZip(zipArchive(t, map[string]string{"../evil": "x"}), dest); err == nil {
+		t.Error("expected zip with ../ to be rejected")
+	}
+	if err := extractTarGz(tarGzArchive(t, map[string]string{"a/../../evil": "x"}), dest); err == nil {
+		t.Error("expected tarball with ../ to be rejected")
+	}
+}
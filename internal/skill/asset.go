@@ -0,0 +1,237 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/provider"
+)
+
+// AssetDir returns the central storage directory for an asset type. Non-skill
+// assets live next to the skills directory (e.g. ~/.agents/commands).
+func (s *Store) AssetDir(t provider.AssetType) string {
+	if t == provider.AssetSkills {
+		return s.BaseDir
+	}
+	return filepath.Join(filepath.Dir(s.BaseDir), string(t))
+}
+
+// assetEntryName returns the on-disk entry name for an asset: skills are
+// directories, commands and agents are markdown files, MCP servers are JSON files.
+func assetEntryName(t provider.AssetType, name string) string {
+	switch {
+	case t.IsFile():
+		return name + ".md"
+	case t == provider.AssetMCP:
+		return name + ".json"
+	default:
+		return name
+	}
+}
+
+// ListAssets returns the sorted names of stored assets of the given type,
+// without file extensions. A missing storage directory yields no assets.
+func (s *Store) ListAssets(t provider.AssetType) ([]string, error) {
+	entries, err := os.ReadDir(s.AssetDir(t))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		switch {
+		case provider.Ignored(name):
+			continue
+		case t == provider.AssetSkills:
+			if !e.IsDir() {
+				continue
+			}
+		case t.IsFile():
+			if e.IsDir() || !strings.HasSuffix(name, ".md") {
+				continue
+			}
+			name = strings.TrimSuffix(name, ".md")
+		case t == provider.AssetMCP:
+			if e.IsDir() || !strings.HasSuffix(name, ".json") {
+				continue
+			}
+			name = strings.TrimSuffix(name, ".json")
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// AddAsset writes a file-based asset (command, agent or MCP definition) into
+// central storage, replacing any existing copy.
+func (s *Store) AddAsset(t provider.AssetType, name string, content []byte) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if t == provider.AssetSkills {
+		return fmt.Errorf("skills are installed as directories, not single files")
+	}
+	dir := s.AssetDir(t)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, assetEntryName(t, name)), content, 0644)
+}
+
+// RemoveAsset deletes an asset from central storage.
+func (s *Store) RemoveAsset(t provider.AssetType, name string) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return os.RemoveAll(filepath.Join(s.AssetDir(t), assetEntryName(t, name)))
+}
+
+// LinkAsset creates a relative symlink to a stored asset inside targetDir.
+// MCP definitions are merged into provider config files instead of linked.
+func (s *Store) LinkAsset(t provider.AssetType, name, targetDir string) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if t == provider.AssetMCP {
+		return fmt.Errorf("MCP servers are synced into provider config, not linked")
+	}
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return err
+	}
+
+	entry := assetEntryName(t, name)
+	sourcePath := filepath.Join(s.AssetDir(t), entry)
+	targetPath := filepath.Join(targetDir, entry)
+
+	if err := os.Remove(targetPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("replacing %s: %w", targetPath, err)
+	}
+
+	relPath, err := filepath.Rel(targetDir, sourcePath)
+	if err != nil {
+		return err
+	}
+	return os.Symlink(relPath, targetPath)
+}
+
+// UnlinkAsset removes a linked asset from targetDir.
+func (s *Store) UnlinkAsset(t provider.AssetType, name, targetDir string) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return os.Remove(filepath.Join(targetDir, assetEntryName(t, name)))
+}
+
+// RemoteAsset is a file-based asset discovered in a GitHub repository.
+type RemoteAsset struct {
+	Name        string
+	Path        string
+	DownloadURL string
+}
+
+// assetSearchDirs lists the repository folders conventionally holding each
+// file-based asset type.
+var assetSearchDirs = map[provider.AssetType][]string{
+	provider.AssetCommands: {"commands", ".claude/commands"},
+	provider.AssetAgents:   {"agents", ".claude/agents"},
+}
+
+// DiscoverAssets lists the markdown assets of type t published in owner/repo,
+// looking in the conventional folders via the GitHub contents API.
+func DiscoverAssets(owner, repo string, t provider.AssetType) ([]RemoteAsset, error) {
+	dirs, ok := assetSearchDirs[t]
+	if !ok {
+		return nil, fmt.Errorf("cannot discover %s in repositories", t)
+	}
+
+	seen := make(map[string]bool)
+	var assets []RemoteAsset
+	for _, dir := range dirs {
+		url := fmt.Sprintf("%s/repos/%s/%s/contents/%s", apiBase(owner, repo), owner, repo, dir)
+		resp, err := GitHubGet(url)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", dir, err)
+		}
+
+		var entries []struct {
+			Name        string `json:"name"`
+			Path        string `json:"path"`
+			Type        string `json:"type"`
+			DownloadURL string `json:"download_url"`
+		}
+		status := resp.StatusCode
+		if status == http.StatusOK {
+			err = json.NewDecoder(resp.Body).Decode(&entries)
+		}
+		resp.Body.Close()
+
+		if status == http.StatusNotFound {
+			continue
+		}
+		if status != http.StatusOK {
+			return nil, errs.New(errs.Status(status, resp.Header), "GitHub API returned status %d for %s", status, dir)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding contents of %s: %w", dir, err)
+		}
+
+		for _, e := range entries {
+			if e.Type != "file" || !strings.HasSuffix(e.Name, ".md") || strings.EqualFold(e.Name, "README.md") {
+				continue
+			}
+			name := strings.TrimSuffix(e.Name, ".md")
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			assets = append(assets, RemoteAsset{Name: name, Path: e.Path, DownloadURL: e.DownloadURL})
+		}
+	}
+
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Name < assets[j].Name })
+	return assets, nil
+}
+
+// InstallRemoteAsset downloads a discovered asset into central storage. The
+// store is only locked by AddAsset, once the download is done.
+func (s *Store) InstallRemoteAsset(t provider.AssetType, asset RemoteAsset) error {
+	resp, err := GitHubGet(asset.DownloadURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errs.FromResponse(resp, "failed to download %s: %s", asset.Path, resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return s.AddAsset(t, asset.Name, content)
+}
@@ -0,0 +1,103 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/provider"
+)
+
+func TestAssetDirIsSiblingOfSkills(t *testing.T) {
+	store := NewStore("/home/alice/.agents/skills")
+
+	if got := store.AssetDir(provider.AssetSkills); got != "/home/alice/.agents/skills" {
+		t.Errorf("AssetDir(skills) = %q, want store BaseDir", got)
+	}
+	if got := store.AssetDir(provider.AssetCommands); got != "/home/alice/.agents/commands" {
+		t.Errorf("AssetDir(commands) = %q, want %q", got, "/home/alice/.agents/commands")
+	}
+}
+
+func TestAddListLinkAsset(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, ".agents", "skills"))
+
+	if err := store.AddAsset(provider.AssetCommands, "review", []byte("# Review")); err != nil {
+		t.Fatalf("AddAsset error: %v", err)
+	}
+	names, err := store.ListAssets(provider.AssetCommands)
+	if err != nil {
+		t.Fatalf("ListAssets error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "review" {
+		t.Fatalf("ListAssets = %v, want [review]", names)
+	}
+
+	target := filepath.Join(tmp, ".claude", "commands")
+	if err := store.LinkAsset(provider.AssetCommands, "review", target); err != nil {
+		t.Fatalf("LinkAsset error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(target, "review.md"))
+	if err != nil || string(data) != "# Review" {
+		t.Fatalf("linked command content = %q, err %v", data, err)
+	}
+
+	if err := store.UnlinkAsset(provider.AssetCommands, "review", target); err != nil {
+		t.Fatalf("UnlinkAsset error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(target, "review.md")); !os.IsNotExist(err) {
+		t.Fatalf("link still present after UnlinkAsset: %v", err)
+	}
+}
+
+func TestListAssetsMissingDir(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	names, err := store.ListAssets(provider.AssetAgents)
+	if err != nil || len(names) != 0 {
+		t.Fatalf("ListAssets on missing dir = %v, %v; want empty, nil", names, err)
+	}
+}
+
+func TestDiscoverAndInstallRemoteAssets(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/contents/commands":
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"name": "review.md", "path": "commands/review.md", "type": "file", "download_url": server.URL + "/raw/review.md"},
+				{"name": "README.md", "path": "commands/README.md", "type": "file", "download_url": server.URL + "/raw/README.md"},
+				{"name": "nested", "path": "commands/nested", "type": "dir"},
+			})
+		case "/raw/review.md":
+			w.Write([]byte("# Review"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	orig := gitHubAPIBaseURL
+	gitHubAPIBaseURL = server.URL
+	defer func() { gitHubAPIBaseURL = orig }()
+
+	assets, err := DiscoverAssets("owner", "repo", provider.AssetCommands)
+	if err != nil {
+		t.Fatalf("DiscoverAssets error: %v", err)
+	}
+	if len(assets) != 1 || assets[0].Name != "review" {
+		t.Fatalf("DiscoverAssets = %+v, want only review", assets)
+	}
+
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	if err := store.InstallRemoteAsset(provider.AssetCommands, assets[0]); err != nil {
+		t.Fatalf("InstallRemoteAsset error: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(store.AssetDir(provider.AssetCommands), "review.md"))
+	if string(data) != "# Review" {
+		t.Fatalf("installed content = %q, want %q", data, "# Review")
+	}
+}
@@ -0,0 +1,177 @@
+package skill
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/lmarques/efx-skills/internal/secrets"
+)
+
+// GitHub requests are authenticated when a token is available, so skills
+// can be installed and previewed from private repositories. The token is
+// looked up, in order, from SetGitHubToken (the "github_token" config
+// setting, read from "$VAR"), $GITHUB_TOKEN, $GH_TOKEN, the keychain
+// (`efx-skills auth set github`), and finally `gh auth token`.
+var (
+	tokenMu         sync.Mutex
+	configuredToken string
+	savedToken      string
+	savedLoaded     bool
+	ghToken         string
+	ghTokenLoaded   bool
+	ghHostTokens    = make(map[string]string)
+)
+
+// savedGitHubToken reads the token saved in the keychain or its encrypted
+// file fallback. Tests replace it.
+var savedGitHubToken = func() string {
+	token, _, _ := secrets.Get(secrets.GitHub)
+	return token
+}
+
+// ghAuthToken asks the GitHub CLI for its token. Tests replace it.
+var ghAuthToken = func() string {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+	out, err := exec.Command("gh", "auth", "token").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// ghHostToken asks the GitHub CLI for its token of an enterprise host.
+// Tests replace it.
+var ghHostToken = func(host string) string {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+	out, err := exec.Command("gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// SetGitHubToken sets the token configured by the user, which must be a
+// "$VAR" naming an environment variable holding the token. Plaintext
+// tokens are ignored; the keychain holds those.
+func SetGitHubToken(token string) {
+	if strings.HasPrefix(token, "$") {
+		token = os.Getenv(token[1:])
+	} else {
+		token = ""
+	}
+	tokenMu.Lock()
+	configuredToken = strings.TrimSpace(token)
+	tokenMu.Unlock()
+}
+
+// GitHubToken returns the token used for GitHub requests, or "".
+func GitHubToken() string {
+	tokenMu.Lock()
+	defer tokenMu.Unlock()
+	if configuredToken != "" {
+		return configuredToken
+	}
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return token
+		}
+	}
+	// The keychain is only read once per run
+	if !savedLoaded {
+		savedToken, savedLoaded = savedGitHubToken(), true
+	}
+	if savedToken != "" {
+		return savedToken
+	}
+	// gh is only asked once per run
+	if !ghTokenLoaded {
+		ghToken, ghTokenLoaded = ghAuthToken(), true
+	}
+	return ghToken
+}
+
+// enterpriseToken returns the token for a GitHub Enterprise host from
+// $GH_ENTERPRISE_TOKEN or $GITHUB_ENTERPRISE_TOKEN, like gh, else gh's
+// token for that host. The github.com token is never sent to another host.
+func enterpriseToken(host string) string {
+	for _, env := range []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return token
+		}
+	}
+	tokenMu.Lock()
+	defer tokenMu.Unlock()
+	// gh is only asked once per host and run
+	token, ok := ghHostTokens[host]
+	if !ok {
+		token = ghHostToken(host)
+		ghHostTokens[host] = token
+	}
+	return token
+}
+
+// isGitHubHost reports whether u is served by GitHub, a configured
+// enterprise host (or the API server used in tests), the only hosts a
+// token is ever sent to.
+func isGitHubHost(u string) bool {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	switch parsed.Host {
+	case "api.github.com", "raw.githubusercontent.com", "github.com", "codeload.github.com":
+		return true
+	}
+	if isEnterpriseHost(parsed.Host) {
+		return true
+	}
+	if base, err := url.Parse(gitHubAPIBaseURL); err == nil {
+		return parsed.Host == base.Host
+	}
+	return false
+}
+
+// NewGitHubRequest builds a GET request for u, authenticated when u is a
+// GitHub URL and a token is available.
+func NewGitHubRequest(u string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isGitHubHost(u) {
+		token := GitHubToken()
+		if isEnterpriseHost(req.URL.Host) {
+			token = enterpriseToken(req.URL.Hostname())
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	return req, nil
+}
+
+// GitHubGet is http.Get with NewGitHubRequest's authentication.
+func GitHubGet(u string) (*http.Response, error) {
+	req, err := NewGitHubRequest(u)
+	if err != nil {
+		return nil, err
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// privateRepoHint suggests authenticating when a repository could not be
+// read without a token.
+func privateRepoHint() string {
+	if GitHubToken() != "" {
+		return ""
+	}
+	return " (for a private repository, run efx-skills auth set github or gh auth login)"
+}
@@ -0,0 +1,125 @@
+package skill
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// TestMain keeps the tests away from the keychain of the machine running them.
+func TestMain(m *testing.M) {
+	keyring.MockInit()
+	os.Exit(m.Run())
+}
+
+// useToken isolates the token lookup from the environment and gh.
+func useToken(t *testing.T, configured, env, gh string) {
+	t.Helper()
+	useTokens(t, configured, "", env, gh)
+}
+
+// useTokens also sets the token saved in the keychain.
+func useTokens(t *testing.T, configured, saved, env, gh string) {
+	t.Helper()
+	t.Setenv("GITHUB_TOKEN", env)
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+	origGH, origHost, origSaved := ghAuthToken, ghHostToken, savedGitHubToken
+	ghAuthToken = func() string { return gh }
+	ghHostToken = func(string) string { return "" }
+	savedGitHubToken = func() string { return saved }
+	// config.json only names the variable holding its token
+	t.Setenv("CONFIGURED_TOKEN", configured)
+	SetGitHubToken("$CONFIGURED_TOKEN")
+	ghTokenLoaded, savedLoaded = false, false
+	clear(ghHostTokens)
+	t.Cleanup(func() {
+		ghAuthToken, ghHostToken, savedGitHubToken = origGH, origHost, origSaved
+		SetGitHubToken("")
+		ghTokenLoaded, savedLoaded = false, false
+		clear(ghHostTokens)
+	})
+}
+
+func TestGitHubTokenPrecedence(t *testing.T) {
+	tests := []struct {
+		configured, saved, env, gh, want string
+	}{
+		{"cfg", "saved", "env", "gh", "cfg"},
+		{"", "saved", "env", "gh", "env"},
+		{"", "saved", "", "gh", "saved"},
+		{"", "", "", "gh", "gh"},
+		{"", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		useTokens(t, tt.configured, tt.saved, tt.env, tt.gh)
+		if got := GitHubToken(); got != tt.want {
+			t.Errorf("GitHubToken() with %+v = %q, want %q", tt, got, tt.want)
+		}
+	}
+}
+
+func TestSetGitHubTokenReadsVariable(t *testing.T) {
+	useToken(t, "", "", "")
+	t.Setenv("COMPANY_TOKEN", "secret")
+	SetGitHubToken("$COMPANY_TOKEN")
+	if got := GitHubToken(); got != "secret" {
+		t.Errorf("GitHubToken() = %q, want secret", got)
+	}
+}
+
+func TestSetGitHubTokenIgnoresPlaintext(t *testing.T) {
+	useToken(t, "", "", "")
+	SetGitHubToken("ghp_plaintext")
+	if got := GitHubToken(); got != "" {
+		t.Errorf("GitHubToken() = %q, want the plaintext token ignored", got)
+	}
+}
+
+func TestGitHubGetSendsTokenToGitHubOnly(t *testing.T) {
+	useToken(t, "secret", "", "")
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	orig := gitHubAPIBaseURL
+	gitHubAPIBaseURL = server.URL
+	resp, err := GitHubGet(server.URL + "/repos/owner/private")
+	if err != nil {
+		t.Fatalf("GitHubGet error: %v", err)
+	}
+	resp.Body.Close()
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want Bearer secret", auth)
+	}
+
+	gitHubAPIBaseURL = "https://api.github.com"
+	defer func() { gitHubAPIBaseURL = orig }()
+	resp, err = GitHubGet(server.URL + "/elsewhere")
+	if err != nil {
+		t.Fatalf("GitHubGet error: %v", err)
+	}
+	resp.Body.Close()
+	if auth != "" {
+		t.Errorf("token sent to non-GitHub host: %q", auth)
+	}
+}
+
+func TestSkillNotFoundSuggestsAuth(t *testing.T) {
+	newRepoServer(t)
+	useToken(t, "", "", "")
+	_, _, err := findSkillDir("owner", "repo", "missing", "", "")
+	if err == nil {
+		t.Fatal("expected error for missing skill")
+	}
+	if got := err.Error(); !strings.Contains(got, "efx-skills auth set github") || !strings.Contains(got, "gh auth login") {
+		t.Errorf("error %q does not suggest authenticating", got)
+	}
+}
@@ -0,0 +1,88 @@
+package skill
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// composeSeparator divides skills in a composed instructions file.
+const composeSeparator = "\n\n---\n\n"
+
+// composedHeader starts every composed file, telling generated files from
+// hand-written ones.
+const composedHeader = "<!-- Generated by efx-skills"
+
+// Compose concatenates the SKILL.md of each named skill, in order, under a
+// generated header. YAML frontmatter is dropped since single-file providers
+// do not read it.
+func (s *Store) Compose(names []string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s from: %s -->\n", composedHeader, strings.Join(names, ", "))
+	b.WriteString("<!-- Do not edit: changes are overwritten by `efx-skills sync`. -->")
+
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(s.BaseDir, name, "SKILL.md"))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		b.WriteString(composeSeparator)
+		fmt.Fprintf(&b, "<!-- skill: %s -->\n", name)
+		b.WriteString(strings.TrimSpace(stripFrontmatter(string(data))))
+	}
+	b.WriteString("\n")
+	return b.Bytes(), nil
+}
+
+// WriteComposed regenerates output from the named skills, leaving the file
+// untouched when its content is already current. It reports whether the
+// file was written. A file without the generated header was written by
+// hand and is never overwritten.
+func (s *Store) WriteComposed(output string, names []string) (bool, error) {
+	content, err := s.Compose(names)
+	if err != nil {
+		return false, err
+	}
+	if current, err := os.ReadFile(output); err == nil {
+		if bytes.Equal(current, content) {
+			return false, nil
+		}
+		if !bytes.HasPrefix(current, []byte(composedHeader)) {
+			return false, errs.WithHint(errs.Conflict, "move it aside, or point the compose target at another file",
+				"%s was not generated by efx-skills", output)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(output, content, 0644)
+}
+
+// ComposedCurrent reports whether output already holds what WriteComposed
+// would write, without writing it.
+func (s *Store) ComposedCurrent(output string, names []string) (bool, error) {
+	content, err := s.Compose(names)
+	if err != nil {
+		return false, err
+	}
+	current, err := os.ReadFile(output)
+	return err == nil && bytes.Equal(current, content), nil
+}
+
+// stripFrontmatter removes a leading "---" delimited YAML block.
+func stripFrontmatter(content string) string {
+	if !strings.HasPrefix(content, "---") {
+		return content
+	}
+	rest := content[3:]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return content
+	}
+	rest = rest[end+4:]
+	return strings.TrimPrefix(strings.TrimPrefix(rest, "\r"), "\n")
+}
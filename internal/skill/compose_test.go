@@ -0,0 +1,63 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+func TestWriteComposedOrdersAndRegenerates(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "skills"))
+	for name, body := range map[string]string{
+		"alpha": "---\nname: alpha\n---\n# Alpha\n",
+		"beta":  "# Beta\n",
+	} {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte(body), 0644)
+	}
+
+	output := filepath.Join(tmp, "CONVENTIONS.md")
+	written, err := store.WriteComposed(output, []string{"beta", "alpha"})
+	if err != nil || !written {
+		t.Fatalf("WriteComposed = %v, %v; want written", written, err)
+	}
+	data, _ := os.ReadFile(output)
+	content := string(data)
+	if strings.Contains(content, "name: alpha") {
+		t.Fatalf("frontmatter not stripped:\n%s", content)
+	}
+	if strings.Index(content, "# Beta") > strings.Index(content, "# Alpha") {
+		t.Fatalf("skills not in requested order:\n%s", content)
+	}
+
+	if written, _ := store.WriteComposed(output, []string{"beta", "alpha"}); written {
+		t.Fatal("WriteComposed rewrote an up-to-date file")
+	}
+	os.WriteFile(filepath.Join(store.BaseDir, "beta", "SKILL.md"), []byte("# Beta v2\n"), 0644)
+	if current, err := store.ComposedCurrent(output, []string{"beta", "alpha"}); err != nil || current {
+		t.Fatalf("ComposedCurrent after a source change = %v, %v; want false", current, err)
+	}
+	if written, _ := store.WriteComposed(output, []string{"beta", "alpha"}); !written {
+		t.Fatal("WriteComposed did not regenerate after a source change")
+	}
+}
+
+func TestWriteComposedKeepsHandWrittenFiles(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "alpha"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "alpha", "SKILL.md"), []byte("# Alpha\n"), 0644)
+	output := filepath.Join(tmp, "AGENTS.md")
+	os.WriteFile(output, []byte("# Our own rules\n"), 0644)
+
+	if written, err := store.WriteComposed(output, []string{"alpha"}); written || errs.KindOf(err) != errs.Conflict {
+		t.Fatalf("WriteComposed over a hand-written file = %v, %v; want a conflict", written, err)
+	}
+	if data, _ := os.ReadFile(output); string(data) != "# Our own rules\n" {
+		t.Errorf("hand-written file changed to %q", data)
+	}
+}
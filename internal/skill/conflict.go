@@ -0,0 +1,118 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+)
+
+// ConflictStrategy says what an update does with a skill edited in the
+// store since it was installed or last updated.
+type ConflictStrategy string
+
+const (
+	StrategyRefuse ConflictStrategy = ""           // fail, reporting the local changes
+	KeepLocal      ConflictStrategy = "keep"       // leave the edited skill as it is
+	TakeUpstream   ConflictStrategy = "upstream"   // overwrite the edits
+	SaveLocal      ConflictStrategy = "save-local" // keep the edits as <skill>.local, then take upstream
+)
+
+// LocalCopySuffix is appended to a skill's name for the copy SaveLocal
+// keeps.
+const LocalCopySuffix = ".local"
+
+// ParseConflictStrategy reads a --strategy value.
+func ParseConflictStrategy(value string) (ConflictStrategy, error) {
+	switch s := ConflictStrategy(value); s {
+	case StrategyRefuse, KeepLocal, TakeUpstream, SaveLocal:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown strategy %q (expected keep, upstream or save-local)", value)
+}
+
+// RecordFolderHash stores the hash of the skill's folder in the lock file,
+// making what is in the store now the baseline LocallyModified compares
+// against. Skills missing from the lock file are left alone.
+//
+// The hash goes in localHash rather than skillFolderHash, which npx skills
+// shares and fills with the GitHub tree SHA of the skill's folder.
+func (s *Store) RecordFolderHash(skillName string) error {
+	hash, err := FolderHash(filepath.Join(s.BaseDir, skillName))
+	if err != nil {
+		return err
+	}
+
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	entry, ok := lock.Skills[skillName]
+	if !ok || entry.LocalHash == hash {
+		return nil
+	}
+	entry.LocalHash = hash
+	lock.Skills[skillName] = entry
+	return s.WriteLockFile(lock)
+}
+
+// LocallyModified reports whether a skill's folder in the store differs
+// from the hash recorded when efx-skills installed or last updated it.
+// Skills without one, such as those installed by npx skills or before
+// hashes were recorded, and local and dev skills, which have no upstream
+// to conflict with, are never reported.
+func (s *Store) LocallyModified(skillName string) (bool, error) {
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return false, err
+	}
+	entry, ok := lock.Skills[skillName]
+	if !ok || entry.LocalHash == "" ||
+		entry.SourceType == SourceTypeLocal || entry.SourceType == SourceTypeDev {
+		return false, nil
+	}
+	hash, err := FolderHash(filepath.Join(s.BaseDir, skillName))
+	if err != nil {
+		return false, err
+	}
+	return hash != entry.LocalHash, nil
+}
+
+// SaveLocalCopy copies a skill to <skill>.local in the store and records
+// the copy as a local skill, so its edits survive the skill being updated.
+// An earlier copy is never overwritten.
+func (s *Store) SaveLocalCopy(skillName string) (string, error) {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return "", err
+	}
+	copyName := skillName + LocalCopySuffix
+	dst := filepath.Join(s.BaseDir, copyName)
+	if _, err := os.Lstat(dst); err == nil {
+		return "", errs.WithHint(errs.Conflict, "rename or remove "+copyName+" first",
+			"%s already exists in the store", copyName)
+	}
+	if err := copyTree(filepath.Join(s.BaseDir, skillName), dst); err != nil {
+		os.RemoveAll(dst)
+		return "", err
+	}
+	if err := s.AddLocalToLock(copyName); err != nil {
+		return "", err
+	}
+	return copyName, nil
+}
+
+// LocalChangesError reports a skill whose local edits an update would
+// overwrite.
+func LocalChangesError(skillName string) error {
+	return errs.WithHint(errs.Conflict,
+		"choose with: efx-skills update "+skillName+" --strategy keep|upstream|save-local",
+		"%s has local changes that the update would overwrite", skillName)
+}
@@ -0,0 +1,104 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// modifiedFixture installs "mine" from owner/repo with a recorded hash and
+// then edits it in the store.
+func modifiedFixture(t *testing.T) *Store {
+	t.Helper()
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, ".agents", "skills"))
+	dir := filepath.Join(store.BaseDir, "mine")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# Mine"), 0644)
+	if err := store.AddToLock("mine", "owner/repo", "abc"); err != nil {
+		t.Fatalf("AddToLock error: %v", err)
+	}
+	if modified, err := store.LocallyModified("mine"); modified || err != nil {
+		t.Fatalf("LocallyModified after install = %v, %v; want false, nil", modified, err)
+	}
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# Mine, edited"), 0644)
+	return store
+}
+
+func TestLocallyModifiedDetectsEdits(t *testing.T) {
+	store := modifiedFixture(t)
+	if modified, err := store.LocallyModified("mine"); !modified || err != nil {
+		t.Fatalf("LocallyModified after edit = %v, %v; want true, nil", modified, err)
+	}
+
+	if err := store.RecordFolderHash("mine"); err != nil {
+		t.Fatalf("RecordFolderHash error: %v", err)
+	}
+	if modified, _ := store.LocallyModified("mine"); modified {
+		t.Fatal("recorded folder still reported as modified")
+	}
+}
+
+func TestLocallyModifiedIgnoresLegacyEntries(t *testing.T) {
+	store := modifiedFixture(t)
+	lock, _ := store.ReadLockFile()
+	entry := lock.Skills["mine"]
+	// As written by npx skills: a GitHub tree SHA and no local hash
+	entry.LocalHash, entry.SkillFolderHash = "", "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+	lock.Skills["mine"] = entry
+	store.WriteLockFile(lock)
+
+	if modified, err := store.LocallyModified("mine"); modified || err != nil {
+		t.Fatalf("LocallyModified without a hash = %v, %v; want false, nil", modified, err)
+	}
+}
+
+func TestUpdateSkillRefusesLocalChanges(t *testing.T) {
+	store := modifiedFixture(t)
+
+	err := store.UpdateSkill("mine")
+	if errs.KindOf(err) != errs.Conflict {
+		t.Fatalf("UpdateSkill error = %v, want a conflict", err)
+	}
+	if err := store.UpdateSkillWith("mine", KeepLocal); err != nil {
+		t.Fatalf("UpdateSkillWith(keep) error: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(store.BaseDir, "mine", "SKILL.md"))
+	if string(data) != "# Mine, edited" {
+		t.Fatalf("SKILL.md = %q, want the local edit kept", data)
+	}
+}
+
+func TestSaveLocalCopy(t *testing.T) {
+	store := modifiedFixture(t)
+
+	name, err := store.SaveLocalCopy("mine")
+	if err != nil || name != "mine.local" {
+		t.Fatalf("SaveLocalCopy = %q, %v; want mine.local, nil", name, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(store.BaseDir, "mine.local", "SKILL.md"))
+	if string(data) != "# Mine, edited" {
+		t.Fatalf("copy SKILL.md = %q, want the local edit", data)
+	}
+	lock, _ := store.ReadLockFile()
+	if e := lock.Skills["mine.local"]; e.SourceType != SourceTypeLocal {
+		t.Fatalf("copy lock entry = %+v, want a local skill", e)
+	}
+
+	if _, err := store.SaveLocalCopy("mine"); errs.KindOf(err) != errs.Conflict {
+		t.Fatalf("second SaveLocalCopy error = %v, want a conflict", err)
+	}
+}
+
+func TestParseConflictStrategy(t *testing.T) {
+	for _, value := range []string{"", "keep", "upstream", "save-local"} {
+		if _, err := ParseConflictStrategy(value); err != nil {
+			t.Errorf("ParseConflictStrategy(%q) error: %v", value, err)
+		}
+	}
+	if _, err := ParseConflictStrategy("merge"); err == nil {
+		t.Error("ParseConflictStrategy(merge) should fail")
+	}
+}
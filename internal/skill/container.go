@@ -0,0 +1,40 @@
+package skill
+
+import (
+	"os"
+	"strings"
+)
+
+// containerMarkers are files container runtimes leave in the root
+// filesystem (Docker and Podman respectively).
+var containerMarkers = []string{"/.dockerenv", "/run/.containerenv"}
+
+// containerEnv are variables set by devcontainer tooling and WSL.
+var containerEnv = []string{"REMOTE_CONTAINERS", "CODESPACES", "DEVCONTAINER", "WSL_DISTRO_NAME"}
+
+// procVersion identifies the kernel; WSL kernels mention Microsoft.
+var procVersion = "/proc/version"
+
+// inContainer is swapped in tests so results do not depend on where they run.
+var inContainer = detectContainer
+
+// InContainer reports whether efx-skills runs in a container, devcontainer
+// or WSL, where the paths seen by providers may not match the host's.
+func InContainer() bool {
+	return inContainer()
+}
+
+func detectContainer() bool {
+	for _, name := range containerEnv {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	for _, marker := range containerMarkers {
+		if _, err := os.Stat(marker); err == nil {
+			return true
+		}
+	}
+	data, err := os.ReadFile(procVersion)
+	return err == nil && strings.Contains(strings.ToLower(string(data)), "microsoft")
+}
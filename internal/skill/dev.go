@@ -0,0 +1,122 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+	"github.com/lmarques/efx-skills/internal/provider"
+)
+
+// SourceTypeDev marks lock entries for working directories symlinked into
+// the store with LinkDev. Edits show up in every provider at once, and
+// update checks skip them.
+const SourceTypeDev = "dev"
+
+// IsSkillEntry reports whether a store entry is a skill: a directory, or a
+// symlink to one as left by LinkDev, that no ignore rule matches.
+func IsSkillEntry(dir string, e os.DirEntry) bool {
+	if provider.Ignored(e.Name()) {
+		return false
+	}
+	if e.IsDir() {
+		return true
+	}
+	if e.Type()&os.ModeSymlink == 0 {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(dir, e.Name()))
+	return err == nil && info.IsDir()
+}
+
+// LinkDev symlinks the skill folder dir into the store as skillName (the
+// folder's name when empty) and records it as a dev skill. An installed
+// skill of that name is never replaced; an earlier dev link is.
+func (s *Store) LinkDev(dir, skillName string) (string, error) {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(filepath.Join(abs, "SKILL.md")); err != nil {
+		return "", fmt.Errorf("%s has no SKILL.md", dir)
+	}
+	if skillName == "" {
+		skillName = filepath.Base(abs)
+	}
+	if err := fsutil.ValidName(skillName); err != nil {
+		return "", err
+	}
+
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return "", err
+	}
+	target := filepath.Join(s.BaseDir, skillName)
+	if _, err := os.Lstat(target); err == nil {
+		if lock.Skills[skillName].SourceType != SourceTypeDev {
+			return "", errs.New(errs.Conflict, "%s is already installed; remove it first", skillName)
+		}
+		if err := os.Remove(target); err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.Symlink(abs, target); err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	lock.Skills[skillName] = LockEntry{
+		Source:      abs,
+		SourceType:  SourceTypeDev,
+		SourceURL:   "file://" + filepath.ToSlash(abs),
+		InstalledAt: now,
+		UpdatedAt:   now,
+	}
+	return skillName, s.WriteLockFile(lock)
+}
+
+// UnlinkDev removes a dev skill's symlink from the store and its lock
+// entry. The working directory itself is left untouched.
+func (s *Store) UnlinkDev(skillName string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
+	}
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	if lock.Skills[skillName].SourceType != SourceTypeDev {
+		return fmt.Errorf("%s is not a dev skill", skillName)
+	}
+
+	target := filepath.Join(s.BaseDir, skillName)
+	if info, err := os.Lstat(target); err == nil {
+		if info.Mode()&os.ModeSymlink == 0 {
+			return fmt.Errorf("%s is not a symlink, refusing to delete it", target)
+		}
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+	delete(lock.Skills, skillName)
+	return s.WriteLockFile(lock)
+}
@@ -0,0 +1,66 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLinkDevSymlinksWorkingDirectory(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, ".agents", "skills"))
+	work := filepath.Join(tmp, "src", "my-skill")
+	os.MkdirAll(work, 0755)
+	os.WriteFile(filepath.Join(work, "SKILL.md"), []byte("# v1"), 0644)
+
+	name, err := store.LinkDev(work, "")
+	if err != nil || name != "my-skill" {
+		t.Fatalf("LinkDev = %q, %v", name, err)
+	}
+
+	// Edits are visible through the store at once
+	os.WriteFile(filepath.Join(work, "SKILL.md"), []byte("# v2"), 0644)
+	if data, _ := os.ReadFile(filepath.Join(store.BaseDir, "my-skill", "SKILL.md")); string(data) != "# v2" {
+		t.Errorf("store copy = %q, want live edits", data)
+	}
+	if installed, _ := store.ListInstalled(); len(installed) != 1 || installed[0] != "my-skill" {
+		t.Errorf("ListInstalled = %v, want the dev skill", installed)
+	}
+
+	lock, _ := store.ReadLockFile()
+	if entry := lock.Skills["my-skill"]; entry.SourceType != SourceTypeDev || entry.Channel() != "dev" {
+		t.Errorf("lock entry = %+v", entry)
+	}
+	if hasUpdate, _, _, err := store.CheckForUpdate("my-skill"); hasUpdate || err != nil {
+		t.Errorf("CheckForUpdate = %v, %v; want no update", hasUpdate, err)
+	}
+
+	if err := store.UnlinkDev("my-skill"); err != nil {
+		t.Fatalf("UnlinkDev error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(store.BaseDir, "my-skill")); !os.IsNotExist(err) {
+		t.Error("store link left behind")
+	}
+	if _, err := os.Stat(filepath.Join(work, "SKILL.md")); err != nil {
+		t.Error("working directory was touched")
+	}
+}
+
+func TestLinkDevRefusesInstalledSkill(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "demo"), 0755)
+	work := filepath.Join(tmp, "demo")
+	os.MkdirAll(work, 0755)
+	os.WriteFile(filepath.Join(work, "SKILL.md"), []byte("# Demo"), 0644)
+
+	if _, err := store.LinkDev(work, ""); err == nil {
+		t.Fatal("LinkDev replaced an installed skill")
+	}
+	if err := store.UnlinkDev("demo"); err == nil {
+		t.Fatal("UnlinkDev removed a regular skill")
+	}
+	if _, err := store.LinkDev(filepath.Join(tmp, "empty"), "x"); err == nil {
+		t.Fatal("LinkDev accepted a folder without SKILL.md")
+	}
+}
@@ -0,0 +1,99 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrStoreBusy is returned when another efx-skills process holds the store
+// lock for longer than lockWait.
+var ErrStoreBusy = errors.New("another efx-skills instance is changing the store")
+
+// lockWait is how long Lock waits for another process; tests shorten it.
+var lockWait = 5 * time.Second
+
+// pathLocks serialise the goroutines of this process taking the same store
+// lock; the flock only keeps other processes out.
+var (
+	pathLocksMu sync.Mutex
+	pathLocks   = make(map[string]*sync.Mutex)
+)
+
+// pathLock returns the mutex of the store lock at path.
+func pathLock(path string) *sync.Mutex {
+	pathLocksMu.Lock()
+	defer pathLocksMu.Unlock()
+	mu, ok := pathLocks[path]
+	if !ok {
+		mu = new(sync.Mutex)
+		pathLocks[path] = mu
+	}
+	return mu
+}
+
+// lockPath is the file flocked to guard the store and its lock file.
+func (s *Store) lockPath() string {
+	return s.LockFile + ".lock"
+}
+
+// Lock takes the lock guarding the store and lock file against other
+// goroutines and other efx-skills processes, such as a dev watcher and a CLI
+// run in another terminal. Goroutines wait their turn; another process is
+// waited for up to a few seconds, then Lock fails with ErrStoreBusy.
+//
+// The lock is not re-entrant. Lock returns the store to use while it is
+// held, whose methods do not take it again, and the function releasing it.
+// Locking that store again does nothing.
+func (s *Store) Lock() (*Store, func(), error) {
+	if s.locked {
+		return s, func() {}, nil
+	}
+	path := s.lockPath()
+	mu := pathLock(path)
+	mu.Lock()
+
+	f, err := lockFile(path, filepath.Dir(s.LockFile))
+	if err != nil {
+		mu.Unlock()
+		return nil, nil, err
+	}
+	held := *s
+	held.locked = true
+	return &held, sync.OnceFunc(func() {
+		unlockFile(f)
+		f.Close()
+		mu.Unlock()
+	}), nil
+}
+
+// lockFile opens and flocks path, waiting up to lockWait for another
+// process holding it.
+func lockFile(path, storeDir string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(lockWait)
+	for {
+		ok, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ok {
+			return f, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("%w (%s); try again once it finishes", ErrStoreBusy, storeDir)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,95 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockedStoreDoesNotLockAgain(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), ".agents", "skills"))
+
+	locked, unlock, err := store.Lock()
+	if err != nil {
+		t.Fatalf("Lock error: %v", err)
+	}
+	// Methods of the locked store must not deadlock
+	if err := locked.AddToLock("demo", "owner/repo", "abc"); err != nil {
+		t.Fatalf("AddToLock under Lock error: %v", err)
+	}
+	unlock()
+	unlock()
+
+	// Released: the original store can take the lock again
+	if err := store.RemoveFromLock("demo"); err != nil {
+		t.Fatalf("RemoveFromLock after unlock error: %v", err)
+	}
+}
+
+func TestLockSerialisesGoroutines(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), ".agents", "skills"))
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := store.AddToLock(fmt.Sprintf("skill-%d", i), "owner/repo", "abc"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	lock, err := store.ReadLockFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Skills) != 50 {
+		t.Errorf("lock file has %d entries after 50 concurrent updates, want 50", len(lock.Skills))
+	}
+}
+
+func TestLockFailsWhileAnotherProcessHoldsIt(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), ".agents", "skills"))
+	prev := lockWait
+	lockWait = 200 * time.Millisecond
+	t.Cleanup(func() { lockWait = prev })
+
+	// A separate open file stands in for the other process's lock
+	os.MkdirAll(filepath.Dir(store.lockPath()), 0755)
+	other, err := os.OpenFile(store.lockPath(), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if ok, err := tryLockFile(other); !ok || err != nil {
+		t.Fatalf("tryLockFile = %v, %v", ok, err)
+	}
+
+	if err := store.RemoveFromLock("demo"); !errors.Is(err, ErrStoreBusy) {
+		t.Fatalf("RemoveFromLock error = %v, want ErrStoreBusy", err)
+	}
+
+	unlockFile(other)
+	if err := store.RemoveFromLock("demo"); err != nil {
+		t.Fatalf("RemoveFromLock after release error: %v", err)
+	}
+}
+
+func TestWriteLockFileLeavesNoTempFiles(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), ".agents", "skills"))
+	if err := store.AddToLock("demo", "owner/repo", "abc"); err != nil {
+		t.Fatalf("AddToLock error: %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(store.LockFile))
+	for _, e := range entries {
+		if e.Name() != ".skill-lock.json" && e.Name() != ".skill-lock.json.lock" && e.Name() != ".skill-lock.json.bak" {
+			t.Errorf("unexpected file %s next to the lock file", e.Name())
+		}
+	}
+}
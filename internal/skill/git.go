@@ -0,0 +1,206 @@
+package skill
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SourceTypeGit marks lock entries installed by cloning a git URL, such as
+// git@github.com:org/private-skills.git, instead of through the GitHub API.
+const SourceTypeGit = "git"
+
+// IsGitURL reports whether source is an SSH git URL rather than owner/repo.
+// These are cloned with the user's git and SSH agent, for repositories
+// only reachable over SSH.
+func IsGitURL(source string) bool {
+	return strings.HasPrefix(source, "git@") || strings.HasPrefix(source, "ssh://")
+}
+
+// lockSource returns the sourceType and sourceUrl recorded for source.
+func lockSource(source string) (sourceType, sourceURL string) {
+	switch {
+	case IsGitURL(source):
+		return SourceTypeGit, source
+	case IsArchiveURL(source):
+		u, _ := splitChecksum(source)
+		return SourceTypeArchive, u
+	}
+	return "github", WebURL(source) + ".git"
+}
+
+// runGit runs git without ever prompting: the TUI owns the terminal, so
+// credentials must come from the SSH agent or git's own helpers.
+func runGit(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if os.Getenv("GIT_SSH_COMMAND") == "" {
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = teeOutput(&stderr)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// installGit shallow-clones source at ref (the default branch when empty)
+// and moves skillName's folder into the store. The clone is made inside
+// the store, under a staging name, so the move is a rename.
+func (s *Store) installGit(source, skillName, ref, hint string, track bool) error {
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	if entry, ok := lock.Skills[skillName]; ok && entry.Source == source && hint == "" {
+		hint = entry.SkillPath
+	}
+
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(s.BaseDir, "."+skillName+".install-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	args := []string{"clone", "--depth", "1", "--quiet"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	clone := filepath.Join(tmp, "repo")
+	if _, err := runGit(append(args, "--", source, clone)...); err != nil {
+		return err
+	}
+
+	dir, err := findClonedSkill(clone, skillName, hint, strings.TrimSuffix(path.Base(source), ".git"))
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, source)
+	}
+	src := filepath.Join(clone, filepath.FromSlash(dir))
+	if err := os.RemoveAll(filepath.Join(src, ".git")); err != nil {
+		return err
+	}
+
+	if err := s.moveIntoStore(src, skillName); err != nil {
+		return err
+	}
+	return s.recordInstall(skillName, source, dir, ref, track)
+}
+
+// findClonedSkill returns the folder of a clone holding skillName's
+// SKILL.md, trying hint, then any folder named skillName, then the usual
+// spots, then the root when its SKILL.md is the skill of repoName.
+func findClonedSkill(clone, skillName, hint, repoName string) (string, error) {
+	var candidates []string
+	if hint != "" {
+		candidates = append(candidates, hint)
+	}
+	filepath.WalkDir(clone, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && d.Name() == "SKILL.md" && filepath.Base(filepath.Dir(p)) == skillName {
+			if rel, err := filepath.Rel(clone, filepath.Dir(p)); err == nil {
+				candidates = append(candidates, filepath.ToSlash(rel))
+			}
+		}
+		return nil
+	})
+	candidates = append(candidates, skillDirCandidates(skillName)...)
+
+	for _, dir := range candidates {
+		if _, err := os.Stat(filepath.Join(clone, filepath.FromSlash(dir), "SKILL.md")); err == nil {
+			return dir, nil
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(clone, "SKILL.md")); err == nil && rootSkillMatches(content, skillName, repoName) {
+		return "", nil
+	}
+	return "", fmt.Errorf("skill %s not found", skillName)
+}
+
+// gitCommitHash resolves ref, or the default branch when empty, on a git
+// remote without cloning it.
+func gitCommitHash(source, ref string) (string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	out, err := runGit("ls-remote", "--", source, ref, ref+"^{}")
+	if err != nil {
+		return "", err
+	}
+	var sha string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		// An annotated tag's ^{} line names the commit, not the tag object
+		if sha == "" || strings.HasSuffix(fields[1], "^{}") {
+			sha = fields[0]
+		}
+	}
+	if sha == "" {
+		return "", fmt.Errorf("version %s not found in %s", ref, source)
+	}
+	return sha, nil
+}
+
+// gitVersions lists the tags of a git remote, newest version first.
+func gitVersions(source string) ([]string, error) {
+	out, err := runGit("ls-remote", "--tags", "--refs", "--sort=-v:refname", "--", source)
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			versions = append(versions, strings.TrimPrefix(fields[1], "refs/tags/"))
+		}
+	}
+	return versions, nil
+}
+
+// SourceCommit resolves ref on source: owner/repo through the GitHub API, a
+// git URL with git ls-remote, or an archive URL to the SHA-256 it serves.
+func SourceCommit(source, ref string) (string, error) {
+	switch {
+	case IsGitURL(source):
+		return gitCommitHash(source, ref)
+	case IsArchiveURL(source):
+		return archiveCommitHash(source)
+	}
+	src, err := ParseSource(source)
+	if err != nil || src.Kind != SourceGitHub {
+		return "", fmt.Errorf("invalid source format: %s", source)
+	}
+	return FetchCommitHash(src.Owner, src.Repo, ref)
+}
+
+// SourceVersions lists the tags of source, either owner/repo or a git URL.
+// Archives have no versions.
+func SourceVersions(source string) ([]string, error) {
+	switch {
+	case IsGitURL(source):
+		return gitVersions(source)
+	case IsArchiveURL(source):
+		return nil, nil
+	}
+	src, err := ParseSource(source)
+	if err != nil || src.Kind != SourceGitHub {
+		return nil, fmt.Errorf("invalid source format: %s", source)
+	}
+	return FetchVersions(src.Owner, src.Repo)
+}
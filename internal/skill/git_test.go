@@ -0,0 +1,95 @@
+package skill
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newGitRemote creates a local repository holding skills/demo, tagged v1.
+func newGitRemote(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "skills", "demo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "skills", "demo", "SKILL.md"), []byte("# Demo"), 0644)
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "--quiet", "-m", "init"},
+		{"tag", "v1"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	return dir
+}
+
+func TestIsGitURL(t *testing.T) {
+	for source, want := range map[string]bool{
+		"git@github.com:org/skills.git":    true,
+		"ssh://git@example.com/org/skills": true,
+		"owner/repo":                       false,
+		"https://github.com/owner/repo":    false,
+	} {
+		if got := IsGitURL(source); got != want {
+			t.Errorf("IsGitURL(%q) = %v, want %v", source, got, want)
+		}
+	}
+}
+
+func TestInstallGitClonesSkillFolder(t *testing.T) {
+	remote := newGitRemote(t)
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+
+	if err := store.installGit(remote, "demo", "v1", "", false); err != nil {
+		t.Fatalf("installGit error: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(store.BaseDir, "demo", "SKILL.md")); err != nil || string(data) != "# Demo" {
+		t.Errorf("SKILL.md = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(store.BaseDir, "demo", ".git")); !os.IsNotExist(err) {
+		t.Error("clone metadata left in the store")
+	}
+	entries, _ := os.ReadDir(store.BaseDir)
+	if len(entries) != 1 {
+		t.Errorf("store has %d entries, want only the skill", len(entries))
+	}
+
+	lock, _ := store.ReadLockFile()
+	entry := lock.Skills["demo"]
+	if entry.SkillPath != "skills/demo" || entry.Ref != "v1" {
+		t.Errorf("lock entry = %+v", entry)
+	}
+
+	if err := store.installGit(remote, "missing", "", "", false); err == nil {
+		t.Error("expected error for a skill not in the repository")
+	}
+}
+
+func TestGitCommitHashResolvesRefs(t *testing.T) {
+	remote := newGitRemote(t)
+	out, err := exec.Command("git", "-C", remote, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := string(out[:40])
+
+	for _, ref := range []string{"", "v1"} {
+		if got, err := gitCommitHash(remote, ref); err != nil || got != head {
+			t.Errorf("gitCommitHash(%q) = %q, %v; want %s", ref, got, err, head)
+		}
+	}
+	if _, err := gitCommitHash(remote, "nope"); err == nil {
+		t.Error("expected error for an unknown ref")
+	}
+	if versions, err := gitVersions(remote); err != nil || len(versions) != 1 || versions[0] != "v1" {
+		t.Errorf("gitVersions = %v, %v", versions, err)
+	}
+}
@@ -0,0 +1,133 @@
+package skill
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Repositories may live on a GitHub Enterprise Server instead of
+// github.com: either all of them, set with SetGitHubHost (the "github_host"
+// config setting), or single repositories, set with SetRepoHost (the "host"
+// of a repo source). Enterprise hosts serve the API under /api/v3 and raw
+// files under /raw.
+var (
+	hostMu      sync.RWMutex
+	defaultHost string            // base URL, "" for github.com
+	repoHosts   map[string]string // base URL by lower-case "owner/repo"
+)
+
+// normalizeHost turns "github.mycorp.com" or a URL into a base URL without
+// a trailing slash. github.com and "" yield "", meaning public GitHub.
+func normalizeHost(host string) string {
+	host = strings.TrimRight(strings.TrimSpace(host), "/")
+	if host == "" {
+		return ""
+	}
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	if u, err := url.Parse(host); err != nil || u.Host == "github.com" || u.Host == "www.github.com" {
+		return ""
+	}
+	return host
+}
+
+// SetGitHubHost sets the GitHub Enterprise host every repository lives on,
+// unless SetRepoHost says otherwise. "" restores github.com.
+func SetGitHubHost(host string) {
+	hostMu.Lock()
+	defaultHost = normalizeHost(host)
+	hostMu.Unlock()
+}
+
+// SetRepoHost sets the host owner/repo lives on. An empty host, or
+// github.com, keeps the repository on public GitHub even when a default
+// enterprise host is set.
+func SetRepoHost(owner, repo, host string) {
+	hostMu.Lock()
+	defer hostMu.Unlock()
+	if repoHosts == nil {
+		repoHosts = make(map[string]string)
+	}
+	repoHosts[strings.ToLower(owner+"/"+repo)] = normalizeHost(host)
+}
+
+// ResetGitHubHosts forgets every configured host, so all repositories are
+// on github.com again.
+func ResetGitHubHosts() {
+	hostMu.Lock()
+	defaultHost, repoHosts = "", nil
+	hostMu.Unlock()
+}
+
+// hostBase returns the base URL of the enterprise host owner/repo lives on,
+// or "" for github.com.
+func hostBase(owner, repo string) string {
+	hostMu.RLock()
+	defer hostMu.RUnlock()
+	if host, ok := repoHosts[strings.ToLower(owner+"/"+repo)]; ok {
+		return host
+	}
+	return defaultHost
+}
+
+// GitHubHost returns the host name owner/repo lives on, e.g.
+// "github.mycorp.com", or "github.com".
+func GitHubHost(owner, repo string) string {
+	if base := hostBase(owner, repo); base != "" {
+		if u, err := url.Parse(base); err == nil {
+			return u.Host
+		}
+	}
+	return "github.com"
+}
+
+// apiBase returns the REST API base URL for owner/repo. Empty owner and
+// repo give the default host's, for searches across repositories.
+func apiBase(owner, repo string) string {
+	if base := hostBase(owner, repo); base != "" {
+		return base + "/api/v3"
+	}
+	return gitHubAPIBaseURL
+}
+
+// RawURL returns the URL serving the raw contents of path in owner/repo at
+// ref, a branch, tag or commit.
+func RawURL(owner, repo, ref, path string) string {
+	if base := hostBase(owner, repo); base != "" {
+		return base + "/raw/" + owner + "/" + repo + "/" + ref + "/" + path
+	}
+	return "https://raw.githubusercontent.com/" + owner + "/" + repo + "/" + ref + "/" + path
+}
+
+// WebURL returns the web page of source, "owner/repo" optionally followed
+// by a path inside the repository.
+func WebURL(source string) string {
+	owner, rest, _ := strings.Cut(source, "/")
+	repo, _, _ := strings.Cut(rest, "/")
+	if base := hostBase(owner, repo); base != "" {
+		return base + "/" + source
+	}
+	return "https://github.com/" + source
+}
+
+// isEnterpriseHost reports whether host is one of the configured
+// enterprise hosts.
+func isEnterpriseHost(host string) bool {
+	hostMu.RLock()
+	defer hostMu.RUnlock()
+	matches := func(base string) bool {
+		u, err := url.Parse(base)
+		return err == nil && base != "" && u.Host == host
+	}
+	if matches(defaultHost) {
+		return true
+	}
+	for _, base := range repoHosts {
+		if matches(base) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestEnterpriseHostURLs(t *testing.T) {
+	t.Cleanup(ResetGitHubHosts)
+	SetGitHubHost("github.mycorp.com/")
+	SetRepoHost("anthropics", "skills", "github.com")
+
+	if got := RawURL("team", "skills", "main", "SKILL.md"); got != "https://github.mycorp.com/raw/team/skills/main/SKILL.md" {
+		t.Errorf("enterprise RawURL = %q", got)
+	}
+	if got := apiBase("team", "skills"); got != "https://github.mycorp.com/api/v3" {
+		t.Errorf("enterprise apiBase = %q", got)
+	}
+	if got := WebURL("team/skills/pdf"); got != "https://github.mycorp.com/team/skills/pdf" {
+		t.Errorf("enterprise WebURL = %q", got)
+	}
+	// A repo pinned to github.com stays there
+	if got := RawURL("anthropics", "skills", "main", "SKILL.md"); got != "https://raw.githubusercontent.com/anthropics/skills/main/SKILL.md" {
+		t.Errorf("public RawURL = %q", got)
+	}
+	if got := GitHubHost("Anthropics", "Skills"); got != "github.com" {
+		t.Errorf("GitHubHost = %q, want github.com", got)
+	}
+
+	ResetGitHubHosts()
+	if got := apiBase("team", "skills"); got != gitHubAPIBaseURL {
+		t.Errorf("apiBase after reset = %q", got)
+	}
+}
+
+func TestEnterpriseRepoUsesItsHostAndToken(t *testing.T) {
+	useToken(t, "public", "", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "corp")
+	var path, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("Authorization")
+		json.NewEncoder(w).Encode(map[string]any{"tree": []map[string]string{{"path": "SKILL.md", "type": "blob"}}})
+	}))
+	defer server.Close()
+	t.Cleanup(ResetGitHubHosts)
+	SetRepoHost("team", "skills", server.URL)
+
+	found, err := DiscoverSkills("team", "skills", "")
+	if err != nil || len(found) != 1 {
+		t.Fatalf("DiscoverSkills = %+v, %v", found, err)
+	}
+	if path != "/api/v3/repos/team/skills/git/trees/HEAD" {
+		t.Errorf("requested %s, want the enterprise API", path)
+	}
+	if auth != "Bearer corp" {
+		t.Errorf("Authorization = %q, want the enterprise token", auth)
+	}
+}
+
+func TestEnterpriseHostNeverGetsTheGitHubToken(t *testing.T) {
+	useToken(t, "public", "", "")
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{"tree": []map[string]string{{"path": "SKILL.md", "type": "blob"}}})
+	}))
+	defer server.Close()
+	t.Cleanup(ResetGitHubHosts)
+	SetRepoHost("team", "skills", server.URL)
+
+	if _, err := DiscoverSkills("team", "skills", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(auth) == 0 || auth[0] != "" {
+		t.Errorf("Authorization = %q, want none without an enterprise token", auth)
+	}
+
+	// gh is asked once per host and run
+	clear(ghHostTokens)
+	asked := ""
+	ghHostToken = func(host string) string { asked = host; return "gh-corp" }
+	if _, err := DiscoverSkills("team", "skills", ""); err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(server.URL)
+	if host := u.Hostname(); asked != host || auth[len(auth)-1] != "Bearer gh-corp" {
+		t.Errorf("asked gh for %q and sent %q, want gh's token for %s", asked, auth[len(auth)-1], host)
+	}
+}
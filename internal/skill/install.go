@@ -0,0 +1,290 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+)
+
+// UseNpxEnv opts back into installing through `npx skills add` when set to "1".
+const UseNpxEnv = "EFX_SKILLS_USE_NPX"
+
+// contentEntry is an item returned by the GitHub contents API.
+type contentEntry struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+	DownloadURL string `json:"download_url"`
+}
+
+// skillDirCandidates lists the repository folders a skill conventionally
+// lives in, most specific first. The repository root is only tried when
+// its SKILL.md is the requested skill; see rootSkillMatches.
+func skillDirCandidates(skillName string) []string {
+	return []string{
+		"skills/" + skillName,
+		skillName,
+		".claude/skills/" + skillName,
+	}
+}
+
+// rootSkillMatches reports whether content, the SKILL.md at a repository's
+// root, is skillName's: its frontmatter names it or, without a name, the
+// repository does. Single-skill repositories still install, while a skill
+// missing from its folder never falls back to whatever the root holds.
+func rootSkillMatches(content []byte, skillName, repoName string) bool {
+	fields, _ := ParseFrontmatter(string(content))
+	if name := fields["name"]; name != "" {
+		return name == skillName
+	}
+	return repoName == skillName
+}
+
+// listContents lists a repository folder at ref (the default branch when
+// empty). A missing folder returns nil entries and no error.
+func listContents(owner, repo, dir, ref string) ([]contentEntry, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/contents/%s", apiBase(owner, repo), owner, repo, dir)
+	if ref != "" {
+		url += "?ref=" + neturl.QueryEscape(ref)
+	}
+	resp, err := GitHubGet(url)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", dir, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errs.FromResponse(resp, "GitHub API returned status %d for %s/%s/%s", resp.StatusCode, owner, repo, dir)
+	}
+
+	var entries []contentEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		// A file path decodes as an object, not a folder listing
+		return nil, nil
+	}
+	return entries, nil
+}
+
+// findSkillDir returns the repository folder holding skillName's SKILL.md
+// along with its listing. hint, when set, is tried before the usual spots.
+func findSkillDir(owner, repo, skillName, hint, ref string) (string, []contentEntry, error) {
+	// Locate the folder with one tree listing before guessing
+	var candidates []string
+	if hint != "" {
+		candidates = append(candidates, hint)
+	} else if found, err := DiscoverSkills(owner, repo, ref); err == nil {
+		for _, rs := range found {
+			if rs.Name == skillName {
+				candidates = append(candidates, rs.Path)
+			}
+		}
+	}
+	candidates = append(candidates, skillDirCandidates(skillName)...)
+	for _, dir := range candidates {
+		entries, err := listContents(owner, repo, dir, ref)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, e := range entries {
+			if e.Type == "file" && e.Name == "SKILL.md" {
+				return dir, entries, nil
+			}
+		}
+	}
+	entries, err := listContents(owner, repo, "", ref)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, e := range entries {
+		if e.Type == "file" && e.Name == "SKILL.md" {
+			if content, err := fetchFile(e.DownloadURL); err == nil && rootSkillMatches(content, skillName, repo) {
+				return "", entries, nil
+			}
+		}
+	}
+	if ref != "" {
+		return "", nil, fmt.Errorf("skill %s not found in %s/%s at %s%s", skillName, owner, repo, ref, privateRepoHint())
+	}
+	return "", nil, fmt.Errorf("skill %s not found in %s/%s%s", skillName, owner, repo, privateRepoHint())
+}
+
+// fetchFile downloads one repository file.
+func fetchFile(url string) ([]byte, error) {
+	resp, err := GitHubGet(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errs.FromResponse(resp, "downloading %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// installNative downloads every file of a skill folder from GitHub at ref
+// into the store. Files are written to a temporary folder first so a failed
+// download never leaves a half-installed skill behind. hint is the folder to
+// try first, defaulting to the one recorded in the lock; track records ref
+// as the branch to follow rather than a pinned version.
+func (s *Store) installNative(source, skillName, ref, hint string, track bool) error {
+	switch {
+	case IsGitURL(source):
+		return s.installGit(source, skillName, ref, hint, track)
+	case IsArchiveURL(source):
+		if ref != "" {
+			return fmt.Errorf("archive sources have no versions: %s", source)
+		}
+		return s.installArchive(source, skillName)
+	}
+	src, err := ParseSource(source)
+	if err != nil || src.Kind != SourceGitHub {
+		return fmt.Errorf("invalid source format: %s (expected owner/repo)", source)
+	}
+	owner, repo := src.Owner, src.Repo
+
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	if entry, ok := lock.Skills[skillName]; ok && entry.Source == source && hint == "" {
+		hint = entry.SkillPath
+	}
+
+	dir, entries, err := findSkillDir(owner, repo, skillName, hint, ref)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(s.BaseDir, "."+skillName+".install-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := downloadTree(owner, repo, ref, entries, dir, tmp); err != nil {
+		return err
+	}
+
+	if err := s.moveIntoStore(tmp, skillName); err != nil {
+		return err
+	}
+	return s.recordInstall(skillName, source, dir, ref, track)
+}
+
+// moveIntoStore vets the downloaded folder staged and moves it into the
+// store as skillName, replacing the installed copy.
+func (s *Store) moveIntoStore(staged, skillName string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
+	}
+	if s.Vet != nil {
+		if err := s.Vet(staged); err != nil {
+			return err
+		}
+	}
+	final := filepath.Join(s.BaseDir, skillName)
+	if err := os.RemoveAll(final); err != nil {
+		return err
+	}
+	return os.Rename(staged, final)
+}
+
+// downloadTree writes the files in entries (relative to base) below dest,
+// descending into sub-folders.
+func downloadTree(owner, repo, ref string, entries []contentEntry, base, dest string) error {
+	for _, e := range entries {
+		rel := strings.TrimPrefix(strings.TrimPrefix(e.Path, base), "/")
+		if rel == "" || strings.HasPrefix(path.Clean(rel), "..") {
+			continue
+		}
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+
+		switch e.Type {
+		case "dir":
+			children, err := listContents(owner, repo, e.Path, ref)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			if err := downloadTree(owner, repo, ref, children, base, dest); err != nil {
+				return err
+			}
+		case "file":
+			if err := downloadFile(e.DownloadURL, target); err != nil {
+				return fmt.Errorf("downloading %s: %w", e.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func downloadFile(url, target string) error {
+	resp, err := GitHubGet(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errs.FromResponse(resp, "%s", resp.Status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// recordInstall stores where in its repository a skill was found and the
+// version or branch it follows, so later updates fetch the same folder. A
+// missing entry is created; callers fill in the commit with AddToLock.
+func (s *Store) recordInstall(skillName, source, skillPath, ref string, track bool) error {
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	entry, ok := lock.Skills[skillName]
+	if !ok || entry.Source != source {
+		now := time.Now().UTC().Format(time.RFC3339)
+		sourceType, sourceURL := lockSource(source)
+		entry = LockEntry{
+			Source:      source,
+			SourceType:  sourceType,
+			SourceURL:   sourceURL,
+			InstalledAt: now,
+			UpdatedAt:   now,
+		}
+	}
+	entry.SkillPath = skillPath
+	entry.Ref, entry.Branch = ref, ""
+	if track {
+		entry.Ref, entry.Branch = "", ref
+	}
+	lock.Skills[skillName] = entry
+	return s.WriteLockFile(lock)
+}
@@ -0,0 +1,198 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newRepoServer serves a fake repository holding a multi-file skill under
+// skills/demo.
+func newRepoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/contents/skills/demo":
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"name": "SKILL.md", "path": "skills/demo/SKILL.md", "type": "file", "download_url": server.URL + "/raw/SKILL.md"},
+				{"name": "scripts", "path": "skills/demo/scripts", "type": "dir"},
+			})
+		case "/repos/owner/repo/contents/skills/demo/scripts":
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"name": "run.sh", "path": "skills/demo/scripts/run.sh", "type": "file", "download_url": server.URL + "/raw/run.sh"},
+			})
+		case "/repos/owner/repo/git/trees/HEAD":
+			json.NewEncoder(w).Encode(map[string]any{"tree": []map[string]string{
+				{"path": "skills/demo/SKILL.md", "type": "blob"},
+				{"path": "packs/tools/nested/SKILL.md", "type": "blob"},
+			}})
+		case "/repos/owner/repo/contents/packs/tools/nested":
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"name": "SKILL.md", "path": "packs/tools/nested/SKILL.md", "type": "file", "download_url": server.URL + "/raw/SKILL.md"},
+			})
+		case "/repos/owner/repo/commits/next":
+			json.NewEncoder(w).Encode(map[string]string{"sha": "nextsha"})
+		case "/raw/SKILL.md":
+			w.Write([]byte("# Demo"))
+		case "/raw/run.sh":
+			w.Write([]byte("echo hi"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	orig := gitHubAPIBaseURL
+	gitHubAPIBaseURL = server.URL
+	t.Cleanup(func() { gitHubAPIBaseURL = orig })
+	return server
+}
+
+func TestInstallDownloadsWholeSkillFolder(t *testing.T) {
+	newRepoServer(t)
+	t.Setenv(UseNpxEnv, "")
+
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	if err := store.Install("owner/repo", "demo"); err != nil {
+		t.Fatalf("Install error: %v", err)
+	}
+
+	for rel, want := range map[string]string{"SKILL.md": "# Demo", "scripts/run.sh": "echo hi"} {
+		data, err := os.ReadFile(filepath.Join(store.BaseDir, "demo", rel))
+		if err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", rel, data, err, want)
+		}
+	}
+
+	lock, _ := store.ReadLockFile()
+	if got := lock.Skills["demo"].SkillPath; got != "skills/demo" {
+		t.Errorf("SkillPath = %q, want %q", got, "skills/demo")
+	}
+
+	// AddToLock after installing keeps the recorded folder
+	if err := store.AddToLock("demo", "owner/repo", "abc"); err != nil {
+		t.Fatal(err)
+	}
+	lock, _ = store.ReadLockFile()
+	if e := lock.Skills["demo"]; e.SkillPath != "skills/demo" || e.CommitHash != "abc" {
+		t.Errorf("lock entry after AddToLock = %+v", e)
+	}
+}
+
+func TestInstallReplacesPreviousCopy(t *testing.T) {
+	newRepoServer(t)
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+
+	stale := filepath.Join(store.BaseDir, "demo", "old.md")
+	os.MkdirAll(filepath.Dir(stale), 0755)
+	os.WriteFile(stale, []byte("old"), 0644)
+
+	if err := store.Install("owner/repo", "demo"); err != nil {
+		t.Fatalf("Install error: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("files from the previous copy should be removed")
+	}
+}
+
+func TestInstallMissingSkillLeavesStoreUntouched(t *testing.T) {
+	newRepoServer(t)
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+
+	if err := store.Install("owner/repo", "nope"); err == nil {
+		t.Fatal("expected an error for a skill missing from the repository")
+	}
+	entries, _ := os.ReadDir(store.BaseDir)
+	if len(entries) != 0 {
+		t.Errorf("store should stay empty, found %d entries", len(entries))
+	}
+}
+
+func TestStoreRefusesNamesOutsideIt(t *testing.T) {
+	newRepoServer(t)
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	kept := filepath.Join(store.BaseDir, "demo", "SKILL.md")
+	os.MkdirAll(filepath.Dir(kept), 0755)
+	os.WriteFile(kept, []byte("# demo"), 0644)
+
+	for _, name := range []string{"", ".", "..", "a/b"} {
+		if err := store.Install("owner/repo", name); err == nil {
+			t.Errorf("Install(%q) should fail", name)
+		}
+		if err := store.moveIntoStore(t.TempDir(), name); err == nil {
+			t.Errorf("moveIntoStore(%q) should fail", name)
+		}
+	}
+	if _, err := os.Stat(kept); err != nil {
+		t.Errorf("store changed: %v", err)
+	}
+}
+
+func TestInstallFindsNestedSkillThroughTree(t *testing.T) {
+	newRepoServer(t)
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+
+	if err := store.Install("owner/repo", "nested"); err != nil {
+		t.Fatalf("Install error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(store.BaseDir, "nested", "SKILL.md")); err != nil {
+		t.Errorf("SKILL.md not installed: %v", err)
+	}
+	lock, _ := store.ReadLockFile()
+	if got := lock.Skills["nested"].SkillPath; got != "packs/tools/nested" {
+		t.Errorf("SkillPath = %q, want packs/tools/nested", got)
+	}
+}
+
+func TestInstallFallsBackToRootOnlyForTheSameSkill(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/lint/contents/":
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"name": "SKILL.md", "path": "SKILL.md", "type": "file", "download_url": server.URL + "/raw/SKILL.md"},
+			})
+		case "/raw/SKILL.md":
+			w.Write([]byte("---\nname: lint\n---\n# Lint"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	orig := gitHubAPIBaseURL
+	gitHubAPIBaseURL = server.URL
+	defer func() { gitHubAPIBaseURL = orig }()
+	t.Setenv(UseNpxEnv, "")
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+
+	if err := store.Install("owner/lint", "format"); err == nil {
+		t.Fatal("a missing skill was installed from another skill at the root")
+	}
+	if err := store.Install("owner/lint", "lint"); err != nil {
+		t.Fatalf("Install of the root skill: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(store.BaseDir, "lint", "SKILL.md")); string(data) != "---\nname: lint\n---\n# Lint" {
+		t.Errorf("SKILL.md = %q", data)
+	}
+}
+
+func TestRootSkillMatches(t *testing.T) {
+	tests := []struct {
+		content, skill, repo string
+		want                 bool
+	}{
+		{"---\nname: lint\n---\n", "lint", "tools", true},
+		{"---\nname: lint\n---\n", "format", "format", false},
+		{"# No frontmatter", "tools", "tools", true},
+		{"# No frontmatter", "lint", "tools", false},
+	}
+	for _, tt := range tests {
+		if got := rootSkillMatches([]byte(tt.content), tt.skill, tt.repo); got != tt.want {
+			t.Errorf("rootSkillMatches(%q, %s, %s) = %v, want %v", tt.content, tt.skill, tt.repo, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// licenseFiles are the files a license is read from, in order.
+var licenseFiles = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "LICENCE", "COPYING"}
+
+// licenseMarkers identify common licenses by a phrase of their text, most
+// specific first: the AGPL and LGPL texts mention the GPL.
+var licenseMarkers = []struct {
+	id     string
+	phrase *regexp.Regexp
+}{
+	{"AGPL-3.0", regexp.MustCompile(`(?i)GNU AFFERO GENERAL PUBLIC LICENSE`)},
+	{"LGPL-3.0", regexp.MustCompile(`(?i)GNU LESSER GENERAL PUBLIC LICENSE\s+Version 3`)},
+	{"LGPL-2.1", regexp.MustCompile(`(?i)GNU LESSER GENERAL PUBLIC LICENSE\s+Version 2\.1`)},
+	{"GPL-3.0", regexp.MustCompile(`(?i)GNU GENERAL PUBLIC LICENSE\s+Version 3`)},
+	{"GPL-2.0", regexp.MustCompile(`(?i)GNU GENERAL PUBLIC LICENSE\s+Version 2`)},
+	{"MPL-2.0", regexp.MustCompile(`(?i)Mozilla Public License,? (Version|v\.) ?2\.0`)},
+	{"Apache-2.0", regexp.MustCompile(`(?i)Apache License,?\s+Version 2\.0`)},
+	{"BSD-3-Clause", regexp.MustCompile(`(?i)Neither the name of`)},
+	{"BSD-2-Clause", regexp.MustCompile(`(?i)Redistribution and use in source and binary forms`)},
+	{"ISC", regexp.MustCompile(`(?i)Permission to use, copy, modify, and/or distribute this software`)},
+	{"MIT", regexp.MustCompile(`(?i)Permission is hereby granted, free of charge`)},
+	{"Unlicense", regexp.MustCompile(`(?i)This is free and unencumbered software released into the public domain`)},
+	{"CC-BY-4.0", regexp.MustCompile(`(?i)Creative Commons Attribution 4\.0`)},
+	{"CC0-1.0", regexp.MustCompile(`(?i)CC0 1\.0 Universal`)},
+}
+
+// IdentifyLicense returns the SPDX identifier of a license text, or "" when
+// it is not one of the common licenses.
+func IdentifyLicense(text string) string {
+	for _, m := range licenseMarkers {
+		if m.phrase.MatchString(text) {
+			return m.id
+		}
+	}
+	return ""
+}
+
+// License returns the license of the skill in dir: the "license" field of
+// its frontmatter, else the license identified in a LICENSE file next to
+// SKILL.md. It is "" when neither says.
+func License(dir string) string {
+	if data, err := os.ReadFile(filepath.Join(dir, "SKILL.md")); err == nil {
+		if fields, _ := ParseFrontmatter(string(data)); fields["license"] != "" {
+			return fields["license"]
+		}
+	}
+	for _, name := range licenseFiles {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			return IdentifyLicense(string(data))
+		}
+	}
+	return ""
+}
+
+// FetchRepoLicense returns the SPDX identifier of the license GitHub
+// detected for owner/repo, or "" when the repository has none or GitHub
+// could not tell.
+func FetchRepoLicense(owner, repo string) (string, error) {
+	resp, err := GitHubGet(fmt.Sprintf("%s/repos/%s/%s/license", apiBase(owner, repo), owner, repo))
+	if err != nil {
+		return "", fmt.Errorf("fetching license: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errs.FromResponse(resp, "GitHub API returned status %d for the license of %s/%s", resp.StatusCode, owner, repo)
+	}
+	var body struct {
+		License struct {
+			SPDXID string `json:"spdx_id"`
+		} `json:"license"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding license: %w", err)
+	}
+	if id := body.License.SPDXID; id != "NOASSERTION" && !strings.EqualFold(id, "other") {
+		return id, nil
+	}
+	return "", nil
+}
+
+// SetLicense records the license of a locked skill.
+func (s *Store) SetLicense(skillName, license string) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	entry, ok := lock.Skills[skillName]
+	if !ok {
+		return fmt.Errorf("skill %q not found in lock file", skillName)
+	}
+	entry.License = license
+	lock.Skills[skillName] = entry
+	return s.WriteLockFile(lock)
+}
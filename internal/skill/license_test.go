@@ -0,0 +1,52 @@
+package skill
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLicense(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: pdf\n---\n# PDF\n"), 0644)
+	if got := License(dir); got != "" {
+		t.Errorf("License without any = %q, want empty", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, "LICENSE"), []byte("GNU LESSER GENERAL PUBLIC LICENSE\n   Version 3, 29 June 2007\n"), 0644)
+	if got := License(dir); got != "LGPL-3.0" {
+		t.Errorf("License from LICENSE = %q, want LGPL-3.0", got)
+	}
+
+	// The frontmatter wins over the file
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: pdf\nlicense: MIT\n---\n# PDF\n"), 0644)
+	if got := License(dir); got != "MIT" {
+		t.Errorf("License from frontmatter = %q, want MIT", got)
+	}
+}
+
+func TestFetchRepoLicense(t *testing.T) {
+	useToken(t, "", "", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/team/skills/license":
+			w.Write([]byte(`{"license":{"key":"apache-2.0","spdx_id":"Apache-2.0"}}`))
+		case "/api/v3/repos/team/custom/license":
+			w.Write([]byte(`{"license":{"key":"other","spdx_id":"NOASSERTION"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	t.Cleanup(ResetGitHubHosts)
+	SetGitHubHost(server.URL)
+
+	for repo, want := range map[string]string{"skills": "Apache-2.0", "custom": "", "none": ""} {
+		got, err := FetchRepoLicense("team", repo)
+		if err != nil || got != want {
+			t.Errorf("FetchRepoLicense(team/%s) = %q, %v, want %q", repo, got, err, want)
+		}
+	}
+}
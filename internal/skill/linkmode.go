@@ -0,0 +1,190 @@
+package skill
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// LinkMode is how a stored skill is placed into a provider's skills folder.
+// Some tools cache or refuse symlinked skill directories and need a real copy.
+type LinkMode string
+
+const (
+	LinkSymlink  LinkMode = "symlink"  // relative symlink to the store (default)
+	LinkCopy     LinkMode = "copy"     // independent copy of the skill folder
+	LinkHardlink LinkMode = "hardlink" // real folders whose files share the store's inodes
+	LinkPortable LinkMode = "portable" // symlink between resolved paths, or a copy inside containers
+)
+
+// ParseLinkMode validates a configured link mode. "" means LinkSymlink.
+func ParseLinkMode(s string) (LinkMode, error) {
+	switch LinkMode(s) {
+	case "", LinkSymlink:
+		return LinkSymlink, nil
+	case LinkCopy, LinkHardlink, LinkPortable:
+		return LinkMode(s), nil
+	}
+	return "", fmt.Errorf("unknown link mode %q (expected symlink, copy, hardlink or portable)", s)
+}
+
+// Effective resolves LinkPortable for the current machine: inside a
+// container the store and providers are usually bind mounts whose layout
+// differs from the host's, so links are replaced by copies.
+func (m LinkMode) Effective() LinkMode {
+	if m == LinkPortable && InContainer() {
+		return LinkCopy
+	}
+	return m
+}
+
+// Copied reports whether the mode leaves a separate folder in the provider
+// that has to be refreshed when the stored skill changes.
+func (m LinkMode) Copied() bool {
+	m = m.Effective()
+	return m == LinkCopy || m == LinkHardlink
+}
+
+// LinkToProviderMode places a stored skill into providerPath using mode.
+// Copies are assembled next to the target and swapped in, replacing any
+// previous link or copy of the skill. Anything else in the way is left
+// alone and reported as a conflict.
+func (s *Store) LinkToProviderMode(skillName, providerPath string, mode LinkMode) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	mode, err = ParseLinkMode(string(mode))
+	if err != nil {
+		return err
+	}
+	switch mode = mode.Effective(); mode {
+	case LinkSymlink:
+		return s.linkToProvider(skillName, providerPath)
+	case LinkPortable:
+		return s.linkPortable(skillName, providerPath)
+	}
+
+	// Dev skills are symlinks to a working directory: copy what they point to
+	sourcePath, err := filepath.EvalSymlinks(filepath.Join(s.BaseDir, skillName))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(providerPath, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp(providerPath, "."+skillName+".link-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	staged := filepath.Join(tmp, skillName)
+	if mode == LinkHardlink {
+		err = hardlinkTree(sourcePath, staged)
+	} else {
+		err = copyTree(sourcePath, staged)
+	}
+	if err != nil {
+		return err
+	}
+
+	targetPath := filepath.Join(providerPath, skillName)
+	if err := replaceable(targetPath, true); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(targetPath); err != nil {
+		return err
+	}
+	return os.Rename(staged, targetPath)
+}
+
+// linkPortable symlinks a stored skill with a link computed between the
+// resolved store and provider directories. A link computed from paths that
+// go through a symlinked home or mount point only works where that symlink
+// exists; one between the real directories keeps working wherever both
+// trees are mounted with the same relative layout.
+func (s *Store) linkPortable(skillName, providerPath string) error {
+	if err := os.MkdirAll(providerPath, 0755); err != nil {
+		return err
+	}
+	realProvider, err := filepath.EvalSymlinks(providerPath)
+	if err != nil {
+		return err
+	}
+	realStore, err := filepath.EvalSymlinks(s.BaseDir)
+	if err != nil {
+		return err
+	}
+	relPath, err := filepath.Rel(realProvider, filepath.Join(realStore, skillName))
+	if err != nil {
+		return err
+	}
+
+	targetPath := filepath.Join(providerPath, skillName)
+	if err := replaceable(targetPath, false); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(targetPath); err != nil {
+		return err
+	}
+	return os.Symlink(relPath, targetPath)
+}
+
+// replaceable returns a conflict unless the entry at targetPath is missing,
+// a link, or a folder where the provider copies skills, i.e. a previous
+// copy. Files and folders of the user or another tool are never deleted.
+func replaceable(targetPath string, copies bool) error {
+	info, err := os.Lstat(targetPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 || (copies && info.IsDir()) {
+		return nil
+	}
+	return errs.WithHint(errs.Conflict, "move it aside or remove it, then link again",
+		"%s is not a link or copy made by efx-skills; leaving it alone", targetPath)
+}
+
+// hardlinkTree recreates the folders of src below dst and hard-links every
+// file. Both must be on the same filesystem.
+func hardlinkTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		}
+		if err := os.Link(p, target); err != nil {
+			return fmt.Errorf("hard-linking %s (the store and provider must share a filesystem): %w", rel, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,181 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+func TestLinkToProviderMode(t *testing.T) {
+	for _, mode := range []LinkMode{LinkCopy, LinkHardlink} {
+		t.Run(string(mode), func(t *testing.T) {
+			tmp := t.TempDir()
+			store := NewStore(filepath.Join(tmp, "store"))
+			src := filepath.Join(store.BaseDir, "demo")
+			os.MkdirAll(filepath.Join(src, "scripts"), 0755)
+			os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("v1"), 0644)
+			os.WriteFile(filepath.Join(src, "scripts", "run.sh"), []byte("echo"), 0755)
+
+			providerPath := filepath.Join(tmp, "provider")
+			// A previous symlink is replaced by the copy
+			if err := store.LinkToProvider("demo", providerPath); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.LinkToProviderMode("demo", providerPath, mode); err != nil {
+				t.Fatalf("LinkToProviderMode error: %v", err)
+			}
+
+			target := filepath.Join(providerPath, "demo")
+			info, err := os.Lstat(target)
+			if err != nil || !info.IsDir() {
+				t.Fatalf("target is not a real directory: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(target, "scripts", "run.sh")); err != nil {
+				t.Errorf("nested file missing: %v", err)
+			}
+
+			srcInfo, _ := os.Stat(filepath.Join(src, "SKILL.md"))
+			dstInfo, _ := os.Stat(filepath.Join(target, "SKILL.md"))
+			if same := os.SameFile(srcInfo, dstInfo); same != (mode == LinkHardlink) {
+				t.Errorf("SameFile = %v for %s", same, mode)
+			}
+
+			entries, _ := os.ReadDir(providerPath)
+			if len(entries) != 1 {
+				t.Errorf("provider holds %d entries, want only the skill", len(entries))
+			}
+		})
+	}
+}
+
+func TestLinkToProviderModeLeavesUnmanagedEntries(t *testing.T) {
+	inContainer = func() bool { return false }
+	t.Cleanup(func() { inContainer = detectContainer })
+
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "store"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "demo"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "demo", "SKILL.md"), []byte("v1"), 0644)
+	providerPath := filepath.Join(tmp, "provider")
+	os.MkdirAll(providerPath, 0755)
+
+	// A file of the user's is in the way of a copy...
+	userFile := filepath.Join(providerPath, "demo")
+	os.WriteFile(userFile, []byte("mine"), 0644)
+	err := store.LinkToProviderMode("demo", providerPath, LinkCopy)
+	if errs.KindOf(err) != errs.Conflict {
+		t.Errorf("copy over a file: err = %v, want a conflict", err)
+	}
+	if data, _ := os.ReadFile(userFile); string(data) != "mine" {
+		t.Error("the user's file was replaced")
+	}
+
+	// ...and a folder of the user's in the way of a portable link
+	os.Remove(userFile)
+	os.MkdirAll(userFile, 0755)
+	os.WriteFile(filepath.Join(userFile, "SKILL.md"), []byte("mine"), 0644)
+	err = store.LinkToProviderMode("demo", providerPath, LinkPortable)
+	if errs.KindOf(err) != errs.Conflict {
+		t.Errorf("portable link over a folder: err = %v, want a conflict", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(userFile, "SKILL.md")); string(data) != "mine" {
+		t.Error("the user's folder was replaced")
+	}
+}
+
+func TestParseLinkMode(t *testing.T) {
+	if mode, err := ParseLinkMode(""); err != nil || mode != LinkSymlink {
+		t.Errorf(`ParseLinkMode("") = %q, %v; want symlink`, mode, err)
+	}
+	if _, err := ParseLinkMode("junction"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestLinkToProviderPortable(t *testing.T) {
+	inContainer = func() bool { return false }
+	t.Cleanup(func() { inContainer = detectContainer })
+
+	// The provider is reached through a symlinked folder, as with a home
+	// directory linked to another disk
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "store"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "demo"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "demo", "SKILL.md"), []byte("v1"), 0644)
+	os.MkdirAll(filepath.Join(tmp, "disk", "claude"), 0755)
+	os.MkdirAll(filepath.Join(tmp, "home"), 0755)
+	os.Symlink(filepath.Join(tmp, "disk", "claude"), filepath.Join(tmp, "home", ".claude"))
+	providerPath := filepath.Join(tmp, "home", ".claude", "skills")
+
+	if err := store.LinkToProviderMode("demo", providerPath, LinkPortable); err != nil {
+		t.Fatalf("LinkToProviderMode error: %v", err)
+	}
+	target := filepath.Join(providerPath, "demo")
+	dest, err := os.Readlink(target)
+	if err != nil {
+		t.Fatalf("not a symlink: %v", err)
+	}
+	if want := filepath.Join("..", "..", "..", "store", "demo"); dest != want {
+		t.Errorf("link = %q, want %q", dest, want)
+	}
+	if _, err := os.Stat(filepath.Join(target, "SKILL.md")); err != nil {
+		t.Errorf("link does not resolve: %v", err)
+	}
+}
+
+func TestLinkToProviderPortableInContainer(t *testing.T) {
+	inContainer = func() bool { return true }
+	t.Cleanup(func() { inContainer = detectContainer })
+
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "store"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "demo"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "demo", "SKILL.md"), []byte("v1"), 0644)
+
+	if !LinkPortable.Copied() {
+		t.Error("portable mode should report a copy inside a container")
+	}
+	providerPath := filepath.Join(tmp, "provider")
+	if err := store.LinkToProviderMode("demo", providerPath, LinkPortable); err != nil {
+		t.Fatalf("LinkToProviderMode error: %v", err)
+	}
+	info, err := os.Lstat(filepath.Join(providerPath, "demo"))
+	if err != nil || !info.IsDir() {
+		t.Fatalf("expected a copied folder: %v", err)
+	}
+}
+
+func TestDetectContainer(t *testing.T) {
+	tmp := t.TempDir()
+	oldMarkers, oldProc := containerMarkers, procVersion
+	t.Cleanup(func() { containerMarkers, procVersion = oldMarkers, oldProc })
+	for _, name := range containerEnv {
+		t.Setenv(name, "")
+	}
+
+	containerMarkers = []string{filepath.Join(tmp, ".dockerenv")}
+	procVersion = filepath.Join(tmp, "version")
+	os.WriteFile(procVersion, []byte("Linux version 6.1.0-generic"), 0644)
+	if detectContainer() {
+		t.Error("detected a container without markers")
+	}
+
+	os.WriteFile(procVersion, []byte("Linux version 5.15.90.1-microsoft-standard-WSL2"), 0644)
+	if !detectContainer() {
+		t.Error("WSL kernel not detected")
+	}
+
+	os.WriteFile(procVersion, []byte("Linux"), 0644)
+	os.WriteFile(containerMarkers[0], nil, 0644)
+	if !detectContainer() {
+		t.Error("marker file not detected")
+	}
+
+	os.Remove(containerMarkers[0])
+	t.Setenv("REMOTE_CONTAINERS", "true")
+	if !detectContainer() {
+		t.Error("devcontainer variable not detected")
+	}
+}
@@ -0,0 +1,153 @@
+package skill
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Link is a URL or relative file reference found in a skill's markdown.
+type Link struct {
+	Target string
+	Line   int
+}
+
+// External reports whether the link points to the web rather than a file
+// shipped with the skill.
+func (l Link) External() bool {
+	return strings.HasPrefix(l.Target, "http://") || strings.HasPrefix(l.Target, "https://")
+}
+
+// LinkResult is the outcome of checking one link. Status describes a dead
+// link; it is empty for live ones.
+type LinkResult struct {
+	Link
+	OK        bool
+	Status    string
+	Unchecked bool // a relative reference with no local folder to check it against
+}
+
+var (
+	mdLinkPattern   = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	autoLinkPattern = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	bareURLPattern  = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+)
+
+// ExtractLinks returns the links of a markdown document in order: inline
+// links and images, autolinks, and bare URLs. Fenced code blocks, in-page
+// anchors and mailto: links are skipped.
+func ExtractLinks(content string) []Link {
+	var links []Link
+	inFence := false
+	for i, line := range strings.Split(content, "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		add := func(target string) {
+			target = strings.TrimRight(target, ".,;:")
+			if target == "" || seen[target] || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "mailto:") {
+				return
+			}
+			seen[target] = true
+			links = append(links, Link{Target: target, Line: i + 1})
+		}
+		for _, m := range mdLinkPattern.FindAllStringSubmatch(line, -1) {
+			add(m[1])
+		}
+		for _, m := range autoLinkPattern.FindAllStringSubmatch(line, -1) {
+			add(m[1])
+		}
+		for _, m := range bareURLPattern.FindAllString(line, -1) {
+			add(m)
+		}
+	}
+	return links
+}
+
+// linkCheckConcurrency caps the URLs checked at once.
+const linkCheckConcurrency = 8
+
+// checkURL is replaced in tests.
+var checkURL = func(u string) (bool, string) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := NewGitHubRequest(u)
+		if err != nil {
+			return false, err.Error()
+		}
+		req.Method = method
+		resp, err := client.Do(req)
+		if err != nil {
+			return false, err.Error()
+		}
+		resp.Body.Close()
+		// Some servers refuse HEAD; ask again with GET before giving up
+		if method == http.MethodHead && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusForbidden) {
+			continue
+		}
+		if resp.StatusCode >= 400 {
+			return false, resp.Status
+		}
+		return true, ""
+	}
+	return false, "no response"
+}
+
+// CheckLinks verifies links: URLs must answer without an error status, and
+// relative references must exist below dir. When dir is "" (content fetched
+// from a repository), relative references are reported as unchecked.
+func CheckLinks(dir string, links []Link) []LinkResult {
+	results := make([]LinkResult, len(links))
+	sem := make(chan struct{}, linkCheckConcurrency)
+	var wg sync.WaitGroup
+	for i, l := range links {
+		results[i].Link = l
+		switch {
+		case !l.External() && dir == "":
+			results[i].OK, results[i].Unchecked = true, true
+			continue
+		case !l.External():
+			results[i].OK, results[i].Status = checkFile(dir, l.Target)
+			continue
+		}
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i].OK, results[i].Status = checkURL(u)
+		}(i, l.Target)
+	}
+	wg.Wait()
+	return results
+}
+
+// checkFile resolves a relative reference against the skill folder.
+func checkFile(dir, target string) (bool, string) {
+	if u, err := url.Parse(target); err == nil && u.Scheme != "" {
+		return true, ""
+	}
+	target, _, _ = strings.Cut(target, "#")
+	target, _, _ = strings.Cut(target, "?")
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	if filepath.IsAbs(target) {
+		return false, "absolute path"
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(target))); err != nil {
+		return false, "no such file"
+	}
+	return true, ""
+}
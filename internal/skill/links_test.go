@@ -0,0 +1,70 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	content := "# Demo\n" +
+		"See [docs](https://example.com/docs \"Docs\") and ![diagram](images/flow.png).\n" +
+		"Run [the script](scripts/run.sh#usage), or visit https://example.com/docs.\n" +
+		"Jump to [usage](#usage) or <https://example.org/>.\n" +
+		"```\n" +
+		"curl https://in-code.example.com\n" +
+		"```\n"
+
+	got := ExtractLinks(content)
+	want := []Link{
+		{"https://example.com/docs", 2},
+		{"images/flow.png", 2},
+		{"scripts/run.sh#usage", 3},
+		{"https://example.com/docs", 3},
+		{"https://example.org/", 4},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ExtractLinks = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("link %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCheckLinks(t *testing.T) {
+	orig := checkURL
+	checkURL = func(u string) (bool, string) {
+		if u == "https://dead.example.com" {
+			return false, "404 Not Found"
+		}
+		return true, ""
+	}
+	t.Cleanup(func() { checkURL = orig })
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "scripts"), 0755)
+	os.WriteFile(filepath.Join(dir, "scripts", "run.sh"), []byte("echo"), 0644)
+
+	links := []Link{
+		{Target: "https://ok.example.com"},
+		{Target: "https://dead.example.com"},
+		{Target: "scripts/run.sh#usage"},
+		{Target: "missing.md"},
+	}
+	results := CheckLinks(dir, links)
+	for i, wantOK := range []bool{true, false, true, false} {
+		if results[i].OK != wantOK {
+			t.Errorf("%s OK = %v, want %v (%s)", results[i].Target, results[i].OK, wantOK, results[i].Status)
+		}
+	}
+	if results[1].Status != "404 Not Found" {
+		t.Errorf("dead link status = %q", results[1].Status)
+	}
+
+	remote := CheckLinks("", links[2:3])
+	if !remote[0].Unchecked {
+		t.Error("relative reference without a folder should be unchecked")
+	}
+}
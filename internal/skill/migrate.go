@@ -0,0 +1,111 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+)
+
+// LockVersion is the lock file format efx-skills writes, matching the
+// current upstream skills CLI.
+const LockVersion = 3
+
+// sourceURLPattern recognises sources written as GitHub URLs rather than
+// owner/repo, as older skills CLI releases did. URLs of other hosts never
+// match.
+var sourceURLPattern = regexp.MustCompile(`^(?:(?:https?://|git@)github\.com[:/]|github:)?([\w.-]+)/([\w.-]+?)(?:\.git)?(?:/tree/[^ ]*)?/?$`)
+
+// NormalizeSource converts "https://github.com/o/r.git", "github:o/r" and
+// similar spellings to "o/r". Archive URLs and other unrecognised sources
+// are returned unchanged.
+func NormalizeSource(source string) string {
+	if source == SourceTypeLocal || IsArchiveURL(source) {
+		return source
+	}
+	if m := sourceURLPattern.FindStringSubmatch(strings.TrimSpace(source)); m != nil {
+		return m[1] + "/" + m[2]
+	}
+	return source
+}
+
+// NormalizeLock upgrades entries written by other skills CLI versions in
+// place: sources become owner/repo, missing sourceType/sourceUrl and
+// timestamps are filled in, and the version is raised to LockVersion. It
+// returns the names of the entries it changed. Git, archive, dev and local
+// entries are efx-skills' own and are left untouched: their sources are
+// cloned, downloaded or linked as written.
+func (s *Store) NormalizeLock(lock *LockFile) []string {
+	if lock.Skills == nil {
+		lock.Skills = make(map[string]LockEntry)
+	}
+	var changed []string
+	for name, e := range lock.Skills {
+		switch e.SourceType {
+		case SourceTypeGit, SourceTypeArchive, SourceTypeDev, SourceTypeLocal:
+			continue
+		}
+		before := e
+
+		e.Source = NormalizeSource(e.Source)
+		if e.SourceType == "" {
+			e.SourceType = "github"
+		}
+		if e.SourceURL == "" && e.SourceType == "github" && strings.Count(e.Source, "/") == 1 {
+			e.SourceURL = fmt.Sprintf("https://github.com/%s.git", e.Source)
+		}
+		if e.InstalledAt == "" {
+			e.InstalledAt = dirModTime(filepath.Join(s.BaseDir, name))
+		}
+		if e.UpdatedAt == "" {
+			e.UpdatedAt = e.InstalledAt
+		}
+
+		if e != before {
+			lock.Skills[name] = e
+			changed = append(changed, name)
+		}
+	}
+	lock.Version = LockVersion
+	return changed
+}
+
+// ImportDir copies a skill directory from another store location into this
+// store. Existing skills are left untouched.
+func (s *Store) ImportDir(src, skillName string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
+	}
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	dst := filepath.Join(s.BaseDir, skillName)
+	if _, err := os.Lstat(dst); err == nil {
+		return errs.New(errs.Conflict, "%s already exists in the store", skillName)
+	}
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return err
+	}
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return nil
+}
+
+// dirModTime returns a directory's modification time as RFC 3339, or now
+// when it cannot be read.
+func dirModTime(dir string) string {
+	if info, err := os.Stat(dir); err == nil {
+		return info.ModTime().UTC().Format(time.RFC3339)
+	}
+	return time.Now().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,67 @@
+package skill
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeSource(t *testing.T) {
+	tests := map[string]string{
+		"owner/repo":                              "owner/repo",
+		"https://github.com/owner/repo.git":       "owner/repo",
+		"https://github.com/owner/repo/tree/main": "owner/repo",
+		"git@github.com:owner/repo.git":           "owner/repo",
+		"github:owner/repo":                       "owner/repo",
+		"local":                                   "local",
+		"https://example.com/deploy.zip":          "https://example.com/deploy.zip",
+		"https://gitlab.com/owner/repo.git":       "https://gitlab.com/owner/repo.git",
+	}
+	for in, want := range tests {
+		if got := NormalizeSource(in); got != want {
+			t.Errorf("NormalizeSource(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeLockLeavesOwnSourceTypesAlone(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	entries := map[string]LockEntry{
+		"ssh":     {Source: "git@github.com:org/private.git", SourceType: SourceTypeGit},
+		"archive": {Source: "https://example.com/deploy.zip", SourceType: SourceTypeArchive},
+		"dev":     {Source: "/home/me/src/lint", SourceType: SourceTypeDev},
+		"local":   {Source: SourceTypeLocal, SourceType: SourceTypeLocal},
+	}
+	lock := &LockFile{Version: 1, Skills: make(map[string]LockEntry)}
+	for name, e := range entries {
+		lock.Skills[name] = e
+	}
+
+	if changed := store.NormalizeLock(lock); len(changed) != 0 {
+		t.Errorf("NormalizeLock changed = %v, want nothing", changed)
+	}
+	for name, want := range entries {
+		if got := lock.Skills[name]; got != want {
+			t.Errorf("%s entry = %+v, want it untouched", name, got)
+		}
+	}
+}
+
+func TestNormalizeLockFillsMissingFields(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	lock := &LockFile{Version: 1, Skills: map[string]LockEntry{
+		"legacy":  {Source: "https://github.com/owner/repo.git"},
+		"current": {Source: "a/b", SourceType: "github", SourceURL: "https://github.com/a/b.git", InstalledAt: "x", UpdatedAt: "y"},
+	}}
+
+	changed := store.NormalizeLock(lock)
+	if len(changed) != 1 || changed[0] != "legacy" {
+		t.Fatalf("NormalizeLock changed = %v, want [legacy]", changed)
+	}
+	e := lock.Skills["legacy"]
+	if e.Source != "owner/repo" || e.SourceType != "github" || e.SourceURL != "https://github.com/owner/repo.git" || e.InstalledAt == "" {
+		t.Fatalf("normalized entry = %+v", e)
+	}
+	if lock.Version != LockVersion {
+		t.Fatalf("Version = %d, want %d", lock.Version, LockVersion)
+	}
+}
@@ -0,0 +1,226 @@
+package skill
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SourceKind says where a Source is fetched from.
+type SourceKind string
+
+const (
+	SourceGitHub   SourceKind = "github"   // a repository on GitHub or an enterprise host
+	SourceGit      SourceKind = "git"      // an SSH git URL, cloned with git
+	SourceArchive  SourceKind = "archive"  // a .zip or .tar.gz URL
+	SourceLocal    SourceKind = "local"    // a folder on disk
+	SourceRegistry SourceKind = "registry" // a skill id in a search registry
+)
+
+// Source is the canonical form of anything naming a skill or a repository
+// of skills: "owner/repo", "owner/repo/skill[@version]", a GitHub URL, an
+// SSH git URL, an archive URL, a local path or "<registry>:<id>".
+type Source struct {
+	Kind     SourceKind
+	Host     string // enterprise host of a GitHub repository; "" is github.com
+	Owner    string
+	Repo     string
+	URL      string // the git or archive URL, or the absolute folder of a local source
+	Registry string // the registry of a registry id
+	ID       string // the id within Registry
+	Path     string // the skill's folder within the repository; "" names the repository
+	Ref      string // the version after "@", or the branch of a /tree/<ref>/ URL
+}
+
+// registryIDPattern matches "<registry>:<id>" inputs such as
+// "skills.sh:owner/repo/skill".
+var registryIDPattern = regexp.MustCompile(`^([a-z0-9][\w.-]+):([^/].*)$`)
+
+// ParseSource parses input into a Source without touching the network.
+// Registry ids are returned as they are; resolving them to a repository
+// needs the registry.
+func ParseSource(input string) (Source, error) {
+	input = strings.TrimSpace(input)
+	switch {
+	case input == "":
+		return Source{}, fmt.Errorf("empty source")
+	case IsArchiveURL(input):
+		if _, err := ArchiveSkillName(input); err != nil {
+			return Source{}, err
+		}
+		return Source{Kind: SourceArchive, URL: input}, nil
+	case IsGitURL(input):
+		return parseGitSource(input), nil
+	case isLocalPath(input):
+		return parseLocalSource(input)
+	case strings.HasPrefix(input, "https://") || strings.HasPrefix(input, "http://"):
+		return parseWebSource(input)
+	}
+	if m := registryIDPattern.FindStringSubmatch(input); m != nil {
+		src := Source{Kind: SourceRegistry, Registry: m[1]}
+		src.ID, src.Ref = cutRef(m[2])
+		return src, nil
+	}
+	return parseRepoSource(input)
+}
+
+// Name returns the name of the skill the source names, "" when it names a
+// whole repository.
+func (s Source) Name() string {
+	switch s.Kind {
+	case SourceArchive:
+		name, _ := ArchiveSkillName(s.URL)
+		return name
+	case SourceLocal:
+		return filepath.Base(s.URL)
+	case SourceRegistry:
+		return path.Base(s.ID)
+	}
+	if s.Path == "" {
+		return ""
+	}
+	return path.Base(s.Path)
+}
+
+// Repository returns the source recorded in the lock file for skills
+// installed from s: "owner/repo", or the git, archive or folder URL.
+func (s Source) Repository() string {
+	switch s.Kind {
+	case SourceGitHub:
+		return s.Owner + "/" + s.Repo
+	case SourceRegistry:
+		return s.Registry + ":" + s.ID
+	}
+	return s.URL
+}
+
+// String returns the canonical reference of s, which ParseSource reads
+// back unchanged.
+func (s Source) String() string {
+	ref := s.Repository()
+	switch s.Kind {
+	case SourceGitHub:
+		if s.Host != "" {
+			ref = s.Host + "/" + ref
+		}
+	case SourceArchive, SourceLocal:
+		return ref
+	}
+	if s.Path != "" && s.Kind != SourceRegistry {
+		ref += "/" + s.Path
+	}
+	if s.Ref != "" {
+		ref += "@" + s.Ref
+	}
+	return ref
+}
+
+// cutRef splits a trailing "@version" off ref.
+func cutRef(ref string) (rest, version string) {
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// parseGitSource splits "<git url>.git/skill[@version]". A URL without a
+// skill names the repository.
+func parseGitSource(input string) Source {
+	i := strings.Index(input, ".git/")
+	if i < 0 {
+		return Source{Kind: SourceGit, URL: input}
+	}
+	src := Source{Kind: SourceGit, URL: input[:i+len(".git")]}
+	src.Path, src.Ref = cutRef(strings.Trim(input[i+len(".git/"):], "/"))
+	src.Path = strings.Trim(src.Path, "/")
+	return src
+}
+
+// isLocalPath reports whether input is written as a path rather than a
+// reference, so a folder in the working directory never shadows
+// "owner/repo".
+func isLocalPath(input string) bool {
+	if input == "." || input == ".." || input == "~" || filepath.IsAbs(input) {
+		return true
+	}
+	for _, prefix := range []string{"/", "./", "../", "~/"} {
+		if strings.HasPrefix(input, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseLocalSource(input string) (Source, error) {
+	if input == "~" || strings.HasPrefix(input, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Source{}, err
+		}
+		input = filepath.Join(home, strings.TrimPrefix(input, "~"))
+	}
+	dir, err := filepath.Abs(input)
+	if err != nil {
+		return Source{}, err
+	}
+	return Source{Kind: SourceLocal, URL: dir}, nil
+}
+
+// parseWebSource reads a repository URL, including the /tree/<ref>/<path>
+// and /blob/<ref>/<path>/SKILL.md pages of a skill. A host other than
+// github.com is a GitHub Enterprise host.
+func parseWebSource(input string) (Source, error) {
+	u, err := url.Parse(input)
+	if err != nil || u.Host == "" {
+		return Source{}, fmt.Errorf("invalid source URL: %s", input)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return Source{}, fmt.Errorf("invalid source URL: %s (expected https://host/owner/repo)", input)
+	}
+	src := Source{Kind: SourceGitHub, Host: repoHost(u.Host), Owner: parts[0], Repo: strings.TrimSuffix(parts[1], ".git")}
+	rest := parts[2:]
+	if len(rest) >= 2 && (rest[0] == "tree" || rest[0] == "blob") {
+		src.Ref, rest = rest[1], rest[2:]
+		if len(rest) > 0 && rest[len(rest)-1] == "SKILL.md" {
+			rest = rest[:len(rest)-1]
+		}
+	}
+	src.Path = strings.Join(rest, "/")
+	return src, nil
+}
+
+// parseRepoSource reads "[host/]owner/repo[/path][@version]". GitHub
+// owners cannot contain dots, so a first segment with one is a host.
+func parseRepoSource(input string) (Source, error) {
+	rest, ref := cutRef(input)
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	src := Source{Kind: SourceGitHub, Ref: ref}
+	if len(parts) >= 3 && strings.Contains(parts[0], ".") {
+		src.Host, parts = repoHost(parts[0]), parts[1:]
+	}
+	for _, p := range parts {
+		if p == "" {
+			return Source{}, fmt.Errorf("invalid source: %s (expected owner/repo[/skill][@version])", input)
+		}
+	}
+	if len(parts) < 2 {
+		return Source{}, fmt.Errorf("invalid source: %s (expected owner/repo[/skill][@version])", input)
+	}
+	src.Owner, src.Repo = parts[0], strings.TrimSuffix(parts[1], ".git")
+	src.Path = strings.Join(parts[2:], "/")
+	return src, nil
+}
+
+// repoHost returns host, or "" for github.com.
+func repoHost(host string) string {
+	host = strings.ToLower(host)
+	if host == "github.com" || host == "www.github.com" {
+		return ""
+	}
+	return host
+}
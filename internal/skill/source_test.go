@@ -0,0 +1,74 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseSource(t *testing.T) {
+	tests := map[string]Source{
+		"owner/repo":                        {Kind: SourceGitHub, Owner: "owner", Repo: "repo"},
+		"owner/repo/lint@v1.2":              {Kind: SourceGitHub, Owner: "owner", Repo: "repo", Path: "lint", Ref: "v1.2"},
+		"github.mycorp.com/team/skills":     {Kind: SourceGitHub, Host: "github.mycorp.com", Owner: "team", Repo: "skills"},
+		"https://github.com/owner/repo.git": {Kind: SourceGitHub, Owner: "owner", Repo: "repo"},
+		"https://github.com/owner/repo/tree/dev/skills/lint": {
+			Kind: SourceGitHub, Owner: "owner", Repo: "repo", Path: "skills/lint", Ref: "dev",
+		},
+		"https://github.mycorp.com/team/skills/blob/main/pdf/SKILL.md": {
+			Kind: SourceGitHub, Host: "github.mycorp.com", Owner: "team", Repo: "skills", Path: "pdf", Ref: "main",
+		},
+		"git@github.com:org/skills.git/deploy@v2": {Kind: SourceGit, URL: "git@github.com:org/skills.git", Path: "deploy", Ref: "v2"},
+		"git@github.com:org/skills":               {Kind: SourceGit, URL: "git@github.com:org/skills"},
+		"https://example.com/dl/deploy.zip":       {Kind: SourceArchive, URL: "https://example.com/dl/deploy.zip"},
+		"skills.sh:owner/repo/lint":               {Kind: SourceRegistry, Registry: "skills.sh", ID: "owner/repo/lint"},
+	}
+	for input, want := range tests {
+		if got, err := ParseSource(input); err != nil || got != want {
+			t.Errorf("ParseSource(%q) = %+v, %v; want %+v", input, got, err, want)
+		}
+	}
+
+	for _, bad := range []string{"", "lint", "owner//lint", "https://github.com/owner"} {
+		if got, err := ParseSource(bad); err == nil {
+			t.Errorf("ParseSource(%q) = %+v, want an error", bad, got)
+		}
+	}
+}
+
+func TestParseLocalSource(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	got, err := ParseSource("~/skills/lint")
+	if err != nil || got.Kind != SourceLocal || got.URL != filepath.Join(home, "skills", "lint") || got.Name() != "lint" {
+		t.Fatalf("ParseSource(~/skills/lint) = %+v, %v", got, err)
+	}
+
+	// A folder named like owner/repo in the working directory is not a path
+	wd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(wd) })
+	os.Chdir(home)
+	os.MkdirAll(filepath.Join(home, "owner", "repo"), 0755)
+	if got, _ := ParseSource("owner/repo"); got.Kind != SourceGitHub {
+		t.Errorf("ParseSource(owner/repo) = %+v, want a GitHub source", got)
+	}
+}
+
+func TestSourceStringRoundTrips(t *testing.T) {
+	for _, input := range []string{
+		"owner/repo",
+		"owner/repo/lint@v1",
+		"github.mycorp.com/team/skills/pdf",
+		"git@github.com:org/skills.git/deploy@v2",
+		"https://example.com/dl/deploy.tar.gz#sha256=abc",
+		"skills.sh:owner/repo/lint",
+	} {
+		src, err := ParseSource(input)
+		if err != nil || src.String() != input {
+			t.Errorf("ParseSource(%q).String() = %q, %v", input, src.String(), err)
+		}
+	}
+	if got := (Source{Kind: SourceGitHub, Owner: "o", Repo: "r", Path: "skills/lint"}).Repository(); got != "o/r" {
+		t.Errorf("Repository = %q, want o/r", got)
+	}
+}
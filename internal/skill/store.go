@@ -1,6 +1,7 @@
 package skill
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,12 +11,22 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/fsutil"
 )
 
 // Store handles local skill storage
 type Store struct {
 	BaseDir  string // ~/.agents/skills
 	LockFile string // ~/.agents/.skill-lock.json
+
+	// Vet, when set, checks a downloaded skill folder before it replaces the
+	// installed copy. An error aborts the install with the store and lock
+	// file left as they were.
+	Vet func(dir string) error
+
+	locked bool // returned by Lock: the store lock is already held
 }
 
 // NewStore creates a new skill store. If skillsPath is empty, it defaults
@@ -31,86 +42,113 @@ func NewStore(skillsPath string) *Store {
 	}
 }
 
-// Install installs a skill from a source
+// Install downloads a skill folder from its GitHub source into the store
+// and records the folder it was found in. The npx skills CLI is only used
+// when EFX_SKILLS_USE_NPX=1 is set and npx is on the PATH.
 func (s *Store) Install(source, skillName string) error {
-	// Use npx skills if available
-	if _, err := exec.LookPath("npx"); err == nil {
-		return s.installViaSkills(source, skillName)
+	return s.InstallVersion(source, skillName, "")
+}
+
+// InstallVersion installs a skill at a tag or branch and pins it in the lock
+// file. An empty ref installs the default branch. Pinned installs always use
+// the native downloader, since npx skills cannot select a version.
+func (s *Store) InstallVersion(source, skillName, ref string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
 	}
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	// Fallback to direct download
-	return s.installDirect(source, skillName)
+	return s.installVersion(source, skillName, ref)
 }
 
-// installViaSkills uses npx skills add command
-func (s *Store) installViaSkills(source, skillName string) error {
-	args := []string{"skills", "add", source, "-g", "-y"}
-	if skillName != "" {
-		args = append(args, "--skill", skillName)
+// installVersion is InstallVersion with the store lock already held.
+func (s *Store) installVersion(source, skillName, ref string) error {
+	// npx skills installs in place, so vetted installs use the native downloader
+	if ref == "" && s.Vet == nil && os.Getenv(UseNpxEnv) == "1" && !IsGitURL(source) && !IsArchiveURL(source) {
+		if _, err := exec.LookPath("npx"); err == nil {
+			return s.installViaSkills(source, skillName)
+		}
 	}
+	return s.installNative(source, skillName, ref, "", false)
+}
 
-	cmd := exec.Command("npx", args...)
-	// Capture output silently to avoid breaking the TUI
-	output, err := cmd.CombinedOutput()
+// InstallPath installs the skill stored in a known repository folder, as
+// listed by DiscoverSkills, from the default branch.
+func (s *Store) InstallPath(source, skillName, skillPath string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
+	}
+	s, unlock, err := s.Lock()
 	if err != nil {
-		return fmt.Errorf("%w: %s", err, string(output))
+		return err
 	}
-	return nil
+	defer unlock()
+
+	return s.installNative(source, skillName, "", skillPath, false)
 }
 
-// installDirect downloads skill directly from GitHub
-func (s *Store) installDirect(source, skillName string) error {
-	// Parse source (e.g., "owner/repo")
-	parts := strings.Split(source, "/")
-	if len(parts) < 2 {
-		return fmt.Errorf("invalid source format: %s (expected owner/repo)", source)
+// InstallBranch installs a skill from branch and records it as the skill's
+// channel, so updates follow that branch instead of the default one.
+func (s *Store) InstallBranch(source, skillName, branch string) error {
+	if err := fsutil.ValidName(skillName); err != nil {
+		return err
+	}
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	owner := parts[0]
-	repo := parts[1]
+	return s.installNative(source, skillName, branch, "", true)
+}
 
-	// Create skill directory
-	skillDir := filepath.Join(s.BaseDir, skillName)
-	if err := os.MkdirAll(skillDir, 0755); err != nil {
-		return err
+// installViaSkills uses npx skills add command
+func (s *Store) installViaSkills(source, skillName string) error {
+	args := []string{"skills", "add", source, "-g", "-y"}
+	if skillName != "" {
+		args = append(args, "--skill", skillName)
 	}
 
-	// Download SKILL.md
-	url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/skills/%s/SKILL.md", owner, repo, skillName)
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
+	cmd := exec.Command("npx", args...)
+	// Never write to the terminal directly; the TUI owns it
+	var output bytes.Buffer
+	cmd.Stdout = teeOutput(&output)
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, output.String())
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		// Try alternative path
-		url = fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/%s/SKILL.md", owner, repo, skillName)
-		resp, err = http.Get(url)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+// CommandOutput receives the output of external commands as they run, e.g.
+// a log pane in the TUI. When nil the output is only captured for errors.
+var CommandOutput io.Writer
 
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("failed to download skill: %s", resp.Status)
-		}
+// teeOutput returns a writer copying to buf and to CommandOutput, if set.
+func teeOutput(buf *bytes.Buffer) io.Writer {
+	if CommandOutput == nil {
+		return buf
 	}
+	return io.MultiWriter(buf, CommandOutput)
+}
 
-	// Write SKILL.md
-	skillFile := filepath.Join(skillDir, "SKILL.md")
-	out, err := os.Create(skillFile)
+// LinkToProvider creates a symlink from provider skills dir to central storage
+func (s *Store) LinkToProvider(skillName, providerPath string) error {
+	s, unlock, err := s.Lock()
 	if err != nil {
 		return err
 	}
-	defer out.Close()
+	defer unlock()
 
-	_, err = io.Copy(out, resp.Body)
-	return err
+	return s.linkToProvider(skillName, providerPath)
 }
 
-// LinkToProvider creates a symlink from provider skills dir to central storage
-func (s *Store) LinkToProvider(skillName, providerPath string) error {
+// linkToProvider is LinkToProvider with the store lock already held.
+func (s *Store) linkToProvider(skillName, providerPath string) error {
 	// Ensure provider directory exists
 	if err := os.MkdirAll(providerPath, 0755); err != nil {
 		return err
@@ -123,7 +161,9 @@ func (s *Store) LinkToProvider(skillName, providerPath string) error {
 	targetPath := filepath.Join(providerPath, skillName)
 
 	// Remove existing link if present
-	os.Remove(targetPath)
+	if err := os.Remove(targetPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("replacing %s: %w", targetPath, err)
+	}
 
 	// Create relative symlink
 	relPath, err := filepath.Rel(providerPath, sourcePath)
@@ -136,6 +176,12 @@ func (s *Store) LinkToProvider(skillName, providerPath string) error {
 
 // UnlinkFromProvider removes a symlink from provider skills dir
 func (s *Store) UnlinkFromProvider(skillName, providerPath string) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	targetPath := filepath.Join(providerPath, skillName)
 	return os.Remove(targetPath)
 }
@@ -143,13 +189,17 @@ func (s *Store) UnlinkFromProvider(skillName, providerPath string) error {
 // ListInstalled returns all installed skills
 func (s *Store) ListInstalled() ([]string, error) {
 	entries, err := os.ReadDir(s.BaseDir)
+	if os.IsNotExist(err) {
+		// Nothing has been installed yet
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	var skills []string
 	for _, e := range entries {
-		if e.IsDir() {
+		if IsSkillEntry(s.BaseDir, e) {
 			skills = append(skills, e.Name())
 		}
 	}
@@ -174,7 +224,12 @@ type LockEntry struct {
 	SourceType      string `json:"sourceType"`
 	SourceURL       string `json:"sourceUrl"`
 	SkillPath       string `json:"skillPath,omitempty"`
+	Ref             string `json:"ref,omitempty"`     // tag or branch the skill is pinned to
+	Branch          string `json:"branch,omitempty"`  // branch followed by updates, default branch when empty
+	License         string `json:"license,omitempty"` // SPDX identifier found at install, "" when unknown
+	Held            bool   `json:"held,omitempty"`    // frozen with hold: updates skip it until unhold
 	SkillFolderHash string `json:"skillFolderHash"`
+	LocalHash       string `json:"localHash,omitempty"` // FolderHash of the folder as efx-skills last wrote it
 	CommitHash      string `json:"commitHash"`
 	InstalledAt     string `json:"installedAt"`
 	UpdatedAt       string `json:"updatedAt"`
@@ -204,7 +259,8 @@ func (s *Store) ReadLockFile() (*LockFile, error) {
 	return &lock, nil
 }
 
-// WriteLockFile writes the skill lock file
+// WriteLockFile replaces the skill lock file. Callers that read, change and
+// write it back should hold Lock.
 func (s *Store) WriteLockFile(lock *LockFile) error {
 	dir := filepath.Dir(s.LockFile)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -216,25 +272,43 @@ func (s *Store) WriteLockFile(lock *LockFile) error {
 		return err
 	}
 
-	return os.WriteFile(s.LockFile, data, 0644)
+	return fsutil.WriteFile(s.LockFile, data, 0644)
 }
 
 // AddToLock adds a skill to the lock file with an optional commit hash.
 func (s *Store) AddToLock(skillName, source, commitHash string) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	lock, err := s.ReadLockFile()
 	if err != nil {
 		return err
 	}
 
 	now := time.Now().UTC().Format(time.RFC3339)
-	lock.Skills[skillName] = LockEntry{
+	sourceType, sourceURL := lockSource(source)
+	entry := LockEntry{
 		Source:      source,
-		SourceType:  "github",
-		SourceURL:   fmt.Sprintf("https://github.com/%s.git", source),
+		SourceType:  sourceType,
+		SourceURL:   sourceURL,
 		CommitHash:  commitHash,
 		InstalledAt: now,
 		UpdatedAt:   now,
 	}
+	// The folder as downloaded is the baseline local edits are detected against
+	entry.LocalHash, _ = FolderHash(filepath.Join(s.BaseDir, skillName))
+	// Keep the repository folder, version and license recorded by Install
+	if prev, ok := lock.Skills[skillName]; ok && prev.Source == source {
+		entry.SkillPath = prev.SkillPath
+		entry.Ref = prev.Ref
+		entry.Branch = prev.Branch
+		entry.License = prev.License
+		entry.Held = prev.Held
+	}
+	lock.Skills[skillName] = entry
 
 	return s.WriteLockFile(lock)
 }
@@ -242,6 +316,12 @@ func (s *Store) AddToLock(skillName, source, commitHash string) error {
 // RemoveFromLock removes a skill entry from the lock file.
 // If the skill is not present, this is a no-op.
 func (s *Store) RemoveFromLock(skillName string) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	lock, err := s.ReadLockFile()
 	if err != nil {
 		return err
@@ -252,15 +332,15 @@ func (s *Store) RemoveFromLock(skillName string) error {
 
 // FetchLatestCommitHash fetches the HEAD commit SHA for a GitHub owner/repo.
 func FetchLatestCommitHash(owner, repo string) (string, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/commits?per_page=1", gitHubAPIBaseURL, owner, repo)
-	resp, err := http.Get(url)
+	url := fmt.Sprintf("%s/repos/%s/%s/commits?per_page=1", apiBase(owner, repo), owner, repo)
+	resp, err := GitHubGet(url)
 	if err != nil {
 		return "", fmt.Errorf("fetching latest commit: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return "", errs.FromResponse(resp, "GitHub API returned status %d", resp.StatusCode)
 	}
 
 	var commits []struct {
@@ -287,14 +367,12 @@ func (s *Store) CheckForUpdate(skillName string) (hasUpdate bool, currentHash st
 	if !ok {
 		return false, "", "", fmt.Errorf("skill %q not found in lock file", skillName)
 	}
-
-	// Parse owner/repo from source
-	parts := strings.Split(entry.Source, "/")
-	if len(parts) < 2 {
-		return false, "", "", fmt.Errorf("invalid source format: %s", entry.Source)
+	if entry.SourceType == SourceTypeLocal || entry.SourceType == SourceTypeDev || entry.Ref != "" || entry.Held {
+		// Local and dev skills have no upstream; pinned and held ones stay at their version
+		return false, entry.CommitHash, entry.CommitHash, nil
 	}
 
-	latestHash, err = FetchLatestCommitHash(parts[0], parts[1])
+	latestHash, err = SourceCommit(entry.Source, entry.Branch)
 	if err != nil {
 		return false, entry.CommitHash, "", err
 	}
@@ -309,8 +387,24 @@ func (s *Store) CheckForUpdate(skillName string) (hasUpdate bool, currentHash st
 	return currentHash != latestHash, currentHash, latestHash, nil
 }
 
-// UpdateSkill re-downloads a skill and updates the lock entry with the new commit hash.
+// UpdateSkill re-downloads a skill and updates the lock entry with the new
+// commit hash. A skill edited in the store is not overwritten; see
+// UpdateSkillWith.
 func (s *Store) UpdateSkill(skillName string) error {
+	return s.UpdateSkillWith(skillName, StrategyRefuse)
+}
+
+// UpdateSkillWith updates a skill like UpdateSkill, resolving local edits
+// in the store with strategy: KeepLocal leaves the skill untouched,
+// TakeUpstream overwrites the edits and SaveLocal copies the skill to
+// <skill>.local first.
+func (s *Store) UpdateSkillWith(skillName string, strategy ConflictStrategy) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	lock, err := s.ReadLockFile()
 	if err != nil {
 		return err
@@ -320,34 +414,89 @@ func (s *Store) UpdateSkill(skillName string) error {
 	if !ok {
 		return fmt.Errorf("skill %q not found in lock file", skillName)
 	}
+	if entry.Held {
+		return HeldError(skillName)
+	}
+
+	modified, err := s.LocallyModified(skillName)
+	if err != nil {
+		return err
+	}
+	if modified {
+		switch strategy {
+		case StrategyRefuse:
+			return LocalChangesError(skillName)
+		case KeepLocal:
+			return nil
+		case SaveLocal:
+			if _, err := s.SaveLocalCopy(skillName); err != nil {
+				return fmt.Errorf("saving local changes of %s: %w", skillName, err)
+			}
+		}
+	}
 
-	// Re-install from source
-	if err := s.Install(entry.Source, skillName); err != nil {
+	// Re-install from source, at the pinned version or tracked branch if any
+	if entry.Branch != "" {
+		err = s.installNative(entry.Source, skillName, entry.Branch, "", true)
+	} else {
+		err = s.installVersion(entry.Source, skillName, entry.Ref)
+	}
+	if err != nil {
 		return fmt.Errorf("reinstalling %s: %w", skillName, err)
 	}
 
 	// Fetch latest commit hash
-	parts := strings.Split(entry.Source, "/")
-	if len(parts) < 2 {
-		return fmt.Errorf("invalid source format: %s", entry.Source)
+	ref := entry.Ref
+	if entry.Branch != "" {
+		ref = entry.Branch
 	}
-	latestHash, err := FetchLatestCommitHash(parts[0], parts[1])
+	latestHash, err := SourceCommit(entry.Source, ref)
 	if err != nil {
 		return fmt.Errorf("fetching commit hash for %s: %w", skillName, err)
 	}
 
-	// Update lock entry
+	// Update lock entry, re-read so the folder recorded by Install is kept
+	if lock, err = s.ReadLockFile(); err != nil {
+		return err
+	}
+	if fresh, ok := lock.Skills[skillName]; ok {
+		entry = fresh
+	}
 	entry.CommitHash = latestHash
+	entry.LocalHash, _ = FolderHash(filepath.Join(s.BaseDir, skillName))
 	entry.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
 	lock.Skills[skillName] = entry
 
 	return s.WriteLockFile(lock)
 }
 
+// Channel describes what a lock entry follows: its tracked branch, "@<ref>"
+// for a pinned version, or "default" for the repository's default branch.
+func (e LockEntry) Channel() string {
+	switch {
+	case e.Branch != "":
+		return e.Branch
+	case e.Ref != "":
+		return "@" + e.Ref
+	case e.SourceType == SourceTypeLocal:
+		return "local"
+	case e.SourceType == SourceTypeDev:
+		return "dev"
+	}
+	return "default"
+}
+
 // UpdateAllSkills iterates all locked skills and updates each that has a newer upstream commit.
 // Returns the list of skill names that were updated. Individual failures are collected but do not
-// stop processing of remaining skills.
+// stop processing of remaining skills. Skills edited in the store count as failures.
 func (s *Store) UpdateAllSkills() (updated []string, err error) {
+	return s.UpdateAllSkillsWith(StrategyRefuse)
+}
+
+// UpdateAllSkillsWith updates every skill like UpdateAllSkills, resolving
+// local edits with strategy. Skills kept with KeepLocal are not reported
+// as updated.
+func (s *Store) UpdateAllSkillsWith(strategy ConflictStrategy) (updated []string, err error) {
 	lock, err := s.ReadLockFile()
 	if err != nil {
 		return nil, err
@@ -364,7 +513,13 @@ func (s *Store) UpdateAllSkills() (updated []string, err error) {
 			continue
 		}
 
-		if updateErr := s.UpdateSkill(name); updateErr != nil {
+		if strategy == KeepLocal {
+			if modified, _ := s.LocallyModified(name); modified {
+				continue
+			}
+		}
+
+		if updateErr := s.UpdateSkillWith(name, strategy); updateErr != nil {
 			errs = append(errs, fmt.Sprintf("%s: update failed: %v", name, updateErr))
 			continue
 		}
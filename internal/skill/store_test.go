@@ -462,3 +462,26 @@ func TestUpdateAllSkills(t *testing.T) {
 	// Verify function signature exists and returns correct types
 	_ = fmt.Sprintf("UpdateAllSkills returns ([]string, error): updated=%v, err=%v", updated, err)
 }
+
+func TestListInstalledSkipsIgnoredEntries(t *testing.T) {
+	store := NewStore(t.TempDir())
+	for _, dir := range []string{"demo", ".git", "node_modules", ".demo.install-123"} {
+		os.MkdirAll(filepath.Join(store.BaseDir, dir), 0755)
+	}
+
+	skills, err := store.ListInstalled()
+	if err != nil {
+		t.Fatalf("ListInstalled error: %v", err)
+	}
+	if len(skills) != 1 || skills[0] != "demo" {
+		t.Errorf("ListInstalled = %v, want [demo]", skills)
+	}
+}
+
+func TestListInstalledMissingDir(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	names, err := store.ListInstalled()
+	if err != nil || len(names) != 0 {
+		t.Fatalf("ListInstalled on missing dir = %v, %v; want empty, nil", names, err)
+	}
+}
@@ -0,0 +1,84 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseTags returns the tags declared in SKILL.md frontmatter, written
+// inline ("tags: [go, testing]" or "tags: go, testing") or as a block list
+// of "- go" lines. Tags are lowercased.
+func ParseTags(content string) []string {
+	var tags []string
+	for _, t := range frontmatterList(content, "tags") {
+		tags = append(tags, strings.ToLower(t))
+	}
+	return tags
+}
+
+// ParseRequires returns the skills a SKILL.md declares it builds on with a
+// "requires" frontmatter list, in the same forms as tags.
+func ParseRequires(content string) []string {
+	return frontmatterList(content, "requires")
+}
+
+// frontmatterList reads the list value of key from SKILL.md frontmatter.
+func frontmatterList(content, key string) []string {
+	fields, err := ParseFrontmatter(content)
+	if err != nil || fields == nil {
+		return nil
+	}
+	if _, ok := fields[key]; !ok {
+		return nil
+	}
+	value := strings.Trim(fields[key], "[]")
+	if value == "" {
+		// Block list: the "- item" lines following "key:"
+		lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+		var items []string
+		inList := false
+		for _, line := range lines[1:] {
+			if line == "---" {
+				break
+			}
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, key+":"):
+				inList = true
+			case inList && strings.HasPrefix(trimmed, "- "):
+				items = append(items, strings.TrimPrefix(trimmed, "- "))
+			case inList && trimmed != "":
+				inList = false
+			}
+		}
+		value = strings.Join(items, ",")
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.Trim(strings.TrimSpace(item), `"'`)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Tags reads the tags of the skill folder dir.
+func Tags(dir string) []string {
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		return nil
+	}
+	return ParseTags(string(data))
+}
+
+// Requires reads the skills required by the skill folder dir.
+func Requires(dir string) []string {
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		return nil
+	}
+	return ParseRequires(string(data))
+}
@@ -0,0 +1,38 @@
+package skill
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"---\nname: demo\ntags: [Go, testing]\n---\n", "go,testing"},
+		{"---\nname: demo\ntags: go, \"cli\"\n---\n", "go,cli"},
+		{"---\nname: demo\ntags:\n  - go\n  - testing\ndescription: x\n---\n", "go,testing"},
+		{"---\nname: demo\n---\n", ""},
+		{"# No frontmatter", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(ParseTags(tt.content), ","); got != tt.want {
+			t.Errorf("ParseTags(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestParseRequires(t *testing.T) {
+	inline := "---\nname: deploy\nrequires: [git-basics, Docker]\n---\n"
+	if got := ParseRequires(inline); strings.Join(got, ",") != "git-basics,Docker" {
+		t.Errorf("inline requires = %v", got)
+	}
+	block := "---\nname: deploy\nrequires:\n  - git-basics\n  - docker\ntags: [ops]\n---\n"
+	if got := ParseRequires(block); strings.Join(got, ",") != "git-basics,docker" {
+		t.Errorf("block requires = %v", got)
+	}
+	if got := ParseRequires("---\nname: deploy\n---\n"); got != nil {
+		t.Errorf("requires without the field = %v, want nil", got)
+	}
+}
@@ -0,0 +1,99 @@
+package skill
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// templateVarPattern matches placeholders such as {{PROJECT_NAME}}. Only
+// upper-case names are treated as variables so ordinary handlebars-style
+// examples inside skills are left alone.
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Z][A-Z0-9_]*)\s*\}\}`)
+
+// isTemplateFile reports whether a file inside a skill is rendered.
+func isTemplateFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".txt", ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// TemplateVars returns the sorted, de-duplicated placeholder names used by
+// the text files under path (a skill directory or a single asset file).
+func TemplateVars(path string) ([]string, error) {
+	seen := make(map[string]bool)
+	err := walkTemplateFiles(path, func(file string, data []byte) error {
+		for _, m := range templateVarPattern.FindAllSubmatch(data, -1) {
+			seen[string(m[1])] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// RenderTemplate substitutes placeholders in the text files under path in
+// place. Placeholders without a value are kept so they can be filled later.
+func RenderTemplate(path string, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return walkTemplateFiles(path, func(file string, data []byte) error {
+		rendered := templateVarPattern.ReplaceAllFunc(data, func(match []byte) []byte {
+			name := string(templateVarPattern.FindSubmatch(match)[1])
+			if v, ok := values[name]; ok {
+				return []byte(v)
+			}
+			return match
+		})
+		if string(rendered) == string(data) {
+			return nil
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(file, rendered, info.Mode().Perm())
+	})
+}
+
+// walkTemplateFiles calls fn with the content of every renderable file
+// under root, following a top-level symlink but not nested ones.
+func walkTemplateFiles(root string, fn func(file string, data []byte) error) error {
+	resolved, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(resolved, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != resolved && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !isTemplateFile(path) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return fn(path, data)
+	})
+}
@@ -0,0 +1,35 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplateVarsAndRender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "style")
+	os.MkdirAll(filepath.Join(dir, "references"), 0755)
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# {{PROJECT_NAME}}\nSee {{ STYLE_GUIDE_URL }} and {{lowercase}}."), 0644)
+	os.WriteFile(filepath.Join(dir, "references", "notes.md"), []byte("Project: {{PROJECT_NAME}}"), 0644)
+	os.WriteFile(filepath.Join(dir, "logo.png"), []byte("{{BINARY}}"), 0644)
+
+	vars, err := TemplateVars(dir)
+	if err != nil {
+		t.Fatalf("TemplateVars error: %v", err)
+	}
+	if len(vars) != 2 || vars[0] != "PROJECT_NAME" || vars[1] != "STYLE_GUIDE_URL" {
+		t.Fatalf("TemplateVars = %v, want [PROJECT_NAME STYLE_GUIDE_URL]", vars)
+	}
+
+	if err := RenderTemplate(dir, map[string]string{"PROJECT_NAME": "efx"}); err != nil {
+		t.Fatalf("RenderTemplate error: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if want := "# efx\nSee {{ STYLE_GUIDE_URL }} and {{lowercase}}."; string(data) != want {
+		t.Fatalf("rendered SKILL.md = %q, want %q", data, want)
+	}
+	nested, _ := os.ReadFile(filepath.Join(dir, "references", "notes.md"))
+	if string(nested) != "Project: efx" {
+		t.Fatalf("rendered notes.md = %q", nested)
+	}
+}
@@ -0,0 +1,52 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EstimateTokens approximates how many BPE tokens (tiktoken cl100k-style) a
+// text uses without shipping a tokenizer: words cost one token per ~4
+// characters and each punctuation or symbol character costs one on its own.
+func EstimateTokens(text string) int {
+	tokens := 0
+	wordLen := 0
+	flush := func() {
+		if wordLen > 0 {
+			tokens += (wordLen + 3) / 4
+			wordLen = 0
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			wordLen++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
+
+// FileTokens estimates the tokens of a single file, returning 0 when it
+// cannot be read or is not valid UTF-8 text.
+func FileTokens(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil || !utf8.Valid(data) {
+		return 0
+	}
+	return EstimateTokens(string(data))
+}
+
+// SkillTokens estimates the context cost of the skill in dir, i.e. the
+// SKILL.md an agent loads when the skill activates.
+func SkillTokens(dir string) int {
+	return FileTokens(filepath.Join(dir, "SKILL.md"))
+}
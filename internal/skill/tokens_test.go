@@ -0,0 +1,35 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"hello world", 4},        // 5 chars -> 2, 5 chars -> 2
+		{"go test ./...", 7},      // go, test, then 5 punctuation characters
+		{"# Title\n\nUse it.", 6}, // #, Title(2), Use, it, .
+	}
+	for _, tt := range tests {
+		if got := EstimateTokens(tt.text); got != tt.want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSkillTokensReadsSkillFile(t *testing.T) {
+	dir := t.TempDir()
+	if got := SkillTokens(dir); got != 0 {
+		t.Fatalf("SkillTokens without SKILL.md = %d, want 0", got)
+	}
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("hello world"), 0644)
+	if got := SkillTokens(dir); got != 4 {
+		t.Fatalf("SkillTokens = %d, want 4", got)
+	}
+}
@@ -0,0 +1,99 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// DefaultSkillTopics are the GitHub topics skill repositories are usually
+// tagged with.
+var DefaultSkillTopics = []string{"claude-skills", "agent-skills"}
+
+// TopicRepo is a GitHub repository found by topic.
+type TopicRepo struct {
+	Owner       string
+	Name        string
+	Description string
+	Stars       int
+	Topics      []string
+}
+
+// FullName is "owner/repo".
+func (r TopicRepo) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+// SearchTopicRepos lists the repositories tagged with any of topics, most
+// starred first, with at most limit per topic. A repository tagged with
+// several of the topics is listed once.
+func SearchTopicRepos(topics []string, limit int) ([]TopicRepo, error) {
+	seen := make(map[string]bool)
+	var repos []TopicRepo
+	for _, topic := range topics {
+		found, err := searchTopic(topic, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range found {
+			key := strings.ToLower(r.FullName())
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			repos = append(repos, r)
+		}
+	}
+	sort.SliceStable(repos, func(i, j int) bool { return repos[i].Stars > repos[j].Stars })
+	return repos, nil
+}
+
+// searchTopic runs one GitHub repository search for topic.
+func searchTopic(topic string, limit int) ([]TopicRepo, error) {
+	u := fmt.Sprintf("%s/search/repositories?q=%s&sort=stars&order=desc&per_page=%d",
+		apiBase("", ""), url.QueryEscape("topic:"+topic), limit)
+	resp, err := GitHubGet(u)
+	if err != nil {
+		return nil, fmt.Errorf("searching topic %s: %w", topic, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errs.FromResponse(resp, "GitHub API returned status %d searching topic %s", resp.StatusCode, topic)
+	}
+
+	var result struct {
+		Items []struct {
+			Name        string   `json:"name"`
+			Description string   `json:"description"`
+			Stars       int      `json:"stargazers_count"`
+			Topics      []string `json:"topics"`
+			Archived    bool     `json:"archived"`
+			Owner       struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding search for topic %s: %w", topic, err)
+	}
+
+	repos := make([]TopicRepo, 0, len(result.Items))
+	for _, it := range result.Items {
+		if it.Archived {
+			continue
+		}
+		repos = append(repos, TopicRepo{
+			Owner:       it.Owner.Login,
+			Name:        it.Name,
+			Description: it.Description,
+			Stars:       it.Stars,
+			Topics:      it.Topics,
+		})
+	}
+	return repos, nil
+}
@@ -0,0 +1,46 @@
+package skill
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchTopicRepos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/repositories" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Query().Get("q") {
+		case "topic:claude-skills":
+			fmt.Fprint(w, `{"items":[
+				{"name":"tools","owner":{"login":"acme"},"stargazers_count":10},
+				{"name":"old","owner":{"login":"acme"},"stargazers_count":99,"archived":true}]}`)
+		case "topic:agent-skills":
+			fmt.Fprint(w, `{"items":[
+				{"name":"Tools","owner":{"login":"Acme"},"stargazers_count":10},
+				{"name":"kit","owner":{"login":"beta"},"stargazers_count":50,"description":"Agent kit"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	orig := gitHubAPIBaseURL
+	gitHubAPIBaseURL = server.URL
+	defer func() { gitHubAPIBaseURL = orig }()
+
+	repos, err := SearchTopicRepos(DefaultSkillTopics, 20)
+	if err != nil {
+		t.Fatalf("SearchTopicRepos error: %v", err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("repos = %+v, want acme/tools and beta/kit once each", repos)
+	}
+	if repos[0].FullName() != "beta/kit" || repos[0].Description != "Agent kit" {
+		t.Errorf("first repo = %+v, want the most starred", repos[0])
+	}
+	if repos[1].FullName() != "acme/tools" {
+		t.Errorf("second repo = %+v", repos[1])
+	}
+}
@@ -0,0 +1,79 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// RepoSkill is a skill folder found in a repository.
+type RepoSkill struct {
+	Name string // folder name, or the repository name for a root SKILL.md
+	Path string // folder inside the repository, "" for the root
+}
+
+// DiscoverSkills lists every folder holding a SKILL.md in a repository at
+// ref (HEAD when empty) with a single recursive git trees call, so skills
+// nested at any depth are found without guessing paths.
+func DiscoverSkills(owner, repo, ref string) ([]RepoSkill, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	u := fmt.Sprintf("%s/repos/%s/%s/git/trees/%s?recursive=1", apiBase(owner, repo), owner, repo, url.PathEscape(ref))
+	resp, err := GitHubGet(u)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s/%s: %w", owner, repo, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, errs.New(errs.NotFound, "repository %s/%s not found%s", owner, repo, privateRepoHint())
+		}
+		return nil, errs.FromResponse(resp, "GitHub API returned status %d for %s/%s", resp.StatusCode, owner, repo)
+	}
+
+	var tree struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("decoding tree of %s/%s: %w", owner, repo, err)
+	}
+
+	var skills []RepoSkill
+	for _, e := range tree.Tree {
+		if e.Type != "blob" || path.Base(e.Path) != "SKILL.md" {
+			continue
+		}
+		dir := path.Dir(e.Path)
+		if dir == "." {
+			skills = append(skills, RepoSkill{Name: repo, Path: ""})
+			continue
+		}
+		skills = append(skills, RepoSkill{Name: path.Base(dir), Path: dir})
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].Path < skills[j].Path })
+
+	if len(skills) == 0 && tree.Truncated {
+		return nil, fmt.Errorf("%s/%s is too large to list in one call", owner, repo)
+	}
+	return skills, nil
+}
+
+// Group returns the parent folder of a discovered skill, used to show
+// monorepos as a tree; "" for skills at the top level.
+func (r RepoSkill) Group() string {
+	if dir := path.Dir(r.Path); dir != "." {
+		return dir
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverSkillsFromTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/mono/git/trees/HEAD" || r.URL.Query().Get("recursive") != "1" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"tree": []map[string]string{
+				{"path": "README.md", "type": "blob"},
+				{"path": "SKILL.md", "type": "blob"},
+				{"path": "skills/web/react", "type": "tree"},
+				{"path": "skills/web/react/SKILL.md", "type": "blob"},
+				{"path": "skills/web/react/refs/api.md", "type": "blob"},
+				{"path": "skills/db/postgres/SKILL.md", "type": "blob"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	orig := gitHubAPIBaseURL
+	gitHubAPIBaseURL = server.URL
+	defer func() { gitHubAPIBaseURL = orig }()
+
+	found, err := DiscoverSkills("owner", "mono", "")
+	if err != nil {
+		t.Fatalf("DiscoverSkills error: %v", err)
+	}
+	want := []RepoSkill{
+		{Name: "mono", Path: ""},
+		{Name: "postgres", Path: "skills/db/postgres"},
+		{Name: "react", Path: "skills/web/react"},
+	}
+	if len(found) != len(want) {
+		t.Fatalf("DiscoverSkills = %+v, want %+v", found, want)
+	}
+	for i := range want {
+		if found[i] != want[i] {
+			t.Errorf("skill %d = %+v, want %+v", i, found[i], want[i])
+		}
+	}
+	if g := found[2].Group(); g != "skills/web" {
+		t.Errorf("Group() = %q, want skills/web", g)
+	}
+	if g := found[0].Group(); g != "" {
+		t.Errorf("root Group() = %q, want empty", g)
+	}
+}
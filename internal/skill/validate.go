@@ -0,0 +1,78 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxDescriptionLen is the longest description providers accept in SKILL.md
+// frontmatter.
+const maxDescriptionLen = 1024
+
+// ParseFrontmatter reads the top-level "key: value" pairs of a leading
+// "---" delimited YAML block. Nested values and lists are ignored; content
+// without frontmatter yields no fields.
+func ParseFrontmatter(content string) (map[string]string, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, nil
+	}
+	rest := content[len("---\n"):]
+	// The closing delimiter may directly follow the opening one
+	end := strings.Index("\n"+rest, "\n---")
+	if end < 0 {
+		return nil, fmt.Errorf("frontmatter is not closed with ---")
+	}
+
+	fields := make(map[string]string)
+	for _, line := range strings.Split(rest[:max(end-1, 0)], "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid frontmatter line: %q", line)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		fields[strings.TrimSpace(key)] = value
+	}
+	return fields, nil
+}
+
+// ValidateSkill checks the SKILL.md of the skill folder dir and returns the
+// problems found: a missing or malformed frontmatter, a missing name or
+// description, or a name that differs from the folder's.
+func ValidateSkill(dir string) []string {
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		return []string{"SKILL.md is missing"}
+	}
+	fields, err := ParseFrontmatter(string(data))
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if fields == nil {
+		return []string{"SKILL.md has no frontmatter (--- name/description ---)"}
+	}
+
+	var problems []string
+	name := fields["name"]
+	switch {
+	case name == "":
+		problems = append(problems, "frontmatter has no name")
+	case name != filepath.Base(dir):
+		problems = append(problems, fmt.Sprintf("name %q does not match the folder %q", name, filepath.Base(dir)))
+	}
+	switch desc := fields["description"]; {
+	case desc == "":
+		problems = append(problems, "frontmatter has no description")
+	case len(desc) > maxDescriptionLen:
+		problems = append(problems, fmt.Sprintf("description is %d characters, the limit is %d", len(desc), maxDescriptionLen))
+	}
+	return problems
+}
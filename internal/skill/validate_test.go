@@ -0,0 +1,45 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFrontmatter(t *testing.T) {
+	fields, err := ParseFrontmatter("---\nname: demo\ndescription: \"Does things\"\nmetadata:\n  tags: x\n---\n# Demo")
+	if err != nil || fields["name"] != "demo" || fields["description"] != "Does things" || fields["tags"] != "" {
+		t.Fatalf("ParseFrontmatter = %v, %v", fields, err)
+	}
+	if fields, err := ParseFrontmatter("# No frontmatter"); fields != nil || err != nil {
+		t.Errorf("without frontmatter = %v, %v; want nil, nil", fields, err)
+	}
+	if _, err := ParseFrontmatter("---\nname: demo\n# never closed"); err == nil {
+		t.Error("expected error for unclosed frontmatter")
+	}
+}
+
+func TestValidateSkill(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	os.MkdirAll(dir, 0755)
+	write := func(content string) {
+		os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644)
+	}
+
+	write("---\nname: demo\ndescription: Does things\n---\n")
+	if problems := ValidateSkill(dir); len(problems) != 0 {
+		t.Errorf("valid skill reported %v", problems)
+	}
+
+	write("---\nname: other\n---\n")
+	problems := strings.Join(ValidateSkill(dir), "; ")
+	if !strings.Contains(problems, "does not match") || !strings.Contains(problems, "no description") {
+		t.Errorf("problems = %q", problems)
+	}
+
+	write("# Demo")
+	if problems := ValidateSkill(dir); len(problems) != 1 {
+		t.Errorf("problems without frontmatter = %v", problems)
+	}
+}
@@ -0,0 +1,119 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// FetchVersions lists the tags of a GitHub repository, newest first as
+// returned by the API.
+func FetchVersions(owner, repo string) ([]string, error) {
+	u := fmt.Sprintf("%s/repos/%s/%s/tags?per_page=100", apiBase(owner, repo), owner, repo)
+	resp, err := GitHubGet(u)
+	if err != nil {
+		return nil, fmt.Errorf("fetching tags: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errs.FromResponse(resp, "GitHub API returned status %d", resp.StatusCode)
+	}
+
+	var tags []struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("decoding tags response: %w", err)
+	}
+
+	versions := make([]string, 0, len(tags))
+	for _, t := range tags {
+		versions = append(versions, t.Name)
+	}
+	return versions, nil
+}
+
+// FetchCommitHash resolves a tag or branch to its commit SHA. An empty ref
+// resolves the default branch, like FetchLatestCommitHash.
+func FetchCommitHash(owner, repo, ref string) (string, error) {
+	if ref == "" {
+		return FetchLatestCommitHash(owner, repo)
+	}
+
+	u := fmt.Sprintf("%s/repos/%s/%s/commits/%s", apiBase(owner, repo), owner, repo, url.PathEscape(ref))
+	resp, err := GitHubGet(u)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", ref, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity {
+		return "", errs.New(errs.NotFound, "version %s not found in %s/%s", ref, owner, repo)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errs.FromResponse(resp, "GitHub API returned status %d", resp.StatusCode)
+	}
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return "", fmt.Errorf("decoding commit response: %w", err)
+	}
+	return commit.SHA, nil
+}
+
+// SetBranch makes a locked skill follow branch, dropping any pinned
+// version. An empty branch goes back to the default branch. The stored copy
+// is left alone until the next UpdateSkill.
+func (s *Store) SetBranch(skillName, branch string) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	entry, ok := lock.Skills[skillName]
+	if !ok {
+		return fmt.Errorf("skill %q not found in lock file", skillName)
+	}
+	entry.Branch, entry.Ref = branch, ""
+	lock.Skills[skillName] = entry
+	return s.WriteLockFile(lock)
+}
+
+// SetHeld holds a locked skill at its installed commit, so updates skip it,
+// or with held false lets it follow its channel again.
+func (s *Store) SetHeld(skillName string, held bool) error {
+	s, unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	lock, err := s.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	entry, ok := lock.Skills[skillName]
+	if !ok {
+		return fmt.Errorf("skill %q not found in lock file", skillName)
+	}
+	entry.Held = held
+	lock.Skills[skillName] = entry
+	return s.WriteLockFile(lock)
+}
+
+// HeldError reports an update of a skill frozen with hold.
+func HeldError(skillName string) error {
+	return errs.WithHint(errs.Conflict, "resume updates with: efx-skills unhold "+skillName,
+		"%s is held at its installed version", skillName)
+}
@@ -0,0 +1,175 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+func TestFetchVersionsAndCommitHash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/tags":
+			json.NewEncoder(w).Encode([]map[string]string{{"name": "v1.2.0"}, {"name": "v1.1.0"}})
+		case "/repos/owner/repo/commits/v1.2.0":
+			json.NewEncoder(w).Encode(map[string]string{"sha": "tagsha"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	orig := gitHubAPIBaseURL
+	gitHubAPIBaseURL = server.URL
+	defer func() { gitHubAPIBaseURL = orig }()
+
+	versions, err := FetchVersions("owner", "repo")
+	if err != nil || len(versions) != 2 || versions[0] != "v1.2.0" {
+		t.Fatalf("FetchVersions = %v, %v", versions, err)
+	}
+
+	sha, err := FetchCommitHash("owner", "repo", "v1.2.0")
+	if err != nil || sha != "tagsha" {
+		t.Fatalf("FetchCommitHash = %q, %v; want tagsha", sha, err)
+	}
+	if _, err := FetchCommitHash("owner", "repo", "v9"); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+}
+
+func TestInstallVersionPinsLockEntry(t *testing.T) {
+	newRepoServer(t)
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+
+	if err := store.InstallVersion("owner/repo", "demo", "v1.2.0"); err != nil {
+		t.Fatalf("InstallVersion error: %v", err)
+	}
+	if err := store.AddToLock("demo", "owner/repo", "tagsha"); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, _ := store.ReadLockFile()
+	if got := lock.Skills["demo"].Ref; got != "v1.2.0" {
+		t.Fatalf("Ref = %q, want v1.2.0", got)
+	}
+
+	// Pinned skills are never reported as outdated
+	hasUpdate, _, _, err := store.CheckForUpdate("demo")
+	if err != nil || hasUpdate {
+		t.Errorf("CheckForUpdate = %v, %v; want false, nil", hasUpdate, err)
+	}
+
+	// Reinstalling without a version drops the pin
+	if err := store.Install("owner/repo", "demo"); err != nil {
+		t.Fatal(err)
+	}
+	lock, _ = store.ReadLockFile()
+	if got := lock.Skills["demo"].Ref; got != "" {
+		t.Errorf("Ref after unpinned install = %q, want empty", got)
+	}
+}
+
+func TestInstallBranchTracksChannel(t *testing.T) {
+	newRepoServer(t)
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+
+	if err := store.InstallBranch("owner/repo", "demo", "next"); err != nil {
+		t.Fatalf("InstallBranch error: %v", err)
+	}
+	if err := store.AddToLock("demo", "owner/repo", "oldsha"); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, _ := store.ReadLockFile()
+	entry := lock.Skills["demo"]
+	if entry.Branch != "next" || entry.Ref != "" || entry.Channel() != "next" {
+		t.Fatalf("lock entry = %+v, want branch next", entry)
+	}
+
+	// Updates compare against the tracked branch head
+	hasUpdate, _, latest, err := store.CheckForUpdate("demo")
+	if err != nil || !hasUpdate || latest != "nextsha" {
+		t.Errorf("CheckForUpdate = %v, %q, %v; want true, nextsha", hasUpdate, latest, err)
+	}
+	if err := store.UpdateSkill("demo"); err != nil {
+		t.Fatalf("UpdateSkill error: %v", err)
+	}
+	lock, _ = store.ReadLockFile()
+	if e := lock.Skills["demo"]; e.Branch != "next" || e.CommitHash != "nextsha" {
+		t.Errorf("lock entry after update = %+v", e)
+	}
+}
+
+func TestLockEntryChannel(t *testing.T) {
+	cases := map[string]LockEntry{
+		"default": {SourceType: "github"},
+		"@v1.0":   {Ref: "v1.0"},
+		"beta":    {Branch: "beta"},
+		"local":   {SourceType: SourceTypeLocal},
+	}
+	for want, e := range cases {
+		if got := e.Channel(); got != want {
+			t.Errorf("Channel(%+v) = %q, want %q", e, got, want)
+		}
+	}
+}
+
+func TestSetBranchDropsPin(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	lock := &LockFile{Version: LockVersion, Skills: map[string]LockEntry{"demo": {Source: "owner/repo", Ref: "v1.0"}}}
+	if err := store.WriteLockFile(lock); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.SetBranch("demo", "beta"); err != nil {
+		t.Fatalf("SetBranch error: %v", err)
+	}
+	lock, _ = store.ReadLockFile()
+	if e := lock.Skills["demo"]; e.Branch != "beta" || e.Ref != "" {
+		t.Errorf("lock entry = %+v, want branch beta and no pin", e)
+	}
+	if err := store.SetBranch("missing", "beta"); err == nil {
+		t.Error("expected an error for a skill missing from the lock")
+	}
+}
+
+func TestHeldSkillsSkipUpdates(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "skills"))
+	lock := &LockFile{Version: LockVersion, Skills: map[string]LockEntry{"demo": {Source: "owner/repo", SourceType: "github", CommitHash: "abc"}}}
+	if err := store.WriteLockFile(lock); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.SetHeld("demo", true); err != nil {
+		t.Fatalf("SetHeld error: %v", err)
+	}
+	// Held skills never reach the network
+	if hasUpdate, _, _, err := store.CheckForUpdate("demo"); hasUpdate || err != nil {
+		t.Errorf("CheckForUpdate on a held skill = %v, %v; want no update", hasUpdate, err)
+	}
+	if err := store.UpdateSkill("demo"); errs.KindOf(err) != errs.Conflict {
+		t.Errorf("UpdateSkill on a held skill error = %v, want a conflict", err)
+	}
+	if err := store.AddToLock("demo", "owner/repo", "def"); err != nil {
+		t.Fatal(err)
+	}
+	lock, _ = store.ReadLockFile()
+	if !lock.Skills["demo"].Held {
+		t.Error("AddToLock dropped the hold")
+	}
+
+	if err := store.SetHeld("demo", false); err != nil {
+		t.Fatalf("SetHeld(false) error: %v", err)
+	}
+	lock, _ = store.ReadLockFile()
+	if lock.Skills["demo"].Held {
+		t.Error("skill still held after SetHeld(false)")
+	}
+	if err := store.SetHeld("missing", true); err == nil {
+		t.Error("expected an error for a skill missing from the lock")
+	}
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// adoptCandidate is a real skill directory found inside a provider.
+type adoptCandidate struct {
+	Provider Provider
+	Name     string
+}
+
+// findAdoptable lists real (non-symlinked) skill directories containing a
+// SKILL.md in the given providers' skills directories.
+func findAdoptable(providers []Provider) []adoptCandidate {
+	var found []adoptCandidate
+	for _, p := range providers {
+		if p.Hook != "" {
+			continue
+		}
+		entries, err := os.ReadDir(p.Path)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !e.IsDir() || e.Type()&os.ModeSymlink != 0 || provider.Ignored(e.Name()) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(p.Path, e.Name(), "SKILL.md")); err == nil {
+				found = append(found, adoptCandidate{Provider: p, Name: e.Name()})
+			}
+		}
+	}
+	return found
+}
+
+// adoptSkill moves one candidate into the store, links it back and records
+// it in the lock file, detecting a GitHub source from the skill's git
+// remote when possible. It returns the detected source ("" when local).
+func adoptSkill(store *skill.Store, c adoptCandidate) (string, error) {
+	dir := filepath.Join(c.Provider.Path, c.Name)
+	source, commit := skill.DetectGitSource(dir)
+
+	if err := store.Adopt(c.Provider.Path, c.Name); err != nil {
+		return "", err
+	}
+
+	if source == "" {
+		return "", store.AddLocalToLock(c.Name)
+	}
+	if err := store.AddToLock(c.Name, source, commit); err != nil {
+		return source, err
+	}
+	return source, addSkillToConfig(SkillMeta{
+		Owner:     source,
+		Name:      c.Name,
+		Registry:  "github",
+		URL:       skill.WebURL(source),
+		Version:   commit,
+		Installed: time.Now().UTC().Format(time.RFC3339),
+	})
+}
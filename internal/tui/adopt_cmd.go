@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunAdopt moves real skill directories found in provider skills folders
+// into the central store and replaces them with symlinks. Without provider
+// names every configured provider is scanned.
+func RunAdopt(providerNames []string, dryRun bool) error {
+	wanted := make(map[string]bool)
+	for _, name := range providerNames {
+		wanted[name] = true
+	}
+
+	var providers []Provider
+	for _, p := range detectProviders() {
+		if (len(wanted) > 0 && wanted[p.Name]) || (len(wanted) == 0 && p.Configured) {
+			providers = append(providers, p)
+			delete(wanted, p.Name)
+		}
+	}
+	for name := range wanted {
+		return fmt.Errorf("unknown provider: %s", name)
+	}
+
+	candidates := findAdoptable(providers)
+	if len(candidates) == 0 {
+		fmt.Println("No unmanaged skill directories found.")
+		return nil
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	adopted, failed := 0, 0
+	for _, c := range candidates {
+		label := fmt.Sprintf("%s/%s", c.Provider.Name, c.Name)
+		if store.IsInstalled(c.Name) {
+			fmt.Printf("  · %s: skipped, %s already exists in the store\n", label, c.Name)
+			continue
+		}
+		if dryRun {
+			fmt.Printf("  • %s would be adopted\n", label)
+			continue
+		}
+
+		source, err := adoptSkill(store, c)
+		if err != nil {
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", label, err)
+			continue
+		}
+		adopted++
+		if source == "" {
+			source = "local"
+		}
+		fmt.Printf("  ✓ %s adopted (source: %s)\n", label, source)
+	}
+
+	if !dryRun {
+		fmt.Printf("\n%d adopted, %d failed\n", adopted, failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("adopt finished with %d error(s)", failed)
+	}
+	return nil
+}
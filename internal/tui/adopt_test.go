@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestFindAndAdoptProviderSkills(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	claudeDir := filepath.Join(home, ".claude", "skills")
+	os.MkdirAll(filepath.Join(claudeDir, "mine"), 0755)
+	os.WriteFile(filepath.Join(claudeDir, "mine", "SKILL.md"), []byte("# Mine"), 0644)
+	os.MkdirAll(filepath.Join(claudeDir, "not-a-skill"), 0755)
+
+	claude := Provider{Name: "claude", Path: claudeDir, Configured: true}
+	candidates := findAdoptable([]Provider{claude})
+	if len(candidates) != 1 || candidates[0].Name != "mine" {
+		t.Fatalf("findAdoptable = %+v, want only mine", candidates)
+	}
+
+	source, err := adoptSkill(store, candidates[0])
+	if err != nil {
+		t.Fatalf("adoptSkill error: %v", err)
+	}
+	if source != "" {
+		t.Fatalf("source = %q, want local", source)
+	}
+	lock, _ := store.ReadLockFile()
+	if lock.Skills["mine"].SourceType != skill.SourceTypeLocal {
+		t.Fatalf("lock entry = %+v, want local source type", lock.Skills["mine"])
+	}
+	if again := findAdoptable([]Provider{claude}); len(again) != 0 {
+		t.Fatalf("findAdoptable after adopt = %+v, want none", again)
+	}
+}
@@ -3,9 +3,12 @@ package tui
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
 )
 
 // View states
@@ -17,29 +20,56 @@ const (
 	viewPreview
 	viewManage
 	viewConfig
+	viewRecent
+	viewCollections
+	viewCategories
+	viewInstalled
+
+	viewCount // number of views
 )
 
 // Main application model
 type model struct {
-	state     viewState
-	prevState viewState // track where to return from preview
-	width     int
-	height    int
-	err       error
+	state  viewState
+	stack  []viewState // views to return to, innermost last
+	width  int
+	height int
+	err    error
 
 	// Sub-models
-	statusModel  statusModel
-	searchModel  searchModel
-	previewModel previewModel
-	manageModel  manageModel
-	configModel  configModel
+	statusModel      statusModel
+	searchModel      searchModel
+	previewModel     previewModel
+	manageModel      manageModel
+	configModel      configModel
+	recentModel      recentModel
+	collectionsModel collectionsModel
+	categoriesModel  categoriesModel
+	installedModel   installedModel
+
+	// Output of external commands, shown on demand
+	logPane logPane
+
+	// Long-running actions and the spinner animating them
+	tasks taskManager
+	// Incremented whenever a view's model is replaced, so results of tasks
+	// started from the previous model are dropped
+	gen [viewCount]int
+
+	// Search saved by the last session, resumed when search opens
+	resumeSearch *listSession
 }
 
+// cmdLog collects subprocess output for the running program.
+var cmdLog = &commandLog{}
+
 // Initialize the main model
 func initialModel() model {
 	return model{
 		state:       viewStatus,
 		statusModel: newStatusModel(),
+		logPane:     newLogPane(cmdLog),
+		tasks:       newTaskManager(),
 	}
 }
 
@@ -47,35 +77,127 @@ func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		tea.EnterAltScreen,
 		m.statusModel.Init(),
+		m.restoreCmd(),
 	)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if tick, ok := msg.(spinner.TickMsg); ok {
+		// Ticks only redraw the spinners; they stop once nothing is running
+		if !m.busy() {
+			m.tasks.ticking = false
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.tasks.spinner, cmd = m.tasks.spinner.Update(tick)
+		return m, cmd
+	}
+	m, cmd := m.update(msg)
+	if tick := m.tasks.tick(m.busy()); tick != nil {
+		cmd = tea.Batch(cmd, tick)
+	}
+	return m, cmd
+}
+
+// busy reports whether a background task is running or the current view is
+// waiting on something, which keeps the spinner ticking.
+func (m model) busy() bool {
+	if len(m.tasks.running) > 0 {
+		return true
+	}
+	switch m.state {
+	case viewStatus:
+		return m.statusModel.loading
+	case viewSearch:
+		return m.searchModel.loading || m.searchModel.installing
+	case viewPreview:
+		return m.previewModel.loading
+	case viewManage:
+		return m.manageModel.loading || m.manageModel.updating
+	case viewConfig:
+		return m.configModel.validatingRepo != nil || m.configModel.suggestLoading
+	case viewRecent:
+		return m.recentModel.loading
+	case viewCollections:
+		return m.collectionsModel.loading || m.collectionsModel.installing
+	case viewCategories:
+		return m.categoriesModel.loading
+	case viewInstalled:
+		return m.installedModel.loading || m.installedModel.updating
+	}
+	return false
+}
+
+func (m model) update(msg tea.Msg) (model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case startTaskMsg:
+		return m, m.tasks.start(msg.label, m.state, m.gen[m.state], msg.cmd)
+
+	case taskDoneMsg:
+		// Deliver the result to the view that started the task, unless it
+		// has been replaced since
+		task, ok := m.tasks.finish(msg.id)
+		if !ok || msg.result == nil || task.gen != m.gen[task.origin] {
+			return m, nil
+		}
+		return m.updateView(task.origin, msg.result)
+
 	case tea.KeyMsg:
+		// The log pane takes scroll keys while it is open
+		if m.logPane.visible {
+			var cmd tea.Cmd
+			m.logPane, cmd = m.logPane.Update(msg)
+			return m, cmd
+		}
+		// Let the search view own every key while it is prompting for input
+		if m.state == viewSearch && m.searchModel.promptingVars() {
+			break
+		}
+		// ...and the config view while a repo is being typed or picked
+		if m.state == viewConfig && (m.configModel.addingRepo || m.configModel.suggesting || m.configModel.confirmingExit) {
+			break
+		}
+		// ...and the status view while a panel or picker is open
+		if m.state == viewStatus && m.statusModel.capturesKeys() {
+			break
+		}
+		// ...and the installed view while confirming or picking a provider
+		if m.state == viewInstalled && m.installedModel.capturesKeys() {
+			break
+		}
+		// ...and the manage view while a prompt or the apply report is open
+		if m.state == viewManage && m.manageModel.capturesKeys() {
+			break
+		}
+		// Leaving the config view with unsaved changes asks first
+		if m.state == viewConfig && m.configModel.guardsExit(msg.String()) {
+			break
+		}
 		// Global key bindings
 		switch msg.String() {
-		case "ctrl+c", "q":
+		case "ctrl+o":
+			m.logPane.visible = true
+			m.logPane.refresh()
+			return m, nil
+		case "ctrl+c":
+			return m, tea.Quit
+		case "q":
 			if m.state == viewStatus {
 				return m, tea.Quit
 			}
 			// Return to status view
+			m.stack = nil
 			m.state = viewStatus
 			return m, m.statusModel.Init()
 		case "s":
 			if m.state == viewStatus {
-				m.state = viewSearch
-				m.searchModel = newSearchModel()
-				return m, m.searchModel.Init()
+				m.push(viewSearch)
+				m.searchModel = m.newSearch()
+				return m, tea.Batch(m.searchModel.Init(), m.restoreCmd())
 			}
 		case "esc":
-			if m.state == viewPreview {
-				// Return to previous view (search or manage)
-				m.state = m.prevState
-				return m, nil
-			} else if m.state != viewStatus {
-				m.state = viewStatus
-				return m, m.statusModel.Init()
+			if m.state != viewStatus {
+				return m, m.pop()
 			}
 		}
 
@@ -88,57 +210,166 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.manageModel.width = int(float64(msg.Width) * 0.9)
 		m.manageModel.height = msg.Height
 		m.configModel.width = int(float64(msg.Width) * 0.9)
+		m.recentModel.width = int(float64(msg.Width) * 0.9)
+		m.recentModel.height = msg.Height
+		m.collectionsModel.width = int(float64(msg.Width) * 0.9)
+		m.collectionsModel.height = msg.Height
+		m.categoriesModel.width = int(float64(msg.Width) * 0.9)
+		m.categoriesModel.height = msg.Height
+		m.installedModel.width = int(float64(msg.Width) * 0.9)
+		m.installedModel.height = msg.Height
+		m.logPane.setSize(int(float64(msg.Width)*0.9), msg.Height)
+		m.logPane.refresh()
+
+	case logUpdatedMsg:
+		m.logPane.refresh()
+		return m, nil
 
 	case openManageMsg:
-		m.state = viewManage
+		m.push(viewManage)
 		m.manageModel = newManageModel(msg.provider)
 		m.manageModel.width = int(float64(m.width) * 0.9)
 		m.manageModel.height = m.height
 		return m, m.manageModel.Init()
 
 	case openConfigMsg:
-		m.state = viewConfig
+		m.push(viewConfig)
 		m.configModel = newConfigModel()
 		m.configModel.width = int(float64(m.width) * 0.9)
 		return m, m.configModel.Init()
 
+	case leaveConfigMsg:
+		return m, m.pop()
+
+	case openRecentMsg:
+		m.push(viewRecent)
+		m.recentModel = newRecentModel()
+		m.recentModel.width = int(float64(m.width) * 0.9)
+		m.recentModel.height = m.height
+		return m, m.recentModel.Init()
+
+	case openCollectionsMsg:
+		m.push(viewCollections)
+		m.collectionsModel = newCollectionsModel()
+		m.collectionsModel.width = int(float64(m.width) * 0.9)
+		m.collectionsModel.height = m.height
+		return m, m.collectionsModel.Init()
+
+	case openCategoriesMsg:
+		m.push(viewCategories)
+		m.categoriesModel = newCategoriesModel()
+		m.categoriesModel.width = int(float64(m.width) * 0.9)
+		m.categoriesModel.height = m.height
+		return m, m.categoriesModel.Init()
+
+	case openInstalledMsg:
+		m.push(viewInstalled)
+		m.installedModel = newInstalledModel()
+		m.installedModel.parityOnly = msg.parity
+		m.installedModel.width = int(float64(m.width) * 0.9)
+		m.installedModel.height = m.height
+		return m, m.installedModel.Init()
+
+	case openTopicMsg:
+		// List the topic's skills as search results, to preview and install them
+		m.push(viewSearch)
+		m.searchModel = newSearchModel()
+		m.searchModel.width = int(float64(m.width) * 0.9)
+		query := "#" + msg.topic
+		m.searchModel.input.SetValue(query)
+		skills := msg.skills
+		return m, func() tea.Msg { return searchResultsMsg{query: query, results: skills} }
+
 	case openPreviewMsg:
-		m.prevState = m.state
-		m.state = viewPreview
-		m.previewModel = newPreviewModel(msg.skill.Source+"/"+msg.skill.Name, m.width, m.height)
+		m.push(viewPreview)
+		ref := skillRef(msg.skill)
+		if content, ok := sessionPreviewCache.get(ref); ok {
+			m.previewModel = newPreviewModelWithContent(ref, content, m.width, m.height)
+		} else {
+			m.previewModel = newPreviewModel(ref, m.width, m.height)
+		}
+		m.previewModel.badge = skillBadge(msg.skill)
+		m.previewModel.skill = msg.skill
 		return m, m.previewModel.Init()
 
 	case openLocalPreviewMsg:
-		m.prevState = m.state
-		m.state = viewPreview
+		m.push(viewPreview)
 		m.previewModel = newLocalPreviewModel(msg.skillName, m.width, m.height)
 		return m, m.previewModel.Init()
 
 	case openLocalPreviewWithContentMsg:
-		m.prevState = m.state
-		m.state = viewPreview
+		m.push(viewPreview)
 		m.previewModel = newPreviewModelWithContent(msg.skillName, msg.content, m.width, m.height)
 		return m, m.previewModel.Init()
 	}
 
 	// Delegate to sub-models based on current state
+	return m.updateView(m.state, msg)
+}
+
+// updateView passes msg to the model of view state.
+func (m model) updateView(state viewState, msg tea.Msg) (model, tea.Cmd) {
 	var cmd tea.Cmd
-	switch m.state {
+	switch state {
 	case viewStatus:
 		m.statusModel, cmd = m.statusModel.Update(msg)
 	case viewSearch:
 		m.searchModel, cmd = m.searchModel.Update(msg)
+		if prefetch := m.searchModel.schedulePrefetch(); prefetch != nil {
+			cmd = tea.Batch(cmd, prefetch)
+		}
 	case viewPreview:
 		m.previewModel, cmd = m.previewModel.Update(msg)
 	case viewManage:
 		m.manageModel, cmd = m.manageModel.Update(msg)
 	case viewConfig:
 		m.configModel, cmd = m.configModel.Update(msg)
+	case viewRecent:
+		m.recentModel, cmd = m.recentModel.Update(msg)
+	case viewCollections:
+		m.collectionsModel, cmd = m.collectionsModel.Update(msg)
+	case viewCategories:
+		m.categoriesModel, cmd = m.categoriesModel.Update(msg)
+	case viewInstalled:
+		m.installedModel, cmd = m.installedModel.Update(msg)
 	}
 
 	return m, cmd
 }
 
+// push opens next on top of the current view, which keeps its state until
+// it is returned to. Opening a view already on the stack returns to it
+// instead, as each view has a single model.
+func (m *model) push(next viewState) {
+	m.gen[next]++
+	for i, v := range m.stack {
+		if v == next {
+			m.stack = m.stack[:i]
+			m.state = next
+			return
+		}
+	}
+	if m.state != next {
+		m.stack = append(m.stack, m.state)
+	}
+	m.state = next
+}
+
+// pop returns to the view the current one was opened from, or the status
+// view. The status view reloads since the providers may have changed; other
+// views come back as they were left.
+func (m *model) pop() tea.Cmd {
+	m.state = viewStatus
+	if n := len(m.stack); n > 0 {
+		m.state = m.stack[n-1]
+		m.stack = m.stack[:n-1]
+	}
+	if m.state == viewStatus {
+		return m.statusModel.Init()
+	}
+	return nil
+}
+
 func (m model) View() string {
 	var content string
 
@@ -153,18 +384,62 @@ func (m model) View() string {
 		content = m.manageModel.View()
 	case viewConfig:
 		content = m.configModel.View()
+	case viewRecent:
+		content = m.recentModel.View()
+	case viewCollections:
+		content = m.collectionsModel.View()
+	case viewCategories:
+		content = m.categoriesModel.View()
+	case viewInstalled:
+		content = m.installedModel.View()
 	}
 
-	return appStyle.Render(content)
+	return asciiSafe(appStyle.Render(content + m.tasks.View() + m.logPane.View()))
 }
 
-// Run starts the main TUI
-func Run() error {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen(), tea.WithMouseCellMotion())
-	_, err := p.Run()
+// runProgram runs the TUI with external command output routed into the
+// log pane instead of the terminal. When session restore is enabled the
+// final view is saved for the next launch.
+func runProgram(m model) error {
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	if terminalOut != nil {
+		// Draw on the terminal, not the filter or /dev/null put on stdout
+		opts = append(opts, tea.WithOutput(terminalOut))
+	}
+	p := tea.NewProgram(m, opts...)
+	detach := cmdLog.attach(p)
+	defer detach()
+	final, err := p.Run()
+	if err == nil && sessionEnabled() {
+		if fm, ok := final.(model); ok {
+			_ = saveSession(sessionFromModel(fm))
+		}
+	}
 	return err
 }
 
+// Run starts the main TUI, reopening the last session when enabled. The
+// first run opens on the starter packs.
+func Run() error {
+	m := initialModel()
+	if isFirstRun() {
+		m.push(viewCollections)
+		m.collectionsModel = newWelcomeModel()
+		// Writing the defaults marks setup as done, so the packs are
+		// offered once
+		if err := saveConfigData(loadOrDefaultConfig()); err != nil {
+			return err
+		}
+		return runProgram(m)
+	}
+	if sessionEnabled() {
+		if st, ok := loadSession(); ok {
+			m = restoreSession(m, st)
+		}
+	}
+	return runProgram(m)
+}
+
 // RunStatus starts directly in status view
 func RunStatus() error {
 	return Run()
@@ -177,9 +452,7 @@ func RunSearch(query string) error {
 	m.searchModel = newSearchModel()
 	m.searchModel.input.SetValue(query)
 
-	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	_, err := p.Run()
-	return err
+	return runProgram(m)
 }
 
 // RunPreview shows skill preview
@@ -188,40 +461,79 @@ func RunPreview(skill string) error {
 	m.state = viewPreview
 	m.previewModel = newPreviewModel(skill, 80, 24) // Default size, will be updated by WindowSizeMsg
 
-	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	_, err := p.Run()
-	return err
+	return runProgram(m)
 }
 
-// RunInstall installs a skill
-func RunInstall(skill string, providers []string) error {
-	fmt.Printf("Installing %s to providers: %v\n", skill, providers)
-	// TODO: Implement installation logic
-	return nil
+// RunInstalled starts in the installed skills view
+func RunInstalled() error {
+	m := initialModel()
+	m.state = viewInstalled
+	m.installedModel = newInstalledModel()
+
+	return runProgram(m)
 }
 
-// RunList lists installed skills
-func RunList() error {
+// RunList lists installed skills with their cached registry stats,
+// refreshing stale ones, or all of them with refreshStats.
+func RunList(refreshStats bool) error {
 	providers := detectProviders()
 
 	fmt.Println("Installed Skills")
 	fmt.Println("================")
 
-	skillsDir := filepath.Join(os.Getenv("HOME"), ".agents", "skills")
+	skillsDir := getSkillsPath()
 	entries, err := os.ReadDir(skillsDir)
-	if err != nil {
+	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to read skills directory: %w", err)
 	}
 
-	fmt.Printf("\nCentral storage: %s\n", skillsDir)
-	fmt.Printf("Total skills: %d\n\n", len(entries))
-
+	var skills []os.DirEntry
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if skill.IsSkillEntry(skillsDir, entry) {
+			skills = append(skills, entry)
+		}
+	}
+
+	fmt.Printf("\nCentral storage: %s\n", skillsDir)
+	fmt.Printf("Total skills: %d\n\n", len(skills))
+
+	store := skill.NewStore(getSkillsPath())
+	lock, _ := store.ReadLockFile()
+	stats := cachedSkillStats(refreshStats)
+	for _, entry := range skills {
+		channel := ""
+		if e, ok := lockEntry(lock, entry.Name()); ok {
+			channel = "[" + e.Channel() + "]"
+			if e.Held {
+				channel = "[" + e.Channel() + ", held]"
+			}
+		}
+		summary := stats.Skills[entry.Name()].summary()
+		switch {
+		case summary != "":
+			fmt.Printf("  • %s %s %s\n", padRight(entry.Name(), 30), statusMutedStyle.Render(padRight(channel, 10)), statusMutedStyle.Render(summary))
+		case channel != "":
+			fmt.Printf("  • %s %s\n", padRight(entry.Name(), 30), statusMutedStyle.Render(channel))
+		default:
 			fmt.Printf("  • %s\n", entry.Name())
 		}
 	}
 
+	// Other asset types stored alongside skills
+	for _, t := range provider.AssetTypes() {
+		if t == provider.AssetSkills {
+			continue
+		}
+		names, _ := store.ListAssets(t)
+		if len(names) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s (%s):\n", strings.ToUpper(string(t)[:1])+string(t)[1:], store.AssetDir(t))
+		for _, name := range names {
+			fmt.Printf("  • %s\n", name)
+		}
+	}
+
 	fmt.Println("\nProvider Status:")
 	for _, p := range providers {
 		fmt.Printf("  %s %s: %d skills\n",
@@ -233,11 +545,13 @@ func RunList() error {
 	return nil
 }
 
-// RunSync syncs skills across providers
-func RunSync() error {
-	fmt.Println("Syncing skills across all providers...")
-	// TODO: Implement sync logic
-	return nil
+// lockEntry looks up name in a possibly nil lock file.
+func lockEntry(lock *skill.LockFile, name string) (skill.LockEntry, bool) {
+	if lock == nil {
+		return skill.LockEntry{}, false
+	}
+	e, ok := lock.Skills[name]
+	return e, ok
 }
 
 // RunConfig shows config view
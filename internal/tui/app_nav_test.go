@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEscReturnsToTheOpeningView(t *testing.T) {
+	setTestHome(t)
+	app := initialModel()
+	step := func(msg tea.Msg) {
+		next, _ := app.Update(msg)
+		app = next.(model)
+	}
+
+	step(openInstalledMsg{})
+	app.installedModel.selectedIdx = 4
+	step(openLocalPreviewWithContentMsg{skillName: "demo", content: "# demo"})
+	if app.state != viewPreview {
+		t.Fatalf("state = %v, want preview", app.state)
+	}
+	step(key("esc"))
+	if app.state != viewInstalled {
+		t.Fatalf("esc from a preview opened in the installed view went to %v", app.state)
+	}
+	if app.installedModel.selectedIdx != 4 {
+		t.Errorf("installed view lost its cursor: %d", app.installedModel.selectedIdx)
+	}
+	step(key("esc"))
+	if app.state != viewStatus || len(app.stack) != 0 {
+		t.Errorf("state = %v, stack = %v; want status with an empty stack", app.state, app.stack)
+	}
+}
+
+func TestLeavingConfigReturnsToManage(t *testing.T) {
+	setTestHome(t)
+	app := initialModel()
+	step := func(msg tea.Msg) {
+		next, _ := app.Update(msg)
+		app = next.(model)
+	}
+
+	step(openManageMsg{provider: Provider{Name: "claude"}})
+	app.manageModel.tagFilter = "go"
+	step(openConfigMsg{provider: Provider{Name: "claude"}})
+	step(leaveConfigMsg{})
+	if app.state != viewManage || app.manageModel.tagFilter != "go" {
+		t.Errorf("state = %v, tag filter %q; want manage as it was left", app.state, app.manageModel.tagFilter)
+	}
+}
+
+func TestReopeningAViewOnTheStackReturnsToIt(t *testing.T) {
+	app := initialModel()
+	app.push(viewCategories)
+	app.push(viewSearch)
+	app.push(viewPreview)
+	app.push(viewSearch)
+	if app.state != viewSearch || len(app.stack) != 2 {
+		t.Errorf("state = %v, stack = %v; want search above status and topics", app.state, app.stack)
+	}
+}
+
+func TestStatusPanelsKeepTheirKeys(t *testing.T) {
+	setTestHome(t)
+	app := initialModel()
+	step := func(msg tea.Msg) tea.Cmd {
+		next, cmd := app.Update(msg)
+		app = next.(model)
+		return cmd
+	}
+
+	app.statusModel.loading = false
+	app.statusModel.explaining = "claude"
+	if cmd := step(key("q")); cmd != nil {
+		t.Error("q in the explanation panel should close it, not quit")
+	}
+	if app.statusModel.explaining != "" {
+		t.Error("q did not close the explanation panel")
+	}
+
+	app.statusModel.workspaces = []string{"default"}
+	app.statusModel.pickingSpace = true
+	step(key("s"))
+	if app.state != viewStatus {
+		t.Errorf("s in the workspace picker opened %v", app.state)
+	}
+
+	app.statusModel.pickingSpace = false
+	app.statusModel.pickingClone = true
+	step(key("s"))
+	if app.state != viewStatus {
+		t.Errorf("s in the clone picker opened %v", app.state)
+	}
+}
@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// applyResult is the outcome of one change made to a provider.
+type applyResult struct {
+	Provider string
+	Action   string // "link", "unlink" or "merge"
+	Asset    string // e.g. "skill demo"
+	Skipped  string // why nothing had to be done, if so
+	Err      error
+
+	retry applyOp // makes the change again, set when it failed
+}
+
+// applyOp performs one change and reports how it went.
+type applyOp func() applyResult
+
+// applyReport collects the results of applying changes, grouped by provider
+// in name order.
+type applyReport []applyResult
+
+// applyConcurrently runs the operations of each provider in order, with
+// different providers handled in parallel. Providers never share target
+// folders, so their changes do not interfere.
+func applyConcurrently(ops map[string][]applyOp) applyReport {
+	results := make(map[string][]applyResult, len(ops))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, list := range ops {
+		wg.Add(1)
+		go func(name string, list []applyOp) {
+			defer wg.Done()
+			var out []applyResult
+			for _, op := range list {
+				res := op()
+				if res.Err != nil {
+					res.retry = op
+				}
+				out = append(out, res)
+			}
+			mu.Lock()
+			results[name] = out
+			mu.Unlock()
+		}(name, list)
+	}
+	wg.Wait()
+
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var report applyReport
+	for _, name := range names {
+		report = append(report, results[name]...)
+	}
+	return report
+}
+
+// counts tallies successes, skips and failures.
+func (r applyReport) counts() (done, skipped, failed int) {
+	for _, res := range r {
+		switch {
+		case res.Err != nil:
+			failed++
+		case res.Skipped != "":
+			skipped++
+		default:
+			done++
+		}
+	}
+	return done, skipped, failed
+}
+
+// retryFailed makes the failed changes again. It returns the report with
+// their new results in place of the old ones, and the new results alone.
+func (r applyReport) retryFailed() (report, retried applyReport) {
+	ops := make(map[string][]applyOp)
+	for _, res := range r {
+		if res.Err != nil && res.retry != nil {
+			ops[res.Provider] = append(ops[res.Provider], res.retry)
+		}
+	}
+	retried = applyConcurrently(ops)
+	byProvider := make(map[string][]applyResult)
+	for _, res := range retried {
+		byProvider[res.Provider] = append(byProvider[res.Provider], res)
+	}
+
+	report = make(applyReport, len(r))
+	for i, res := range r {
+		if res.Err != nil && res.retry != nil {
+			res = byProvider[res.Provider][0]
+			byProvider[res.Provider] = byProvider[res.Provider][1:]
+		}
+		report[i] = res
+	}
+	return report, retried
+}
+
+// err summarises failures, or returns nil when every change went through.
+func (r applyReport) err() error {
+	if _, _, failed := r.counts(); failed > 0 {
+		return fmt.Errorf("%d change(s) failed", failed)
+	}
+	return nil
+}
+
+// lines renders the report as a per-provider summary followed by one line
+// per change.
+func (r applyReport) lines() []string {
+	var lines []string
+	for i := 0; i < len(r); {
+		j := i
+		for j < len(r) && r[j].Provider == r[i].Provider {
+			j++
+		}
+		group := r[i:j]
+		done, skipped, failed := group.counts()
+		lines = append(lines, fmt.Sprintf("%s: %d done, %d skipped, %d failed", r[i].Provider, done, skipped, failed))
+		for _, res := range group {
+			switch {
+			case res.Err != nil:
+				lines = append(lines, fmt.Sprintf("  ✗ %s %s: %v", res.Action, res.Asset, res.Err))
+			case res.Skipped != "":
+				lines = append(lines, fmt.Sprintf("  - %s %s: %s", res.Action, res.Asset, res.Skipped))
+			default:
+				lines = append(lines, fmt.Sprintf("  ✓ %s %s", pastTense(res.Action), res.Asset))
+			}
+		}
+		i = j
+	}
+	return lines
+}
+
+// renderApplyReport renders the report screen shown after applying changes.
+func renderApplyReport(r applyReport) string {
+	var b strings.Builder
+	done, skipped, failed := r.counts()
+	b.WriteString(subtitleStyle.Render(fmt.Sprintf("Applied changes: %d done, %d skipped, %d failed", done, skipped, failed)))
+	b.WriteString("\n\n")
+	for _, line := range r.lines() {
+		switch {
+		case strings.HasPrefix(line, "  ✓"):
+			b.WriteString(statusOkStyle.Render(line))
+		case strings.HasPrefix(line, "  ✗"):
+			b.WriteString(errorStyle.Render(line))
+		case strings.HasPrefix(line, "  -"):
+			b.WriteString(statusMutedStyle.Render(line))
+		default:
+			b.WriteString(groupActiveStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	for _, hint := range r.hints() {
+		b.WriteString(statusMutedStyle.Render("  Hint: " + hint))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// hints returns how to fix the failed changes, once per distinct remedy.
+func (r applyReport) hints() []string {
+	var hints []string
+	seen := make(map[string]bool)
+	for _, res := range r {
+		if hint := errs.Hint(res.Err); hint != "" && !seen[hint] {
+			seen[hint] = true
+			hints = append(hints, hint)
+		}
+	}
+	return hints
+}
+
+// assetNoun names a single asset of type t in reports.
+func assetNoun(t provider.AssetType) string {
+	if t == provider.AssetMCP {
+		return "MCP server"
+	}
+	return strings.TrimSuffix(string(t), "s")
+}
+
+func pastTense(action string) string {
+	switch action {
+	case "link":
+		return "linked"
+	case "unlink":
+		return "unlinked"
+	case "merge":
+		return "merged"
+	case "refresh":
+		return "refreshed"
+	case "convert":
+		return "converted"
+	}
+	return action
+}
+
+// linkSkillOp links a stored skill, skipping skills missing from the store
+// and those the provider refuses.
+func linkSkillOp(store *skill.Store, p Provider, name string) applyOp {
+	return func() applyResult {
+		res := applyResult{Provider: p.Name, Action: "link", Asset: "skill " + name}
+		if reason := linkRefusal(p, name); reason != "" {
+			res.Skipped = reason
+			return res
+		}
+		if _, err := os.Stat(filepath.Join(store.BaseDir, name)); os.IsNotExist(err) {
+			res.Skipped = "not in the store"
+			return res
+		}
+		res.Err = linkSkillToProvider(store, p, name)
+		return res
+	}
+}
+
+// unlinkSkillOp removes a skill, skipping ones already gone from a
+// path-based provider and frozen providers.
+func unlinkSkillOp(p Provider, name string) applyOp {
+	return func() applyResult {
+		res := applyResult{Provider: p.Name, Action: "unlink", Asset: "skill " + name}
+		if p.Frozen {
+			res.Skipped = "the provider is frozen"
+			return res
+		}
+		if p.Hook == "" {
+			if _, err := os.Lstat(filepath.Join(p.Path, name)); os.IsNotExist(err) {
+				res.Skipped = "already removed"
+				return res
+			}
+		}
+		res.Err = unlinkSkillFromProvider(p, name)
+		return res
+	}
+}
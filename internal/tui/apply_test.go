@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestApplyConcurrentlyGroupsByProvider(t *testing.T) {
+	op := func(provider, name string, err error, skipped string) applyOp {
+		return func() applyResult {
+			return applyResult{Provider: provider, Action: "link", Asset: "skill " + name, Err: err, Skipped: skipped}
+		}
+	}
+	report := applyConcurrently(map[string][]applyOp{
+		"cursor": {op("cursor", "b", nil, ""), op("cursor", "a", errors.New("boom"), "")},
+		"claude": {op("claude", "c", nil, "not in the store")},
+	})
+
+	done, skipped, failed := report.counts()
+	if done != 1 || skipped != 1 || failed != 1 {
+		t.Errorf("counts = %d/%d/%d, want 1/1/1", done, skipped, failed)
+	}
+	want := []string{
+		"claude: 0 done, 1 skipped, 0 failed",
+		"  - link skill c: not in the store",
+		"cursor: 1 done, 0 skipped, 1 failed",
+		"  ✓ linked skill b",
+		"  ✗ link skill a: boom",
+	}
+	if got := report.lines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("lines =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if report.err() == nil {
+		t.Error("expected an error for the failed change")
+	}
+}
+
+func TestApplySkillChangesReportsSkips(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	os.MkdirAll(filepath.Join(store.BaseDir, "present"), 0755)
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills"), Configured: true}
+
+	report, err := applySkillChanges(claude, []SkillEntry{
+		{Name: "present", Selected: true},
+		{Name: "missing", Selected: true},
+		{Name: "gone", Linked: true},
+	})
+	if err != nil {
+		t.Fatalf("applySkillChanges error: %v", err)
+	}
+	done, skipped, failed := report.counts()
+	if done != 1 || skipped != 2 || failed != 0 {
+		t.Errorf("counts = %d/%d/%d, want 1 linked and 2 skipped", done, skipped, failed)
+	}
+	if _, err := os.Lstat(filepath.Join(claude.Path, "present")); err != nil {
+		t.Errorf("present not linked: %v", err)
+	}
+}
+
+func TestManageShowsReportUntilDismissed(t *testing.T) {
+	m := manageModel{provider: Provider{Name: "claude"}, width: 80}
+	report := applyReport{{Provider: "claude", Action: "link", Asset: "skill demo"}}
+	m, _ = m.Update(applyDoneMsg{report: report})
+	if !strings.Contains(m.View(), "linked skill demo") {
+		t.Fatalf("View() should show the report, got:\n%s", m.View())
+	}
+	if !m.capturesKeys() {
+		t.Fatal("the report should keep esc and q from leaving the view")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.report != nil {
+		t.Error("expected esc to close the report")
+	}
+	if m.capturesKeys() {
+		t.Error("keys should go back to the app once the report is closed")
+	}
+	m.confirmingRemove = true
+	if !m.capturesKeys() {
+		t.Error("the remove confirmation should keep esc and q from leaving the view")
+	}
+}
+
+func TestApplyFailuresCanBeRetried(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	os.MkdirAll(filepath.Join(store.BaseDir, "present"), 0755)
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills"), Configured: true}
+	// A local folder of the same name is in the way
+	blocker := filepath.Join(claude.Path, "present")
+	os.MkdirAll(blocker, 0755)
+	os.WriteFile(filepath.Join(blocker, "SKILL.md"), []byte("local"), 0644)
+
+	report, _ := applySkillChanges(claude, []SkillEntry{{Name: "present", Selected: true}})
+	if _, _, failed := report.counts(); failed != 1 || !strings.Contains(report[0].Err.Error(), "replacing") {
+		t.Fatalf("report = %+v, want the link to fail", report)
+	}
+
+	m := manageModel{provider: claude, width: 80}
+	m, _ = m.Update(applyDoneMsg{report: report})
+	if !strings.Contains(m.View(), "[r] retry failed") {
+		t.Fatalf("View() should offer a retry, got:\n%s", m.View())
+	}
+
+	os.RemoveAll(blocker)
+	m, cmd := m.Update(key("r"))
+	if cmd == nil {
+		t.Fatal("r did not retry")
+	}
+	done, ok := runTaskCmd(cmd).(applyDoneMsg)
+	if !ok {
+		t.Fatal("retry did not report back")
+	}
+	if d, _, failed := done.report.counts(); d != 1 || failed != 0 {
+		t.Errorf("retried report = %+v, want the link done", done.report)
+	}
+	if _, err := os.Lstat(blocker); err != nil {
+		t.Errorf("present not linked after retry: %v", err)
+	}
+}
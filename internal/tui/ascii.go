@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// asciiMode is set when output must stay within ASCII, for dumb terminals
+// and CI logs.
+var asciiMode bool
+
+// asciiGlyphs maps the symbols drawn by the TUI and the commands to ASCII
+// stand-ins of the same width, so layouts computed beforehand still line up.
+var asciiGlyphs = strings.NewReplacer(
+	"✓", "+", "✗", "x", "⚠", "!",
+	"●", "*", "○", "o", "◐", "~", "◆", "*", "★", "*",
+	"•", "*", "·", "-", "—", "-",
+	"→", ">", "←", "<", "↑", "^", "↓", "v", "▶", ">", "▼", "v",
+	// Box drawing, from lipgloss borders and glamour
+	"─", "-", "│", "|", "└", "+", "┌", "+", "┐", "+", "┘", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+)
+
+// asciiTerm reports whether term names a terminal without Unicode support.
+// Only the DEC terminals themselves count: vte-256color and the like
+// are modern emulators.
+func asciiTerm(term string) bool {
+	switch term {
+	case "dumb", "vt52", "vt100", "vt102", "vt220":
+		return true
+	}
+	return false
+}
+
+// ASCIIMode reports whether the --ascii flag or TERM asks for ASCII output.
+func ASCIIMode(flag bool) bool {
+	return flag || asciiTerm(os.Getenv("TERM"))
+}
+
+// asciiSafe replaces the non-ASCII symbols of s in ASCII mode.
+func asciiSafe(s string) string {
+	if !asciiMode {
+		return s
+	}
+	return asciiGlyphs.Replace(s)
+}
+
+// terminalOut is the terminal the TUI draws on while standard output is
+// filtered or discarded, nil otherwise.
+var terminalOut *os.File
+
+// EnableASCII switches to ASCII output: symbols are replaced, the spinner
+// uses plain characters and colors are limited to the basic 16, which
+// limited terminals and CI logs render. Standard output is filtered until
+// the returned function is called, which flushes it.
+func EnableASCII() (flush func()) {
+	asciiMode = true
+	activitySpinner = spinner.Line
+	if lipgloss.ColorProfile() < termenv.ANSI {
+		lipgloss.SetColorProfile(termenv.ANSI)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return func() {}
+	}
+	out := os.Stdout
+	if terminalOut == nil {
+		terminalOut = out
+	}
+	os.Stdout = w
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		copyASCII(out, r)
+	}()
+	return func() {
+		os.Stdout = out
+		w.Close()
+		<-done
+	}
+}
+
+// copyASCII copies r to w line by line, replacing symbols. Whole lines are
+// replaced so multi-byte symbols are never split.
+func copyASCII(w io.Writer, r io.Reader) {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			asciiGlyphs.WriteString(w, line)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
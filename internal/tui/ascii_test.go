@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestASCIITerm(t *testing.T) {
+	for term, want := range map[string]bool{
+		"dumb": true, "vt100": true, "vt220": true,
+		"xterm-256color": false, "screen": false, "": false,
+		"vte-256color": false, "vtnt": false,
+	} {
+		if got := asciiTerm(term); got != want {
+			t.Errorf("asciiTerm(%q) = %v, want %v", term, got, want)
+		}
+	}
+}
+
+func TestASCIISafeKeepsWidths(t *testing.T) {
+	asciiMode = true
+	t.Cleanup(func() { asciiMode = false })
+
+	in := "● claude ✓ synced · ⚠ 2 broken → ╭─╮ ✗"
+	out := asciiSafe(in)
+	for _, r := range out {
+		if r > 127 {
+			t.Fatalf("%q is not ASCII", out)
+		}
+	}
+	if lipgloss.Width(out) != lipgloss.Width(in) {
+		t.Errorf("width %d, want %d", lipgloss.Width(out), lipgloss.Width(in))
+	}
+	if out != "* claude + synced - ! 2 broken > +-+ x" {
+		t.Errorf("asciiSafe = %q", out)
+	}
+}
+
+func TestCopyASCII(t *testing.T) {
+	var b strings.Builder
+	copyASCII(&b, strings.NewReader("  ✓ linked\n  ✗ failed"))
+	if b.String() != "  + linked\n  x failed" {
+		t.Errorf("copied %q", b.String())
+	}
+}
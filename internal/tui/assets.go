@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// assetTypeLabel returns a capitalized display label for an asset type.
+func assetTypeLabel(t provider.AssetType) string {
+	s := string(t)
+	if t == provider.AssetMCP {
+		return "MCP servers"
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// manageableAssetTypes returns the link-based asset types a provider supports,
+// always starting with skills.
+func manageableAssetTypes(p Provider) []provider.AssetType {
+	types := []provider.AssetType{provider.AssetSkills}
+	for _, t := range provider.AssetTypes() {
+		if t.IsFile() && providerAssetPath(p, t) != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// linkedAssetNames returns the names of markdown assets present in dir.
+func linkedAssetNames(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") && !provider.Ignored(e.Name()) {
+			names = append(names, strings.TrimSuffix(e.Name(), ".md"))
+		}
+	}
+	return names
+}
+
+// loadAssetsForProvider builds manage-view entries for a file-based asset
+// type, mirroring loadSkillsForProvider: stored assets plus any files found
+// only in the provider directory.
+func loadAssetsForProvider(p Provider, t provider.AssetType) []SkillEntry {
+	store := skill.NewStore(getSkillsPath())
+	stored, _ := store.ListAssets(t)
+
+	linked := make(map[string]bool)
+	if p.Configured {
+		for _, name := range linkedAssetNames(providerAssetPath(p, t)) {
+			linked[name] = true
+		}
+	}
+
+	storedSet := make(map[string]bool)
+	allNames := append([]string{}, stored...)
+	for _, name := range stored {
+		storedSet[name] = true
+	}
+	for name := range linked {
+		if !storedSet[name] {
+			allNames = append(allNames, name)
+		}
+	}
+
+	var entries []SkillEntry
+	for _, name := range allNames {
+		origin := "agents"
+		file := filepath.Join(store.AssetDir(t), name+".md")
+		if !storedSet[name] {
+			origin = "local provider"
+			file = filepath.Join(providerAssetPath(p, t), name+".md")
+		}
+		entries = append(entries, SkillEntry{
+			Name:     name,
+			Group:    extractGroup(name, allNames),
+			Linked:   linked[name],
+			Selected: linked[name],
+			Origin:   origin,
+			Tokens:   skill.FileTokens(file),
+			Bytes:    fileSize(file),
+		})
+		if info, err := os.Stat(file); err == nil {
+			entries[len(entries)-1].InstalledAt = info.ModTime()
+			entries[len(entries)-1].UpdatedAt = info.ModTime()
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Group != entries[j].Group {
+			return entries[i].Group < entries[j].Group
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// applyAssetChanges links or unlinks file assets for a provider to match the
+// selection made in the manage view. Frozen providers are left as they are.
+func applyAssetChanges(p Provider, t provider.AssetType, entries []SkillEntry) applyReport {
+	dir := providerAssetPath(p, t)
+	store := skill.NewStore(getSkillsPath())
+	label := assetNoun(t)
+
+	var ops []applyOp
+	for _, e := range entries {
+		name := e.Name
+		if p.Frozen && e.Selected != e.Linked {
+			action := "unlink"
+			if e.Selected {
+				action = "link"
+			}
+			ops = append(ops, func() applyResult {
+				return applyResult{Provider: p.Name, Action: action, Asset: label + " " + name, Skipped: "the provider is frozen"}
+			})
+			continue
+		}
+		if e.Selected && !e.Linked {
+			ops = append(ops, func() applyResult {
+				return applyResult{Provider: p.Name, Action: "link", Asset: label + " " + name, Err: store.LinkAsset(t, name, dir)}
+			})
+		} else if !e.Selected && e.Linked {
+			ops = append(ops, func() applyResult {
+				return applyResult{Provider: p.Name, Action: "unlink", Asset: label + " " + name, Err: store.UnlinkAsset(t, name, dir)}
+			})
+		}
+	}
+	return applyConcurrently(map[string][]applyOp{p.Name: ops})
+}
+
+// removeAssetFully unlinks a file asset from every configured provider and
+// deletes it from central storage.
+func removeAssetFully(t provider.AssetType, name string) {
+	store := skill.NewStore(getSkillsPath())
+	target := filepath.Join(store.AssetDir(t), name+".md")
+	for _, p := range detectProviders() {
+		dir := providerAssetPath(p, t)
+		if dir == "" {
+			continue
+		}
+		// Disabled providers only lose links that point at the store copy
+		if p.Configured || linkPointsTo(filepath.Join(dir, name+".md"), target) {
+			store.UnlinkAsset(t, name, dir)
+		}
+	}
+	store.RemoveAsset(t, name)
+}
+
+// assetFilePath returns the stored file for a file-based asset.
+func assetFilePath(t provider.AssetType, name string) string {
+	store := skill.NewStore(getSkillsPath())
+	return filepath.Join(store.AssetDir(t), name+".md")
+}
@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// assetTargets resolves the directories an asset should be linked into: the
+// providers of linkTargets supporting t, using project-scoped folders under
+// the working directory when project is set.
+func assetTargets(t provider.AssetType, providerNames []string, project bool) (map[string]string, error) {
+	providers, err := linkTargets(providerNames)
+	if err != nil {
+		return nil, err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]string)
+	for _, p := range providers {
+		dir := providerAssetPath(p, t)
+		if project {
+			dir = ""
+			for _, def := range provider.Definitions() {
+				if def.Name == p.Name {
+					dir = def.ProjectAssetPath(cwd, t)
+				}
+			}
+		}
+		if dir != "" {
+			targets[p.Name] = dir
+		}
+	}
+
+	for _, name := range providerNames {
+		if _, ok := targets[name]; !ok {
+			return nil, fmt.Errorf("provider %s does not support %s", name, t)
+		}
+	}
+	return targets, nil
+}
+
+// RunAssetList prints stored assets of type t and which providers link each.
+func RunAssetList(t provider.AssetType) error {
+	store := skill.NewStore(getSkillsPath())
+	names, err := store.ListAssets(t)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%s): %d\n", assetTypeLabel(t), store.AssetDir(t), len(names))
+	providers := detectProviders()
+	for _, name := range names {
+		var linkedTo []string
+		for _, p := range providers {
+			dir := providerAssetPath(p, t)
+			if !p.Configured || dir == "" {
+				continue
+			}
+			for _, linked := range linkedAssetNames(dir) {
+				if linked == name {
+					linkedTo = append(linkedTo, p.Name)
+				}
+			}
+		}
+		if len(linkedTo) > 0 {
+			fmt.Printf("  • %s → %s\n", name, strings.Join(linkedTo, ", "))
+		} else {
+			fmt.Printf("  • %s\n", name)
+		}
+	}
+	return nil
+}
+
+// RunAssetInstall discovers assets of type t in an owner/repo source, installs
+// the requested ones (all when names is empty) and links them to providers.
+func RunAssetInstall(t provider.AssetType, source string, names, providerNames []string, project bool) error {
+	src, err := skill.ParseSource(source)
+	if err != nil || src.Kind != skill.SourceGitHub {
+		return fmt.Errorf("invalid source format: %s (expected owner/repo)", source)
+	}
+
+	found, err := skill.DiscoverAssets(src.Owner, src.Repo, t)
+	if err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("no %s found in %s", t, source)
+	}
+
+	wanted := make(map[string]bool)
+	for _, n := range names {
+		wanted[n] = true
+	}
+
+	targets, err := assetTargets(t, providerNames, project)
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	values := configTemplateValues()
+	installed := 0
+	for _, asset := range found {
+		if len(wanted) > 0 && !wanted[asset.Name] {
+			continue
+		}
+		delete(wanted, asset.Name)
+		if err := store.InstallRemoteAsset(t, asset); err != nil {
+			return fmt.Errorf("installing %s: %w", asset.Name, err)
+		}
+		file := filepath.Join(store.AssetDir(t), asset.Name+".md")
+		if err := skill.RenderTemplate(file, values); err != nil {
+			return fmt.Errorf("rendering %s: %w", asset.Name, err)
+		}
+		if missing := missingTemplateVars(file, values); len(missing) > 0 {
+			fmt.Printf("! %s has unset placeholders: %s (set them under \"variables\" in config.json)\n", asset.Name, strings.Join(missing, ", "))
+		}
+		var linked []string
+		for name, dir := range targets {
+			if err := store.LinkAsset(t, asset.Name, dir); err == nil {
+				linked = append(linked, name)
+			}
+		}
+		installed++
+		fmt.Printf("✓ Installed %s → %s\n", asset.Name, strings.Join(linked, ", "))
+	}
+
+	for name := range wanted {
+		fmt.Printf("✗ %s not found in %s\n", name, source)
+	}
+	if installed == 0 {
+		return fmt.Errorf("nothing installed")
+	}
+	return nil
+}
+
+// RunAssetLink links a stored asset into providers.
+func RunAssetLink(t provider.AssetType, name string, providerNames []string, project bool) error {
+	store := skill.NewStore(getSkillsPath())
+	if _, err := os.Stat(assetFilePath(t, name)); err != nil {
+		return fmt.Errorf("%s %q is not installed", strings.TrimSuffix(string(t), "s"), name)
+	}
+
+	targets, err := assetTargets(t, providerNames, project)
+	if err != nil {
+		return err
+	}
+	for pname, dir := range targets {
+		if err := store.LinkAsset(t, name, dir); err != nil {
+			return fmt.Errorf("linking %s to %s: %w", name, pname, err)
+		}
+		fmt.Printf("✓ Linked %s → %s (%s)\n", name, pname, dir)
+	}
+	return nil
+}
+
+// RunAssetUnlink removes an asset link from providers.
+func RunAssetUnlink(t provider.AssetType, name string, providerNames []string, project bool) error {
+	store := skill.NewStore(getSkillsPath())
+	targets, err := assetTargets(t, providerNames, project)
+	if err != nil {
+		return err
+	}
+	for pname, dir := range targets {
+		if err := store.UnlinkAsset(t, name, dir); err == nil {
+			fmt.Printf("✓ Unlinked %s from %s\n", name, pname)
+		}
+	}
+	return nil
+}
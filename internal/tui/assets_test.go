@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/provider"
+)
+
+func TestLoadAndApplyCommandsForProvider(t *testing.T) {
+	home := setTestHome(t)
+	commandsDir := filepath.Join(home, ".agents", "commands")
+	os.MkdirAll(commandsDir, 0755)
+	os.WriteFile(filepath.Join(commandsDir, "review.md"), []byte("# Review"), 0644)
+	claudeCommands := filepath.Join(home, ".claude", "commands")
+	os.MkdirAll(claudeCommands, 0755)
+	os.WriteFile(filepath.Join(claudeCommands, "local.md"), []byte("# Local"), 0644)
+
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills"), Configured: true}
+	entries := loadAssetsForProvider(claude, provider.AssetCommands)
+	if len(entries) != 2 {
+		t.Fatalf("loadAssetsForProvider = %+v, want 2 entries", entries)
+	}
+	for i := range entries {
+		switch entries[i].Name {
+		case "review":
+			if entries[i].Linked || entries[i].Origin != "agents" {
+				t.Fatalf("review entry = %+v, want unlinked stored command", entries[i])
+			}
+			entries[i].Selected = true
+		case "local":
+			if !entries[i].Linked || entries[i].Origin != "local provider" {
+				t.Fatalf("local entry = %+v, want linked provider-only command", entries[i])
+			}
+		}
+	}
+
+	if err := applyAssetChanges(claude, provider.AssetCommands, entries).err(); err != nil {
+		t.Fatalf("applyAssetChanges error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(claudeCommands, "review.md")); err != nil {
+		t.Fatalf("review command not linked: %v", err)
+	}
+}
+
+func TestManageableAssetTypes(t *testing.T) {
+	setTestHome(t)
+	claude := manageableAssetTypes(Provider{Name: "claude"})
+	if len(claude) < 2 || claude[0] != provider.AssetSkills || claude[1] != provider.AssetCommands {
+		t.Fatalf("manageableAssetTypes(claude) = %v, want skills then commands", claude)
+	}
+	if qoder := manageableAssetTypes(Provider{Name: "qoder"}); len(qoder) != 1 {
+		t.Fatalf("manageableAssetTypes(qoder) = %v, want skills only", qoder)
+	}
+}
+
+func TestAssetTargetsProjectScope(t *testing.T) {
+	setTestHome(t)
+	project := t.TempDir()
+	wd, _ := os.Getwd()
+	os.Chdir(project)
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	targets, err := assetTargets(provider.AssetCommands, []string{"claude"}, true)
+	if err != nil {
+		t.Fatalf("assetTargets error: %v", err)
+	}
+	// Compare resolved paths: the temp dir may sit behind a symlink (macOS /var)
+	got := targets["claude"]
+	if !strings.HasSuffix(got, filepath.Join(".claude", "commands")) || mustEval(t, filepath.Dir(filepath.Dir(got))) != mustEval(t, project) {
+		t.Fatalf("claude target = %q, want %q", got, filepath.Join(project, ".claude", "commands"))
+	}
+
+	if _, err := assetTargets(provider.AssetCommands, []string{"qoder"}, false); err == nil {
+		t.Fatal("assetTargets(qoder) error = nil, want unsupported provider error")
+	}
+}
+
+func mustEval(t *testing.T, path string) string {
+	t.Helper()
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		t.Fatalf("EvalSymlinks(%s): %v", path, err)
+	}
+	return resolved
+}
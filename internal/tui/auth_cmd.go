@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+	"github.com/lmarques/efx-skills/internal/secrets"
+)
+
+// authTarget resolves a target of the auth commands, "github" or the name
+// of a registry in config.json, to its keychain account and the token
+// config.json sets for it.
+func authTarget(cfg *ConfigData, target string) (account, configured string, err error) {
+	if target == secrets.GitHub {
+		return secrets.GitHub, cfg.GitHubToken, nil
+	}
+	i := slices.IndexFunc(cfg.Registries, func(r Registry) bool { return r.Name == target })
+	if i < 0 {
+		return "", "", errs.WithHint(errs.NotFound, "expected github or a registry listed by \"efx-skills registry list\"",
+			"unknown token target: %s", target)
+	}
+	return secrets.Registry(target), cfg.Registries[i].Token, nil
+}
+
+// backendName describes where secrets saved a token.
+func backendName(b secrets.Backend) string {
+	if b == secrets.File {
+		return displayPath(secrets.FilePath()) + " (no keychain available)"
+	}
+	return "the keychain"
+}
+
+// RunAuthSet saves the token of target, "github" or a registry name, in the
+// keychain. The token is read from in without echo when in is a terminal.
+func RunAuthSet(target string, in io.Reader) error {
+	cfg := loadOrDefaultConfig()
+	account, configured, err := authTarget(cfg, target)
+	if err != nil {
+		return err
+	}
+	token, err := readToken(target, in)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return fmt.Errorf("no token given")
+	}
+	backend, err := secrets.Set(account, token)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Saved the %s token in %s\n", target, backendName(backend))
+	if strings.HasPrefix(configured, "$") {
+		fmt.Printf("• config.json also sets a token for %s, which is used first; remove it to use this one\n", target)
+	} else if env := gitHubTokenEnv(account); env != "" {
+		fmt.Printf("• $%s is set and used first; unset it to use this one\n", env)
+	}
+	return nil
+}
+
+// readToken reads one line holding a token, prompting for it without echo
+// on a terminal.
+func readToken(target string, in io.Reader) (string, error) {
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fmt.Printf("Token for %s: ", target)
+		token, err := term.ReadPassword(int(f.Fd()))
+		fmt.Println()
+		return strings.TrimSpace(string(token)), err
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// RunAuthRemove forgets the token saved for target.
+func RunAuthRemove(target string) error {
+	account, _, err := authTarget(loadOrDefaultConfig(), target)
+	if err != nil {
+		return err
+	}
+	if err := secrets.Delete(account); errors.Is(err, secrets.ErrNotFound) {
+		return errs.New(errs.NotFound, "no %s token saved", target)
+	} else if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Removed the %s token\n", target)
+	return nil
+}
+
+// RunAuthStatus prints where the token of GitHub and of each registry
+// comes from, never the token itself.
+func RunAuthStatus() error {
+	cfg := loadOrDefaultConfig()
+	targets := []string{secrets.GitHub}
+	for _, r := range cfg.Registries {
+		targets = append(targets, r.Name)
+	}
+	for _, target := range targets {
+		account, configured, _ := authTarget(cfg, target)
+		source := tokenSource(account, configured)
+		mark := "●"
+		if source == "" {
+			mark = "○"
+			source = "none"
+			if target == secrets.GitHub {
+				source = "none saved; gh auth token is used"
+			}
+		}
+		fmt.Printf("  %s %s %s\n", mark, padRight(target, 20), source)
+	}
+	return nil
+}
+
+// gitHubTokenEnv returns the environment variable the GitHub token is read
+// from ahead of the keychain, "" when none is set or account is another.
+func gitHubTokenEnv(account string) string {
+	if account != secrets.GitHub {
+		return ""
+	}
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if os.Getenv(env) != "" {
+			return env
+		}
+	}
+	return ""
+}
+
+// tokenSource describes where the token of account is read from, "" when
+// none is set. Plaintext tokens in config.json are ignored.
+func tokenSource(account, configured string) string {
+	if strings.HasPrefix(configured, "$") {
+		return configured
+	}
+	if env := gitHubTokenEnv(account); env != "" {
+		return "$" + env
+	}
+	if _, backend, err := secrets.Get(account); err == nil {
+		return string(backend)
+	}
+	return ""
+}
+
+// RunAuthMigrate moves the tokens written in plaintext in config.json to
+// the keychain. Tokens read from "$VAR" stay as they are.
+func RunAuthMigrate() error {
+	cfg := loadOrDefaultConfig()
+	moved := 0
+	migrate := func(target, account string, token *string) error {
+		if *token == "" || strings.HasPrefix(*token, "$") {
+			return nil
+		}
+		backend, err := secrets.Set(account, *token)
+		if err != nil {
+			return fmt.Errorf("saving the %s token: %w", target, err)
+		}
+		*token = ""
+		moved++
+		fmt.Printf("✓ Moved the %s token to %s\n", target, backendName(backend))
+		return nil
+	}
+	if err := migrate(secrets.GitHub, secrets.GitHub, &cfg.GitHubToken); err != nil {
+		return err
+	}
+	for i := range cfg.Registries {
+		r := &cfg.Registries[i]
+		if err := migrate(r.Name, secrets.Registry(r.Name), &r.Token); err != nil {
+			return err
+		}
+	}
+	if moved == 0 {
+		fmt.Println("- No plaintext tokens in config.json")
+		return nil
+	}
+	if err := saveConfigData(cfg); err != nil {
+		return err
+	}
+	fmt.Printf("! %s%s and the backups in %s still hold the old tokens; delete them once the migration works\n",
+		displayPath(configFilePath()), fsutil.BackupSuffix, displayPath(filepath.Join(filepath.Dir(configFilePath()), fsutil.BackupDirName)))
+	return nil
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/secrets"
+)
+
+// TestMain keeps the tests away from the keychain of the machine running them.
+func TestMain(m *testing.M) {
+	keyring.MockInit()
+	os.Exit(m.Run())
+}
+
+func TestRunAuthMigrateMovesPlaintextTokens(t *testing.T) {
+	setTestHome(t)
+	keyring.MockInit()
+	cfg := loadOrDefaultConfig()
+	cfg.GitHubToken = "ghp_plain"
+	cfg.Registries = append(cfg.Registries,
+		Registry{Name: "corp", URL: "https://skills.corp.test", Enabled: true, Token: "corp-plain"},
+		Registry{Name: "env", URL: "https://skills.env.test", Enabled: true, Token: "$ENV_TOKEN"})
+	saveConfigData(cfg)
+
+	if err := RunAuthMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	cfg = loadConfigFromFile()
+	if cfg.GitHubToken != "" || cfg.Registries[len(cfg.Registries)-2].Token != "" {
+		t.Error("plaintext tokens left in config.json")
+	}
+	if got := cfg.Registries[len(cfg.Registries)-1].Token; got != "$ENV_TOKEN" {
+		t.Errorf("environment token = %q, want it kept", got)
+	}
+	if token, _, _ := secrets.Get(secrets.GitHub); token != "ghp_plain" {
+		t.Errorf("saved github token = %q", token)
+	}
+	if token, _, _ := secrets.Get(secrets.Registry("corp")); token != "corp-plain" {
+		t.Errorf("saved corp token = %q", token)
+	}
+}
+
+func TestRunAuthSetAndRemove(t *testing.T) {
+	setTestHome(t)
+	keyring.MockInit()
+
+	if err := RunAuthSet("nowhere", strings.NewReader("x\n")); errs.KindOf(err) != errs.NotFound {
+		t.Errorf("RunAuthSet for an unknown target = %v, want not found", err)
+	}
+	if err := RunAuthSet("skills.sh", strings.NewReader("  sk_token \n")); err != nil {
+		t.Fatal(err)
+	}
+	if token, _, _ := secrets.Get(secrets.Registry("skills.sh")); token != "sk_token" {
+		t.Errorf("saved token = %q, want sk_token", token)
+	}
+	if err := RunAuthRemove("skills.sh"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RunAuthRemove("skills.sh"); errs.KindOf(err) != errs.NotFound {
+		t.Errorf("second RunAuthRemove = %v, want not found", err)
+	}
+}
+
+func TestTokenSource(t *testing.T) {
+	setTestHome(t)
+	keyring.MockInit()
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	secrets.Set(secrets.GitHub, "saved")
+
+	if got := tokenSource(secrets.Registry("corp"), "plaintext"); got != "" {
+		t.Errorf("plaintext registry token: source = %q, want it ignored", got)
+	}
+	if got := tokenSource(secrets.GitHub, "plaintext"); got != string(secrets.Keychain) {
+		t.Errorf("plaintext github token: source = %q, want it ignored for the keychain", got)
+	}
+	if got := tokenSource(secrets.GitHub, ""); got != string(secrets.Keychain) {
+		t.Errorf("saved github token: source = %q, want the keychain", got)
+	}
+	t.Setenv("GH_TOKEN", "env")
+	if got := tokenSource(secrets.GitHub, ""); got != "$GH_TOKEN" {
+		t.Errorf("github token in the environment: source = %q, want $GH_TOKEN", got)
+	}
+}
@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/backup"
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// configFilePath returns the location of the active workspace's config.json.
+func configFilePath() string {
+	return workspaceConfigPath(activeWorkspace)
+}
+
+// linkKey identifies a provider's asset section in a backup manifest.
+func linkKey(providerName string, t provider.AssetType) string {
+	return providerName + "/" + string(t)
+}
+
+// collectLinkMap records, for each configured provider, which stored
+// skills, commands and agents it currently links.
+func collectLinkMap(store *skill.Store, providers []Provider) map[string][]string {
+	links := make(map[string][]string)
+	for _, p := range providers {
+		if !p.Configured {
+			continue
+		}
+		for _, t := range manageableAssetTypes(p) {
+			stored, _ := store.ListAssets(t)
+			storedSet := make(map[string]bool)
+			for _, name := range stored {
+				storedSet[name] = true
+			}
+
+			var present []string
+			if t == provider.AssetSkills {
+				present = listProviderSkills(p)
+			} else {
+				present = linkedAssetNames(providerAssetPath(p, t))
+			}
+			for _, name := range present {
+				if storedSet[name] {
+					links[linkKey(p.Name, t)] = append(links[linkKey(p.Name, t)], name)
+				}
+			}
+		}
+	}
+	return links
+}
+
+// RunBackup writes a tar.gz snapshot of the store directory (~/.agents),
+// config.json and the provider link map. An empty output picks a
+// timestamped name in the current directory.
+func RunBackup(output string) error {
+	if output == "" {
+		output = fmt.Sprintf("efx-skills-backup-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	agentsDir := filepath.Dir(store.BaseDir)
+	links := collectLinkMap(store, detectProviders())
+
+	if err := backup.Write(output, agentsDir, configFilePath(), backup.Manifest{Links: links}); err != nil {
+		return fmt.Errorf("writing backup: %w", err)
+	}
+
+	linked := 0
+	for _, names := range links {
+		linked += len(names)
+	}
+	fmt.Printf("✓ Backed up %s, config and %d provider links to %s\n", agentsDir, linked, output)
+	return nil
+}
+
+// RunRestore unpacks a backup into the store directory and config, then
+// recreates the recorded provider links using this machine's provider paths.
+// Restoring over a non-empty store requires force.
+func RunRestore(archive string, force bool) error {
+	store := skill.NewStore(getSkillsPath())
+	if names, _ := store.ListInstalled(); len(names) > 0 && !force {
+		return errs.WithHint(errs.Conflict, "use --force to restore over it", "%s already contains %d skills", store.BaseDir, len(names))
+	}
+
+	agentsDir := filepath.Dir(store.BaseDir)
+	m, err := backup.Extract(archive, agentsDir, configFilePath())
+	if err != nil {
+		return fmt.Errorf("restoring backup: %w", err)
+	}
+	fmt.Printf("✓ Restored %s and config from backup of %s\n", agentsDir, m.CreatedAt)
+
+	// The restored config records the old machine's skills path; point it at
+	// the directory the store was just restored into
+	if cfg := loadConfigFromFile(); cfg != nil && filepath.Dir(cfg.SkillsPath) == m.AgentsDir && m.AgentsDir != agentsDir {
+		cfg.SkillsPath = store.BaseDir
+		if err := saveConfigData(cfg); err != nil {
+			return err
+		}
+	}
+	store = skill.NewStore(getSkillsPath())
+	failed := 0
+	for _, p := range detectProviders() {
+		var restored []string
+		for _, t := range manageableAssetTypes(p) {
+			if p.Frozen {
+				if len(m.Links[linkKey(p.Name, t)]) > 0 {
+					fmt.Printf("  - %s: frozen, %s links not restored\n", p.Name, t)
+				}
+				continue
+			}
+			for _, name := range m.Links[linkKey(p.Name, t)] {
+				var err error
+				if t == provider.AssetSkills {
+					err = linkSkillToProvider(store, p, name)
+				} else {
+					err = store.LinkAsset(t, name, providerAssetPath(p, t))
+				}
+				if err != nil {
+					failed++
+					fmt.Printf("  ✗ %s: %s %s: %v\n", p.Name, t, name, err)
+					continue
+				}
+				restored = append(restored, name)
+			}
+		}
+		if len(restored) > 0 {
+			fmt.Printf("  ✓ %s: %s\n", p.Name, strings.Join(restored, ", "))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d link(s) could not be restored", failed)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestBackupRestoreToNewHome(t *testing.T) {
+	oldHome := setTestHome(t)
+	store := skill.NewStore(filepath.Join(oldHome, ".agents", "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "demo"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "demo", "SKILL.md"), []byte("# Demo"), 0644)
+	store.LinkToProvider("demo", filepath.Join(oldHome, ".claude", "skills"))
+	saveConfigData(&ConfigData{Providers: []string{"claude"}, SkillsPath: store.BaseDir})
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	if err := RunBackup(archive); err != nil {
+		t.Fatalf("RunBackup error: %v", err)
+	}
+
+	newHome := setTestHome(t)
+	if err := RunRestore(archive, false); err != nil {
+		t.Fatalf("RunRestore error: %v", err)
+	}
+
+	if got := getSkillsPath(); got != filepath.Join(newHome, ".agents", "skills") {
+		t.Fatalf("skills path after restore = %s, want under new HOME", got)
+	}
+	link := filepath.Join(newHome, ".claude", "skills", "demo")
+	if _, err := os.Stat(filepath.Join(link, "SKILL.md")); err != nil {
+		t.Fatalf("claude link not restored: %v", err)
+	}
+
+	if err := RunRestore(archive, false); err == nil {
+		t.Fatal("restoring over a non-empty store without force should fail")
+	}
+}
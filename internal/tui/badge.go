@@ -0,0 +1,24 @@
+package tui
+
+// skillBadge labels skills flagged official by their registry or published
+// by a verified vendor account; "" for everything else.
+func skillBadge(s Skill) string {
+	switch {
+	case s.Official:
+		return "✓ official"
+	case s.Verified:
+		return "◆ verified owner"
+	}
+	return ""
+}
+
+// badgePrefix is the one-character form of skillBadge for table rows.
+func badgePrefix(s Skill) string {
+	switch {
+	case s.Official:
+		return "✓ "
+	case s.Verified:
+		return "◆ "
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSkillBadge(t *testing.T) {
+	tests := []struct {
+		skill Skill
+		want  string
+	}{
+		{Skill{Official: true, Verified: true}, "✓ official"},
+		{Skill{Verified: true}, "◆ verified owner"},
+		{Skill{}, ""},
+	}
+	for _, tt := range tests {
+		if got := skillBadge(tt.skill); got != tt.want {
+			t.Errorf("skillBadge(%+v) = %q, want %q", tt.skill, got, tt.want)
+		}
+	}
+}
+
+func TestSearchOfficialFilter(t *testing.T) {
+	results := testSkills(4)
+	results[1].Official = true
+	results[3].Verified = true
+
+	m := newSearchModel()
+	m.cache = newSearchCache()
+	m, _ = m.Update(searchResultsMsg{query: "demo", results: results})
+	m.selectedIdx = 2
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	if len(m.results) != 2 || m.results[0].Name != "skill-1" || m.results[1].Name != "skill-3" {
+		t.Fatalf("filtered results = %+v, want skill-1 and skill-3", m.results)
+	}
+	if m.selectedIdx != 0 {
+		t.Errorf("selectedIdx = %d, want 0 after filtering", m.selectedIdx)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	if len(m.results) != 4 {
+		t.Errorf("len(results) = %d after clearing the filter, want 4", len(m.results))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"runtime"
 
 	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
 )
 
 // openInBrowser opens the given URL in the user's default browser.
@@ -26,8 +27,9 @@ func openInBrowser(url string) error {
 // For playbooks.com: returns https://playbooks.com/skills/{Source}/{Name} when both
 // Source and Name are non-empty, otherwise falls back to https://playbooks.com.
 //
-// For other registries (skills.sh, github, etc.): returns https://github.com/{Source}
-// when Source is non-empty, otherwise returns "".
+// For other registries (skills.sh, github, etc.): returns https://github.com/{Source},
+// or its page on the configured GitHub Enterprise host, when Source is
+// non-empty, otherwise returns "".
 func urlForAPISkill(s api.Skill) string {
 	switch s.Registry {
 	case "playbooks.com":
@@ -37,7 +39,7 @@ func urlForAPISkill(s api.Skill) string {
 		return "https://playbooks.com"
 	default:
 		if s.Source != "" {
-			return fmt.Sprintf("https://github.com/%s", s.Source)
+			return skill.WebURL(s.Source)
 		}
 		return ""
 	}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// footprint is the combined size of a set of skills.
+type footprint struct {
+	Skills int
+	Tokens int
+	Bytes  int64
+}
+
+// providerBudget returns the budget configured for a provider, if any.
+func providerBudget(name string) (Budget, bool) {
+	cfg := loadConfigFromFile()
+	if cfg == nil {
+		return Budget{}, false
+	}
+	b, ok := cfg.Budgets[name]
+	return b, ok
+}
+
+// exceeded describes each limit of b that f goes over.
+func (b Budget) exceeded(f footprint) []string {
+	var over []string
+	if b.MaxSkills > 0 && f.Skills > b.MaxSkills {
+		over = append(over, fmt.Sprintf("%d skills > max %d", f.Skills, b.MaxSkills))
+	}
+	if b.MaxTokens > 0 && f.Tokens > b.MaxTokens {
+		over = append(over, fmt.Sprintf("~%s tokens > max %s", formatTokens(f.Tokens), formatTokens(b.MaxTokens)))
+	}
+	if b.MaxBytes > 0 && f.Bytes > b.MaxBytes {
+		over = append(over, fmt.Sprintf("%s > max %s", formatBytes(f.Bytes), formatBytes(b.MaxBytes)))
+	}
+	return over
+}
+
+// formatBytes renders a size in B, KB or MB.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// fileSize returns the size of path, or 0 when it cannot be read.
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// skillFileSize returns the size of the SKILL.md in dir, or 0.
+func skillFileSize(dir string) int64 {
+	return fileSize(filepath.Join(dir, "SKILL.md"))
+}
+
+// selectionFootprint sums the selected manage-view entries.
+func selectionFootprint(entries []SkillEntry) footprint {
+	var f footprint
+	for _, e := range entries {
+		if e.Selected {
+			f.Skills++
+			f.Tokens += e.Tokens
+			f.Bytes += e.Bytes
+		}
+	}
+	return f
+}
+
+// budgetWarnings checks every configured provider's linked skills against
+// its budget, returning one line per provider over budget.
+func budgetWarnings(providers []Provider) []string {
+	cfg := loadConfigFromFile()
+	if cfg == nil || len(cfg.Budgets) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	for _, p := range providers {
+		b, ok := cfg.Budgets[p.Name]
+		if !ok || !p.Configured {
+			continue
+		}
+		over := b.exceeded(selectionFootprint(loadSkillsForProvider(p)))
+		if len(over) > 0 {
+			warnings = append(warnings, fmt.Sprintf("⚠ %s over budget: %s", p.Name, strings.Join(over, ", ")))
+		}
+	}
+	return warnings
+}
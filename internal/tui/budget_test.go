@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBudgetExceeded(t *testing.T) {
+	b := Budget{MaxSkills: 2, MaxTokens: 1000}
+	if over := b.exceeded(footprint{Skills: 2, Tokens: 900}); len(over) != 0 {
+		t.Fatalf("exceeded within budget = %v, want none", over)
+	}
+	over := b.exceeded(footprint{Skills: 3, Tokens: 1500, Bytes: 1 << 20})
+	if len(over) != 2 {
+		t.Fatalf("exceeded = %v, want skills and tokens (bytes unlimited)", over)
+	}
+}
+
+func TestBudgetWarningsForLinkedSkills(t *testing.T) {
+	home := setTestHome(t)
+	skillsDir := filepath.Join(home, ".agents", "skills")
+	claudeDir := filepath.Join(home, ".claude", "skills")
+	os.MkdirAll(claudeDir, 0755)
+	for _, name := range []string{"one", "two"} {
+		os.MkdirAll(filepath.Join(skillsDir, name), 0755)
+		os.WriteFile(filepath.Join(skillsDir, name, "SKILL.md"), []byte("# "+name), 0644)
+		os.Symlink(filepath.Join(skillsDir, name), filepath.Join(claudeDir, name))
+	}
+	if err := saveConfigData(&ConfigData{Budgets: map[string]Budget{"claude": {MaxSkills: 1}}}); err != nil {
+		t.Fatalf("saveConfigData error: %v", err)
+	}
+
+	claude := Provider{Name: "claude", Path: claudeDir, Configured: true}
+	warnings := budgetWarnings([]Provider{claude})
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "2 skills > max 1") {
+		t.Fatalf("budgetWarnings = %v, want claude over its skill limit", warnings)
+	}
+}
+
+func TestManageModelReadsBudgetOnce(t *testing.T) {
+	setTestHome(t)
+	saveConfigData(&ConfigData{Budgets: map[string]Budget{"claude": {MaxSkills: 1}}})
+
+	m := newManageModel(Provider{Name: "claude"})
+	if m.budget == nil || m.budget.MaxSkills != 1 {
+		t.Fatalf("budget = %+v, want the configured one", m.budget)
+	}
+	if other := newManageModel(Provider{Name: "cursor"}); other.budget != nil {
+		t.Errorf("budget for a provider without one = %+v", other.budget)
+	}
+}
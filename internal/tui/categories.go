@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// localTaggedSkills lists the stored skills with the tags of their
+// frontmatter, as search results from the "local" registry.
+func localTaggedSkills() []Skill {
+	store := skill.NewStore(getSkillsPath())
+	names, _ := store.ListInstalled()
+	lock, _ := store.ReadLockFile()
+	var skills []Skill
+	for _, name := range names {
+		tags := skill.Tags(filepath.Join(store.BaseDir, name))
+		if len(tags) == 0 {
+			continue
+		}
+		s := Skill{Name: name, Registry: "local", Tags: tags}
+		if lock != nil {
+			s.Source = lock.Skills[name].Source
+		}
+		skills = append(skills, s)
+	}
+	return skills
+}
+
+// topicDirectory groups local and registry skills by topic. Local skills
+// come first within a topic.
+func topicDirectory(local, remote []Skill) []api.Category {
+	return api.GroupByTag(append(append([]Skill{}, local...), remote...))
+}
+
+// categoriesModel is the topic directory: every tag found in the stored
+// skills' frontmatter and the registries' metadata, with its skill count.
+type categoriesModel struct {
+	categories  []api.Category
+	selectedIdx int
+	nav         vimNav
+	width       int
+	height      int
+	loading     bool
+	err         error // registries unreachable; local topics are still listed
+}
+
+type categoriesLoadedMsg struct {
+	categories []api.Category
+	err        error
+}
+
+type openCategoriesMsg struct{}
+
+// openTopicMsg lists the skills of one topic in the search view.
+type openTopicMsg struct {
+	topic  string
+	skills []Skill
+}
+
+func newCategoriesModel() categoriesModel {
+	return categoriesModel{loading: true}
+}
+
+func (m categoriesModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		remote, err := api.TaggedCatalog()
+		return categoriesLoadedMsg{categories: topicDirectory(localTaggedSkills(), remote), err: err}
+	}
+}
+
+// visibleRows is how many topics fit on screen.
+func (m categoriesModel) visibleRows() int {
+	if m.height <= 0 {
+		return 15
+	}
+	if rows := m.height - 12; rows > 5 {
+		return rows
+	}
+	return 5
+}
+
+func (m categoriesModel) Update(msg tea.Msg) (categoriesModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case categoriesLoadedMsg:
+		m.loading = false
+		m.categories = msg.categories
+		m.err = msg.err
+
+	case tea.KeyMsg:
+		if idx, ok := m.nav.handle(msg.String(), m.selectedIdx, len(m.categories), m.visibleRows()); ok {
+			m.selectedIdx = idx
+			return m, nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			if m.selectedIdx > 0 {
+				m.selectedIdx--
+			}
+		case "down", "j":
+			if m.selectedIdx < len(m.categories)-1 {
+				m.selectedIdx++
+			}
+		case "enter":
+			if len(m.categories) > 0 {
+				c := m.categories[m.selectedIdx]
+				return m, func() tea.Msg {
+					return openTopicMsg{topic: c.Name, skills: c.Skills}
+				}
+			}
+		case "r":
+			m.loading = true
+			return m, m.Init()
+		}
+	}
+	return m, nil
+}
+
+// localCount counts the stored skills of a category.
+func localCount(c api.Category) int {
+	n := 0
+	for _, s := range c.Skills {
+		if s.Registry == "local" {
+			n++
+		}
+	}
+	return n
+}
+
+func (m categoriesModel) View() string {
+	var b strings.Builder
+
+	w := m.width
+	if w <= 0 {
+		w = 80
+	}
+
+	b.WriteString(renderTitleBox("Topics"))
+	b.WriteString("\n")
+
+	if m.loading {
+		b.WriteString(spinnerStyle.Render("  " + withSpinner("Loading...")))
+		return b.String()
+	}
+	if m.err != nil {
+		b.WriteString(statusWarnStyle.Render(fmt.Sprintf("  Registries unavailable (%v); showing local topics", m.err)))
+		b.WriteString("\n")
+	}
+	if len(m.categories) == 0 {
+		b.WriteString(statusMutedStyle.Render("  No tagged skills yet; add tags: [...] to a SKILL.md frontmatter"))
+		b.WriteString(renderHelpBar(m.width, []string{"[esc] back", "[q] quit"}))
+		return b.String()
+	}
+
+	t := categoriesTable()
+	widths := t.layout(w - 4)
+	b.WriteString(getTableHeaderStyle(w).Render("  " + t.header(widths)))
+	b.WriteString("\n")
+
+	rows := m.visibleRows()
+	start := 0
+	if m.selectedIdx >= rows {
+		start = m.selectedIdx - rows + 1
+	}
+	end := min(start+rows, len(m.categories))
+	for i := start; i < end; i++ {
+		c := m.categories[i]
+		local := localCount(c)
+		row := "  " + t.row(widths, c.Name, fmt.Sprintf("%d", len(c.Skills)), fmt.Sprintf("%d", local), fmt.Sprintf("%d", len(c.Skills)-local))
+		if i == m.selectedIdx {
+			b.WriteString(getSelectedRowStyle(w).Render(row))
+		} else {
+			b.WriteString(tableRowStyle.Render(row))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(statusMutedStyle.Render(fmt.Sprintf("\n  %d of %d", m.selectedIdx+1, len(m.categories))))
+	b.WriteString(renderHelpBar(m.width, []string{"[enter] browse topic", "[up/down] navigate", "[gg/G] top/bottom", "[r] refresh", "[esc] back", "[q] quit"}))
+	return b.String()
+}
+
+// categoriesTable lays out the topic directory.
+func categoriesTable() table {
+	return table{gap: 2, columns: []tableColumn{
+		{Title: "Topic", Min: 16},
+		{Title: "Skills", Width: 6, Right: true},
+		{Title: "Local", Width: 6, Right: true, Priority: 2},
+		{Title: "Registry", Width: 8, Right: true, Priority: 1},
+	}}
+}
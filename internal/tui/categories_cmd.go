@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/api"
+)
+
+// RunTopics prints the topic directory, or the skills of one topic. Local
+// topics are listed even when the registries cannot be reached.
+func RunTopics(topic string) error {
+	remote, err := api.TaggedCatalog()
+	if err != nil {
+		fmt.Printf("! registries unavailable (%v); showing local topics\n", err)
+	}
+	cats := topicDirectory(localTaggedSkills(), remote)
+
+	if topic == "" {
+		if len(cats) == 0 {
+			fmt.Println("No tagged skills yet; add tags: [...] to a SKILL.md frontmatter.")
+			return nil
+		}
+		for _, c := range cats {
+			local := localCount(c)
+			fmt.Printf("%s %3d skills (%d local, %d registry)\n", padRight(c.Name, 24), len(c.Skills), local, len(c.Skills)-local)
+		}
+		return nil
+	}
+
+	topic = strings.ToLower(topic)
+	for _, c := range cats {
+		if c.Name != topic {
+			continue
+		}
+		for _, s := range c.Skills {
+			ref := s.Name
+			if s.Source != "" {
+				ref = s.Source + "/" + s.Name
+			}
+			fmt.Printf("%s %s\n", padRight(ref, 48), statusMutedStyle.Render(s.Registry))
+		}
+		return nil
+	}
+	return fmt.Errorf("no skills tagged %q", topic)
+}
@@ -0,0 +1,24 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestTopicDirectoryMergesLocalTags(t *testing.T) {
+	setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	os.MkdirAll(filepath.Join(store.BaseDir, "go-test"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "go-test", "SKILL.md"), []byte("---\nname: go-test\ntags: [testing, golang]\n---\n"), 0644)
+
+	cats := topicDirectory(localTaggedSkills(), []Skill{{Name: "py-test", Source: "acme/py", Registry: "playbooks.com", Tags: []string{"testing"}}})
+	if len(cats) != 2 || cats[0].Name != "testing" || len(cats[0].Skills) != 2 {
+		t.Fatalf("topics = %+v, want testing with the local and the registry skill", cats)
+	}
+	if localCount(cats[0]) != 1 || cats[0].Skills[0].Name != "go-test" {
+		t.Errorf("testing = %+v, want the local skill first", cats[0].Skills)
+	}
+}
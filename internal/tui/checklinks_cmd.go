@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// markdownFiles lists the markdown files of a skill folder, SKILL.md first.
+func markdownFiles(dir string) []string {
+	files := []string{filepath.Join(dir, "SKILL.md")}
+	filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(strings.ToLower(d.Name()), ".md") && p != files[0] {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files
+}
+
+// RunCheckLinks reports dead URLs and file references in a skill's markdown.
+// target is a skill folder, an installed skill name, or an
+// owner/repo/skill reference checked before installing; for the latter only
+// SKILL.md is fetched and its relative references cannot be verified.
+func RunCheckLinks(target string) error {
+	type doc struct {
+		name, dir, content string
+	}
+	var docs []doc
+
+	dir := target
+	if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err != nil {
+		dir = filepath.Join(getSkillsPath(), target)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err == nil {
+		for _, file := range markdownFiles(dir) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			rel, _ := filepath.Rel(dir, file)
+			docs = append(docs, doc{name: rel, dir: filepath.Dir(file), content: string(data)})
+		}
+	} else {
+		source, name, _, err := parseSkillRef(target)
+		if err != nil {
+			return fmt.Errorf("%s is not a skill folder, an installed skill or an owner/repo/skill reference", target)
+		}
+		content, err := fetchSkillContent(source + "/" + name)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc{name: "SKILL.md", content: content})
+	}
+
+	var total, dead, unchecked int
+	for _, d := range docs {
+		for _, r := range skill.CheckLinks(d.dir, skill.ExtractLinks(d.content)) {
+			total++
+			switch {
+			case r.Unchecked:
+				unchecked++
+			case !r.OK:
+				dead++
+				fmt.Printf("✗ %s:%d %s (%s)\n", d.name, r.Line, r.Target, r.Status)
+			}
+		}
+	}
+
+	summary := fmt.Sprintf("Checked %d link(s) in %d file(s): %d dead", total, len(docs), dead)
+	if unchecked > 0 {
+		summary += fmt.Sprintf(", %d relative reference(s) not checked (install the skill to verify them)", unchecked)
+	}
+	fmt.Println(summary)
+	if dead > 0 {
+		return fmt.Errorf("%d dead link(s)", dead)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// cloneOps plans the changes giving dst the skills linked in src: a link for
+// each one dst lacks and, with prune, an unlink for each one src lacks.
+func cloneOps(store *skill.Store, src, dst Provider, prune bool) []applyOp {
+	want := make(map[string]bool)
+	for _, name := range listProviderSkills(src) {
+		want[name] = true
+	}
+	have := make(map[string]bool)
+	for _, name := range listProviderSkills(dst) {
+		have[name] = true
+	}
+
+	var link, unlink []string
+	for name := range want {
+		if !have[name] {
+			link = append(link, name)
+		}
+	}
+	if prune {
+		for name := range have {
+			if !want[name] {
+				unlink = append(unlink, name)
+			}
+		}
+	}
+	sort.Strings(link)
+	sort.Strings(unlink)
+
+	var ops []applyOp
+	for _, name := range link {
+		ops = append(ops, linkSkillOp(store, dst, name))
+	}
+	for _, name := range unlink {
+		ops = append(ops, unlinkSkillOp(dst, name))
+	}
+	return ops
+}
+
+// cloneProvider brings dst to parity with src and records the outcome in
+// dst's sync journal.
+func cloneProvider(store *skill.Store, src, dst Provider, prune bool) applyReport {
+	ops := cloneOps(store, src, dst, prune)
+	if len(ops) == 0 {
+		return nil
+	}
+	report := applyConcurrently(map[string][]applyOp{dst.Name: ops})
+	recordSyncOutcomes(store, reportOutcomes("clone", report))
+	return report
+}
+
+// RunCloneProvider links into dst every skill linked in src. With prune,
+// skills dst has and src does not are removed so both end up identical.
+func RunCloneProvider(srcName, dstName string, prune bool) error {
+	if srcName == dstName {
+		return fmt.Errorf("cannot clone %s into itself", srcName)
+	}
+	var src, dst *Provider
+	providers := detectProviders()
+	for i := range providers {
+		switch providers[i].Name {
+		case srcName:
+			src = &providers[i]
+		case dstName:
+			dst = &providers[i]
+		}
+	}
+	if src == nil {
+		return fmt.Errorf("unknown provider: %s", srcName)
+	}
+	if dst == nil {
+		return fmt.Errorf("unknown provider: %s", dstName)
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	store, unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	report := cloneProvider(store, *src, *dst, prune)
+	if len(report) == 0 {
+		fmt.Printf("%s already has every skill of %s.\n", dst.Name, src.Name)
+		return nil
+	}
+	for _, line := range report.lines() {
+		fmt.Println("  " + line)
+	}
+	done, skipped, failed := report.counts()
+	fmt.Printf("\n%d done, %d skipped, %d failed\n", done, skipped, failed)
+	if err := report.err(); err != nil {
+		return partialFailure(done, fmt.Errorf("clone finished with %w", err))
+	}
+	return nil
+}
+
+// cloneTargets lists the providers the selected one can be cloned into.
+func (m statusModel) cloneTargets() []Provider {
+	var targets []Provider
+	for i, p := range m.providers {
+		if i != m.selectedIdx {
+			targets = append(targets, p)
+		}
+	}
+	return targets
+}
+
+// updateClonePicker handles keys while choosing the provider to clone the
+// selected one into.
+func (m statusModel) updateClonePicker(msg tea.KeyMsg) (statusModel, tea.Cmd) {
+	targets := m.cloneTargets()
+	switch msg.String() {
+	case "up", "k":
+		if m.cloneIdx > 0 {
+			m.cloneIdx--
+		}
+	case "down", "j":
+		if m.cloneIdx < len(targets)-1 {
+			m.cloneIdx++
+		}
+	case "enter":
+		m.pickingClone = false
+		if len(targets) == 0 {
+			return m, nil
+		}
+		src, dst := m.providers[m.selectedIdx], targets[m.cloneIdx]
+		m.loading = true
+		return m, runTask("Cloning "+src.Name+" into "+dst.Name, func() tea.Msg {
+			store := skill.NewStore(getSkillsPath())
+			store, unlock, err := store.Lock()
+			if err != nil {
+				return errMsg{err: err}
+			}
+			report := cloneProvider(store, src, dst, false)
+			unlock()
+			if err := report.err(); err != nil {
+				return errMsg{err: fmt.Errorf("cloning %s into %s: %w", src.Name, dst.Name, err)}
+			}
+			return loadProviders()
+		})
+	case "esc", "q", "C":
+		m.pickingClone = false
+	}
+	return m, nil
+}
+
+// clonePickerView lists the providers the selected one can be cloned into.
+func (m statusModel) clonePickerView() string {
+	var b strings.Builder
+	src := m.providers[m.selectedIdx]
+	b.WriteString("\n")
+	b.WriteString(subtitleStyle.Render(fmt.Sprintf("Clone %s's skills into", src.Name)))
+	b.WriteString("\n\n")
+	for i, p := range m.cloneTargets() {
+		line := padRight(p.Name, 20)
+		if p.Configured {
+			line += fmt.Sprintf(" %d skills", p.SkillCount)
+		} else {
+			line += " not configured"
+		}
+		if i == m.cloneIdx {
+			b.WriteString(getSelectedRowStyle(m.width).Render("  " + line))
+		} else {
+			b.WriteString(tableRowStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(statusMutedStyle.Render(fmt.Sprintf("  Links the skills of %s that the target lacks; nothing is removed.", src.Name)))
+	b.WriteString("\n")
+	b.WriteString(renderHelpBar(m.width, []string{"[↑/↓] select", "[enter] clone", "[esc] cancel"}))
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestCloneProvider(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	for _, name := range []string{"alpha", "beta", "extra"} {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte(name), 0644)
+	}
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills")}
+	cursor := Provider{Name: "cursor", Path: filepath.Join(home, ".cursor", "skills")}
+	for _, name := range []string{"alpha", "beta"} {
+		if err := linkSkillToProvider(store, claude, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := linkSkillToProvider(store, cursor, "extra"); err != nil {
+		t.Fatal(err)
+	}
+
+	report := cloneProvider(store, claude, cursor, false)
+	if done, _, failed := report.counts(); done != 2 || failed != 0 {
+		t.Fatalf("clone = %d done, %d failed; want 2 done", done, failed)
+	}
+	got := listProviderSkills(cursor)
+	sort.Strings(got)
+	if len(got) != 3 {
+		t.Fatalf("cursor skills after clone = %v, want alpha, beta and extra", got)
+	}
+	if o, _ := loadSyncState().Providers["cursor"].last(); o.Action != "clone" || o.Added != 2 {
+		t.Errorf("cursor journal = %+v, want a clone adding 2", o)
+	}
+
+	cloneProvider(store, claude, cursor, true)
+	got = listProviderSkills(cursor)
+	sort.Strings(got)
+	if len(got) != 2 || got[0] != "alpha" || got[1] != "beta" {
+		t.Errorf("cursor skills after pruning clone = %v, want [alpha beta]", got)
+	}
+	if ops := cloneOps(store, claude, cursor, true); len(ops) != 0 {
+		t.Errorf("cloneOps after parity = %d ops, want none", len(ops))
+	}
+}
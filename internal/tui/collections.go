@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// collectionResult is the outcome of installing one skill of a collection.
+type collectionResult struct {
+	Skill  string
+	Linked []string
+	Err    error
+}
+
+// installCollection installs every skill of c and links it into targets,
+// honouring link rules. Skills already in the store are linked without
+// being downloaded again.
+func installCollection(store *skill.Store, c api.Collection, targets []Provider) []collectionResult {
+	rules := linkRules()
+	values := configTemplateValues()
+	var results []collectionResult
+	for _, s := range c.Skills {
+		res := collectionResult{Skill: s.Name}
+		if !store.IsInstalled(s.Name) {
+			if err := installSkill(store, s, "", ""); err != nil {
+				res.Err = err
+				results = append(results, res)
+				continue
+			}
+			if err := renderSkill(store, s.Name, values); err != nil {
+				res.Err = err
+				results = append(results, res)
+				continue
+			}
+		}
+		kept, _ := applyLinkRules(rules, store, s.Name, targets)
+		for _, p := range kept {
+			if p.excludes(s.Name) {
+				continue
+			}
+			if err := linkSkillToProvider(store, p, s.Name); err != nil {
+				res.Err = fmt.Errorf("%s: %w", p.Name, err)
+				continue
+			}
+			res.Linked = append(res.Linked, p.Name)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// collectionsModel lists the registries' curated collections and installs
+// a whole collection at once.
+type collectionsModel struct {
+	collections []api.Collection
+	selectedIdx int
+	nav         vimNav
+	expanded    bool // show the skills of the selected collection
+	welcome     bool // first run: offer only the starter packs
+	installing  bool
+	statusMsg   string
+	width       int
+	height      int
+	loading     bool
+	err         error
+}
+
+type collectionsLoadedMsg struct {
+	collections []api.Collection
+	err         error
+}
+
+type collectionInstalledMsg struct {
+	name    string
+	results []collectionResult
+}
+
+type openCollectionsMsg struct{}
+
+func newCollectionsModel() collectionsModel {
+	return collectionsModel{loading: true}
+}
+
+// newWelcomeModel offers the starter packs on first run.
+func newWelcomeModel() collectionsModel {
+	return collectionsModel{loading: true, welcome: true}
+}
+
+func (m collectionsModel) Init() tea.Cmd {
+	if m.welcome {
+		return func() tea.Msg {
+			return collectionsLoadedMsg{collections: api.ListPacks()}
+		}
+	}
+	return func() tea.Msg {
+		cols, err := api.ListCollections()
+		return collectionsLoadedMsg{collections: cols, err: err}
+	}
+}
+
+// visibleRows is how many collections fit on screen next to the details.
+func (m collectionsModel) visibleRows() int {
+	if m.height <= 0 {
+		return 10
+	}
+	if rows := (m.height - 14) / 2; rows > 5 {
+		return rows
+	}
+	return 5
+}
+
+func (m collectionsModel) Update(msg tea.Msg) (collectionsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case collectionsLoadedMsg:
+		m.loading = false
+		m.collections = msg.collections
+		m.err = msg.err
+
+	case collectionInstalledMsg:
+		m.installing = false
+		var failed []string
+		for _, r := range msg.results {
+			if r.Err != nil {
+				failed = append(failed, r.Skill)
+			}
+		}
+		if len(failed) > 0 {
+			m.statusMsg = fmt.Sprintf("Installed %s with %d failure(s): %s", msg.name, len(failed), strings.Join(failed, ", "))
+		} else {
+			m.statusMsg = fmt.Sprintf("Installed %d skill(s) from %s", len(msg.results), msg.name)
+		}
+
+	case tea.KeyMsg:
+		if m.installing {
+			return m, nil
+		}
+		if idx, ok := m.nav.handle(msg.String(), m.selectedIdx, len(m.collections), m.visibleRows()); ok {
+			m.selectedIdx = idx
+			return m, nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			if m.selectedIdx > 0 {
+				m.selectedIdx--
+			}
+		case "down", "j":
+			if m.selectedIdx < len(m.collections)-1 {
+				m.selectedIdx++
+			}
+		case "enter":
+			m.expanded = !m.expanded
+		case "i":
+			if len(m.collections) > 0 {
+				c := m.collections[m.selectedIdx]
+				m.installing = true
+				m.statusMsg = fmt.Sprintf("Installing %d skill(s) from %s...", len(c.Skills), c.Name)
+				return m, runTask("Installing "+c.Name, func() tea.Msg {
+					targets, _ := linkTargets(nil)
+					results := installCollection(skill.NewStore(getSkillsPath()), c, targets)
+					return collectionInstalledMsg{name: c.Name, results: results}
+				})
+			}
+		case "r":
+			m.loading = true
+			return m, m.Init()
+		}
+	}
+	return m, nil
+}
+
+func (m collectionsModel) View() string {
+	var b strings.Builder
+
+	w := m.width
+	if w <= 0 {
+		w = 80
+	}
+
+	if m.welcome {
+		b.WriteString(renderTitleBox("Welcome to efx-skills"))
+		b.WriteString("\n")
+		b.WriteString(statusMutedStyle.Render("  Start with a pack of skills for your work, installed and linked to every detected provider."))
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(renderTitleBox("Collections"))
+		b.WriteString("\n")
+	}
+
+	if m.loading {
+		b.WriteString(spinnerStyle.Render("  " + withSpinner("Loading...")))
+		return b.String()
+	}
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("  Error: %v", m.err)))
+		b.WriteString(renderErrorHint(m.err))
+		return b.String()
+	}
+	if len(m.collections) == 0 {
+		b.WriteString(statusMutedStyle.Render("  No registry publishes collections"))
+		b.WriteString(renderHelpBar(m.width, []string{"[esc] back", "[q] quit"}))
+		return b.String()
+	}
+
+	t := collectionsTable()
+	widths := t.layout(w - 4)
+	b.WriteString(getTableHeaderStyle(w).Render("  " + t.header(widths)))
+	b.WriteString("\n")
+
+	rows := m.visibleRows()
+	start := 0
+	if m.selectedIdx >= rows {
+		start = m.selectedIdx - rows + 1
+	}
+	end := min(start+rows, len(m.collections))
+	for i := start; i < end; i++ {
+		c := m.collections[i]
+		row := "  " + t.row(widths, c.Name, fmt.Sprintf("%d", len(c.Skills)), c.Registry, c.Description)
+		if i == m.selectedIdx {
+			b.WriteString(getSelectedRowStyle(w).Render(row))
+		} else {
+			b.WriteString(tableRowStyle.Render(row))
+		}
+		b.WriteString("\n")
+	}
+
+	if m.expanded {
+		c := m.collections[m.selectedIdx]
+		b.WriteString("\n")
+		b.WriteString(subtitleStyle.Render(c.Name))
+		b.WriteString("\n")
+		store := skill.NewStore(getSkillsPath())
+		for _, s := range c.Skills {
+			line := "  " + padRight(s.Name, 28) + " " + statusMutedStyle.Render(s.Source)
+			if store.IsInstalled(s.Name) {
+				line += statusOkStyle.Render("  ✓ installed")
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if m.statusMsg != "" {
+		b.WriteString("\n")
+		b.WriteString(statusMutedStyle.Render("  " + m.statusMsg))
+		b.WriteString("\n")
+	}
+	if m.welcome {
+		b.WriteString(renderHelpBar(m.width, []string{"[enter] show skills", "[i] install pack", "[up/down] navigate", "[esc] skip"}))
+		return b.String()
+	}
+	b.WriteString(renderHelpBar(m.width, []string{"[enter] show skills", "[i] install collection", "[up/down] navigate", "[r] refresh", "[esc] back", "[q] quit"}))
+	return b.String()
+}
+
+// collectionsTable lays out the collection list; the description takes the
+// remaining width and is dropped first on narrow terminals.
+func collectionsTable() table {
+	return table{gap: 2, columns: []tableColumn{
+		{Title: "Collection", Width: 28},
+		{Title: "Skills", Width: 6, Right: true},
+		{Title: "Registry", Width: 14, Priority: 2},
+		{Title: "Description", Min: 10, Priority: 1},
+	}}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunCollectionsList prints the collections published by the registries.
+func RunCollectionsList() error {
+	cols, err := api.ListCollections()
+	if err != nil {
+		return err
+	}
+	if len(cols) == 0 {
+		fmt.Println("No registry publishes collections.")
+		return nil
+	}
+	for _, c := range cols {
+		fmt.Printf("%s %s %s\n", padRight(c.Registry+"/"+c.ID, 36), padRight(fmt.Sprintf("%d skills", len(c.Skills)), 10), c.Name)
+		if c.Description != "" {
+			fmt.Printf("  %s\n", statusMutedStyle.Render(c.Description))
+		}
+	}
+	return nil
+}
+
+// RunCollectionInstall installs every skill of a collection and links them
+// to the named providers, or every configured provider when none are given.
+func RunCollectionInstall(id string, providerNames []string) error {
+	cols, err := api.ListCollections()
+	if err != nil {
+		return err
+	}
+	c, ok := api.FindCollection(cols, id)
+	if !ok {
+		return fmt.Errorf("unknown collection: %s (see efx-skills collections)", id)
+	}
+	return installCollectionReport(c, providerNames)
+}
+
+// installCollectionReport installs the skills of c, linking them to the
+// named providers, and prints a line per skill.
+func installCollectionReport(c api.Collection, providerNames []string) error {
+	targets, err := linkTargets(providerNames)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Installing %d skill(s) from %s...\n", len(c.Skills), c.Name)
+	var failed []string
+	for i, r := range installCollection(skill.NewStore(getSkillsPath()), c, targets) {
+		fmt.Printf("[%d/%d] ", i+1, len(c.Skills))
+		if r.Err != nil {
+			fmt.Printf("✗ %s: %v\n", r.Skill, r.Err)
+			failed = append(failed, r.Skill)
+			continue
+		}
+		fmt.Printf("✓ %s → %s\n", r.Skill, strings.Join(r.Linked, ", "))
+	}
+
+	installed := len(c.Skills) - len(failed)
+	fmt.Printf("\n%d installed, %d failed\n", installed, len(failed))
+	if len(failed) > 0 {
+		return partialFailure(installed, fmt.Errorf("failed to install %s", strings.Join(failed, ", ")))
+	}
+	return nil
+}
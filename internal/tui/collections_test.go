@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestInstallCollectionLinksStoredSkills(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	for _, name := range []string{"go-style", "go-test"} {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte("---\nname: "+name+"\n---\n"), 0644)
+	}
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills")}
+	c := api.Collection{Name: "Go kit", Skills: []api.Skill{
+		{Name: "go-style", Source: "acme/skills"},
+		{Name: "go-test", Source: "acme/skills"},
+	}}
+
+	results := installCollection(store, c, []Provider{claude})
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want one per skill", results)
+	}
+	for _, r := range results {
+		if r.Err != nil || len(r.Linked) != 1 || r.Linked[0] != "claude" {
+			t.Errorf("result = %+v, want linked to claude", r)
+		}
+	}
+	if got := listProviderSkills(claude); len(got) != 2 {
+		t.Errorf("claude skills = %v, want both collection skills", got)
+	}
+}
+
+func TestCollectionsView(t *testing.T) {
+	setTestHome(t)
+	m := newCollectionsModel()
+	m, _ = m.Update(collectionsLoadedMsg{collections: []api.Collection{
+		{ID: "go-kit", Name: "Go kit", Registry: "playbooks.com", Skills: []api.Skill{{Name: "go-style", Source: "acme/skills"}}},
+		{ID: "web", Name: "Web kit", Registry: "playbooks.com"},
+	}})
+	if strings.Contains(m.View(), "go-style") {
+		t.Error("collection skills shown before expanding")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.View(), "go-style") {
+		t.Error("enter did not list the collection's skills")
+	}
+}
+
+func TestFirstRunOffersStarterPacks(t *testing.T) {
+	setTestHome(t)
+	if !isFirstRun() {
+		t.Fatal("a fresh home should be a first run")
+	}
+
+	m := newWelcomeModel()
+	m, _ = m.Update(collectionsLoadedMsg{collections: []api.Collection{{ID: "go", Name: "Go developer", Registry: api.PacksRegistry}}})
+	view := m.View()
+	if !strings.Contains(view, "Welcome") || !strings.Contains(view, "Go developer") || !strings.Contains(view, "[esc] skip") {
+		t.Errorf("welcome view:\n%s", view)
+	}
+
+	if err := saveConfigData(loadOrDefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	if isFirstRun() {
+		t.Error("a saved config.json should end the first run")
+	}
+}
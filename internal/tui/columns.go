@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Optional search result columns, configurable through "result_columns" in
+// config.json. Name and source are always shown first.
+const (
+	columnInstalls    = "installs"
+	columnStars       = "stars"
+	columnRegistry    = "registry"
+	columnDescription = "description"
+)
+
+// defaultResultColumns matches the layout used before columns were configurable,
+// with the description added on wide terminals.
+var defaultResultColumns = []string{columnRegistry, columnInstalls, columnDescription}
+
+// descriptionMinWidth is the terminal width below which the description
+// column is dropped, leaving room for names and sources.
+const descriptionMinWidth = 110
+
+// fixedColumnWidths are the widths of the non-flexible columns.
+var fixedColumnWidths = map[string]int{
+	columnInstalls: 6,
+	columnStars:    6,
+	columnRegistry: 12,
+}
+
+// resultColumns returns the configured columns, ignoring unknown names and
+// duplicates. A missing setting, or one without any known column, uses
+// defaultResultColumns.
+func resultColumns(cfg *ConfigData) []string {
+	if cfg == nil || len(cfg.ResultColumns) == 0 {
+		return defaultResultColumns
+	}
+	var cols []string
+	seen := make(map[string]bool)
+	for _, c := range cfg.ResultColumns {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if seen[c] {
+			continue
+		}
+		if _, fixed := fixedColumnWidths[c]; fixed || c == columnDescription {
+			cols = append(cols, c)
+			seen[c] = true
+		}
+	}
+	if len(cols) == 0 {
+		return defaultResultColumns
+	}
+	return cols
+}
+
+// columnPriority is the order optional columns are dropped in when the
+// terminal is too narrow for them, lowest first.
+var columnPriority = map[string]int{
+	columnDescription: 1,
+	columnRegistry:    2,
+	columnStars:       3,
+	columnInstalls:    4,
+}
+
+// resultLayout holds the column widths for one terminal width.
+type resultLayout struct {
+	nameWidth   int
+	sourceWidth int
+	columns     []string
+	widths      map[string]int
+	table       table
+	candidates  []string // optional columns in table, including dropped ones
+	cellWidths  []int    // widths of table's columns: name, source, then candidates
+}
+
+// layoutResults fits name, source and the chosen columns into width w.
+func layoutResults(cols []string, w int) resultLayout {
+	if cols == nil {
+		cols = defaultResultColumns
+	}
+
+	withDescription := false
+	var candidates []string
+	for _, c := range cols {
+		if c == columnDescription {
+			if w < descriptionMinWidth {
+				continue
+			}
+			withDescription = true
+		}
+		candidates = append(candidates, c)
+	}
+
+	// Name and source share the flexible width with the description
+	name := tableColumn{Weight: 40, Min: 10}
+	source := tableColumn{Weight: 60, Min: 8}
+	if withDescription {
+		name.Weight, source.Weight = 25, 30
+	}
+	t := table{gap: 1, columns: []tableColumn{name, source}}
+	for _, c := range candidates {
+		col := tableColumn{Width: fixedColumnWidths[c], Priority: columnPriority[c]}
+		switch c {
+		case columnDescription:
+			col.Weight, col.Min = 45, 20
+		case columnInstalls, columnStars:
+			col.Right = true
+		}
+		t.columns = append(t.columns, col)
+	}
+
+	// Reserve the row padding
+	available := w - 8
+	if available < 20 {
+		available = 20
+	}
+	l := resultLayout{widths: make(map[string]int), table: t, candidates: candidates, cellWidths: t.layout(available)}
+	l.nameWidth, l.sourceWidth = l.cellWidths[0], l.cellWidths[1]
+	for i, c := range candidates {
+		if width := l.cellWidths[i+2]; width > 0 {
+			l.columns = append(l.columns, c)
+			l.widths[c] = width
+		}
+	}
+	return l
+}
+
+// formatResultRow renders one search result with the layout's columns.
+// When mark is not nil it styles the name and description, e.g. to
+// highlight the query.
+func (l resultLayout) formatResultRow(s Skill, mark func(string) string) string {
+	if mark == nil {
+		mark = func(s string) string { return s }
+	}
+	cells := []string{badgePrefix(s) + mark(s.Name), s.Source}
+	for _, c := range l.candidates {
+		switch c {
+		case columnInstalls:
+			cells = append(cells, l.installsCell(s))
+		case columnStars:
+			stars := ""
+			if s.Stars > 0 {
+				stars = fmt.Sprintf("%d*", s.Stars)
+			}
+			cells = append(cells, stars)
+		case columnRegistry:
+			cells = append(cells, registryDisplayName(s.Registry))
+		case columnDescription:
+			cells = append(cells, mark(strings.Join(strings.Fields(s.Description), " ")))
+		}
+	}
+	return l.table.row(l.cellWidths, cells...)
+}
+
+// installsCell shows the install count, falling back to stars for
+// registries without installs unless stars have a column of their own.
+func (l resultLayout) installsCell(s Skill) string {
+	switch {
+	case s.Installs >= 1000:
+		return fmt.Sprintf("%dk", s.Installs/1000)
+	case s.Installs > 0:
+		return fmt.Sprintf("%d", s.Installs)
+	}
+	if _, ok := l.widths[columnStars]; !ok && s.Stars > 0 {
+		return fmt.Sprintf("%d*", s.Stars)
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResultColumns(t *testing.T) {
+	if got := resultColumns(nil); !reflect.DeepEqual(got, defaultResultColumns) {
+		t.Errorf("resultColumns(nil) = %v, want defaults", got)
+	}
+	cfg := &ConfigData{ResultColumns: []string{"Stars", "bogus", "description", "stars"}}
+	want := []string{columnStars, columnDescription}
+	if got := resultColumns(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("resultColumns = %v, want %v", got, want)
+	}
+}
+
+func TestLayoutResultsDropsDescriptionWhenNarrow(t *testing.T) {
+	cols := []string{columnDescription, columnInstalls}
+
+	narrow := layoutResults(cols, 80)
+	if !reflect.DeepEqual(narrow.columns, []string{columnInstalls}) {
+		t.Errorf("columns at 80 = %v, want installs only", narrow.columns)
+	}
+
+	wide := layoutResults(cols, 160)
+	if !reflect.DeepEqual(wide.columns, cols) {
+		t.Errorf("columns at 160 = %v, want %v", wide.columns, cols)
+	}
+	s := Skill{Name: "demo", Source: "owner/repo", Description: "A  multi-line\ndescription", Installs: 1500}
+	row := wide.formatResultRow(s, nil)
+	if !strings.Contains(row, "A multi-line description") || !strings.HasSuffix(row, "1k") {
+		t.Errorf("row = %q, want the description before the install count", row)
+	}
+	if len([]rune(row)) > 160-8 {
+		t.Errorf("row is %d wide, want it to fit the terminal", len([]rune(row)))
+	}
+}
+
+func TestInstallsFallBackToStars(t *testing.T) {
+	s := Skill{Name: "demo", Stars: 42}
+	if got := layoutResults([]string{columnInstalls}, 80).installsCell(s); got != "42*" {
+		t.Errorf("installsCell = %q, want stars fallback", got)
+	}
+	if got := layoutResults([]string{columnInstalls, columnStars}, 80).installsCell(s); got != "" {
+		t.Errorf("installsCell = %q, want empty with a stars column", got)
+	}
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// expandHome resolves a leading "~/" against $HOME.
+func expandHome(path string) string {
+	if path == "~" {
+		return os.Getenv("HOME")
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(os.Getenv("HOME"), path[2:])
+	}
+	return path
+}
+
+// composeTargets returns the single-file targets configured in config.json.
+func composeTargets() []ComposeTarget {
+	cfg := loadConfigFromFile()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Compose
+}
+
+// regenerateComposed rewrites every compose target whose sources changed,
+// returning a line per target for reporting.
+func regenerateComposed(store *skill.Store, targets []ComposeTarget) ([]string, error) {
+	var report []string
+	var failed int
+	for _, t := range targets {
+		output := expandPath(t.Output)
+		written, err := store.WriteComposed(output, t.Skills)
+		switch {
+		case err != nil:
+			failed++
+			line := fmt.Sprintf("✗ %s: %v", t.Name, err)
+			if hint := errs.Hint(err); hint != "" {
+				line += " (" + hint + ")"
+			}
+			report = append(report, line)
+		case written:
+			report = append(report, fmt.Sprintf("✓ %s: regenerated %s (%d skills)", t.Name, output, len(t.Skills)))
+		default:
+			report = append(report, fmt.Sprintf("· %s: %s is up to date", t.Name, output))
+		}
+	}
+	if failed > 0 {
+		return report, fmt.Errorf("%d composed file(s) failed", failed)
+	}
+	return report, nil
+}
+
+// RunCompose regenerates the configured single-file instruction targets.
+func RunCompose() error {
+	targets := composeTargets()
+	if len(targets) == 0 {
+		fmt.Println("No compose targets configured (add \"compose\" entries to config.json).")
+		return nil
+	}
+	report, err := regenerateComposed(skill.NewStore(getSkillsPath()), targets)
+	for _, line := range report {
+		fmt.Println(line)
+	}
+	return err
+}
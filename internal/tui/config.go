@@ -4,20 +4,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/config"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
 )
 
 // Registry represents a skill registry
 type Registry struct {
-	Name    string `json:"name"`
-	URL     string `json:"url"`
-	Enabled bool   `json:"enabled"`
+	Name    string   `json:"name"`
+	URL     string   `json:"url"`
+	Enabled bool     `json:"enabled"`
+	Mirrors []string `json:"mirrors,omitempty"` // API base URLs tried in order when the registry fails
+	API     string   `json:"api,omitempty"`     // API a custom registry serves: "skills.sh" (default) or "playbooks.com"
+	Token   string   `json:"token,omitempty"`   // "$VAR" reading the bearer token from the environment; plaintext is ignored, use "efx-skills auth set"
 }
 
 // RepoSource represents a custom GitHub repo source
@@ -25,10 +31,15 @@ type RepoSource struct {
 	Owner string `json:"owner"`
 	Repo  string `json:"repo"`
 	URL   string `json:"url"`
+	Host  string `json:"host,omitempty"` // GitHub Enterprise host, e.g. github.mycorp.com; "" uses github_host
 }
 
-// DeriveURL returns the GitHub URL for this repo, derived from owner/repo.
+// DeriveURL returns the GitHub URL for this repo, derived from owner/repo
+// and its host.
 func (r RepoSource) DeriveURL() string {
+	if r.Host != "" {
+		return fmt.Sprintf("https://%s/%s/%s", strings.TrimPrefix(r.Host, "https://"), r.Owner, r.Repo)
+	}
 	return fmt.Sprintf("https://github.com/%s/%s", r.Owner, r.Repo)
 }
 
@@ -42,13 +53,54 @@ type SkillMeta struct {
 	Installed string `json:"installed,omitempty"`
 }
 
+// CustomProvider describes a user-defined provider, or overrides the path,
+// hook or link mode of a built-in provider with the same name.
+type CustomProvider struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path,omitempty"`
+	Hook     string   `json:"hook,omitempty"`      // external command handling link/unlink/list
+	LinkMode string   `json:"link_mode,omitempty"` // "symlink" (default), "copy", "hardlink" or "portable"
+	Exclude  []string `json:"exclude,omitempty"`   // skill name globs never linked into the provider
+	Frozen   bool     `json:"frozen,omitempty"`    // never change the provider, e.g. when another tool manages it
+}
+
+// ComposeTarget is a provider that reads a single instructions file: the
+// listed skills are concatenated, in order, into Output.
+type ComposeTarget struct {
+	Name   string   `json:"name"`
+	Output string   `json:"output"`
+	Skills []string `json:"skills"`
+}
+
+// Budget caps what a provider should load; zero fields are unlimited.
+type Budget struct {
+	MaxSkills int   `json:"max_skills,omitempty"`
+	MaxTokens int   `json:"max_tokens,omitempty"`
+	MaxBytes  int64 `json:"max_bytes,omitempty"`
+}
+
 // ConfigData represents the persistent configuration
 type ConfigData struct {
-	Registries []Registry   `json:"registries"`
-	Repos      []RepoSource `json:"repos"`
-	Providers  []string     `json:"enabled_providers"`
-	SkillsPath string       `json:"skills-path"`
-	Skills     []SkillMeta  `json:"skills"`
+	Registries      []Registry        `json:"registries"`
+	Repos           []RepoSource      `json:"repos"`
+	Providers       []string          `json:"enabled_providers"`
+	SkillsPath      string            `json:"skills-path"`
+	Skills          []SkillMeta       `json:"skills"`
+	CustomProviders []CustomProvider  `json:"custom_providers,omitempty"`
+	Variables       map[string]string `json:"variables,omitempty"` // values for {{NAME}} placeholders in skills
+	Compose         []ComposeTarget   `json:"compose,omitempty"`
+	Budgets         map[string]Budget `json:"budgets,omitempty"`         // keyed by provider name
+	RestoreSession  bool              `json:"restore_session,omitempty"` // reopen the last view on launch
+	ResultColumns   []string          `json:"result_columns,omitempty"`  // search result columns, in order
+	GroupResults    bool              `json:"group_results,omitempty"`   // list search results under a header per registry
+	Ignore          []string          `json:"ignore,omitempty"`          // extra glob patterns skipped when scanning folders
+	GitHubToken     string            `json:"github_token,omitempty"`    // token for private repos, as "$VAR" to read it from the environment; plaintext is ignored, use "efx-skills auth set github"
+	GitHubHost      string            `json:"github_host,omitempty"`     // GitHub Enterprise host of every repo, e.g. github.mycorp.com
+	RegistryMirror  string            `json:"registry_mirror,omitempty"` // directory keeping registry responses for offline use
+	LinkRules       []LinkRule        `json:"link_rules,omitempty"`      // provider links applied on install and sync
+	LicensePolicy   LicensePolicy     `json:"license_policy,omitempty"`  // licenses blocked or warned about on install
+	Autosave        bool              `json:"autosave,omitempty"`        // save config view changes as they are made
+	Backups         int               `json:"backups,omitempty"`         // timestamped backups kept of config.json and the lock file; -1 keeps none
 }
 
 // configModel handles the config view
@@ -60,11 +112,27 @@ type configModel struct {
 	skillsPath  string
 	section     int // 0=registries, 1=repos, 2=providers
 	selectedIdx int
+	nav         vimNav
 	width       int
 	addingRepo  bool
 	textInput   textinput.Model
 	dirty       bool // track unsaved changes
+	autosave    bool // save every change right away
 	err         error
+
+	confirmingExit bool // asking whether to save before leaving
+
+	// Validation of the repo being added
+	validatingRepo *RepoSource // repo being checked on GitHub
+	repoErr        error       // why the typed repo was rejected
+	rejectedRepo   string      // input that failed the check, added anyway on a second enter
+	repoNotice     string      // result of the last add
+
+	// Repos suggested from GitHub topics
+	suggesting     bool
+	suggestLoading bool
+	suggestions    []skill.TopicRepo
+	suggestIdx     int
 }
 
 // registryDisplayName returns a friendly label for a registry.
@@ -82,17 +150,85 @@ func registryDisplayName(name string) string {
 	}
 }
 
+// registryHealth summarises how the registries requested this session
+// answered, e.g. "skills.sh: 180ms avg · playbooks.com: 2 failures", to
+// help decide which ones to disable. It is "" before any request.
+func registryHealth() string {
+	var parts []string
+	for _, s := range api.SessionStats() {
+		parts = append(parts, s.Registry+": "+s.Summary())
+	}
+	return strings.Join(parts, " · ")
+}
+
 type configSavedMsg struct{}
 
+// defaultBackupsKept is how many timestamped backups of config.json and
+// the lock file are kept when the config does not say.
+const defaultBackupsKept = 5
+
+// LoadIgnoreRules applies the "ignore" patterns from config.json on top of
+// the built-in ones, along with the GitHub and registry tokens,
+// the GitHub Enterprise hosts repos live on, the registries searched and
+// their mirrors, and the number of backups kept on each write. It is called once before any
+// command runs.
+func LoadIgnoreRules() {
+	cfg := loadConfigFromFile()
+	fsutil.SetBackupsKept(backupsKept(cfg))
+	if cfg != nil {
+		provider.SetIgnore(cfg.Ignore)
+		skill.SetGitHubToken(cfg.GitHubToken)
+		if cfg.GitHubToken != "" && !strings.HasPrefix(cfg.GitHubToken, "$") {
+			fmt.Fprintln(os.Stderr, "warning: the plaintext github_token in config.json is ignored; move it to the keychain with: efx-skills auth migrate")
+		}
+		skill.SetGitHubHost(cfg.GitHubHost)
+		for _, r := range cfg.Repos {
+			if r.Host != "" {
+				skill.SetRepoHost(r.Owner, r.Repo, r.Host)
+			}
+		}
+		for _, r := range cfg.Registries {
+			api.SetMirrors(r.Name, r.Mirrors)
+			api.SetRegistryToken(r.Name, r.Token)
+			if r.Token != "" && !strings.HasPrefix(r.Token, "$") {
+				fmt.Fprintf(os.Stderr, "warning: the plaintext token of registry %s in config.json is ignored; move it to the keychain with: efx-skills auth migrate\n", r.Name)
+			}
+		}
+		api.SetRegistries(registryEndpoints(cfg.Registries))
+		if cfg.RegistryMirror != "" {
+			api.SetMirrorDir(expandPath(cfg.RegistryMirror))
+		}
+	}
+}
+
+// registryEndpoints returns the registries to search. An unset
+// "registries" (nil) keeps the built-in ones; an empty list searches none.
+func registryEndpoints(list []Registry) []api.RegistryEndpoint {
+	if list == nil {
+		return nil
+	}
+	endpoints := make([]api.RegistryEndpoint, 0, len(list))
+	for _, r := range list {
+		endpoints = append(endpoints, api.RegistryEndpoint{Name: r.Name, URL: r.URL, API: r.API, Enabled: r.Enabled})
+	}
+	return endpoints
+}
+
+// backupsKept reads the "backups" setting: unset means the default and a
+// negative value turns the timestamped backups off.
+func backupsKept(cfg *ConfigData) int {
+	if cfg == nil || cfg.Backups == 0 {
+		return defaultBackupsKept
+	}
+	return max(cfg.Backups, 0)
+}
+
 func defaultSkillsPath() string {
-	return filepath.Join(os.Getenv("HOME"), ".agents", "skills")
+	return workspaceStorePath(activeWorkspace)
 }
 
 func loadConfigFromFile() *ConfigData {
-	home := os.Getenv("HOME")
-	configFile := filepath.Join(home, ".config", "efx-skills", "config.json")
-
-	data, err := os.ReadFile(configFile)
+	data, err := os.ReadFile(configFilePath())
 	if err != nil {
 		return nil
 	}
@@ -146,9 +282,11 @@ func newConfigModel() configModel {
 	repos := defaultRepos()
 	skills := []SkillMeta{}
 	skillsPath := defaultSkillsPath()
+	autosave := false
 
 	if cfg != nil {
-		if len(cfg.Registries) > 0 {
+		autosave = cfg.Autosave
+		if cfg.Registries != nil {
 			registries = cfg.Registries
 		}
 		if cfg.Repos != nil {
@@ -168,47 +306,51 @@ func newConfigModel() configModel {
 		providers:  detectProviders(), // detectProviders already respects config enabled state
 		skills:     skills,
 		skillsPath: skillsPath,
+		autosave:   autosave,
 		textInput:  ti,
 	}
 }
 
+// markDirty records an edit, returning the save when autosave is on.
+func (m *configModel) markDirty() tea.Cmd {
+	m.dirty = true
+	if m.autosave {
+		return m.saveConfig
+	}
+	return nil
+}
+
 func (m configModel) Init() tea.Cmd {
 	return nil
 }
 
 func (m configModel) Update(msg tea.Msg) (configModel, tea.Cmd) {
-	var cmd tea.Cmd
+	if m.confirmingExit {
+		return m.updateExitPrompt(msg)
+	}
+	if m.suggesting {
+		return m.updateSuggestions(msg)
+	}
 
 	// Handle text input mode
 	if m.addingRepo {
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
-			switch msg.String() {
-			case "enter":
-				// Parse and add repo
-				input := m.textInput.Value()
-				if parts := strings.Split(input, "/"); len(parts) == 2 {
-					m.repos = append(m.repos, RepoSource{Owner: parts[0], Repo: parts[1]})
-					m.dirty = true
-				}
-				m.addingRepo = false
-				m.textInput.Reset()
-				return m, nil
-			case "esc":
-				m.addingRepo = false
-				m.textInput.Reset()
-				return m, nil
-			}
-		}
-		m.textInput, cmd = m.textInput.Update(msg)
-		return m, cmd
+		return m.updateAddRepo(msg)
 	}
 
 	switch msg := msg.(type) {
 	case configSavedMsg:
 		m.dirty = false
+		m.err = nil
+
+	case errMsg:
+		m.err = msg.err
 
 	case tea.KeyMsg:
+		rows := m.getMaxIndex() + 1
+		if idx, ok := m.nav.handle(msg.String(), m.selectedIdx, rows, rows); ok {
+			m.selectedIdx = idx
+			return m, nil
+		}
 		switch msg.String() {
 		case "tab":
 			m.section = (m.section + 1) % 3
@@ -227,14 +369,20 @@ func (m configModel) Update(msg tea.Msg) (configModel, tea.Cmd) {
 			}
 		case " ":
 			m.toggleItem()
-			m.dirty = true
+			return m, m.markDirty()
 		case "a":
 			// Add repo (only in repos section)
 			if m.section == 1 {
 				m.addingRepo = true
+				m.repoNotice = ""
 				m.textInput.Focus()
 				return m, textinput.Blink
 			}
+		case "D":
+			// Discover repos by GitHub topic (only in repos section)
+			if m.section == 1 {
+				return m.openSuggestions()
+			}
 		case "d", "r":
 			// Delete/remove repo (only in repos section)
 			if m.section == 1 && len(m.repos) > 0 {
@@ -242,11 +390,17 @@ func (m configModel) Update(msg tea.Msg) (configModel, tea.Cmd) {
 				if m.selectedIdx >= len(m.repos) && m.selectedIdx > 0 {
 					m.selectedIdx = len(m.repos) - 1
 				}
-				m.dirty = true
+				return m, m.markDirty()
 			}
 		case "s":
 			// Save config
 			return m, m.saveConfig
+		case "q", "esc":
+			// Reached only with unsaved changes; the app handles clean exits
+			if m.guardsExit(msg.String()) {
+				m.confirmingExit = true
+				return m, nil
+			}
 		case "o":
 			// Open registry or repo URL in browser
 			switch m.section {
@@ -307,11 +461,7 @@ func (m *configModel) toggleItem() {
 }
 
 func (m configModel) saveConfig() tea.Msg {
-	home := os.Getenv("HOME")
-	configDir := filepath.Join(home, ".config", "efx-skills")
-	os.MkdirAll(configDir, 0755)
-
-	configFile := filepath.Join(configDir, "config.json")
+	configFile := configFilePath()
 
 	// Collect enabled providers
 	var enabledProviders []string
@@ -341,20 +491,18 @@ func (m configModel) saveConfig() tea.Msg {
 		skillsPath = defaultSkillsPath()
 	}
 
-	data := ConfigData{
-		Registries: m.registries,
-		Repos:      repos,
-		Providers:  enabledProviders,
-		SkillsPath: skillsPath,
-		Skills:     skills,
-	}
-
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return errMsg{err: err}
+	// Start from the file on disk so settings this view does not edit survive
+	data := ConfigData{}
+	if existing := loadConfigFromFile(); existing != nil {
+		data = *existing
 	}
+	data.Registries = m.registries
+	data.Repos = repos
+	data.Providers = enabledProviders
+	data.SkillsPath = skillsPath
+	data.Skills = skills
 
-	if err := os.WriteFile(configFile, jsonData, 0644); err != nil {
+	if err := config.WriteJSON(configFile, data); err != nil {
 		return errMsg{err: err}
 	}
 
@@ -377,6 +525,15 @@ func (m configModel) View() string {
 	}
 	b.WriteString(renderTitleBox(title))
 	b.WriteString("\n")
+	if m.confirmingExit {
+		b.WriteString(m.exitPromptView())
+		return b.String()
+	}
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("  Save failed: %v", m.err)))
+		b.WriteString(renderErrorHint(m.err))
+		b.WriteString("\n")
+	}
 
 	// Section box width (account for border chars: 2 per side + 1 padding each side)
 	sectionW := w - 4
@@ -395,24 +552,28 @@ func (m configModel) View() string {
 
 	boldURLStyle := lipgloss.NewStyle().Bold(true)
 
+	// Rows leave room for the section border and padding
+	regTable := checklistTable(18)
+	regWidths := regTable.layout(sectionW - 4)
 	for i, reg := range m.registries {
 		checkbox := "[ ]"
 		if reg.Enabled {
 			checkbox = "[x]"
 		}
-		nameWidth := 18
-		urlWidth := sectionW - nameWidth - 8
 		displayName := registryDisplayName(reg.Name)
 
 		if m.section == 0 && i == m.selectedIdx {
-			line := fmt.Sprintf("%s %-*s %s", checkbox, nameWidth, displayName, truncateStr(reg.URL, urlWidth))
+			line := regTable.row(regWidths, checkbox, displayName, reg.URL)
 			regContent.WriteString(getSelectedRowStyle(sectionW).Render(line))
 		} else {
-			line := fmt.Sprintf("%s %-*s %s", checkbox, nameWidth, displayName, boldURLStyle.Render(truncateStr(reg.URL, urlWidth)))
+			line := regTable.row(regWidths, checkbox, displayName, boldURLStyle.Render(reg.URL))
 			regContent.WriteString(tableRowStyle.Render(line))
 		}
 		regContent.WriteString("\n")
 	}
+	if health := registryHealth(); health != "" {
+		regContent.WriteString(statusMutedStyle.Render("This session: "+health) + "\n")
+	}
 
 	if m.section == 0 {
 		b.WriteString(configSectionActiveStyle.Width(sectionW).Render(regContent.String()))
@@ -430,9 +591,10 @@ func (m configModel) View() string {
 	}
 	reposContent.WriteString("\n")
 
-	ownerWidth := 16
+	repoTable := table{gap: 1, columns: []tableColumn{{Width: 16}, {Min: 8}}}
+	repoWidths := repoTable.layout(sectionW - 6)
 	for i, repo := range m.repos {
-		line := fmt.Sprintf("  %-*s %s", ownerWidth, repo.Owner, repo.Repo)
+		line := "  " + repoTable.row(repoWidths, repo.Owner, repo.Repo)
 
 		if m.section == 1 && i == m.selectedIdx {
 			reposContent.WriteString(getSelectedRowStyle(sectionW).Render(line))
@@ -442,11 +604,17 @@ func (m configModel) View() string {
 		reposContent.WriteString("\n")
 	}
 
-	// Show add repo input or hint
-	if m.addingRepo {
-		reposContent.WriteString(fmt.Sprintf("  Add: %s\n", m.textInput.View()))
+	// Show discovered repos, add repo input or hint
+	if m.suggesting {
+		reposContent.WriteString(m.suggestionsView(sectionW))
+	} else if m.addingRepo {
+		reposContent.WriteString(m.repoInputView())
 	} else if m.section == 1 {
-		hints := "  [a] add repo"
+		if m.repoNotice != "" {
+			reposContent.WriteString(statusOkStyle.Render("  " + m.repoNotice))
+			reposContent.WriteString("\n")
+		}
+		hints := "  [a] add repo  [D] discover repos"
 		if len(m.repos) > 0 {
 			hints += "  [r] remove repo"
 		}
@@ -470,21 +638,19 @@ func (m configModel) View() string {
 	}
 	provContent.WriteString("\n")
 
-	// Dynamic column widths for providers
-	providerNameWidth := 14
-	pathWidth := sectionW - providerNameWidth - 8
-
+	provTable := checklistTable(14)
+	provWidths := provTable.layout(sectionW - 4)
 	for i, p := range m.providers {
 		checkbox := "[ ]"
 		if p.Configured {
 			checkbox = "[x]"
 		}
-		line := fmt.Sprintf("%s %-*s %s", checkbox, providerNameWidth, p.Name, p.Path)
 
 		if m.section == 2 && i == m.selectedIdx {
+			line := provTable.row(provWidths, checkbox, p.Name, p.Path)
 			provContent.WriteString(getSelectedRowStyle(sectionW).Render(line))
 		} else {
-			line = fmt.Sprintf("%s %-*s %s", checkbox, providerNameWidth, p.Name, statusMutedStyle.Render(truncateStr(p.Path, pathWidth)))
+			line := provTable.row(provWidths, checkbox, p.Name, statusMutedStyle.Render(p.Path))
 			provContent.WriteString(tableRowStyle.Render(line))
 		}
 		provContent.WriteString("\n")
@@ -497,34 +663,44 @@ func (m configModel) View() string {
 	}
 
 	// Help
-	helpItems := []string{"[tab] section", "[space] toggle", "[o] open", "[s] save", "[esc] back", "[q] quit"}
+	save := "[s] save"
+	if m.autosave {
+		save = "autosave on"
+	}
+	helpItems := []string{"[tab] section", "[space] toggle", "[o] open", save, "[esc] back", "[q] quit"}
 	if m.section == 1 {
-		helpItems = []string{"[tab] section", "[o] open", "[s] save", "[esc] back", "[q] quit"}
+		helpItems = []string{"[tab] section", "[o] open", save, "[esc] back", "[q] quit"}
 	}
 	b.WriteString(renderHelpBar(m.width, helpItems))
 
 	return b.String()
 }
 
-func truncateStr(s string, maxLen int) string {
-	if maxLen <= 0 || len(s) <= maxLen {
-		return s
+// checklistTable lays out the config sections: a checkbox, a name
+// nameWidth wide and a value taking the remaining width.
+func checklistTable(nameWidth int) table {
+	return table{gap: 1, columns: []tableColumn{{Width: 3}, {Width: nameWidth}, {Min: 8}}}
+}
+
+// loadOrDefaultConfig returns config.json, or the defaults the config view
+// starts from when there is none yet.
+func loadOrDefaultConfig() *ConfigData {
+	if cfg := loadConfigFromFile(); cfg != nil {
+		return cfg
 	}
-	if maxLen <= 3 {
-		return s[:maxLen]
+	return &ConfigData{
+		Registries: defaultRegistries(),
+		Repos:      defaultRepos(),
+		SkillsPath: defaultSkillsPath(),
+		Skills:     []SkillMeta{},
 	}
-	return s[:maxLen-3] + "..."
 }
 
 // saveConfigData writes a ConfigData to ~/.config/efx-skills/config.json.
 // It creates the config directory if it does not exist, ensures Skills is []
 // not null in the output JSON, and defaults SkillsPath if empty.
 func saveConfigData(cfg *ConfigData) error {
-	home := os.Getenv("HOME")
-	configDir := filepath.Join(home, ".config", "efx-skills")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("creating config directory: %w", err)
-	}
+	configFile := configFilePath()
 
 	// Ensure Skills is empty slice, not nil
 	if cfg.Skills == nil {
@@ -536,32 +712,44 @@ func saveConfigData(cfg *ConfigData) error {
 		cfg.SkillsPath = defaultSkillsPath()
 	}
 
-	jsonData, err := json.MarshalIndent(cfg, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshaling config: %w", err)
-	}
+	return config.WriteJSON(configFile, cfg)
+}
 
-	configFile := filepath.Join(configDir, "config.json")
-	if err := os.WriteFile(configFile, jsonData, 0644); err != nil {
-		return fmt.Errorf("writing config file: %w", err)
+// setProviderEnabled adds name to, or removes it from, the enabled
+// providers in config.json, creating the file if needed. A config that
+// never listed providers first lists those detected as configured, so they
+// stay enabled.
+func setProviderEnabled(name string, enabled bool) error {
+	cfg := loadOrDefaultConfig()
+	if len(cfg.Providers) == 0 {
+		for _, p := range detectProviders() {
+			if p.Configured && !providerListContains(cfg.Providers, p.Name) {
+				cfg.Providers = append(cfg.Providers, p.Name)
+			}
+		}
 	}
-
-	return nil
+	listed := providerListContains(cfg.Providers, name)
+	switch {
+	case enabled && !listed:
+		cfg.Providers = append(cfg.Providers, name)
+	case !enabled:
+		// An empty list, unlike a missing one, disables every provider
+		kept := []string{}
+		for _, p := range cfg.Providers {
+			if p != name {
+				kept = append(kept, p)
+			}
+		}
+		cfg.Providers = kept
+	}
+	return saveConfigData(cfg)
 }
 
 // addSkillToConfig appends a SkillMeta to the config.json skills array.
 // It is idempotent: duplicate entries (same Owner AND Name) are not added.
 // If no config file exists, a new one is created with defaults.
 func addSkillToConfig(meta SkillMeta) error {
-	cfg := loadConfigFromFile()
-	if cfg == nil {
-		cfg = &ConfigData{
-			Registries: defaultRegistries(),
-			Repos:      defaultRepos(),
-			SkillsPath: defaultSkillsPath(),
-			Skills:     []SkillMeta{},
-		}
-	}
+	cfg := loadOrDefaultConfig()
 
 	// Check for duplicate by Owner+Name
 	for _, existing := range cfg.Skills {
@@ -594,12 +782,12 @@ func removeSkillFromConfig(skillName string) error {
 }
 
 // skillMetaFromAPISkill constructs a SkillMeta from an api.Skill.
-// Maps: Owner=Source, Name=Name, Registry=Registry, URL=https://github.com/{Source}.
+// Maps: Owner=Source, Name=Name, Registry=Registry, URL=the GitHub page of Source.
 func skillMetaFromAPISkill(s api.Skill) SkillMeta {
 	return SkillMeta{
 		Owner:    s.Source,
 		Name:     s.Name,
 		Registry: s.Registry,
-		URL:      fmt.Sprintf("https://github.com/%s", s.Source),
+		URL:      skill.WebURL(s.Source),
 	}
 }
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"testing"
+)
+
+func TestAutosaveWritesToggles(t *testing.T) {
+	setTestHome(t)
+	if err := saveConfigData(&ConfigData{Registries: defaultRegistries(), Autosave: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newConfigModel()
+	if !m.autosave {
+		t.Fatal("autosave not read from config")
+	}
+	m, cmd := m.Update(key(" "))
+	if cmd == nil {
+		t.Fatal("toggle with autosave returned no save")
+	}
+	m, _ = m.Update(cmd())
+	if m.dirty {
+		t.Error("config still dirty after the autosave")
+	}
+	cfg := loadConfigFromFile()
+	if cfg == nil || cfg.Registries[0].Enabled == defaultRegistries()[0].Enabled {
+		t.Errorf("registries = %+v, want the first one toggled on disk", cfg.Registries)
+	}
+	if !cfg.Autosave {
+		t.Error("saving dropped the autosave option")
+	}
+}
+
+func TestWithoutAutosaveTogglesWaitForSave(t *testing.T) {
+	setTestHome(t)
+	m := newConfigModel()
+	m, cmd := m.Update(key(" "))
+	if cmd != nil || !m.dirty {
+		t.Errorf("cmd = %v, dirty = %v; want an unsaved change", cmd != nil, m.dirty)
+	}
+	if loadConfigFromFile() != nil {
+		t.Error("toggle wrote the config without autosave")
+	}
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/fsutil"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunConfigRestoreBackup lists the timestamped backups of config.json, or
+// of the lock file with lock, newest first. With n it restores the nth
+// newest; the version it replaces becomes a backup in turn, so a restore
+// can be undone the same way.
+func RunConfigRestoreBackup(n int, lock bool) error {
+	store := skill.NewStore(getSkillsPath())
+	path := configFilePath()
+	if lock {
+		path = store.LockFile
+	}
+	backups, err := fsutil.Backups(path)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		fmt.Printf("No backups of %s yet.\n", displayPath(path))
+		return nil
+	}
+
+	if n == 0 {
+		fmt.Printf("Backups of %s:\n", displayPath(path))
+		for i, b := range backups {
+			fmt.Printf("  %d  %s  (%s)\n", i+1, b.Time.Format("2006-01-02 15:04:05"), formatAgo(b.Time, time.Now()))
+		}
+		fmt.Println("\nRestore one with: efx-skills config restore-backup <n>")
+		return nil
+	}
+	if n < 1 || n > len(backups) {
+		return fmt.Errorf("no backup %d; %s has %d", n, displayPath(path), len(backups))
+	}
+
+	data, err := os.ReadFile(backups[n-1].Path)
+	if err != nil {
+		return err
+	}
+	if lock {
+		_, unlock, err := store.Lock()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	if err := fsutil.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Restored %s from %s\n", displayPath(path), backups[n-1].Time.Format("2006-01-02 15:04:05"))
+	return nil
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/fsutil"
+)
+
+func TestBackupsKept(t *testing.T) {
+	for _, tt := range []struct {
+		cfg  *ConfigData
+		want int
+	}{
+		{nil, defaultBackupsKept},
+		{&ConfigData{}, defaultBackupsKept},
+		{&ConfigData{Backups: 12}, 12},
+		{&ConfigData{Backups: -1}, 0},
+	} {
+		if got := backupsKept(tt.cfg); got != tt.want {
+			t.Errorf("backupsKept(%+v) = %d, want %d", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestRestoreConfigBackup(t *testing.T) {
+	setTestHome(t)
+	fsutil.SetBackupsKept(3)
+	t.Cleanup(func() { fsutil.SetBackupsKept(0) })
+
+	for _, owner := range []string{"first", "second", "third"} {
+		if err := saveConfigData(&ConfigData{Repos: []RepoSource{{Owner: owner, Repo: "skills"}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Newest backup is the version "third" replaced
+	if err := RunConfigRestoreBackup(1, false); err != nil {
+		t.Fatalf("RunConfigRestoreBackup error: %v", err)
+	}
+	if cfg := loadConfigFromFile(); cfg.Repos[0].Owner != "second" {
+		t.Fatalf("restored repos = %+v, want second", cfg.Repos)
+	}
+
+	// The restore itself is undoable
+	if err := RunConfigRestoreBackup(1, false); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := loadConfigFromFile(); cfg.Repos[0].Owner != "third" {
+		t.Errorf("after undo repos = %+v, want third", cfg.Repos)
+	}
+
+	if err := RunConfigRestoreBackup(9, false); err == nil {
+		t.Error("restoring a missing backup succeeded")
+	}
+}
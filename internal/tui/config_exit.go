@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// leaveConfigMsg returns from the config view to the status view once
+// unsaved changes were saved or discarded.
+type leaveConfigMsg struct{}
+
+func leaveConfig() tea.Msg {
+	return leaveConfigMsg{}
+}
+
+// guardsExit reports whether leaving the config view with key must first
+// ask what to do with unsaved changes.
+func (m configModel) guardsExit(key string) bool {
+	return m.dirty && (key == "q" || key == "esc")
+}
+
+// updateExitPrompt handles the save/discard/cancel prompt shown when
+// leaving the config view with unsaved changes.
+func (m configModel) updateExitPrompt(msg tea.Msg) (configModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "s", "y", "enter":
+			m.confirmingExit = false
+			save := m.saveConfig
+			return m, func() tea.Msg {
+				if msg, ok := save().(configSavedMsg); !ok {
+					return msg
+				}
+				return leaveConfigMsg{}
+			}
+		case "d", "n":
+			m.confirmingExit = false
+			m.dirty = false
+			return m, leaveConfig
+		case "c", "esc":
+			m.confirmingExit = false
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// exitPromptView is the modal asking what to do with unsaved changes.
+func (m configModel) exitPromptView() string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(warning).
+		Padding(1, 2)
+	body := statusWarnStyle.Render("Unsaved changes") + "\n\n" +
+		"Save the configuration before leaving?\n\n" +
+		statusMutedStyle.Render(fmt.Sprintf("%s  %s  %s", "[s] save", "[d] discard", "[esc] cancel"))
+	return "\n" + box.Render(body) + "\n"
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func key(s string) tea.KeyMsg {
+	if s == "esc" {
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestLeavingDirtyConfigAsksFirst(t *testing.T) {
+	setTestHome(t)
+	app := initialModel()
+	app.state = viewConfig
+	app.configModel = newConfigModel()
+	app.configModel.dirty = true
+
+	next, _ := app.Update(key("q"))
+	app = next.(model)
+	if app.state != viewConfig || !app.configModel.confirmingExit {
+		t.Fatalf("state = %v, confirming = %v; want the save prompt", app.state, app.configModel.confirmingExit)
+	}
+
+	// esc cancels and keeps the changes
+	next, _ = app.Update(key("esc"))
+	app = next.(model)
+	if app.state != viewConfig || app.configModel.confirmingExit || !app.configModel.dirty {
+		t.Fatal("esc did not cancel the prompt")
+	}
+
+	// Discarding leaves without writing the config
+	next, _ = app.Update(key("esc"))
+	app = next.(model)
+	next, cmd := app.Update(key("d"))
+	app = next.(model)
+	if cmd == nil {
+		t.Fatal("discard returned no command")
+	}
+	next, _ = app.Update(cmd())
+	app = next.(model)
+	if app.state != viewStatus {
+		t.Errorf("state = %v, want status after discarding", app.state)
+	}
+	if loadConfigFromFile() != nil {
+		t.Error("discarding wrote the config")
+	}
+}
+
+func TestSaveFromExitPrompt(t *testing.T) {
+	setTestHome(t)
+	m := newConfigModel()
+	m.repos = append(m.repos, RepoSource{Owner: "acme", Repo: "tools"})
+	m.dirty = true
+
+	m, _ = m.Update(key("q"))
+	m, cmd := m.Update(key("s"))
+	if cmd == nil {
+		t.Fatal("save returned no command")
+	}
+	if _, ok := cmd().(leaveConfigMsg); !ok {
+		t.Fatal("saving did not leave the config view")
+	}
+	cfg := loadConfigFromFile()
+	if cfg == nil || len(cfg.Repos) == 0 || cfg.Repos[len(cfg.Repos)-1].Owner != "acme" {
+		t.Errorf("saved config = %+v, want acme/tools", cfg)
+	}
+}
+
+func TestCleanConfigLeavesDirectly(t *testing.T) {
+	setTestHome(t)
+	app := initialModel()
+	app.state = viewConfig
+	app.configModel = newConfigModel()
+
+	next, _ := app.Update(key("esc"))
+	if next.(model).state != viewStatus {
+		t.Error("clean config view did not return to status")
+	}
+}
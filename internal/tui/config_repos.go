@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// discoverRepoSkills is a variable so tests can stub GitHub.
+var discoverRepoSkills = skill.DiscoverSkills
+
+// repoValidatedMsg reports whether a repo typed in the config view exists
+// and holds skills.
+type repoValidatedMsg struct {
+	repo   RepoSource
+	skills int
+	err    error
+}
+
+// parseRepoInput reads "owner/repo", also accepting a GitHub URL. A URL,
+// or "host/owner/repo", on a host other than github.com is a GitHub
+// Enterprise repo.
+func parseRepoInput(input string) (RepoSource, error) {
+	src, err := skill.ParseSource(input)
+	if err != nil || src.Kind != skill.SourceGitHub || src.Path != "" || src.Ref != "" {
+		return RepoSource{}, fmt.Errorf("expected owner/repo")
+	}
+	return RepoSource{Owner: src.Owner, Repo: src.Repo, Host: src.Host}, nil
+}
+
+// validateRepoCmd checks on GitHub, or the repo's enterprise host, that
+// repo exists and has a SKILL.md.
+func validateRepoCmd(repo RepoSource) tea.Cmd {
+	return runTask(fmt.Sprintf("Checking %s/%s", repo.Owner, repo.Repo), func() tea.Msg {
+		if repo.Host != "" {
+			skill.SetRepoHost(repo.Owner, repo.Repo, repo.Host)
+		}
+		found, err := discoverRepoSkills(repo.Owner, repo.Repo, "")
+		if err == nil && len(found) == 0 {
+			err = fmt.Errorf("no SKILL.md in %s/%s", repo.Owner, repo.Repo)
+		}
+		return repoValidatedMsg{repo: repo, skills: len(found), err: err}
+	})
+}
+
+// hasRepo reports whether repo is already listed.
+func (m configModel) hasRepo(repo RepoSource) bool {
+	for _, r := range m.repos {
+		if strings.EqualFold(r.Owner, repo.Owner) && strings.EqualFold(r.Repo, repo.Repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateAddRepo handles the repo input. Enter validates the repo on GitHub
+// before adding it; after a failed check a second enter adds it anyway, for
+// private repos or when GitHub is unreachable.
+func (m configModel) updateAddRepo(msg tea.Msg) (configModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case repoValidatedMsg:
+		if m.validatingRepo == nil || *m.validatingRepo != msg.repo {
+			return m, nil
+		}
+		m.validatingRepo = nil
+		if msg.err != nil {
+			m.repoErr = msg.err
+			m.rejectedRepo = m.textInput.Value()
+			return m, nil
+		}
+		cmd := m.addRepo(msg.repo)
+		m.repoNotice = fmt.Sprintf("✓ Added %s/%s (%d skills)", msg.repo.Owner, msg.repo.Repo, msg.skills)
+		return m, cmd
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if m.validatingRepo != nil {
+				return m, nil
+			}
+			repo, err := parseRepoInput(m.textInput.Value())
+			switch {
+			case err != nil:
+				m.repoErr = err
+			case m.hasRepo(repo):
+				m.repoErr = fmt.Errorf("%s/%s is already listed", repo.Owner, repo.Repo)
+			case m.repoErr != nil && m.rejectedRepo == m.textInput.Value():
+				cmd := m.addRepo(repo)
+				m.repoNotice = fmt.Sprintf("Added %s/%s without validation", repo.Owner, repo.Repo)
+				return m, cmd
+			default:
+				m.repoErr = nil
+				m.validatingRepo = &repo
+				return m, validateRepoCmd(repo)
+			}
+			return m, nil
+		case "esc":
+			m.closeRepoInput()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// addRepo lists repo and closes the input, returning the autosave if any.
+func (m *configModel) addRepo(repo RepoSource) tea.Cmd {
+	m.repos = append(m.repos, repo)
+	m.closeRepoInput()
+	return m.markDirty()
+}
+
+func (m *configModel) closeRepoInput() {
+	m.addingRepo = false
+	m.validatingRepo = nil
+	m.repoErr = nil
+	m.rejectedRepo = ""
+	m.textInput.Reset()
+}
+
+// repoInputView shows the repo input with its validation feedback.
+func (m configModel) repoInputView() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("  Add: %s\n", m.textInput.View()))
+	switch {
+	case m.validatingRepo != nil:
+		b.WriteString(spinnerStyle.Render("  " + withSpinner(fmt.Sprintf("Checking %s/%s on GitHub...", m.validatingRepo.Owner, m.validatingRepo.Repo))))
+		b.WriteString("\n")
+	case m.repoErr != nil:
+		b.WriteString(errorStyle.Render("  ✗ " + m.repoErr.Error()))
+		b.WriteString("\n")
+		if m.rejectedRepo != "" {
+			b.WriteString(statusMutedStyle.Render("  [enter] add anyway  [esc] cancel"))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
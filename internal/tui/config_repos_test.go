@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func stubRepoSkills(t *testing.T, found []skill.RepoSkill, err error) {
+	t.Helper()
+	old := discoverRepoSkills
+	discoverRepoSkills = func(owner, repo, ref string) ([]skill.RepoSkill, error) {
+		return found, err
+	}
+	t.Cleanup(func() { discoverRepoSkills = old })
+}
+
+// typeRepo opens the repo input, types input and presses enter, running
+// the validation command if one is returned.
+func typeRepo(m configModel, input string) configModel {
+	m.section = 1
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m.textInput.SetValue(input)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		m, _ = m.Update(runTaskCmd(cmd))
+	}
+	return m
+}
+
+func TestAddRepoValidatesOnGitHub(t *testing.T) {
+	setTestHome(t)
+	stubRepoSkills(t, []skill.RepoSkill{{Name: "lint", Path: "skills/lint"}}, nil)
+
+	m := typeRepo(newConfigModel(), "https://github.com/acme/tools")
+	if m.addingRepo || !m.hasRepo(RepoSource{Owner: "acme", Repo: "tools"}) {
+		t.Fatalf("repos = %+v, want acme/tools added", m.repos)
+	}
+	if !strings.Contains(m.repoNotice, "1 skills") {
+		t.Errorf("notice = %q, want the skill count", m.repoNotice)
+	}
+}
+
+func TestAddRepoRejectsReposWithoutSkills(t *testing.T) {
+	setTestHome(t)
+	stubRepoSkills(t, nil, nil)
+
+	m := typeRepo(newConfigModel(), "acme/typo")
+	if !m.addingRepo || m.hasRepo(RepoSource{Owner: "acme", Repo: "typo"}) {
+		t.Fatal("a repo without skills was added")
+	}
+	if !strings.Contains(m.View(), "no SKILL.md in acme/typo") {
+		t.Errorf("view lacks the validation error:\n%s", m.View())
+	}
+
+	// A second enter on the same input adds it anyway
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.addingRepo || !m.hasRepo(RepoSource{Owner: "acme", Repo: "typo"}) {
+		t.Error("second enter did not add the repo")
+	}
+}
+
+func TestAddRepoReportsBadInput(t *testing.T) {
+	setTestHome(t)
+	stubRepoSkills(t, nil, errors.New("should not be called"))
+
+	m := typeRepo(newConfigModel(), "not-a-repo")
+	if m.repoErr == nil || m.validatingRepo != nil {
+		t.Errorf("repoErr = %v, want a format error without a GitHub check", m.repoErr)
+	}
+}
+
+func TestParseRepoInputHosts(t *testing.T) {
+	tests := map[string]RepoSource{
+		"owner/repo":                             {Owner: "owner", Repo: "repo"},
+		"https://github.com/owner/repo.git":      {Owner: "owner", Repo: "repo"},
+		"github.mycorp.com/team/skills":          {Owner: "team", Repo: "skills", Host: "github.mycorp.com"},
+		"https://github.mycorp.com/team/skills/": {Owner: "team", Repo: "skills", Host: "github.mycorp.com"},
+	}
+	for input, want := range tests {
+		if got, err := parseRepoInput(input); err != nil || got != want {
+			t.Errorf("parseRepoInput(%q) = %+v, %v; want %+v", input, got, err, want)
+		}
+	}
+	if repo := (RepoSource{Owner: "team", Repo: "skills", Host: "github.mycorp.com"}); repo.DeriveURL() != "https://github.mycorp.com/team/skills" {
+		t.Errorf("DeriveURL = %q", repo.DeriveURL())
+	}
+}
@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// searchTopicRepos is a variable so tests can stub GitHub.
+var searchTopicRepos = skill.SearchTopicRepos
+
+// repoSuggestionsMsg carries the repos found by GitHub topic.
+type repoSuggestionsMsg struct {
+	repos []skill.TopicRepo
+	err   error
+}
+
+// suggestReposCmd searches GitHub for repos tagged with skill topics.
+func suggestReposCmd() tea.Cmd {
+	return runTask("Searching GitHub", func() tea.Msg {
+		repos, err := searchTopicRepos(skill.DefaultSkillTopics, 30)
+		return repoSuggestionsMsg{repos: repos, err: err}
+	})
+}
+
+// openSuggestions starts the repo discovery list.
+func (m configModel) openSuggestions() (configModel, tea.Cmd) {
+	m.suggesting = true
+	m.suggestLoading = true
+	m.suggestions = nil
+	m.suggestIdx = 0
+	m.repoErr = nil
+	m.repoNotice = ""
+	return m, suggestReposCmd()
+}
+
+// updateSuggestions handles the repo discovery list. Enter validates the
+// highlighted repo like a typed one and adds it; the list stays open so
+// several repos can be picked.
+func (m configModel) updateSuggestions(msg tea.Msg) (configModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case configSavedMsg:
+		m.dirty = false
+
+	case repoSuggestionsMsg:
+		m.suggestLoading = false
+		m.suggestions = msg.repos
+		m.repoErr = msg.err
+
+	case repoValidatedMsg:
+		if m.validatingRepo == nil || *m.validatingRepo != msg.repo {
+			return m, nil
+		}
+		m.validatingRepo = nil
+		if msg.err != nil {
+			m.repoErr = msg.err
+			return m, nil
+		}
+		m.repos = append(m.repos, msg.repo)
+		m.repoNotice = fmt.Sprintf("✓ Added %s/%s (%d skills)", msg.repo.Owner, msg.repo.Repo, msg.skills)
+		return m, m.markDirty()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.suggestIdx > 0 {
+				m.suggestIdx--
+			}
+		case "down", "j":
+			if m.suggestIdx < len(m.suggestions)-1 {
+				m.suggestIdx++
+			}
+		case "enter", "a":
+			if m.validatingRepo != nil || len(m.suggestions) == 0 {
+				return m, nil
+			}
+			s := m.suggestions[m.suggestIdx]
+			repo := RepoSource{Owner: s.Owner, Repo: s.Name}
+			if m.hasRepo(repo) {
+				return m, nil
+			}
+			m.repoErr, m.repoNotice = nil, ""
+			m.validatingRepo = &repo
+			return m, validateRepoCmd(repo)
+		case "esc", "D":
+			m.suggesting = false
+			m.validatingRepo = nil
+			m.repoErr = nil
+		}
+	}
+	return m, nil
+}
+
+// suggestionsView lists the discovered repos, marking those already added.
+func (m configModel) suggestionsView(width int) string {
+	var b strings.Builder
+	b.WriteString(statusMutedStyle.Render("  Repos tagged " + strings.Join(skill.DefaultSkillTopics, " or ") + " on GitHub"))
+	b.WriteString("\n")
+	if m.suggestLoading {
+		b.WriteString(spinnerStyle.Render("  " + withSpinner("Searching GitHub...")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	t := table{gap: 1, columns: []tableColumn{{Width: 30}, {Width: 7, Right: true}, {Width: 9}, {Min: 10, Priority: 1}}}
+	widths := t.layout(width - 6)
+	for i, s := range m.suggestions {
+		added := ""
+		if m.hasRepo(RepoSource{Owner: s.Owner, Repo: s.Name}) {
+			added = "✓ added"
+		}
+		line := "  " + t.row(widths, s.FullName(), fmt.Sprintf("%d★", s.Stars), added, s.Description)
+		if i == m.suggestIdx {
+			b.WriteString(getSelectedRowStyle(width).Render(line))
+		} else {
+			b.WriteString(tableRowStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	if len(m.suggestions) == 0 && m.repoErr == nil {
+		b.WriteString(statusMutedStyle.Render("  No repos found"))
+		b.WriteString("\n")
+	}
+
+	switch {
+	case m.validatingRepo != nil:
+		b.WriteString(spinnerStyle.Render("  " + withSpinner(fmt.Sprintf("Checking %s/%s on GitHub...", m.validatingRepo.Owner, m.validatingRepo.Repo))))
+	case m.repoErr != nil:
+		b.WriteString(errorStyle.Render("  ✗ " + m.repoErr.Error()))
+	case m.repoNotice != "":
+		b.WriteString(statusOkStyle.Render("  " + m.repoNotice))
+	default:
+		b.WriteString(statusMutedStyle.Render("  [enter] add repo  [esc] done"))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
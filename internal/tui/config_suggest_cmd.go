@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunDiscoverRepos lists the GitHub repos tagged with topics (the usual
+// skill topics when none are given), marking those already configured.
+// Each repo in add is checked for skills and added as a custom repo.
+func RunDiscoverRepos(topics, add []string) error {
+	cfg := loadConfigFromFile()
+	if cfg == nil {
+		cfg = &ConfigData{}
+	}
+	listed := configModel{repos: cfg.Repos}
+
+	if len(add) > 0 {
+		return addRepos(cfg, add, true)
+	}
+
+	if len(topics) == 0 {
+		topics = skill.DefaultSkillTopics
+	}
+	repos, err := searchTopicRepos(topics, 30)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		fmt.Printf("No repos tagged %s.\n", strings.Join(topics, " or "))
+		return nil
+	}
+	for _, r := range repos {
+		mark := " "
+		if listed.hasRepo(RepoSource{Owner: r.Owner, Repo: r.Name}) {
+			mark = "✓"
+		}
+		fmt.Printf("%s %s %6d★  %s\n", mark, padRight(r.FullName(), 36), r.Stars, r.Description)
+	}
+	fmt.Println("\nAdd one with: efx-skills config discover --add owner/repo")
+	return nil
+}
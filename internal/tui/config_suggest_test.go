@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestSuggestedRepoIsValidatedAndAdded(t *testing.T) {
+	setTestHome(t)
+	stubRepoSkills(t, []skill.RepoSkill{{Name: "lint"}}, nil)
+	old := searchTopicRepos
+	searchTopicRepos = func(topics []string, limit int) ([]skill.TopicRepo, error) {
+		return []skill.TopicRepo{{Owner: "acme", Name: "tools", Stars: 5}, {Owner: "beta", Name: "kit"}}, nil
+	}
+	t.Cleanup(func() { searchTopicRepos = old })
+
+	m := newConfigModel()
+	m.section = 1
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	if !m.suggesting || cmd == nil {
+		t.Fatal("D did not start the repo discovery")
+	}
+	m, _ = m.Update(runTaskCmd(cmd))
+	if len(m.suggestions) != 2 {
+		t.Fatalf("suggestions = %+v", m.suggestions)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter did not validate the suggestion")
+	}
+	m, _ = m.Update(runTaskCmd(cmd))
+	if !m.suggesting || !m.hasRepo(RepoSource{Owner: "beta", Repo: "kit"}) || !m.dirty {
+		t.Errorf("repos = %+v, want beta/kit added with the list still open", m.repos)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.suggesting {
+		t.Error("esc did not close the suggestions")
+	}
+}
+
+func TestRunDiscoverReposAdd(t *testing.T) {
+	setTestHome(t)
+	stubRepoSkills(t, []skill.RepoSkill{{Name: "lint"}}, nil)
+
+	if err := RunDiscoverRepos(nil, []string{"acme/tools"}); err != nil {
+		t.Fatalf("RunDiscoverRepos error: %v", err)
+	}
+	cfg := loadConfigFromFile()
+	if cfg == nil || len(cfg.Repos) != 1 || cfg.Repos[0].Owner != "acme" {
+		t.Fatalf("config repos = %+v, want acme/tools", cfg)
+	}
+	// Adding it again leaves the config alone
+	if err := RunDiscoverRepos(nil, []string{"acme/tools"}); err != nil {
+		t.Fatalf("second RunDiscoverRepos error: %v", err)
+	}
+	if cfg := loadConfigFromFile(); len(cfg.Repos) != 1 {
+		t.Errorf("config repos = %+v, want one entry", cfg.Repos)
+	}
+}
@@ -437,6 +437,23 @@ func TestConfigViewRegistryFriendlyNames(t *testing.T) {
 	}
 }
 
+func TestEmptyRegistriesStayEmpty(t *testing.T) {
+	if registryEndpoints(nil) != nil {
+		t.Error("unset registries should keep the built-in ones")
+	}
+	if got := registryEndpoints([]Registry{}); got == nil || len(got) != 0 {
+		t.Errorf("registryEndpoints([]) = %#v, want an empty list", got)
+	}
+
+	setTestHome(t)
+	if err := saveConfigData(&ConfigData{Registries: []Registry{}}); err != nil {
+		t.Fatal(err)
+	}
+	if m := newConfigModel(); len(m.registries) != 0 {
+		t.Errorf("config view lists %d registries, want none", len(m.registries))
+	}
+}
+
 func TestConfigViewRepoTwoColumn(t *testing.T) {
 	m := configModel{
 		repos: []RepoSource{
@@ -729,7 +746,7 @@ func TestApplySkillChangesConfiguresProviderAndPersistsEnabledProvider(t *testin
 	provider := Provider{Name: "codex", Path: codexPath, Configured: false}
 	skills := []SkillEntry{{Name: "agent-browser", Selected: true, Linked: false}}
 
-	if err := applySkillChanges(provider, skills); err != nil {
+	if _, err := applySkillChanges(provider, skills); err != nil {
 		t.Fatalf("applySkillChanges failed: %v", err)
 	}
 
@@ -761,7 +778,7 @@ func TestApplySkillChangesPreservesDetectedProvidersWhenCreatingConfig(t *testin
 	provider := Provider{Name: "codex", Path: filepath.Join(home, ".codex", "skills"), Configured: false}
 	skills := []SkillEntry{{Name: "agent-browser", Selected: true, Linked: false}}
 
-	if err := applySkillChanges(provider, skills); err != nil {
+	if _, err := applySkillChanges(provider, skills); err != nil {
 		t.Fatalf("applySkillChanges failed: %v", err)
 	}
 
@@ -776,6 +793,46 @@ func TestApplySkillChangesPreservesDetectedProvidersWhenCreatingConfig(t *testin
 	}
 }
 
+func TestDetectProvidersIncludesCustomHookProvider(t *testing.T) {
+	home := setTestHome(t)
+	configDir := filepath.Join(home, ".config", "efx-skills")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+	hook := filepath.Join(home, "hook.sh")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\n[ \"$1\" = list ] && printf 'lint\\ndeploy\\n'\nexit 0\n"), 0755); err != nil {
+		t.Fatalf("write hook: %v", err)
+	}
+	config := `{"custom_providers":[{"name":"remote","hook":"` + hook + `"},{"name":"cursor","path":"/opt/cursor/skills"}]}`
+	if err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var remote, cursor *Provider
+	providers := detectProviders()
+	for i := range providers {
+		switch providers[i].Name {
+		case "remote":
+			remote = &providers[i]
+		case "cursor":
+			cursor = &providers[i]
+		}
+	}
+
+	if remote == nil {
+		t.Fatalf("detectProviders() missing custom provider")
+	}
+	if !remote.Configured {
+		t.Fatalf("remote Configured = false, want true for hook provider")
+	}
+	if remote.SkillCount != 2 {
+		t.Fatalf("remote SkillCount = %d, want 2 from hook list", remote.SkillCount)
+	}
+	if cursor == nil || cursor.Path != "/opt/cursor/skills" {
+		t.Fatalf("cursor = %+v, want path overridden by custom provider", cursor)
+	}
+}
+
 func stringSliceContains(values []string, target string) bool {
 	for _, value := range values {
 		if value == target {
@@ -797,3 +854,22 @@ func searchString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRememberTemplateValues(t *testing.T) {
+	home := setTestHome(t)
+	if err := rememberTemplateValues(map[string]string{"PROJECT_NAME": "efx"}); err != nil {
+		t.Fatalf("rememberTemplateValues error: %v", err)
+	}
+	values := configTemplateValues()
+	if values["PROJECT_NAME"] != "efx" {
+		t.Fatalf("configTemplateValues = %v, want PROJECT_NAME=efx", values)
+	}
+
+	dir := filepath.Join(home, "skill")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("{{PROJECT_NAME}} {{STYLE_GUIDE_URL}}"), 0644)
+	missing := missingTemplateVars(dir, values)
+	if len(missing) != 1 || missing[0] != "STYLE_GUIDE_URL" {
+		t.Fatalf("missingTemplateVars = %v, want [STYLE_GUIDE_URL]", missing)
+	}
+}
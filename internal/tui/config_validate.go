@@ -0,0 +1,451 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// configIssue is one problem found in config.json. Fatal issues (invalid
+// JSON, wrong value types) keep the file from loading at all.
+type configIssue struct {
+	Line    int    // 0 when unknown
+	Field   string // e.g. "registries[1].url", empty for syntax errors
+	Message string
+	Fatal   bool
+}
+
+func (i configIssue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", i.Line)
+	}
+	if i.Field != "" {
+		b.WriteString(i.Field + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// configChecker walks config.json token by token against ConfigData, so
+// every problem is reported with its field path and line.
+type configChecker struct {
+	data   []byte
+	dec    *json.Decoder
+	lines  map[string]int // line of each field path seen
+	issues []configIssue
+}
+
+// validateConfig checks the raw contents of config.json: syntax, unknown
+// fields, value types, and then the values themselves.
+func validateConfig(data []byte) []configIssue {
+	c := &configChecker{data: data, dec: json.NewDecoder(bytes.NewReader(data)), lines: make(map[string]int)}
+	c.dec.UseNumber()
+	if err := c.value(reflect.TypeOf(ConfigData{}), ""); err != nil {
+		return []configIssue{c.syntaxIssue(err)}
+	}
+	if _, err := c.dec.Token(); err != io.EOF {
+		return []configIssue{{Line: c.line(c.dec.InputOffset()), Message: "unexpected content after the closing brace", Fatal: true}}
+	}
+
+	for _, i := range c.issues {
+		if i.Fatal {
+			return c.issues
+		}
+	}
+	var cfg ConfigData
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return append(c.issues, configIssue{Message: err.Error(), Fatal: true})
+	}
+	c.checkValues(&cfg)
+	sort.SliceStable(c.issues, func(i, j int) bool { return c.issues[i].Line < c.issues[j].Line })
+	return c.issues
+}
+
+func (c *configChecker) line(offset int64) int {
+	if offset > int64(len(c.data)) {
+		offset = int64(len(c.data))
+	}
+	return bytes.Count(c.data[:offset], []byte("\n")) + 1
+}
+
+func (c *configChecker) syntaxIssue(err error) configIssue {
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		return configIssue{Line: c.line(syntax.Offset), Message: "invalid JSON: " + syntax.Error(), Fatal: true}
+	}
+	if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+		return configIssue{Line: c.line(int64(len(c.data))), Message: "invalid JSON: unexpected end of file", Fatal: true}
+	}
+	return configIssue{Line: c.line(c.dec.InputOffset()), Message: "invalid JSON: " + err.Error(), Fatal: true}
+}
+
+func (c *configChecker) add(path, format string, args ...any) {
+	c.issues = append(c.issues, configIssue{Line: c.lineOf(path), Field: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// lineOf returns the line of a field, or of its closest parent for a field
+// missing from the file.
+func (c *configChecker) lineOf(path string) int {
+	for path != "" {
+		if line, ok := c.lines[path]; ok {
+			return line
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return 0
+}
+
+// jsonFields maps the JSON names of a struct's fields to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// value reads the next value, checking it against t. Only syntax errors
+// are returned; everything else is recorded as an issue.
+func (c *configChecker) value(t reflect.Type, path string) error {
+	tok, err := c.dec.Token()
+	if err != nil {
+		return err
+	}
+	if path != "" {
+		if _, seen := c.lines[path]; !seen {
+			c.lines[path] = c.line(c.dec.InputOffset())
+		}
+	}
+
+	mismatch := func(got string) {
+		c.issues = append(c.issues, configIssue{Line: c.lines[path], Field: path, Message: fmt.Sprintf("expected %s, got %s", typeName(t), got), Fatal: true})
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		switch {
+		case tok == '{' && t.Kind() == reflect.Struct:
+			fields := jsonFields(t)
+			for c.dec.More() {
+				key, err := c.key()
+				if err != nil {
+					return err
+				}
+				field := joinPath(path, key)
+				ft, ok := fields[key]
+				if !ok {
+					c.lines[field] = c.line(c.dec.InputOffset())
+					c.add(field, "unknown field")
+					if err := c.skip(); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := c.value(ft, field); err != nil {
+					return err
+				}
+			}
+		case tok == '{' && t.Kind() == reflect.Map:
+			for c.dec.More() {
+				key, err := c.key()
+				if err != nil {
+					return err
+				}
+				if err := c.value(t.Elem(), joinPath(path, key)); err != nil {
+					return err
+				}
+			}
+		case tok == '[' && t.Kind() == reflect.Slice:
+			for i := 0; c.dec.More(); i++ {
+				if err := c.value(t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		default:
+			got := "an object"
+			if tok == '[' {
+				got = "a list"
+			}
+			mismatch(got)
+			return c.skipRest()
+		}
+		_, err := c.dec.Token() // closing delimiter
+		return err
+	case string:
+		if t.Kind() != reflect.String {
+			mismatch(fmt.Sprintf("the string %q", tok))
+		}
+	case json.Number:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int64:
+			if _, err := tok.Int64(); err != nil {
+				mismatch("the number " + tok.String())
+			}
+		case reflect.Float64:
+		default:
+			mismatch("the number " + tok.String())
+		}
+	case bool:
+		if t.Kind() != reflect.Bool {
+			mismatch(fmt.Sprintf("%t", tok))
+		}
+	case nil:
+		// null leaves the default in place
+	}
+	return nil
+}
+
+// key reads an object key.
+func (c *configChecker) key() (string, error) {
+	tok, err := c.dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, _ := tok.(string)
+	return key, nil
+}
+
+// skip reads and discards the next value.
+func (c *configChecker) skip() error {
+	tok, err := c.dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); ok && (d == '{' || d == '[') {
+		return c.skipRest()
+	}
+	return nil
+}
+
+// skipRest discards the rest of an object or list whose opening delimiter
+// was already read.
+func (c *configChecker) skipRest() error {
+	for depth := 1; depth > 0; {
+		tok, err := c.dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			if d == '{' || d == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+	}
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	case reflect.Slice:
+		return "a list"
+	case reflect.String:
+		return "a string"
+	case reflect.Int, reflect.Int64:
+		return "a whole number"
+	case reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "true or false"
+	}
+	return t.String()
+}
+
+// checkValues reports values that parse but cannot work.
+func (c *configChecker) checkValues(cfg *ConfigData) {
+	for i, r := range cfg.Registries {
+		path := fmt.Sprintf("registries[%d]", i)
+		if r.Name == "" {
+			c.add(path+".name", "registry name is empty")
+		}
+		if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			c.add(path+".url", "%q is not an http(s) URL", r.URL)
+		}
+		if r.API != "" && !slices.Contains(api.RegistryAPIs, r.API) {
+			c.add(path+".api", "unknown registry API %q (expected %s)", r.API, strings.Join(api.RegistryAPIs, " or "))
+		}
+		for j, m := range r.Mirrors {
+			if u, err := url.Parse(m); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				c.add(fmt.Sprintf("%s.mirrors[%d]", path, j), "%q is not an http(s) URL", m)
+			}
+		}
+	}
+
+	for i, r := range cfg.Repos {
+		path := fmt.Sprintf("repos[%d]", i)
+		if r.Owner == "" || r.Repo == "" {
+			c.add(path, "owner and repo are both required")
+		}
+		if r.URL != "" {
+			if u, err := url.Parse(r.URL); err != nil || u.Scheme == "" || u.Host == "" {
+				c.add(path+".url", "%q is not a URL", r.URL)
+			}
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, def := range provider.Definitions() {
+		known[def.Name] = true
+	}
+	for i, p := range cfg.CustomProviders {
+		path := fmt.Sprintf("custom_providers[%d]", i)
+		if p.Name == "" {
+			c.add(path+".name", "provider name is empty")
+			continue
+		}
+		if !known[p.Name] && p.Path == "" && p.Hook == "" {
+			c.add(path, "a new provider needs a path or a hook")
+		}
+		if p.Path != "" && !filepath.IsAbs(expandPath(p.Path)) {
+			c.add(path+".path", "%q is not absolute; start it with / , ~ or {home}", p.Path)
+		}
+		if _, err := skill.ParseLinkMode(p.LinkMode); err != nil {
+			c.add(path+".link_mode", "%v", err)
+		}
+		for j, pattern := range p.Exclude {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				c.add(fmt.Sprintf("%s.exclude[%d]", path, j), "invalid pattern %q", pattern)
+			}
+		}
+		known[p.Name] = true
+	}
+	for i, name := range cfg.Providers {
+		if !known[name] {
+			c.add(fmt.Sprintf("enabled_providers[%d]", i), "unknown provider %q", name)
+		}
+	}
+
+	if cfg.SkillsPath != "" && !filepath.IsAbs(expandPath(cfg.SkillsPath)) {
+		c.add("skills-path", "%q is not absolute; start it with / , ~ or {home}", cfg.SkillsPath)
+	}
+
+	for i, col := range cfg.ResultColumns {
+		col = strings.ToLower(strings.TrimSpace(col))
+		if _, fixed := fixedColumnWidths[col]; !fixed && col != columnDescription {
+			c.add(fmt.Sprintf("result_columns[%d]", i), "unknown column %q (use installs, stars, registry or description)", col)
+		}
+	}
+
+	for i, t := range cfg.Compose {
+		path := fmt.Sprintf("compose[%d]", i)
+		if t.Name == "" || t.Output == "" {
+			c.add(path, "name and output are both required")
+		}
+	}
+
+	for i, r := range cfg.LinkRules {
+		path := fmt.Sprintf("link_rules[%d]", i)
+		if r.Skill == "" && r.Tag == "" {
+			c.add(path, "a rule needs a skill pattern, a tag or both")
+		}
+		if _, err := filepath.Match(r.Skill, ""); err != nil {
+			c.add(path+".skill", "invalid pattern %q", r.Skill)
+		}
+		if len(r.Providers) == 0 {
+			c.add(path+".providers", "no providers listed")
+		}
+		for j, name := range r.Providers {
+			if !known[name] {
+				c.add(fmt.Sprintf("%s.providers[%d]", path, j), "unknown provider %q", name)
+			}
+		}
+	}
+
+	for name, b := range cfg.Budgets {
+		path := joinPath("budgets", name)
+		if !known[name] {
+			c.add(path, "unknown provider %q", name)
+		}
+		if b.MaxSkills < 0 || b.MaxTokens < 0 || b.MaxBytes < 0 {
+			c.add(path, "limits cannot be negative")
+		}
+	}
+}
+
+// RunConfigValidate checks config.json and prints every problem found.
+func RunConfigValidate() error {
+	path := configFilePath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		fmt.Printf("No config file at %s; the defaults are used.\n", displayPath(path))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	issues := validateConfig(data)
+	if len(issues) == 0 {
+		fmt.Printf("✓ %s is valid\n", displayPath(path))
+		return nil
+	}
+	fmt.Printf("%s:\n", displayPath(path))
+	for _, i := range issues {
+		fmt.Printf("  ✗ %s\n", i)
+	}
+	return fmt.Errorf("%d problem(s) in config", len(issues))
+}
+
+// CheckConfig validates config.json before a command runs. A file that
+// cannot be loaded stops the command instead of silently falling back to
+// the defaults; other problems are printed as warnings.
+func CheckConfig() error {
+	data, err := os.ReadFile(configFilePath())
+	if err != nil {
+		return nil
+	}
+	issues := validateConfig(data)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	var fatal []string
+	for _, i := range issues {
+		if i.Fatal {
+			fatal = append(fatal, "  "+i.String())
+		}
+	}
+	if len(fatal) > 0 {
+		return fmt.Errorf("%s cannot be loaded:\n%s\nFix it, or restore the previous version from config.json.bak",
+			displayPath(configFilePath()), strings.Join(fatal, "\n"))
+	}
+	fmt.Fprintf(os.Stderr, "warning: %d problem(s) in %s; run \"efx-skills config validate\" for details\n",
+		len(issues), displayPath(configFilePath()))
+	return nil
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func issueStrings(issues []configIssue) []string {
+	var out []string
+	for _, i := range issues {
+		out = append(out, i.String())
+	}
+	return out
+}
+
+func TestValidateConfigAcceptsSavedConfig(t *testing.T) {
+	data := `{
+  "registries": [{"name": "skills.sh", "url": "https://skills.sh/api/search", "enabled": true}],
+  "repos": [{"owner": "acme", "repo": "skills", "url": "https://github.com/acme/skills"}],
+  "enabled_providers": ["claude", "mine"],
+  "skills-path": "~/.agents/skills",
+  "skills": [],
+  "custom_providers": [{"name": "mine", "path": "{home}/.mine/skills", "link_mode": "copy"}],
+  "budgets": {"claude": {"max_skills": 20}},
+  "result_columns": ["stars", "Description"],
+  "link_rules": [{"tag": "golang", "providers": ["claude", "cursor"]}, {"skill": "experimental-*", "providers": ["copilot"], "never": true}]
+}`
+	if issues := validateConfig([]byte(data)); len(issues) != 0 {
+		t.Fatalf("validateConfig = %v, want no issues", issueStrings(issues))
+	}
+}
+
+func TestValidateConfigReportsFieldsWithLines(t *testing.T) {
+	data := `{
+  "registries": [
+    {"name": "mine", "url": "ftp://example.com", "enabled": true, "mirrors": ["skills.example.com"]}
+  ],
+  "enabled_providers": ["claude", "nope"],
+  "resul_columns": ["stars"],
+  "custom_providers": [{"name": "x", "path": "relative/dir", "link_mode": "move"}]
+}`
+	got := issueStrings(validateConfig([]byte(data)))
+	want := []string{
+		`line 3: registries[0].url: "ftp://example.com" is not an http(s) URL`,
+		`line 3: registries[0].mirrors[0]: "skills.example.com" is not an http(s) URL`,
+		`line 5: enabled_providers[1]: unknown provider "nope"`,
+		`line 6: resul_columns: unknown field`,
+		`line 7: custom_providers[0].path: "relative/dir" is not absolute`,
+		`line 7: custom_providers[0].link_mode: unknown link mode "move"`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("issues = %q, want %d", got, len(want))
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("issue %d = %q, want prefix %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestValidateConfigTypeMismatchIsFatal(t *testing.T) {
+	data := "{\n  \"restore_session\": \"yes\",\n  \"budgets\": {\"claude\": {\"max_skills\": 2.5}}\n}"
+	issues := validateConfig([]byte(data))
+	if len(issues) != 2 || !issues[0].Fatal || !issues[1].Fatal {
+		t.Fatalf("issues = %q, want two fatal type errors", issueStrings(issues))
+	}
+	if got := issues[0].String(); got != `line 2: restore_session: expected true or false, got the string "yes"` {
+		t.Errorf("issue = %q", got)
+	}
+	if got := issues[1].String(); got != "line 3: budgets.claude.max_skills: expected a whole number, got the number 2.5" {
+		t.Errorf("issue = %q", got)
+	}
+}
+
+func TestValidateConfigSyntaxError(t *testing.T) {
+	issues := validateConfig([]byte("{\n  \"repos\": [],\n  \"skills\": [,]\n}"))
+	if len(issues) != 1 || !issues[0].Fatal || issues[0].Line != 3 {
+		t.Fatalf("issues = %q, want one fatal issue on line 3", issueStrings(issues))
+	}
+}
+
+func TestCheckConfigStopsOnBrokenFile(t *testing.T) {
+	home := setTestHome(t)
+	dir := filepath.Join(home, ".config", "efx-skills")
+	os.MkdirAll(dir, 0755)
+
+	os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"unknown": 1}`), 0644)
+	if err := CheckConfig(); err != nil {
+		t.Errorf("CheckConfig with an unknown field = %v, want only a warning", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"repos": `), 0644)
+	if err := CheckConfig(); err == nil || !strings.Contains(err.Error(), "cannot be loaded") {
+		t.Errorf("CheckConfig with invalid JSON = %v, want an error", err)
+	}
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"path/filepath"
+	"slices"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// dirFingerprint summarises the paths, sizes and modification times below
+// dir, so polling detects saves without reading file contents.
+func dirFingerprint(dir string) uint64 {
+	h := fnv.New64a()
+	filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		fmt.Fprintf(h, "%s|%d|%d\n", p, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return h.Sum64()
+}
+
+// devResync pushes a changed dev skill to the outputs that do not follow
+// the store by themselves: providers holding a copy or handled by a hook,
+// and composed files including the skill. Symlinked providers are live.
+func devResync(store *skill.Store, name string, targets []Provider) ([]string, error) {
+	var report []string
+	var failed int
+	for _, p := range targets {
+		if p.Hook == "" && !p.LinkMode.Copied() || p.excludes(name) {
+			continue
+		}
+		if err := linkSkillToProvider(store, p, name); err != nil {
+			failed++
+			report = append(report, fmt.Sprintf("✗ %s: %v", p.Name, err))
+			continue
+		}
+		report = append(report, fmt.Sprintf("✓ %s: re-synced", p.Name))
+	}
+
+	var composed []ComposeTarget
+	for _, t := range composeTargets() {
+		if slices.Contains(t.Skills, name) {
+			composed = append(composed, t)
+		}
+	}
+	lines, err := regenerateComposed(store, composed)
+	report = append(report, lines...)
+	if err != nil {
+		return report, err
+	}
+	if failed > 0 {
+		return report, fmt.Errorf("%d provider(s) failed to re-sync", failed)
+	}
+	return report, nil
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunDev links the skill in dir like link-dev, then watches it until
+// interrupted: every save re-validates the frontmatter and re-syncs the
+// copies and composed files built from it.
+func RunDev(dir string, providerNames []string, interval time.Duration) error {
+	targets, err := linkTargets(providerNames)
+	if err != nil {
+		return err
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	name, err := store.LinkDev(dir, "")
+	if err != nil {
+		return err
+	}
+	for _, p := range targets {
+		if p.excludes(name) {
+			continue
+		}
+		if err := linkSkillToProvider(store, p, name); err != nil {
+			return fmt.Errorf("linking %s to %s: %w", name, p.Name, err)
+		}
+	}
+
+	fmt.Printf("Watching %s (Ctrl+C to stop)\n", displayPath(dir))
+	devCheck(store, name, dir, targets)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := dirFingerprint(dir)
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Printf("\nStopped. %s stays linked; detach it with: efx-skills unlink-dev %s\n", name, name)
+			return nil
+		case <-ticker.C:
+			if fp := dirFingerprint(dir); fp != last {
+				last = fp
+				devCheck(store, name, dir, targets)
+			}
+		}
+	}
+}
+
+// devCheck validates the skill and, when it is valid, re-syncs its outputs.
+func devCheck(store *skill.Store, name, dir string, targets []Provider) {
+	stamp := time.Now().Format("15:04:05")
+	if problems := skill.ValidateSkill(dir); len(problems) > 0 {
+		fmt.Printf("[%s] ✗ %s is invalid, outputs not synced:\n", stamp, name)
+		for _, p := range problems {
+			fmt.Printf("    - %s\n", p)
+		}
+		return
+	}
+
+	report, err := devResync(store, name, targets)
+	fmt.Printf("[%s] ✓ %s is valid\n", stamp, name)
+	for _, line := range report {
+		fmt.Printf("    %s\n", line)
+	}
+	if err != nil {
+		fmt.Printf("    %v\n", err)
+	}
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestDirFingerprintChangesOnSave(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("v1"), 0644)
+	before := dirFingerprint(dir)
+	if dirFingerprint(dir) != before {
+		t.Fatal("fingerprint changed without edits")
+	}
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("v2 longer"), 0644)
+	if dirFingerprint(dir) == before {
+		t.Fatal("fingerprint did not change after a save")
+	}
+}
+
+func TestDevResyncUpdatesCopiesAndComposedFiles(t *testing.T) {
+	home := useTestWorkspace(t)
+	output := filepath.Join(home, "AGENTS.md")
+	if err := saveConfigData(&ConfigData{Compose: []ComposeTarget{{Name: "agents", Output: output, Skills: []string{"demo"}}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	work := filepath.Join(home, "src", "demo")
+	os.MkdirAll(work, 0755)
+	os.WriteFile(filepath.Join(work, "SKILL.md"), []byte("---\nname: demo\ndescription: d\n---\nv1"), 0644)
+	store := skill.NewStore(getSkillsPath())
+	if _, err := store.LinkDev(work, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	copied := Provider{Name: "copied", Path: filepath.Join(home, ".copied", "skills"), Configured: true, LinkMode: skill.LinkCopy}
+	linked := Provider{Name: "linked", Path: filepath.Join(home, ".linked", "skills"), Configured: true}
+	for _, p := range []Provider{copied, linked} {
+		if err := linkSkillToProvider(store, p, "demo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	os.WriteFile(filepath.Join(work, "SKILL.md"), []byte("---\nname: demo\ndescription: d\n---\nv2"), 0644)
+	report, err := devResync(store, "demo", []Provider{copied, linked})
+	if err != nil {
+		t.Fatalf("devResync error: %v (%v)", err, report)
+	}
+	if len(report) != 2 || !strings.Contains(report[0], "copied") {
+		t.Errorf("report = %v, want the copy and the composed file", report)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(copied.Path, "demo", "SKILL.md"))
+	if !strings.HasSuffix(string(data), "v2") {
+		t.Errorf("copy = %q, want the saved content", data)
+	}
+	if info, err := os.Lstat(filepath.Join(copied.Path, "demo")); err != nil || !info.IsDir() {
+		t.Errorf("copy is not a real folder: %v", err)
+	}
+	if data, _ := os.ReadFile(output); !strings.Contains(string(data), "v2") {
+		t.Errorf("composed file = %q, want the saved content", data)
+	}
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunLinkDev symlinks a skill working directory into the store and links it
+// to the named providers, or every configured provider when none are given.
+// Edits in dir are visible to every provider without reinstalling.
+func RunLinkDev(dir, name string, providerNames []string) error {
+	targets, err := linkTargets(providerNames)
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	name, err = store.LinkDev(dir, name)
+	if err != nil {
+		return err
+	}
+
+	var linked []string
+	for _, p := range targets {
+		if p.excludes(name) {
+			fmt.Printf("- %s: not linked, excluded by the provider\n", p.Name)
+			continue
+		}
+		if err := linkSkillToProvider(store, p, name); err != nil {
+			fmt.Printf("✗ %s: %v\n", p.Name, err)
+			continue
+		}
+		linked = append(linked, p.Name)
+	}
+	fmt.Printf("✓ Linked %s (dev) → %s\n", name, strings.Join(linked, ", "))
+	fmt.Printf("  Detach it with: efx-skills unlink-dev %s\n", name)
+	return nil
+}
+
+// RunUnlinkDev detaches a dev skill from every provider and the store,
+// leaving its working directory alone.
+func RunUnlinkDev(name string) error {
+	store := skill.NewStore(getSkillsPath())
+	lock, err := store.ReadLockFile()
+	if err != nil {
+		return err
+	}
+	if lock.Skills[name].SourceType != skill.SourceTypeDev {
+		return fmt.Errorf("%s is not a dev skill", name)
+	}
+
+	for _, p := range detectProviders() {
+		if !p.Configured || !providerHasSkill(p, name) {
+			continue
+		}
+		if p.Frozen {
+			fmt.Printf("  - %s is frozen; its link was left in place\n", p.Name)
+			continue
+		}
+		if err := unlinkSkillFromProvider(p, name); err != nil {
+			return fmt.Errorf("unlinking %s from %s: %w", name, p.Name, err)
+		}
+		fmt.Printf("  ✓ unlinked from %s\n", p.Name)
+	}
+	for _, p := range removeOrphanLinks(store, name) {
+		fmt.Printf("  ✓ removed leftover link in %s (disabled)\n", p)
+	}
+
+	if err := store.UnlinkDev(name); err != nil {
+		return err
+	}
+	fmt.Printf("Detached %s (working directory kept)\n", name)
+	return nil
+}
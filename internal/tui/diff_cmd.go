@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// providerDiff compares the skill sets of two providers.
+type providerDiff struct {
+	OnlyA, OnlyB []string
+	// Differ lists skills both providers hold with different files, e.g. a
+	// copy made before the stored skill was updated.
+	Differ []skillMismatch
+}
+
+// skillMismatch is a skill whose files differ between two providers.
+type skillMismatch struct {
+	Name       string
+	StaleA     bool // the first provider's files differ from the store
+	StaleB     bool
+	NotInStore bool
+}
+
+func (d providerDiff) empty() bool {
+	return len(d.OnlyA) == 0 && len(d.OnlyB) == 0 && len(d.Differ) == 0
+}
+
+// diffProviders lists the skills held by only one of a and b, and the
+// skills both hold whose contents differ. Contents are only compared for
+// path-based providers; hooks do not expose their files.
+func diffProviders(store *skill.Store, a, b Provider) providerDiff {
+	inA := make(map[string]bool)
+	for _, name := range listProviderSkills(a) {
+		inA[name] = true
+	}
+	inB := make(map[string]bool)
+	for _, name := range listProviderSkills(b) {
+		inB[name] = true
+	}
+
+	var d providerDiff
+	for name := range inA {
+		if !inB[name] {
+			d.OnlyA = append(d.OnlyA, name)
+			continue
+		}
+		if a.Hook != "" || b.Hook != "" {
+			continue
+		}
+		digestA := treeDigest(filepath.Join(a.Path, name))
+		digestB := treeDigest(filepath.Join(b.Path, name))
+		if digestA == digestB {
+			continue
+		}
+		m := skillMismatch{Name: name}
+		if store.IsInstalled(name) {
+			stored := treeDigest(filepath.Join(store.BaseDir, name))
+			m.StaleA, m.StaleB = digestA != stored, digestB != stored
+		} else {
+			m.NotInStore = true
+		}
+		d.Differ = append(d.Differ, m)
+	}
+	for name := range inB {
+		if !inA[name] {
+			d.OnlyB = append(d.OnlyB, name)
+		}
+	}
+	sort.Strings(d.OnlyA)
+	sort.Strings(d.OnlyB)
+	sort.Slice(d.Differ, func(i, j int) bool { return d.Differ[i].Name < d.Differ[j].Name })
+	return d
+}
+
+// RunDiff prints the skills two providers do not share, and the shared ones
+// whose files differ. It fails with ExitOutOfSync when the providers differ.
+func RunDiff(nameA, nameB string) error {
+	if nameA == nameB {
+		return fmt.Errorf("cannot diff %s against itself", nameA)
+	}
+	var a, b *Provider
+	providers := detectProviders()
+	for i := range providers {
+		switch providers[i].Name {
+		case nameA:
+			a = &providers[i]
+		case nameB:
+			b = &providers[i]
+		}
+	}
+	if a == nil {
+		return fmt.Errorf("unknown provider: %s", nameA)
+	}
+	if b == nil {
+		return fmt.Errorf("unknown provider: %s", nameB)
+	}
+
+	d := diffProviders(skill.NewStore(getSkillsPath()), *a, *b)
+	if d.empty() {
+		fmt.Printf("%s and %s have the same skills.\n", a.Name, b.Name)
+		return nil
+	}
+	if len(d.OnlyA) > 0 {
+		fmt.Printf("Only in %s (%d):\n", a.Name, len(d.OnlyA))
+		for _, name := range d.OnlyA {
+			fmt.Printf("  - %s\n", name)
+		}
+	}
+	if len(d.OnlyB) > 0 {
+		fmt.Printf("Only in %s (%d):\n", b.Name, len(d.OnlyB))
+		for _, name := range d.OnlyB {
+			fmt.Printf("  + %s\n", name)
+		}
+	}
+	if len(d.Differ) > 0 {
+		fmt.Printf("Different files (%d):\n", len(d.Differ))
+		for _, m := range d.Differ {
+			note := ""
+			switch {
+			case m.NotInStore:
+				note = "not in the store"
+			case m.StaleA && m.StaleB:
+				note = "both differ from the store"
+			case m.StaleA:
+				note = a.Name + " differs from the store"
+			case m.StaleB:
+				note = b.Name + " differs from the store"
+			}
+			fmt.Printf("  ~ %s (%s)\n", m.Name, note)
+		}
+	}
+	return outOfSync("%s and %s differ", a.Name, b.Name)
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestDiffProviders(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	for _, name := range []string{"shared", "copied", "claude-only", "cursor-only"} {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte(name), 0644)
+	}
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills")}
+	cursor := Provider{Name: "cursor", Path: filepath.Join(home, ".cursor", "skills"), LinkMode: skill.LinkCopy}
+	for _, name := range []string{"shared", "copied", "claude-only"} {
+		if err := linkSkillToProvider(store, claude, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"shared", "copied", "cursor-only"} {
+		if err := linkSkillToProvider(store, cursor, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(store.BaseDir, "copied", "SKILL.md"), []byte("v2"), 0644)
+
+	d := diffProviders(store, claude, cursor)
+	if len(d.OnlyA) != 1 || d.OnlyA[0] != "claude-only" {
+		t.Errorf("OnlyA = %v, want [claude-only]", d.OnlyA)
+	}
+	if len(d.OnlyB) != 1 || d.OnlyB[0] != "cursor-only" {
+		t.Errorf("OnlyB = %v, want [cursor-only]", d.OnlyB)
+	}
+	if len(d.Differ) != 1 || d.Differ[0].Name != "copied" || d.Differ[0].StaleA || !d.Differ[0].StaleB {
+		t.Errorf("Differ = %+v, want a stale cursor copy of copied", d.Differ)
+	}
+
+	if d := diffProviders(store, claude, claude); !d.empty() {
+		t.Errorf("diff of a provider with itself = %+v, want empty", d)
+	}
+}
@@ -266,13 +266,13 @@ func RunDiagnostics(skillsPath string, configSkills []SkillMeta) (*DoctorReport,
 
 	// Read filesystem
 	entries, err := os.ReadDir(skillsPath)
-	if err != nil {
+	if err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("reading skills directory: %w", err)
 	}
 
 	fsSet := make(map[string]bool)
 	for _, e := range entries {
-		if e.IsDir() {
+		if skill.IsSkillEntry(skillsPath, e) {
 			fsSet[e.Name()] = true
 		}
 	}
@@ -320,7 +320,7 @@ func RunDiagnostics(skillsPath string, configSkills []SkillMeta) (*DoctorReport,
 
 	// Skills on filesystem but NOT in config -> UntrackedOnFS
 	for name := range fsSet {
-		if !configSet[name] {
+		if !configSet[name] && !isDevSkill(lockFile, name) {
 			report.UntrackedOnFS = append(report.UntrackedOnFS, name)
 
 			// Check if this is a backfill candidate
@@ -520,3 +520,9 @@ func FormatReport(report *DoctorReport) string {
 
 	return b.String()
 }
+
+// isDevSkill reports whether name is a working directory linked with
+// link-dev, which is deliberately left out of config.json.
+func isDevSkill(lock *skill.LockFile, name string) bool {
+	return lock != nil && lock.Skills[name].SourceType == skill.SourceTypeDev
+}
@@ -60,7 +60,7 @@ func RunDoctor(fix bool) error {
 	}
 
 	if errorCount > 0 {
-		return fmt.Errorf("doctor found %d issue(s)", errorCount)
+		return outOfSync("doctor found %d issue(s)", errorCount)
 	}
 
 	return nil
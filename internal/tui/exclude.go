@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// skillIgnoreFile lists, one glob per line, the skills never linked into the
+// provider folder holding it, for folders another tool also manages.
+const skillIgnoreFile = ".skillignore"
+
+// readSkillIgnore returns the patterns of dir's .skillignore. Blank lines
+// and lines starting with # are skipped; a missing file has none.
+func readSkillIgnore(dir string) []string {
+	f, err := os.Open(filepath.Join(dir, skillIgnoreFile))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns
+}
+
+// excludes reports whether one of p's exclusion patterns matches a skill.
+func (p Provider) excludes(name string) bool {
+	for _, pattern := range p.Exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// linkRefusal returns why a skill must not be linked into p, or "" when it
+// may be: frozen providers are never changed, and excluded skills are kept
+// out.
+func linkRefusal(p Provider, name string) string {
+	switch {
+	case p.Frozen:
+		return "the provider is frozen"
+	case p.excludes(name):
+		return "excluded by the provider"
+	}
+	return ""
+}
+
+// frozenError reports a change attempted on a frozen provider.
+func frozenError(p Provider) error {
+	return errs.WithHint(errs.Conflict,
+		fmt.Sprintf("remove \"frozen\" from %s under custom_providers in config.json to let efx-skills change it", p.Name),
+		"%s is frozen", p.Name)
+}
+
+// excludedError reports a skill linked into a provider that excludes it.
+func excludedError(p Provider, name string) error {
+	return errs.WithHint(errs.Conflict,
+		fmt.Sprintf("edit the exclude list of %s in config.json or its %s", p.Name, skillIgnoreFile),
+		"%s excludes %s", p.Name, name)
+}
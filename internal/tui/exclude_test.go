@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// providerFixture stores skills and configures claude with custom, returning
+// the store and claude as detected.
+func providerFixture(t *testing.T, custom CustomProvider, skills ...string) (*skill.Store, Provider) {
+	t.Helper()
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	for _, name := range skills {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte("# "+name), 0644)
+	}
+	os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0755)
+	custom.Name = "claude"
+	saveConfigData(&ConfigData{Providers: []string{"claude"}, CustomProviders: []CustomProvider{custom}})
+	return store, claudeProvider(t)
+}
+
+func claudeProvider(t *testing.T) Provider {
+	t.Helper()
+	for _, p := range detectProviders() {
+		if p.Name == "claude" {
+			return p
+		}
+	}
+	t.Fatal("claude not detected")
+	return Provider{}
+}
+
+func TestProviderExclusions(t *testing.T) {
+	store, claude := providerFixture(t, CustomProvider{Exclude: []string{"drafts-*"}}, "go-test", "drafts-notes", "vendor-lint")
+	os.WriteFile(filepath.Join(claude.Path, skillIgnoreFile), []byte("# managed by another tool\n\nvendor-*\n"), 0644)
+	claude = claudeProvider(t)
+
+	if !slices.Equal(claude.Exclude, []string{"drafts-*", "vendor-*"}) {
+		t.Fatalf("Exclude = %v, want config patterns then .skillignore", claude.Exclude)
+	}
+	actions := planSync(store, []Provider{claude})
+	if len(actions) != 1 || actions[0].Name != "go-test" {
+		t.Fatalf("planSync = %+v, want only go-test", actions)
+	}
+	if res := linkSkillOp(store, claude, "vendor-lint")(); res.Skipped == "" {
+		t.Errorf("linking an excluded skill = %+v, want skipped", res)
+	}
+	if err := linkSkillToProvider(store, claude, "drafts-notes"); errs.KindOf(err) != errs.Conflict {
+		t.Errorf("linkSkillToProvider error = %v, want a conflict", err)
+	}
+	if slices.Contains(listProviderSkills(claude), skillIgnoreFile) {
+		t.Error(".skillignore listed as a skill")
+	}
+}
+
+func TestFrozenProviderIsLeftUntouched(t *testing.T) {
+	store, claude := providerFixture(t, CustomProvider{Frozen: true}, "go-test", "go-lint")
+	store.LinkToProvider("go-lint", claude.Path)
+	os.Symlink(filepath.Join(store.BaseDir, "gone"), filepath.Join(claude.Path, "gone"))
+
+	if actions := planSyncChanges(store, []Provider{claude}); len(actions) != 0 {
+		t.Fatalf("planSyncChanges = %+v, want nothing for a frozen provider", actions)
+	}
+	if res := linkSkillOp(store, claude, "go-test")(); res.Skipped == "" {
+		t.Errorf("link on a frozen provider = %+v, want skipped", res)
+	}
+	if res := unlinkSkillOp(claude, "go-lint")(); res.Skipped == "" {
+		t.Errorf("unlink on a frozen provider = %+v, want skipped", res)
+	}
+	if _, err := os.Lstat(filepath.Join(claude.Path, "go-lint")); err != nil {
+		t.Errorf("go-lint removed from a frozen provider: %v", err)
+	}
+	if status, _ := providerStatus(claude, claude.LastSync); status != "• frozen" {
+		t.Errorf("providerStatus = %q, want frozen", status)
+	}
+}
+
+func TestTargetsLeaveFrozenProvidersOut(t *testing.T) {
+	providerFixture(t, CustomProvider{Frozen: true})
+
+	if targets, err := linkTargets(nil); err != nil || len(targets) != 0 {
+		t.Errorf("linkTargets = %v, %v, want no targets", targets, err)
+	}
+	if targets, err := assetTargets(provider.AssetCommands, nil, false); err != nil || len(targets) != 0 {
+		t.Errorf("assetTargets = %v, %v, want no targets", targets, err)
+	}
+	if targets, err := mcpTargets(nil); err != nil || len(targets) != 0 {
+		t.Errorf("mcpTargets = %v, %v, want no targets", targets, err)
+	}
+	if _, err := mcpTargets([]string{"claude"}); errs.KindOf(err) != errs.Conflict {
+		t.Errorf("naming a frozen provider: error = %v, want a conflict", err)
+	}
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes of the CLI, so scripts can branch on results without parsing
+// the output.
+const (
+	ExitOK        = 0
+	ExitError     = 1
+	ExitPartial   = 2 // some changes went through, others failed
+	ExitOutOfSync = 3 // a check found providers or the store out of sync
+)
+
+// exitError carries the exit code for an error.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// ExitCode maps an error returned by a command to the process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var e *exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return ExitError
+}
+
+// partialFailure marks err as a partial failure when some changes were
+// done; with nothing done it stays a plain error.
+func partialFailure(done int, err error) error {
+	if err == nil || done == 0 {
+		return err
+	}
+	return &exitError{code: ExitPartial, err: err}
+}
+
+// outOfSync reports a check that found differences.
+func outOfSync(format string, args ...any) error {
+	return &exitError{code: ExitOutOfSync, err: fmt.Errorf(format, args...)}
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	failed := errors.New("failed")
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, ExitOK},
+		{failed, ExitError},
+		{partialFailure(0, failed), ExitError},
+		{partialFailure(2, failed), ExitPartial},
+		{fmt.Errorf("wrapped: %w", partialFailure(1, failed)), ExitPartial},
+		{outOfSync("%d change(s) needed", 3), ExitOutOfSync},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+	if partialFailure(3, nil) != nil {
+		t.Error("partialFailure(3, nil) != nil")
+	}
+}
+
+func TestRunSyncCheckReportsMissingLinks(t *testing.T) {
+	home := setTestHome(t)
+	os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0755)
+	if err := RunSyncCheck(); err != nil {
+		t.Fatalf("RunSyncCheck with an empty store = %v, want in sync", err)
+	}
+
+	skillDir := filepath.Join(home, ".agents", "skills", "demo")
+	os.MkdirAll(skillDir, 0755)
+	os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("# Demo"), 0644)
+	err := RunSyncCheck()
+	if ExitCode(err) != ExitOutOfSync {
+		t.Fatalf("RunSyncCheck = %v (exit %d), want exit %d", err, ExitCode(err), ExitOutOfSync)
+	}
+	if _, statErr := os.Lstat(filepath.Join(home, ".claude", "skills", "demo")); !os.IsNotExist(statErr) {
+		t.Errorf("RunSyncCheck linked the skill: %v", statErr)
+	}
+}
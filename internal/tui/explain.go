@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// explainKind is why an entry puts a provider out of sync.
+type explainKind string
+
+const (
+	explainMissing   explainKind = "missing"   // stored but not linked
+	explainDangling  explainKind = "dangling"  // link whose target is gone
+	explainUnmanaged explainKind = "unmanaged" // provider entry not backed by the store
+	explainStale     explainKind = "stale"     // copy that differs from the store
+)
+
+// explainKinds is the order findings are listed in.
+var explainKinds = []explainKind{explainMissing, explainDangling, explainUnmanaged, explainStale}
+
+// explainFinding is one reason a provider is out of sync, with the command
+// that fixes it.
+type explainFinding struct {
+	Kind   explainKind
+	Asset  provider.AssetType
+	Name   string
+	Detail string
+	Fix    string
+}
+
+// explainProvider lists everything that keeps p out of sync with the store:
+// stored assets it lacks, dangling links, entries the store does not know
+// and copies that no longer match.
+func explainProvider(store *skill.Store, p Provider) []explainFinding {
+	if !p.Configured {
+		return nil
+	}
+
+	var findings []explainFinding
+	for _, a := range planSync(store, []Provider{p}) {
+		f := explainFinding{Kind: explainMissing, Asset: a.AssetType, Name: a.Name}
+		switch {
+		case a.Stale:
+			f.Kind = explainStale
+			f.Detail = "the copy's files differ from the store"
+			f.Fix = "efx-skills sync"
+		case a.Convert:
+			mode := p.LinkMode.Effective()
+			if mode == "" {
+				mode = skill.LinkSymlink
+			}
+			f.Kind = explainStale
+			f.Detail = "placed in another form than the " + string(mode) + " link mode"
+			f.Fix = "efx-skills sync"
+		case a.AssetType == provider.AssetSkills:
+			f.Detail = "stored but not linked"
+			f.Fix = fmt.Sprintf("efx-skills enable %s -p %s", a.Name, p.Name)
+		case a.AssetType == provider.AssetMCP:
+			f.Detail = "stored but not in the provider's config"
+			f.Fix = "efx-skills sync"
+		default:
+			f.Detail = "stored but not linked"
+			f.Fix = fmt.Sprintf("efx-skills %s link %s -p %s", a.AssetType, a.Name, p.Name)
+		}
+		findings = append(findings, f)
+	}
+
+	for _, name := range brokenProviderLinks(p) {
+		f := explainFinding{Kind: explainDangling, Asset: provider.AssetSkills, Name: name, Fix: "efx-skills sync"}
+		if dest, err := os.Readlink(filepath.Join(p.Path, name)); err == nil {
+			f.Detail = "points to " + displayPath(dest) + ", which is gone"
+		}
+		if !store.IsInstalled(name) {
+			f.Fix = "efx-skills prune"
+		}
+		findings = append(findings, f)
+	}
+
+	for _, c := range findPruneCandidates(store, []Provider{p}) {
+		if c.Kind != pruneUnmanaged {
+			continue
+		}
+		f := explainFinding{Kind: explainUnmanaged, Asset: provider.AssetSkills, Name: c.Name,
+			Detail: "not in the store", Fix: "efx-skills prune"}
+		if _, err := os.Stat(filepath.Join(c.Path, "SKILL.md")); err == nil && !isSymlink(c.Path) {
+			f.Detail = "a skill folder that is not in the store"
+			f.Fix = "efx-skills adopt " + p.Name
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// isSymlink reports whether path is a symlink, without following it.
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
+
+// explainLines renders findings grouped by kind, each with its fix.
+func explainLines(findings []explainFinding) []string {
+	var lines []string
+	for _, kind := range explainKinds {
+		var group []explainFinding
+		for _, f := range findings {
+			if f.Kind == kind {
+				group = append(group, f)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, fmt.Sprintf("%s%s (%d):", strings.ToUpper(string(kind[:1])), kind[1:], len(group)))
+		for _, f := range group {
+			line := fmt.Sprintf("  ✗ %s %s", assetNoun(f.Asset), f.Name)
+			if f.Detail != "" {
+				line += ": " + f.Detail
+			}
+			lines = append(lines, line, "      fix: "+f.Fix)
+		}
+	}
+	return lines
+}
+
+// explainMsg carries the explanation of a provider's state for the status view.
+type explainMsg struct {
+	provider string
+	findings []explainFinding
+}
+
+// explainCmd explains the provider p in the background.
+func explainCmd(p Provider) tea.Cmd {
+	return func() tea.Msg {
+		return explainMsg{provider: p.Name, findings: explainProvider(skill.NewStore(getSkillsPath()), p)}
+	}
+}
+
+// updateExplain handles keys while the explanation panel is open.
+func (m statusModel) updateExplain(msg tea.KeyMsg) (statusModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "e", "enter":
+		m.explaining, m.explanation = "", nil
+	}
+	return m, nil
+}
+
+// explainView shows why the explained provider is out of sync.
+func (m statusModel) explainView() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(subtitleStyle.Render("Why " + m.explaining + " is out of sync"))
+	b.WriteString("\n\n")
+	if len(m.explanation) == 0 {
+		b.WriteString(statusOkStyle.Render("  ✓ Nothing to fix: every stored asset is linked and up to date."))
+		b.WriteString("\n")
+	}
+	for _, line := range explainLines(m.explanation) {
+		switch {
+		case strings.HasPrefix(line, "  ✗"):
+			b.WriteString(errorStyle.Render(line))
+		case strings.HasPrefix(line, "      fix:"):
+			b.WriteString(statusMutedStyle.Render(line))
+		default:
+			b.WriteString(groupActiveStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(renderHelpBar(m.width, []string{"[esc] close"}))
+	return b.String()
+}
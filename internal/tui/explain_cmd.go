@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunExplain prints why a provider is out of sync and the command that
+// fixes each problem. It fails with ExitOutOfSync when there is something
+// to fix.
+func RunExplain(name string) error {
+	var p *Provider
+	providers := detectProviders()
+	for i := range providers {
+		if providers[i].Name == name {
+			p = &providers[i]
+		}
+	}
+	if p == nil {
+		return fmt.Errorf("unknown provider: %s", name)
+	}
+	if !p.Configured {
+		fmt.Printf("%s is not enabled, so nothing is linked into it; enable it with efx-skills config.\n", p.Name)
+		return nil
+	}
+
+	findings := explainProvider(skill.NewStore(getSkillsPath()), *p)
+	if len(findings) == 0 {
+		fmt.Printf("%s is in sync.\n", p.Name)
+		return nil
+	}
+	for _, line := range explainLines(findings) {
+		fmt.Println(line)
+	}
+	return outOfSync("%s has %d problem(s)", p.Name, len(findings))
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestExplainProvider(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	for _, name := range []string{"linked", "missing"} {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte(name), 0644)
+	}
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills"), Configured: true}
+	if err := linkSkillToProvider(store, claude, "linked"); err != nil {
+		t.Fatal(err)
+	}
+	os.Symlink(filepath.Join(store.BaseDir, "deleted"), filepath.Join(claude.Path, "deleted"))
+	os.MkdirAll(filepath.Join(claude.Path, "local"), 0755)
+	os.WriteFile(filepath.Join(claude.Path, "local", "SKILL.md"), []byte("local"), 0644)
+
+	got := make(map[string]explainFinding)
+	for _, f := range explainProvider(store, claude) {
+		got[f.Name] = f
+	}
+	if len(got) != 3 {
+		t.Fatalf("findings = %+v, want missing, deleted and local", got)
+	}
+	if f := got["missing"]; f.Kind != explainMissing || f.Fix != "efx-skills enable missing -p claude" {
+		t.Errorf("missing = %+v", f)
+	}
+	if f := got["deleted"]; f.Kind != explainDangling || f.Fix != "efx-skills prune" {
+		t.Errorf("deleted = %+v", f)
+	}
+	if f := got["local"]; f.Kind != explainUnmanaged || f.Fix != "efx-skills adopt claude" {
+		t.Errorf("local = %+v", f)
+	}
+
+	cursor := Provider{Name: "cursor", Path: filepath.Join(home, ".cursor", "skills"), LinkMode: skill.LinkCopy, Configured: true}
+	for _, name := range []string{"linked", "missing"} {
+		if err := linkSkillToProvider(store, cursor, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(store.BaseDir, "linked", "SKILL.md"), []byte("v2"), 0644)
+	findings := explainProvider(store, cursor)
+	if len(findings) != 1 || findings[0].Kind != explainStale || findings[0].Name != "linked" {
+		t.Fatalf("cursor findings = %+v, want a stale copy of linked", findings)
+	}
+	lines := strings.Join(explainLines(findings), "\n")
+	if !strings.Contains(lines, "Stale (1):") || !strings.Contains(lines, "fix: efx-skills sync") {
+		t.Errorf("explainLines =\n%s", lines)
+	}
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// matchStyle marks the query's words in unselected search results.
+var matchStyle = lipgloss.NewStyle().Bold(true).Foreground(primary)
+
+// matchSpans finds the byte ranges of s holding one of the query's words,
+// ignoring case. Overlapping and touching ranges are merged.
+func matchSpans(s, query string) [][2]int {
+	lower := strings.ToLower(s)
+	if len(lower) != len(s) {
+		// Case folding changed the byte offsets; nothing can be mapped back
+		return nil
+	}
+	var spans [][2]int
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		for from := 0; ; {
+			i := strings.Index(lower[from:], term)
+			if i < 0 {
+				break
+			}
+			start := from + i
+			spans = append(spans, [2]int{start, start + len(term)})
+			from = start + len(term)
+		}
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	merged := spans[:1]
+	for _, sp := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if sp[0] <= last[1] {
+			last[1] = max(last[1], sp[1])
+			continue
+		}
+		merged = append(merged, sp)
+	}
+	return merged
+}
+
+// highlightMatches renders the query's words in s with match and the rest
+// with base. Every segment is styled on its own so that the reset ending a
+// match does not clear the row style around it.
+func highlightMatches(s, query string, base, match lipgloss.Style) string {
+	spans := matchSpans(s, query)
+	if len(spans) == 0 {
+		return s
+	}
+	var b strings.Builder
+	pos := 0
+	for _, sp := range spans {
+		if sp[0] > pos {
+			b.WriteString(base.Render(s[pos:sp[0]]))
+		}
+		b.WriteString(match.Render(s[sp[0]:sp[1]]))
+		pos = sp[1]
+	}
+	if pos < len(s) {
+		b.WriteString(base.Render(s[pos:]))
+	}
+	return b.String()
+}
+
+// matchMarker returns the function highlighting the query in a result's
+// name and description, or nil when the query is not free text. Selected
+// rows keep their background and underline matches instead.
+func matchMarker(query string, selected bool, width int) func(string) string {
+	if query == "" || strings.HasPrefix(query, "#") || repoQueryPattern.MatchString(query) {
+		return nil
+	}
+	base, match := lipgloss.NewStyle(), matchStyle
+	if selected {
+		base = getSelectedRowStyle(width).UnsetWidth()
+		match = base.Underline(true)
+	}
+	return func(s string) string {
+		return highlightMatches(s, query, base, match)
+	}
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestMatchSpans(t *testing.T) {
+	got := matchSpans("React Testing for react apps", "react TEST")
+	want := [][2]int{{0, 5}, {6, 10}, {18, 23}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matchSpans = %v, want %v", got, want)
+	}
+	if got := matchSpans("aaaa", "aa a"); !reflect.DeepEqual(got, [][2]int{{0, 4}}) {
+		t.Errorf("overlapping spans = %v, want one merged span", got)
+	}
+	if got := matchSpans("demo", ""); got != nil {
+		t.Errorf("empty query spans = %v, want none", got)
+	}
+}
+
+func TestHighlightMatchesKeepsText(t *testing.T) {
+	match := lipgloss.NewStyle().Underline(true)
+	got := highlightMatches("Go testing helpers", "test", lipgloss.NewStyle(), match)
+	if ansi.Strip(got) != "Go testing helpers" {
+		t.Errorf("stripped = %q, want the original text", ansi.Strip(got))
+	}
+	if got := highlightMatches("no hits", "zzz", lipgloss.NewStyle(), match); got != "no hits" {
+		t.Errorf("highlightMatches without matches = %q", got)
+	}
+}
+
+func TestMatchMarkerSkipsNonTextQueries(t *testing.T) {
+	for _, q := range []string{"", "#testing", "owner/repo"} {
+		if matchMarker(q, false, 80) != nil {
+			t.Errorf("matchMarker(%q) is set, want nil", q)
+		}
+	}
+	if matchMarker("react", true, 80) == nil {
+		t.Error("matchMarker(react) is nil, want a highlighter")
+	}
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunHold freezes the named skills at their installed version so update,
+// including update --all, skips them, or with held false lets them follow
+// their channel again.
+func RunHold(names []string, held bool) error {
+	store := skill.NewStore(getSkillsPath())
+	lock, err := store.ReadLockFile()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, name := range names {
+		entry, ok := lock.Skills[name]
+		switch {
+		case !ok:
+			failed++
+			fmt.Printf("✗ %s: not in the lock file\n", name)
+			continue
+		case entry.Held == held:
+			if held {
+				fmt.Printf("• %s is already held\n", name)
+			} else {
+				fmt.Printf("• %s is not held\n", name)
+			}
+			continue
+		}
+		if err := store.SetHeld(name, held); err != nil {
+			failed++
+			fmt.Printf("✗ %s: %v\n", name, err)
+			continue
+		}
+		if held {
+			fmt.Printf("✓ Held %s; updates skip it\n", name)
+		} else {
+			fmt.Printf("✓ %s follows %s again\n", name, entry.Channel())
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d skill(s) not changed", failed)
+	}
+	return nil
+}
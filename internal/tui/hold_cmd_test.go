@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestRunHold(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	lock := &skill.LockFile{Version: skill.LockVersion, Skills: map[string]skill.LockEntry{"demo": {Source: "owner/repo", SourceType: "github"}}}
+	if err := store.WriteLockFile(lock); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RunHold([]string{"demo"}, true); err != nil {
+		t.Fatalf("RunHold error: %v", err)
+	}
+	lock, _ = store.ReadLockFile()
+	if !lock.Skills["demo"].Held {
+		t.Fatal("demo not held")
+	}
+	// Named updates skip held skills without failing
+	if err := RunUpdate([]string{"demo"}, false, "", ""); err != nil {
+		t.Errorf("RunUpdate on a held skill error: %v", err)
+	}
+
+	if err := RunHold([]string{"demo"}, false); err != nil {
+		t.Fatalf("RunHold(false) error: %v", err)
+	}
+	lock, _ = store.ReadLockFile()
+	if lock.Skills["demo"].Held {
+		t.Fatal("demo still held")
+	}
+	if err := RunHold([]string{"missing"}, true); err == nil {
+		t.Error("holding a skill missing from the lock should fail")
+	}
+}
@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// skillInfo is everything known locally about an installed skill, gathered
+// from its SKILL.md, the lock file, config.json, providers and the stats
+// cache.
+type skillInfo struct {
+	Name         string      `json:"name"`
+	Description  string      `json:"description,omitempty"`
+	Path         string      `json:"path"`
+	Source       string      `json:"source,omitempty"`
+	SourceType   string      `json:"source_type,omitempty"`
+	SourceURL    string      `json:"source_url,omitempty"`
+	SkillPath    string      `json:"skill_path,omitempty"`
+	License      string      `json:"license,omitempty"`
+	Registry     string      `json:"registry,omitempty"`
+	Channel      string      `json:"channel,omitempty"` // branch, @ref, local, dev or default
+	Held         bool        `json:"held,omitempty"`
+	CommitHash   string      `json:"commit_hash,omitempty"`
+	FolderHash   string      `json:"folder_hash,omitempty"`
+	InstalledAt  string      `json:"installed_at,omitempty"`
+	UpdatedAt    string      `json:"updated_at,omitempty"`
+	Bytes        int64       `json:"bytes"`
+	Tokens       int         `json:"tokens"`
+	Providers    []string    `json:"providers"`
+	Tags         []string    `json:"tags"`
+	Requires     []string    `json:"requires"`
+	RequiredBy   []string    `json:"required_by"`
+	ComposedInto []string    `json:"composed_into"`
+	Stats        *skillStats `json:"stats,omitempty"`
+}
+
+// folderSize sums the sizes of the files below dir, following a dev
+// skill's symlink to its working directory.
+func folderSize(dir string) int64 {
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = real
+	}
+	var total int64
+	filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
+
+// collectSkillInfo gathers the details of the installed skill name.
+func collectSkillInfo(name string) (*skillInfo, error) {
+	store := skill.NewStore(getSkillsPath())
+	if !store.IsInstalled(name) {
+		return nil, fmt.Errorf("skill %q is not installed", name)
+	}
+	dir := filepath.Join(store.BaseDir, name)
+
+	info := &skillInfo{
+		Name:         name,
+		Path:         dir,
+		Bytes:        folderSize(dir),
+		Tokens:       skill.SkillTokens(dir),
+		Providers:    []string{},
+		Tags:         skill.Tags(dir),
+		Requires:     skill.Requires(dir),
+		RequiredBy:   []string{},
+		ComposedInto: []string{},
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "SKILL.md")); err == nil {
+		if fields, _ := skill.ParseFrontmatter(string(data)); fields != nil {
+			info.Description = fields["description"]
+		}
+	}
+	if info.Tags == nil {
+		info.Tags = []string{}
+	}
+	if info.Requires == nil {
+		info.Requires = []string{}
+	}
+
+	lock, _ := store.ReadLockFile()
+	if e, ok := lockEntry(lock, name); ok {
+		info.Source = e.Source
+		info.SourceType = e.SourceType
+		info.SourceURL = e.SourceURL
+		info.SkillPath = e.SkillPath
+		info.Channel = e.Channel()
+		info.Held = e.Held
+		info.CommitHash = e.CommitHash
+		info.FolderHash = e.SkillFolderHash
+		info.InstalledAt = e.InstalledAt
+		info.UpdatedAt = e.UpdatedAt
+		info.License = e.License
+	}
+	if info.License == "" {
+		info.License = skill.License(dir)
+	}
+	if cfg := loadConfigFromFile(); cfg != nil {
+		for _, m := range cfg.Skills {
+			if m.Name == name {
+				info.Registry = m.Registry
+				if info.Source == "" {
+					info.Source = m.Owner
+				}
+				if info.InstalledAt == "" {
+					info.InstalledAt = m.Installed
+				}
+			}
+		}
+	}
+
+	for _, p := range detectProviders() {
+		if p.Configured && slices.Contains(listProviderSkills(p), name) {
+			info.Providers = append(info.Providers, p.Name)
+		}
+	}
+	if names, err := store.ListInstalled(); err == nil {
+		for _, other := range names {
+			if other != name && slices.Contains(skill.Requires(filepath.Join(store.BaseDir, other)), name) {
+				info.RequiredBy = append(info.RequiredBy, other)
+			}
+		}
+	}
+	for _, t := range composeTargets() {
+		if slices.Contains(t.Skills, name) {
+			info.ComposedInto = append(info.ComposedInto, t.Name)
+		}
+	}
+	if s, ok := loadSkillStats().Skills[name]; ok && !s.Unlisted {
+		info.Stats = &s
+	}
+	return info, nil
+}
+
+// RunInfo prints everything known about an installed skill, as JSON with
+// asJSON.
+func RunInfo(name string, asJSON bool) error {
+	info, err := collectSkillInfo(name)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println(info.Name)
+	fmt.Println(strings.Repeat("=", len(info.Name)))
+	if info.Description != "" {
+		fmt.Printf("\n%s\n", info.Description)
+	}
+	fmt.Println()
+
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Printf("  %s %s\n", padRight(label+":", 14), value)
+		}
+	}
+	list := func(items []string) string {
+		if len(items) == 0 {
+			return statusMutedStyle.Render("none")
+		}
+		return strings.Join(items, ", ")
+	}
+	row("Path", displayPath(info.Path))
+	row("Source", info.Source)
+	row("Skill path", info.SkillPath)
+	row("Registry", registryName(info.Registry))
+	row("License", info.License)
+	if info.Held {
+		row("Channel", info.Channel+" (held)")
+	} else {
+		row("Channel", info.Channel)
+	}
+	row("Commit", info.CommitHash)
+	row("Folder hash", info.FolderHash)
+	row("Installed", info.InstalledAt)
+	row("Updated", info.UpdatedAt)
+	row("Size", fmt.Sprintf("%s, ~%s tokens", formatBytes(info.Bytes), formatTokens(info.Tokens)))
+	if info.Stats != nil {
+		row("Stats", info.Stats.summary())
+	}
+	row("Providers", list(info.Providers))
+	row("Tags", list(info.Tags))
+	row("Requires", list(info.Requires))
+	row("Required by", list(info.RequiredBy))
+	if len(info.ComposedInto) > 0 {
+		row("Composed into", list(info.ComposedInto))
+	}
+	return nil
+}
+
+// registryName is the display name of a recorded registry, "" when none
+// was recorded.
+func registryName(registry string) string {
+	if registry == "" {
+		return ""
+	}
+	return registryDisplayName(registry)
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestCollectSkillInfo(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	write := func(name, frontmatter string) {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte("---\nname: "+name+"\n"+frontmatter+"---\n# "+name), 0644)
+	}
+	write("git-basics", "description: Commit and branch hygiene\ntags: [git]\n")
+	write("deploy", "requires: [git-basics]\n")
+	os.WriteFile(store.LockFile, []byte(`{"version":3,"skills":{"git-basics":{"source":"acme/skills","sourceType":"github","ref":"v1.2.0","commitHash":"abc123","installedAt":"2026-01-02T10:00:00Z"}}}`), 0644)
+	store.LinkToProvider("git-basics", filepath.Join(home, ".claude", "skills"))
+	saveConfigData(&ConfigData{
+		Providers: []string{"claude"},
+		Skills:    []SkillMeta{{Name: "git-basics", Owner: "acme/skills", Registry: "skills.sh"}},
+		Compose:   []ComposeTarget{{Name: "agents-md", Output: "~/AGENTS.md", Skills: []string{"git-basics"}}},
+	})
+
+	info, err := collectSkillInfo("git-basics")
+	if err != nil {
+		t.Fatalf("collectSkillInfo error: %v", err)
+	}
+	if info.Description != "Commit and branch hygiene" || info.Source != "acme/skills" || info.Registry != "skills.sh" {
+		t.Errorf("provenance = %+v", info)
+	}
+	if info.Channel != "@v1.2.0" || info.CommitHash != "abc123" || info.InstalledAt == "" {
+		t.Errorf("lock details = %+v", info)
+	}
+	if strings.Join(info.Providers, ",") != "claude" {
+		t.Errorf("providers = %v, want claude", info.Providers)
+	}
+	if strings.Join(info.RequiredBy, ",") != "deploy" || strings.Join(info.ComposedInto, ",") != "agents-md" {
+		t.Errorf("relationships = required by %v, composed into %v", info.RequiredBy, info.ComposedInto)
+	}
+	if info.Bytes == 0 || strings.Join(info.Tags, ",") != "git" {
+		t.Errorf("size %d, tags %v", info.Bytes, info.Tags)
+	}
+
+	if _, err := collectSkillInfo("missing"); err == nil {
+		t.Error("expected an error for a skill that is not installed")
+	}
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// fetchSkillDetails is a variable so tests can stub the registries.
+var fetchSkillDetails = api.FetchSkillDetails
+
+// resolveSource points a registry result at the repository and folder the
+// registry's details name, as a list entry's source is not always the
+// canonical one. The result is kept as listed when the lookup fails.
+func resolveSource(s Skill) Skill {
+	if s.Path != "" || !hasDetails(s) {
+		return s
+	}
+	d, err := fetchSkillDetails(s)
+	if err != nil || d.Source == "" {
+		return s
+	}
+	s.Source, s.Path = d.Source, d.Path
+	return s
+}
+
+// installSkill downloads s into the store pinned at version, following
+// branch, or from the default branch when both are empty. It records the
+// resolved commit and the skill's license in the lock file and tracks the
+// skill in config.json. A skill the license policy blocks never reaches the
+// store, and an installed copy is kept. Linking is left to the caller.
+func installSkill(store *skill.Store, s Skill, version, branch string) error {
+	s = resolveSource(s)
+	var license string
+	vetted := *store
+	vetted.Vet = licenseVet(s, &license)
+
+	var err error
+	ref := version
+	if branch != "" {
+		ref = branch
+		err = vetted.InstallBranch(s.Source, s.Name, branch)
+	} else if s.Path != "" && version == "" {
+		err = vetted.InstallPath(s.Source, s.Name, s.Path)
+	} else {
+		err = vetted.InstallVersion(s.Source, s.Name, version)
+	}
+	if err != nil {
+		return err
+	}
+	if vetted.Vet == nil {
+		license = detectLicense(filepath.Join(store.BaseDir, s.Name), s.Source)
+	}
+
+	commitHash, _ := skill.SourceCommit(s.Source, ref)
+	if err := store.AddToLock(s.Name, s.Source, commitHash); err != nil {
+		return err
+	}
+	if license != "" {
+		if err := store.SetLicense(s.Name, license); err != nil {
+			return err
+		}
+	}
+
+	meta := skillMetaFromAPISkill(s)
+	meta.Version = commitHash
+	meta.Installed = time.Now().UTC().Format(time.RFC3339)
+	return addSkillToConfig(meta)
+}
+
+// parseSkillRef resolves a skill reference into its source, skill name
+// and optional version. References are written as
+// "owner/repo/skill[@version]", "git@host:org/repo.git/skill[@version]",
+// an archive URL, which names the skill after the file, or a registry id
+// such as "skills.sh:owner/repo/skill", looked up in the registry.
+func parseSkillRef(ref string) (source, name, version string, err error) {
+	src, err := skill.ParseSource(ref)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid skill reference: %s (expected owner/repo/skill[@version])", ref)
+	}
+	switch src.Kind {
+	case skill.SourceArchive:
+		if err := fsutil.ValidName(src.Name()); err != nil {
+			return "", "", "", fmt.Errorf("invalid skill reference: %s: %w", ref, err)
+		}
+		return src.URL, src.Name(), "", nil
+	case skill.SourceGit:
+		if fsutil.ValidName(src.Path) != nil {
+			return "", "", "", fmt.Errorf("invalid skill reference: %s (expected git@host:org/repo.git/skill[@version])", ref)
+		}
+		return src.URL, src.Path, src.Ref, nil
+	case skill.SourceRegistry:
+		d, err := fetchSkillDetails(Skill{ID: src.ID, Name: src.Name(), Registry: src.Registry})
+		if err != nil {
+			return "", "", "", fmt.Errorf("looking up %s: %w", ref, err)
+		}
+		if d.Source == "" {
+			return "", "", "", fmt.Errorf("%s names no repository for %s", src.Registry, src.ID)
+		}
+		if d.Name == "" {
+			d.Name = src.Name()
+		}
+		if err := fsutil.ValidName(d.Name); err != nil {
+			return "", "", "", fmt.Errorf("%s names an invalid skill for %s: %w", src.Registry, src.ID, err)
+		}
+		return d.Source, d.Name, src.Ref, nil
+	case skill.SourceLocal:
+		return "", "", "", fmt.Errorf("%s is a local folder; link it with \"efx-skills link-dev\"", ref)
+	}
+	if fsutil.ValidName(src.Path) != nil {
+		return "", "", "", fmt.Errorf("invalid skill reference: %s (expected owner/repo/skill[@version])", ref)
+	}
+	if src.Host != "" && !strings.EqualFold(skill.GitHubHost(src.Owner, src.Repo), src.Host) {
+		return "", "", "", fmt.Errorf("%s/%s is not configured on %s; add it with \"efx-skills repo add %s/%s/%s\"", src.Owner, src.Repo, src.Host, src.Host, src.Owner, src.Repo)
+	}
+	return src.Repository(), src.Path, src.Ref, nil
+}
@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunInstall installs an owner/repo/skill[@version] reference into the
+// store and links it to the named providers, or every configured provider
+// when none are given. chooseVersion lists the repository tags to pick from
+// when no version is given; branch makes the skill follow that branch.
+func RunInstall(ref string, providerNames []string, chooseVersion bool, branch string) error {
+	source, name, version, err := parseSkillRef(ref)
+	if err != nil {
+		return err
+	}
+	if branch != "" && (version != "" || chooseVersion) {
+		return fmt.Errorf("a skill follows either a branch or a pinned version, not both")
+	}
+	if version == "" && chooseVersion {
+		if version, err = promptVersion(source); err != nil {
+			return err
+		}
+	}
+
+	targets, err := linkTargets(providerNames)
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	switch {
+	case version != "":
+		fmt.Printf("Installing %s %s from %s...\n", name, version, source)
+	case branch != "":
+		fmt.Printf("Installing %s from %s (branch %s)...\n", name, source, branch)
+	default:
+		fmt.Printf("Installing %s from %s...\n", name, source)
+	}
+	if err := installSkill(store, Skill{Name: name, Source: source, Registry: "github"}, version, branch); err != nil {
+		return fmt.Errorf("installing %s: %w", name, err)
+	}
+
+	dir := filepath.Join(store.BaseDir, name)
+	values := configTemplateValues()
+	if err := renderSkill(store, name, values); err != nil {
+		return fmt.Errorf("rendering %s: %w", name, err)
+	}
+	if warning := licenseWarning(store, name); warning != "" {
+		fmt.Printf("! %s\n", warning)
+	}
+	if missing := missingTemplateVars(dir, values); len(missing) > 0 {
+		fmt.Printf("! %s has unset placeholders: %s (set them under \"variables\" in config.json)\n", name, strings.Join(missing, ", "))
+	}
+
+	targets, blocked := applyLinkRules(linkRules(), store, name, targets)
+	for _, p := range blocked {
+		fmt.Printf("- %s: not linked, a link rule keeps %s out\n", p, name)
+	}
+
+	var linked, failed []string
+	for _, p := range targets {
+		if p.excludes(name) {
+			fmt.Printf("- %s: not linked, excluded by the provider\n", p.Name)
+			continue
+		}
+		if err := linkSkillToProvider(store, p, name); err != nil {
+			fmt.Printf("✗ %s: %v\n", p.Name, err)
+			failed = append(failed, p.Name)
+			continue
+		}
+		linked = append(linked, p.Name)
+	}
+	fmt.Printf("✓ Installed %s → %s\n", name, strings.Join(linked, ", "))
+	if len(failed) > 0 {
+		// The skill is in the store even when no provider could be linked
+		return partialFailure(1, fmt.Errorf("%s not linked to %s", name, strings.Join(failed, ", ")))
+	}
+	return nil
+}
+
+// RunInstallBatch installs several references one after the other with
+// the options of RunInstall, numbering each install and ending with a
+// summary. A single "-" reads the references from stdin, one per line.
+func RunInstallBatch(refs []string, providerNames []string, chooseVersion bool, branch string) error {
+	if len(refs) == 1 && refs[0] == "-" {
+		if chooseVersion {
+			return fmt.Errorf("--choose-version reads the answer from stdin and cannot be used with -")
+		}
+		refs = readInstallRefs(os.Stdin)
+		if len(refs) == 0 {
+			return fmt.Errorf("no skills given on stdin")
+		}
+	}
+	if len(refs) == 1 {
+		return RunInstall(refs[0], providerNames, chooseVersion, branch)
+	}
+
+	var failed, unlinked []string
+	for i, ref := range refs {
+		fmt.Printf("[%d/%d] ", i+1, len(refs))
+		err := RunInstall(ref, providerNames, chooseVersion, branch)
+		switch {
+		case err == nil:
+		case ExitCode(err) == ExitPartial:
+			unlinked = append(unlinked, ref)
+		default:
+			fmt.Printf("✗ %s: %v\n", ref, err)
+			failed = append(failed, ref)
+		}
+	}
+
+	installed := len(refs) - len(failed)
+	fmt.Printf("\n%d installed, %d failed\n", installed, len(failed))
+	switch {
+	case len(failed) > 0:
+		return partialFailure(installed, fmt.Errorf("failed to install %s", strings.Join(failed, ", ")))
+	case len(unlinked) > 0:
+		return partialFailure(installed, fmt.Errorf("not linked everywhere: %s", strings.Join(unlinked, ", ")))
+	}
+	return nil
+}
+
+// readInstallRefs reads one reference per line. Only the first word of a
+// line is used, so lines copied from listings with descriptions work;
+// blank lines and # comments are skipped.
+func readInstallRefs(r io.Reader) []string {
+	var refs []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		refs = append(refs, fields[0])
+	}
+	return refs
+}
+
+// linkTargets returns the providers a command changes: the named ones, or
+// every configured provider when none are given. Frozen providers are left
+// out, and naming one is an error; the asset and MCP commands choose their
+// targets through here too.
+func linkTargets(providerNames []string) ([]Provider, error) {
+	wanted := make(map[string]bool)
+	for _, n := range providerNames {
+		wanted[n] = true
+	}
+	var targets []Provider
+	for _, p := range detectProviders() {
+		if len(wanted) > 0 {
+			if !wanted[p.Name] {
+				continue
+			}
+			delete(wanted, p.Name)
+			if p.Frozen {
+				return nil, frozenError(p)
+			}
+		} else if !p.Configured || p.Frozen {
+			continue
+		}
+		targets = append(targets, p)
+	}
+	for n := range wanted {
+		return nil, fmt.Errorf("unknown provider: %s", n)
+	}
+	return targets, nil
+}
+
+// promptVersion lists the tags of source and reads the user's choice. An
+// empty answer keeps the default branch.
+func promptVersion(source string) (string, error) {
+	versions, err := skill.SourceVersions(source)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		fmt.Printf("%s has no tagged versions, using the default branch.\n", source)
+		return "", nil
+	}
+
+	fmt.Printf("Versions of %s:\n", source)
+	for i, v := range versions {
+		fmt.Printf("  %2d) %s\n", i+1, v)
+	}
+	fmt.Print("Pick a version [enter for latest]: ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return "", nil
+	}
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(versions) {
+		return versions[n-1], nil
+	}
+	for _, v := range versions {
+		if v == answer {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unknown version: %s", answer)
+}
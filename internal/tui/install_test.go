@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/api"
+)
+
+func TestParseSkillRef(t *testing.T) {
+	source, name, version, err := parseSkillRef("owner/repo/find-skills")
+	if err != nil || source != "owner/repo" || name != "find-skills" || version != "" {
+		t.Fatalf("parseSkillRef = %q, %q, %q, %v", source, name, version, err)
+	}
+
+	_, name, version, err = parseSkillRef("owner/repo/find-skills@v1.2.0")
+	if err != nil || name != "find-skills" || version != "v1.2.0" {
+		t.Fatalf("parseSkillRef with version = %q, %q, %v", name, version, err)
+	}
+
+	for _, bad := range []string{"find-skills", "owner/repo", "owner//skill", "a/b/c/d", "owner/repo/.", "owner/repo/.."} {
+		if _, _, _, err := parseSkillRef(bad); err == nil {
+			t.Errorf("parseSkillRef(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseGitSkillRef(t *testing.T) {
+	source, name, version, err := parseSkillRef("git@github.com:org/private-skills.git/deploy@v2")
+	if err != nil || source != "git@github.com:org/private-skills.git" || name != "deploy" || version != "v2" {
+		t.Fatalf("parseSkillRef = %q, %q, %q, %v", source, name, version, err)
+	}
+
+	source, name, _, err = parseSkillRef("ssh://git@example.com/org/skills.git/lint")
+	if err != nil || source != "ssh://git@example.com/org/skills.git" || name != "lint" {
+		t.Fatalf("parseSkillRef ssh:// = %q, %q, %v", source, name, err)
+	}
+
+	for _, bad := range []string{"git@github.com:org/skills", "git@github.com:org/skills.git/", "git@github.com:org/skills.git/a/b", "git@github.com:org/skills.git/.", "git@github.com:org/skills.git/.."} {
+		if _, _, _, err := parseSkillRef(bad); err == nil {
+			t.Errorf("parseSkillRef(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseArchiveSkillRef(t *testing.T) {
+	ref := "https://example.com/dl/deploy.tar.gz#sha256=abc"
+	source, name, version, err := parseSkillRef(ref)
+	if err != nil || source != ref || name != "deploy" || version != "" {
+		t.Fatalf("parseSkillRef = %q, %q, %q, %v", source, name, version, err)
+	}
+}
+
+func TestReadInstallRefs(t *testing.T) {
+	input := "owner/repo/one\n\n# picked with fzf\n  owner/repo/two@v1   Does things\ngit@github.com:org/skills.git/three\n"
+	got := readInstallRefs(strings.NewReader(input))
+	want := []string{"owner/repo/one", "owner/repo/two@v1", "git@github.com:org/skills.git/three"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readInstallRefs = %v, want %v", got, want)
+	}
+}
+
+func TestParseRegistrySkillRef(t *testing.T) {
+	old := fetchSkillDetails
+	defer func() { fetchSkillDetails = old }()
+	fetchSkillDetails = func(s Skill) (*api.SkillDetails, error) {
+		if s.Registry != "skills.sh" || s.ID != "lint" {
+			t.Errorf("looked up %+v", s)
+		}
+		return &api.SkillDetails{Skill: api.Skill{Name: "lint", Source: "acme/skills", Path: "skills/lint"}}, nil
+	}
+
+	source, name, version, err := parseSkillRef("skills.sh:lint@v3")
+	if err != nil || source != "acme/skills" || name != "lint" || version != "v3" {
+		t.Fatalf("parseSkillRef = %q, %q, %q, %v", source, name, version, err)
+	}
+	if _, _, _, err := parseSkillRef("./lint"); err == nil || !strings.Contains(err.Error(), "link-dev") {
+		t.Errorf("parseSkillRef(./lint) = %v, want a link-dev hint", err)
+	}
+}
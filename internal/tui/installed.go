@@ -0,0 +1,510 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// installedModel lists every skill in the store with the providers linking
+// it. It shares the manage view's list: grouping, sorting, tag filter,
+// pagination, marking with t/a/n, preview, verify and update all work the
+// same. Actions apply to the marked skills, or the one under the cursor.
+// The parity filter, toggled with P, lists only the skills some providers
+// lack.
+type installedModel struct {
+	manageModel
+
+	linkedBy   map[string][]string  // providers linking each skill
+	providers  []Provider           // configured path or hook providers
+	gaps       map[string]parityGap // skills not linked into every provider
+	parityOnly bool
+
+	confirmingUninstall []string // skills awaiting confirmation
+	resolving           []string // skills edited in the store, waiting for a strategy
+	unlinking           []string // skills whose provider is being picked
+	unlinkChoices       []Provider
+	unlinkIdx           int
+}
+
+type openInstalledMsg struct {
+	parity bool // open with the parity filter on
+}
+
+type installedLoadedMsg struct {
+	skills    []SkillEntry
+	linkedBy  map[string][]string
+	providers []Provider
+	gaps      []parityGap
+}
+
+// installedActionMsg reports an action on several skills, after which the
+// list is reloaded.
+type installedActionMsg struct {
+	done      []string
+	failed    []string
+	conflicts []string // skills left alone for their local changes
+	verb      string
+}
+
+func newInstalledModel() installedModel {
+	return installedModel{manageModel: newManageModel(Provider{Name: "installed"})}
+}
+
+func (m installedModel) Init() tea.Cmd {
+	return tea.Batch(loadInstalled, loadSkillStatsCmd(), refreshSkillStatsCmd())
+}
+
+// loadInstalled reads the store entries and which providers link each one.
+func loadInstalled() tea.Msg {
+	msg := installedLoadedMsg{skills: loadSkillsForProvider(Provider{})}
+	for _, p := range detectProviders() {
+		if p.Configured {
+			msg.providers = append(msg.providers, p)
+		}
+	}
+	msg.linkedBy = providerLinks(msg.providers)
+	if len(msg.providers) > 1 {
+		msg.gaps = parityGaps(skill.NewStore(getSkillsPath()), msg.providers, msg.linkedBy)
+	}
+	return msg
+}
+
+// capturesKeys reports whether a confirmation or the provider picker is
+// open, so esc and q close it instead of leaving the view.
+func (m installedModel) capturesKeys() bool {
+	return m.confirmingUninstall != nil || m.unlinking != nil || m.resolving != nil
+}
+
+// targets returns the marked skills, or the skill under the cursor when
+// none is marked.
+func (m installedModel) targets() []string {
+	var names []string
+	for _, s := range m.skills {
+		if s.Selected {
+			names = append(names, s.Name)
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
+	if m.selectedIdx < len(m.displayList) {
+		if item := m.displayList[m.selectedIdx]; !item.isGroup {
+			return []string{m.skills[item.skillIdx].Name}
+		}
+	}
+	return nil
+}
+
+// linkingProviders lists the configured providers linking any of names.
+func (m installedModel) linkingProviders(names []string) []Provider {
+	var out []Provider
+	for _, p := range m.providers {
+		for _, name := range names {
+			if slices.Contains(m.linkedBy[name], p.Name) {
+				out = append(out, p)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (m installedModel) Update(msg tea.Msg) (installedModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case installedLoadedMsg:
+		m.linkedBy = msg.linkedBy
+		m.providers = msg.providers
+		m.gaps = make(map[string]parityGap)
+		for _, g := range msg.gaps {
+			m.gaps[g.Name] = g
+		}
+		m.applyParityFilter()
+		var cmd tea.Cmd
+		m.manageModel, cmd = m.manageModel.Update(skillsLoadedMsg{skills: msg.skills})
+		return m, cmd
+
+	case installedActionMsg:
+		m.statusMsg = fmt.Sprintf("%s %d skill(s)", msg.verb, len(msg.done))
+		if len(msg.failed) > 0 {
+			m.statusMsg = fmt.Sprintf("Error: %s %d, failed: %s", strings.ToLower(msg.verb), len(msg.done), strings.Join(msg.failed, "; "))
+		}
+		m.resolving = msg.conflicts
+		m.updating = false
+		m.restore = &listPosition{selected: m.selectedIdx, page: m.paginator.Page}
+		return m, loadInstalled
+
+	case tea.KeyMsg:
+		if m.confirmingUninstall != nil {
+			return m.updateUninstallConfirm(msg)
+		}
+		if m.unlinking != nil {
+			return m.updateUnlinkPicker(msg)
+		}
+		if m.resolving != nil {
+			return m.updateResolve(msg)
+		}
+		if m.updating {
+			break
+		}
+		switch msg.String() {
+		case "r", "d":
+			// Uninstall: remove from every provider, the store and the lock file
+			if names := m.targets(); len(names) > 0 {
+				m.confirmingUninstall = names
+			}
+			return m, nil
+		case "x":
+			// Unlink from one provider, picked from those linking the skills
+			names := m.targets()
+			choices := m.linkingProviders(names)
+			if len(choices) == 0 {
+				if len(names) > 0 {
+					m.statusMsg = "No provider links " + strings.Join(names, ", ")
+				}
+				return m, nil
+			}
+			m.unlinking, m.unlinkChoices, m.unlinkIdx = names, choices, 0
+			return m, nil
+		case "P":
+			// Toggle listing only the skills some providers lack
+			m.parityOnly = !m.parityOnly
+			m.applyParityFilter()
+			m.selectedIdx, m.paginator.Page = 0, 0
+			m.buildDisplayList()
+			return m, nil
+		case "L":
+			// Link into every provider lacking them
+			return m.harmonize(true)
+		case "X":
+			// Unlink from every provider linking them
+			return m.harmonize(false)
+		case "u":
+			// Update the marked skills, or the one under the cursor
+			names := m.targets()
+			if len(names) == 0 {
+				return m, nil
+			}
+			m.updating = true
+			m.statusMsg = fmt.Sprintf("Updating %s...", strings.Join(names, ", "))
+			return m, runTask("Updating "+strings.Join(names, ", "), updateSkillsCmd(names, skill.StrategyRefuse))
+		case "s", "tab":
+			// Applying links and switching asset sections are provider actions
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.manageModel, cmd = m.manageModel.Update(msg)
+	return m, cmd
+}
+
+func (m installedModel) updateUninstallConfirm(msg tea.KeyMsg) (installedModel, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		names := m.confirmingUninstall
+		m.confirmingUninstall = nil
+		m.updating = true
+		m.statusMsg = "Uninstalling..."
+		return m, runTask("Uninstalling "+strings.Join(names, ", "), func() tea.Msg {
+			res := installedActionMsg{verb: "Uninstalled"}
+			for _, name := range names {
+				if _, err := removeSkill(name); err != nil {
+					res.failed = append(res.failed, fmt.Sprintf("%s: %v", name, err))
+				} else {
+					res.done = append(res.done, name)
+				}
+			}
+			return res
+		})
+	case "n", "esc", "q":
+		m.confirmingUninstall = nil
+	}
+	return m, nil
+}
+
+// updateResolve handles the answer to the local-changes prompt.
+func (m installedModel) updateResolve(msg tea.KeyMsg) (installedModel, tea.Cmd) {
+	names := m.resolving
+	strategy, ok := conflictKey(msg.String())
+	switch {
+	case msg.String() == "n" || msg.String() == "esc" || msg.String() == "q":
+		m.resolving = nil
+		return m, nil
+	case !ok:
+		return m, nil
+	}
+	m.resolving = nil
+	if strategy == skill.KeepLocal {
+		m.statusMsg = "Kept the local changes of " + strings.Join(names, ", ")
+		return m, nil
+	}
+	m.updating = true
+	m.statusMsg = fmt.Sprintf("Updating %s...", strings.Join(names, ", "))
+	return m, runTask("Updating "+strings.Join(names, ", "), updateSkillsCmd(names, strategy))
+}
+
+func (m installedModel) updateUnlinkPicker(msg tea.KeyMsg) (installedModel, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.unlinkIdx > 0 {
+			m.unlinkIdx--
+		}
+	case "down", "j":
+		if m.unlinkIdx < len(m.unlinkChoices)-1 {
+			m.unlinkIdx++
+		}
+	case "enter":
+		p := m.unlinkChoices[m.unlinkIdx]
+		var ops []applyOp
+		for _, name := range m.unlinking {
+			if slices.Contains(m.linkedBy[name], p.Name) {
+				ops = append(ops, unlinkSkillOp(p, name))
+			}
+		}
+		names := m.unlinking
+		m.unlinking, m.unlinkChoices = nil, nil
+		m.updating = true
+		m.statusMsg = "Unlinking from " + p.Name + "..."
+		return m, runTask("Unlinking "+strings.Join(names, ", ")+" from "+p.Name, func() tea.Msg {
+			report := applyConcurrently(map[string][]applyOp{p.Name: ops})
+			recordSyncOutcomes(skill.NewStore(getSkillsPath()), reportOutcomes("apply", report))
+			res := installedActionMsg{verb: "Unlinked from " + p.Name + ":"}
+			for _, r := range report {
+				name := strings.TrimPrefix(r.Asset, "skill ")
+				if r.Err != nil {
+					res.failed = append(res.failed, fmt.Sprintf("%s: %v", name, r.Err))
+				} else {
+					res.done = append(res.done, name)
+				}
+			}
+			return res
+		})
+	case "esc", "q":
+		m.unlinking, m.unlinkChoices = nil, nil
+	}
+	return m, nil
+}
+
+// applyParityFilter limits the list to the skills with a parity gap while
+// the parity filter is on.
+func (m *installedModel) applyParityFilter() {
+	m.onlyNames = nil
+	if m.parityOnly {
+		m.onlyNames = make(map[string]bool)
+		for name := range m.gaps {
+			m.onlyNames[name] = true
+		}
+	}
+}
+
+// harmonize evens out the targets across providers: linking them into every
+// provider lacking them, or unlinking them from every provider linking them.
+func (m installedModel) harmonize(link bool) (installedModel, tea.Cmd) {
+	names := m.targets()
+	verb, label := "Unlinked everywhere:", "Unlinking "+strings.Join(names, ", ")+" from every provider"
+	if link {
+		verb, label = "Linked everywhere:", "Linking "+strings.Join(names, ", ")+" into every provider"
+	}
+	// The ops run with the store they are built from, so they are built
+	// again from the locked store once the task holds the lock
+	opsFor := func(store *skill.Store) map[string][]applyOp {
+		ops := make(map[string][]applyOp)
+		if link {
+			var gaps []parityGap
+			for _, name := range names {
+				if g, ok := m.gaps[name]; ok {
+					gaps = append(gaps, g)
+				}
+			}
+			return harmonizeOps(store, m.providers, gaps)
+		}
+		for _, p := range m.linkingProviders(names) {
+			for _, name := range names {
+				if slices.Contains(m.linkedBy[name], p.Name) {
+					ops[p.Name] = append(ops[p.Name], unlinkSkillOp(p, name))
+				}
+			}
+		}
+		return ops
+	}
+	if len(opsFor(skill.NewStore(getSkillsPath()))) == 0 {
+		if len(names) > 0 {
+			m.statusMsg = "Nothing to change for " + strings.Join(names, ", ")
+		}
+		return m, nil
+	}
+
+	m.updating = true
+	m.statusMsg = label + "..."
+	return m, runTask(label, func() tea.Msg {
+		store, unlock, err := skill.NewStore(getSkillsPath()).Lock()
+		if err != nil {
+			return installedActionMsg{verb: verb, failed: []string{err.Error()}}
+		}
+		report := applyConcurrently(opsFor(store))
+		recordSyncOutcomes(store, reportOutcomes("parity", report))
+		unlock()
+		res := installedActionMsg{verb: verb}
+		for _, r := range report {
+			name := strings.TrimPrefix(r.Asset, "skill ")
+			if r.Err != nil {
+				res.failed = append(res.failed, fmt.Sprintf("%s → %s: %v", name, r.Provider, r.Err))
+			} else if !slices.Contains(res.done, name) {
+				res.done = append(res.done, name)
+			}
+		}
+		return res
+	})
+}
+
+// updateSkillsCmd updates each named skill from upstream, re-rendering its
+// template values and refreshing provider copies like the manage view. With
+// StrategyRefuse skills edited in the store are left alone and reported as
+// conflicts, so the view can ask what to do.
+func updateSkillsCmd(names []string, strategy skill.ConflictStrategy) tea.Cmd {
+	return func() tea.Msg {
+		store := skill.NewStore(getSkillsPath())
+		values := configTemplateValues()
+		res := installedActionMsg{verb: "Updated"}
+		for _, name := range names {
+			if modified, _ := store.LocallyModified(name); modified && strategy == skill.StrategyRefuse {
+				res.conflicts = append(res.conflicts, name)
+				continue
+			}
+			err := store.UpdateSkillWith(name, strategy)
+			if err == nil {
+				err = renderSkill(store, name, values)
+			}
+			if err == nil {
+				err = refreshCopies(store, name)
+			}
+			if err != nil {
+				res.failed = append(res.failed, fmt.Sprintf("%s: %v", name, err))
+			} else {
+				res.done = append(res.done, name)
+			}
+		}
+		return res
+	}
+}
+
+// providersSuffix lists the providers linking a skill, or flags it as
+// linked nowhere.
+func (m installedModel) providersSuffix(s SkillEntry, styled bool) string {
+	text := " · not linked"
+	if linked := m.linkedBy[s.Name]; len(linked) > 0 {
+		text = " → " + strings.Join(linked, ", ")
+	}
+	if g, ok := m.gaps[s.Name]; ok {
+		text += " · missing in " + strings.Join(g.Missing, ", ")
+	}
+	if !styled {
+		return text
+	}
+	return statusMutedStyle.Render(text)
+}
+
+func (m installedModel) View() string {
+	var b strings.Builder
+
+	w := m.width
+	if w <= 0 {
+		w = 80
+	}
+
+	b.WriteString(renderTitleBox("Installed Skills"))
+	b.WriteString("\n")
+
+	if m.loading {
+		b.WriteString(spinnerStyle.Render(withSpinner("Loading...")))
+		return b.String()
+	}
+	if len(m.skills) == 0 {
+		b.WriteString(statusMutedStyle.Render("  No skills in " + displayPath(getSkillsPath())))
+		b.WriteString(renderHelpBar(w, []string{"[esc] back", "[q] quit"}))
+		return b.String()
+	}
+
+	marked, unlinked := 0, 0
+	for _, s := range m.skills {
+		if s.Selected {
+			marked++
+		}
+		if len(m.linkedBy[s.Name]) == 0 {
+			unlinked++
+		}
+	}
+	b.WriteString("\n")
+	subtitle := fmt.Sprintf("%d skills · %d not linked · %d marked", len(m.skills), unlinked, marked)
+	if m.sortMode != sortByGroup {
+		subtitle += " · sorted by " + m.sortMode.String()
+	}
+	if m.tagFilter != "" {
+		subtitle += " · tag: " + m.tagFilter
+	}
+	if len(m.gaps) > 0 {
+		subtitle += fmt.Sprintf(" · %d not on every provider", len(m.gaps))
+	}
+	if m.parityOnly {
+		subtitle += " · parity view"
+	}
+	b.WriteString(subtitleStyle.Render(subtitle))
+	b.WriteString("\n")
+
+	b.WriteString(m.listView(w, m.providersSuffix))
+
+	switch {
+	case m.confirmingUninstall != nil:
+		b.WriteString("\n")
+		prompt := fmt.Sprintf("Uninstall %s? Removes from every provider, the store and config. [y] confirm [n] cancel", strings.Join(m.confirmingUninstall, ", "))
+		b.WriteString(statusWarnStyle.Width(w - 4).Render("  " + prompt))
+	case m.resolving != nil:
+		b.WriteString("\n")
+		b.WriteString(statusWarnStyle.Width(w - 4).Render("  " + conflictQuestion(m.resolving)))
+	case m.unlinking != nil:
+		b.WriteString("\n")
+		b.WriteString(subtitleStyle.Render("  Unlink " + strings.Join(m.unlinking, ", ") + " from:"))
+		b.WriteString("\n")
+		for i, p := range m.unlinkChoices {
+			line := "    " + p.Name
+			if i == m.unlinkIdx {
+				b.WriteString(getSelectedRowStyle(w).Render(line))
+			} else {
+				b.WriteString(tableRowStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
+	case m.updating:
+		b.WriteString("\n")
+		b.WriteString(spinnerStyle.Render("  " + withSpinner(m.statusMsg)))
+	case strings.HasPrefix(m.statusMsg, "Error"):
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("  " + m.statusMsg))
+	case m.statusMsg != "":
+		b.WriteString("\n")
+		b.WriteString(statusMutedStyle.Render("  " + m.statusMsg))
+	}
+
+	if m.unlinking != nil {
+		b.WriteString(renderHelpBar(w, []string{"[up/down] provider", "[enter] unlink", "[esc] cancel"}))
+		return b.String()
+	}
+	helpItems := []string{
+		"[space] preview", "[o] open", "[v] verify", "[u] update", "[U] update all",
+		"[x] unlink from provider", "[P] parity", "[L] link everywhere", "[X] unlink everywhere", "[r] uninstall", "[t] mark", "[a] all", "[n] none",
+		"[enter] collapse/expand", "[[/]] prev/next group", "[S] sort: " + m.sortMode.String(), "[c] config", "[<-/->] page", "[esc] back",
+	}
+	if len(m.allTags()) > 0 {
+		tag := m.tagFilter
+		if tag == "" {
+			tag = "all"
+		}
+		helpItems = append(helpItems, "[#] tag: "+tag)
+	}
+	b.WriteString(renderHelpBar(w, helpItems))
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// installedFixture stores two skills, links one into claude and loads the
+// installed view.
+func installedFixture(t *testing.T) (installedModel, *skill.Store, string) {
+	t.Helper()
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	for _, name := range []string{"go-test", "go-lint"} {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte("# "+name), 0644)
+	}
+	claude := filepath.Join(home, ".claude", "skills")
+	store.LinkToProvider("go-test", claude)
+	saveConfigData(&ConfigData{Providers: []string{"claude"}})
+
+	m := newInstalledModel()
+	m, _ = m.Update(loadInstalled())
+	return m, store, claude
+}
+
+func TestInstalledViewListsProviders(t *testing.T) {
+	m, _, _ := installedFixture(t)
+	if len(m.skills) != 2 {
+		t.Fatalf("skills = %+v, want both stored skills", m.skills)
+	}
+	view := m.View()
+	if !strings.Contains(view, "→ claude") || !strings.Contains(view, "not linked") {
+		t.Errorf("view does not show where skills are linked:\n%s", view)
+	}
+}
+
+func TestInstalledUnlinkFromProvider(t *testing.T) {
+	m, _, claude := installedFixture(t)
+	// Mark both skills; only go-test is linked anywhere
+	m, _ = m.Update(key("a"))
+	m, _ = m.Update(key("x"))
+	if len(m.unlinkChoices) != 1 || m.unlinkChoices[0].Name != "claude" {
+		t.Fatalf("unlink choices = %+v, want claude", m.unlinkChoices)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.unlinking != nil || cmd == nil {
+		t.Fatal("enter should close the picker and unlink")
+	}
+	res := runTaskCmd(cmd).(installedActionMsg)
+	if len(res.done) != 1 || res.done[0] != "go-test" || len(res.failed) != 0 {
+		t.Errorf("unlink result = %+v", res)
+	}
+	if _, err := os.Lstat(filepath.Join(claude, "go-test")); !os.IsNotExist(err) {
+		t.Error("go-test is still linked into claude")
+	}
+}
+
+func TestInstalledUninstallAsksFirst(t *testing.T) {
+	m, store, _ := installedFixture(t)
+	m.selectedIdx = 1 // first skill below the "go" group header
+	target := m.skills[m.displayList[1].skillIdx].Name
+
+	m, _ = m.Update(key("r"))
+	if len(m.confirmingUninstall) != 1 || m.confirmingUninstall[0] != target {
+		t.Fatalf("confirming = %v, want %s", m.confirmingUninstall, target)
+	}
+	m, _ = m.Update(key("n"))
+	if m.confirmingUninstall != nil || !store.IsInstalled(target) {
+		t.Fatal("n should cancel without removing")
+	}
+
+	m, _ = m.Update(key("r"))
+	m, cmd := m.Update(key("y"))
+	if cmd == nil {
+		t.Fatal("y should uninstall")
+	}
+	runTaskCmd(cmd)
+	if store.IsInstalled(target) {
+		t.Errorf("%s is still installed", target)
+	}
+}
+
+func TestInstalledKeepsEscWhilePicking(t *testing.T) {
+	app := initialModel()
+	app.state = viewInstalled
+	app.installedModel = newInstalledModel()
+	app.installedModel.confirmingUninstall = []string{"go-test"}
+
+	next, _ := app.Update(key("esc"))
+	got := next.(model)
+	if got.state != viewInstalled || got.installedModel.confirmingUninstall != nil {
+		t.Errorf("esc should cancel the prompt and stay, state = %v", got.state)
+	}
+}
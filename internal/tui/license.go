@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// LicensePolicy decides which licenses skills may be installed under.
+// Patterns are SPDX identifiers or globs over them, e.g. "GPL-*", matched
+// case-insensitively.
+type LicensePolicy struct {
+	Block       []string `json:"block,omitempty"`        // refuse skills under these licenses
+	Warn        []string `json:"warn,omitempty"`         // install, but warn
+	WarnUnknown bool     `json:"warn_unknown,omitempty"` // warn when no license is found
+}
+
+// fetchRepoLicense is a variable so tests can stub GitHub.
+var fetchRepoLicense = skill.FetchRepoLicense
+
+// licensePolicy returns the license policy from the config.
+func licensePolicy() LicensePolicy {
+	cfg := loadConfigFromFile()
+	if cfg == nil {
+		return LicensePolicy{}
+	}
+	return cfg.LicensePolicy
+}
+
+// matchLicense reports whether license matches one of patterns.
+func matchLicense(patterns []string, license string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(license)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns an error when the policy blocks license, or a warning
+// when it only warns about it.
+func (p LicensePolicy) check(name, license string) (warning string, err error) {
+	if license == "" {
+		if p.WarnUnknown {
+			return fmt.Sprintf("%s has no known license", name), nil
+		}
+		return "", nil
+	}
+	if matchLicense(p.Block, license) {
+		return "", errs.WithHint(errs.Permission, `allow it under "license_policy" in config.json`,
+			"%s is licensed under %s, which the license policy blocks", name, license)
+	}
+	if matchLicense(p.Warn, license) {
+		return fmt.Sprintf("%s is licensed under %s", name, license), nil
+	}
+	return "", nil
+}
+
+// detectLicense returns the license of the skill downloaded to dir from
+// source: the one it declares, else the one GitHub detected for its
+// repository.
+func detectLicense(dir, source string) string {
+	if license := skill.License(dir); license != "" {
+		return license
+	}
+	src, err := skill.ParseSource(source)
+	if err != nil || src.Kind != skill.SourceGitHub {
+		return ""
+	}
+	license, _ := fetchRepoLicense(src.Owner, src.Repo)
+	return license
+}
+
+// licenseVet returns a check of the skill s against the license policy,
+// run by skill.Store.Vet on the downloaded folder before it reaches the
+// store, or nil when the policy blocks nothing. The license found is stored
+// in license.
+func licenseVet(s Skill, license *string) func(dir string) error {
+	policy := licensePolicy()
+	if len(policy.Block) == 0 {
+		return nil
+	}
+	return func(dir string) error {
+		*license = detectLicense(dir, s.Source)
+		_, err := policy.check(s.Name, *license)
+		return err
+	}
+}
+
+// licenseWarning returns what the license policy warns about the installed
+// skill name, "" when nothing.
+func licenseWarning(store *skill.Store, name string) string {
+	lock, _ := store.ReadLockFile()
+	e, _ := lockEntry(lock, name)
+	warning, _ := licensePolicy().check(name, e.License)
+	return warning
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+func TestLicensePolicyCheck(t *testing.T) {
+	policy := LicensePolicy{Block: []string{"GPL-*", "AGPL-*"}, Warn: []string{"cc-by-*"}, WarnUnknown: true}
+	tests := []struct {
+		license string
+		warn    bool
+		blocked bool
+	}{
+		{"MIT", false, false},
+		{"GPL-3.0", false, true},
+		{"gpl-2.0", false, true},
+		{"LGPL-3.0", false, false},
+		{"CC-BY-4.0", true, false},
+		{"", true, false},
+	}
+	for _, tt := range tests {
+		warning, err := policy.check("pdf", tt.license)
+		if (warning != "") != tt.warn || (err != nil) != tt.blocked {
+			t.Errorf("check(%q) = %q, %v; want warn %v, blocked %v", tt.license, warning, err, tt.warn, tt.blocked)
+		}
+		if err != nil && errs.KindOf(err) != errs.Permission {
+			t.Errorf("check(%q) error kind = %v, want Permission", tt.license, errs.KindOf(err))
+		}
+	}
+	if warning, _ := (LicensePolicy{}).check("pdf", ""); warning != "" {
+		t.Errorf("unknown license warned without warn_unknown: %q", warning)
+	}
+}
+
+func TestLicenseVet(t *testing.T) {
+	home := setTestHome(t)
+	orig := fetchRepoLicense
+	fetchRepoLicense = func(owner, repo string) (string, error) { return "GPL-3.0", nil }
+	t.Cleanup(func() { fetchRepoLicense = orig })
+
+	var license string
+	if licenseVet(Skill{Name: "pdf"}, &license) != nil {
+		t.Fatal("vetted installs without a blocking policy")
+	}
+	saveConfigData(&ConfigData{LicensePolicy: LicensePolicy{Block: []string{"GPL-*"}}})
+
+	staged := filepath.Join(home, "staged")
+	write := func(name, frontmatter string) string {
+		dir := filepath.Join(staged, name)
+		os.MkdirAll(dir, 0755)
+		os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: "+name+"\n"+frontmatter+"---\n"), 0644)
+		return dir
+	}
+
+	// The skill's own license wins over the repository's
+	dir := write("pdf", "license: MIT\n")
+	if err := licenseVet(Skill{Name: "pdf", Source: "team/skills"}, &license)(dir); err != nil || license != "MIT" {
+		t.Errorf("vetting pdf = %q, %v; want MIT", license, err)
+	}
+
+	dir = write("docx", "")
+	if err := licenseVet(Skill{Name: "docx", Source: "team/skills"}, &license)(dir); err == nil {
+		t.Error("a GPL repository should be blocked")
+	}
+}
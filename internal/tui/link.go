@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// providerHook returns the external hook managing p, or nil when p is
+// managed with symlinks in its skills directory.
+func providerHook(p Provider) *provider.Hook {
+	if p.Hook == "" {
+		return nil
+	}
+	return &provider.Hook{Provider: p.Name, Command: p.Hook}
+}
+
+// listProviderSkills returns the skill names present for a provider, asking
+// its hook when one is configured.
+func listProviderSkills(p Provider) []string {
+	if h := providerHook(p); h != nil {
+		names, _ := h.List()
+		return names
+	}
+
+	entries, err := os.ReadDir(p.Path)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if !provider.Ignored(e.Name()) {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// providerAssetPath returns where p keeps assets of type t, or "" when the
+// provider does not support that asset type. Custom providers only hold skills.
+func providerAssetPath(p Provider, t provider.AssetType) string {
+	if t == provider.AssetSkills {
+		return p.Path
+	}
+	for _, def := range provider.Definitions() {
+		if def.Name == p.Name {
+			return def.AssetPath(os.Getenv("HOME"), t)
+		}
+	}
+	return ""
+}
+
+// linkSkillToProvider makes a central-store skill available to a provider,
+// either through its hook or by placing it in its skills directory with the
+// provider's link mode. Frozen providers and excluded skills are refused.
+func linkSkillToProvider(store *skill.Store, p Provider, skillName string) error {
+	if p.Frozen {
+		return frozenError(p)
+	}
+	if p.excludes(skillName) {
+		return excludedError(p, skillName)
+	}
+	if h := providerHook(p); h != nil {
+		return h.Link(skillName, filepath.Join(store.BaseDir, skillName))
+	}
+	return store.LinkToProviderMode(skillName, p.Path, p.LinkMode)
+}
+
+// refreshCopies re-copies a skill into the providers that hold a copy or
+// hard links of it, after the stored skill was updated. Symlinked providers
+// see the new files already.
+func refreshCopies(store *skill.Store, skillName string) error {
+	for _, p := range detectProviders() {
+		if !p.Configured || p.Hook != "" || !p.LinkMode.Copied() || p.Frozen {
+			continue
+		}
+		if _, err := os.Lstat(filepath.Join(p.Path, skillName)); err != nil {
+			continue
+		}
+		if err := linkSkillToProvider(store, p, skillName); err != nil {
+			return fmt.Errorf("%s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// unlinkSkillFromProvider removes a skill from a provider, handling both
+// symlinks and real directories for path-based providers. Frozen providers
+// are refused.
+func unlinkSkillFromProvider(p Provider, skillName string) error {
+	if p.Frozen {
+		return frozenError(p)
+	}
+	if h := providerHook(p); h != nil {
+		return h.Unlink(skillName)
+	}
+	return os.RemoveAll(filepath.Join(p.Path, skillName))
+}
+
+// isBrokenLink reports whether path is a symlink whose target is missing.
+func isBrokenLink(path string) bool {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return false
+	}
+	_, err = os.Stat(path)
+	return os.IsNotExist(err)
+}
+
+// brokenProviderLinks returns the names of dangling symlinks in p's skills
+// directory, e.g. left behind when a skill is deleted from the store.
+// Hook-managed providers are not inspected.
+func brokenProviderLinks(p Provider) []string {
+	if p.Hook != "" {
+		return nil
+	}
+	entries, err := os.ReadDir(p.Path)
+	if err != nil {
+		return nil
+	}
+	var broken []string
+	for _, e := range entries {
+		if isBrokenLink(filepath.Join(p.Path, e.Name())) {
+			broken = append(broken, e.Name())
+		}
+	}
+	return broken
+}
+
+// repairBrokenLinks fixes the dangling symlinks of a provider: links whose
+// skill still exists in the store (e.g. after the store moved) are re-pointed
+// at it, the rest are removed.
+func repairBrokenLinks(store *skill.Store, p Provider) (relinked, removed []string, err error) {
+	for _, name := range brokenProviderLinks(p) {
+		if store.IsInstalled(name) {
+			if err := store.LinkToProviderMode(name, p.Path, p.LinkMode); err != nil {
+				return relinked, removed, err
+			}
+			relinked = append(relinked, name)
+			continue
+		}
+		if err := os.Remove(filepath.Join(p.Path, name)); err != nil {
+			return relinked, removed, err
+		}
+		removed = append(removed, name)
+	}
+	return relinked, removed, nil
+}
+
+// linkPointsTo reports whether linkPath is a symlink to target, comparing
+// the link text lexically so dangling links are still recognised.
+func linkPointsTo(linkPath, target string) bool {
+	dest, err := os.Readlink(linkPath)
+	if err != nil {
+		return false
+	}
+	if !filepath.IsAbs(dest) {
+		dest = filepath.Join(filepath.Dir(linkPath), dest)
+	}
+	if filepath.Clean(dest) == filepath.Clean(target) {
+		return true
+	}
+	// Portable links are relative to the resolved provider directory
+	real, err := filepath.EvalSymlinks(linkPath)
+	if err != nil {
+		return false
+	}
+	want, err := filepath.EvalSymlinks(target)
+	return err == nil && real == want
+}
+
+// removeOrphanLinks deletes the symlinks to a store entry left in the skills
+// directories of every known provider, including disabled ones, so removing
+// a skill never leaves dead links behind. It returns the providers cleaned.
+func removeOrphanLinks(store *skill.Store, skillName string) []string {
+	target := filepath.Join(store.BaseDir, skillName)
+	var cleaned []string
+	for _, p := range detectProviders() {
+		if p.Hook != "" || p.Frozen {
+			continue
+		}
+		link := filepath.Join(p.Path, skillName)
+		if linkPointsTo(link, target) && os.Remove(link) == nil {
+			cleaned = append(cleaned, p.Name)
+		}
+	}
+	return cleaned
+}
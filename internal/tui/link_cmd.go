@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunLink links stored skills into the named providers, or every configured
+// one, exactly as applying a selection in the manage view does: a provider
+// that is not configured yet is enabled first, and the results are recorded
+// as its last sync. With link false the skills are unlinked instead.
+func RunLink(names []string, providerNames []string, link bool) error {
+	store := skill.NewStore(getSkillsPath())
+	if link {
+		for _, name := range names {
+			if !store.IsInstalled(name) {
+				return fmt.Errorf("skill %q is not in the store (install it first)", name)
+			}
+		}
+	}
+	targets, err := linkTargets(providerNames)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no configured providers; name them with --provider")
+	}
+
+	var report applyReport
+	for _, p := range targets {
+		present := listProviderSkills(p)
+		var entries []SkillEntry
+		for _, name := range names {
+			linked := slices.Contains(present, name)
+			if linked != link {
+				entries = append(entries, SkillEntry{Name: name, Linked: linked, Selected: link})
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		r, err := applySkillChanges(p, entries)
+		if err != nil {
+			return fmt.Errorf("enabling %s: %w", p.Name, err)
+		}
+		report = append(report, r...)
+	}
+	if len(report) == 0 {
+		fmt.Println("Nothing to change.")
+		return nil
+	}
+
+	for _, line := range report.lines() {
+		fmt.Println("  " + line)
+	}
+	done, skipped, failed := report.counts()
+	fmt.Printf("\n%d done, %d skipped, %d failed\n", done, skipped, failed)
+	return partialFailure(done, report.err())
+}
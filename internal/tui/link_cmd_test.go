@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestRunLinkEnablesProviderLikeManage(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	os.MkdirAll(filepath.Join(store.BaseDir, "go-style"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "go-style", "SKILL.md"), []byte("---\nname: go-style\n---\n"), 0644)
+	cursor := Provider{Name: "cursor", Path: filepath.Join(home, ".cursor", "skills")}
+
+	if err := RunLink([]string{"go-style"}, []string{"cursor"}, true); err != nil {
+		t.Fatalf("RunLink error: %v", err)
+	}
+	if got := listProviderSkills(cursor); len(got) != 1 || got[0] != "go-style" {
+		t.Fatalf("cursor skills after link = %v, want [go-style]", got)
+	}
+	if cfg := loadConfigFromFile(); cfg == nil || !slices.Contains(cfg.Providers, "cursor") {
+		t.Errorf("linking into cursor should enable it, config = %+v", cfg)
+	}
+
+	if err := RunLink([]string{"go-style"}, []string{"cursor"}, false); err != nil {
+		t.Fatalf("RunLink(unlink) error: %v", err)
+	}
+	if got := listProviderSkills(cursor); len(got) != 0 {
+		t.Errorf("cursor skills after unlink = %v, want none", got)
+	}
+	if _, err := os.Stat(filepath.Join(store.BaseDir, "go-style")); err != nil {
+		t.Errorf("unlink removed the stored skill: %v", err)
+	}
+
+	if err := RunLink([]string{"missing"}, []string{"cursor"}, true); err == nil {
+		t.Error("expected an error linking a skill that is not stored")
+	}
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"path/filepath"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// LinkRule links the skills it matches into providers, or keeps them out,
+// whenever skills are installed or synced. A rule matches on a name glob, a
+// frontmatter tag, or both.
+type LinkRule struct {
+	Skill     string   `json:"skill,omitempty"` // name glob, e.g. "experimental-*"
+	Tag       string   `json:"tag,omitempty"`   // tag from the SKILL.md frontmatter
+	Providers []string `json:"providers"`
+	Never     bool     `json:"never,omitempty"` // keep matching skills out of the providers
+}
+
+// matches reports whether the rule applies to a skill.
+func (r LinkRule) matches(name string, tags []string) bool {
+	if r.Skill == "" && r.Tag == "" {
+		return false
+	}
+	if r.Skill != "" {
+		if ok, _ := filepath.Match(r.Skill, name); !ok {
+			return false
+		}
+	}
+	return r.Tag == "" || hasTag(tags, r.Tag)
+}
+
+// linkRules returns the link rules from the config.
+func linkRules() []LinkRule {
+	cfg := loadConfigFromFile()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.LinkRules
+}
+
+// ruleVerdict tells whether the rules link a skill into a provider and
+// whether they keep it out. A never rule wins over a link rule.
+func ruleVerdict(rules []LinkRule, name string, tags []string, providerName string) (link, never bool) {
+	for _, r := range rules {
+		if !r.matches(name, tags) {
+			continue
+		}
+		for _, p := range r.Providers {
+			if p != providerName {
+				continue
+			}
+			if r.Never {
+				never = true
+			} else {
+				link = true
+			}
+		}
+	}
+	return link && !never, never
+}
+
+// applyLinkRules adjusts the providers a freshly installed skill is linked
+// into: configured providers a rule links it to are added, and providers a
+// rule keeps it out of are dropped and returned.
+func applyLinkRules(rules []LinkRule, store *skill.Store, name string, targets []Provider) (kept []Provider, blocked []string) {
+	if len(rules) == 0 {
+		return targets, nil
+	}
+	tags := skill.Tags(filepath.Join(store.BaseDir, name))
+	seen := make(map[string]bool)
+	for _, p := range targets {
+		seen[p.Name] = true
+		if _, never := ruleVerdict(rules, name, tags, p.Name); never {
+			blocked = append(blocked, p.Name)
+			continue
+		}
+		kept = append(kept, p)
+	}
+	for _, p := range detectProviders() {
+		if seen[p.Name] || !p.Configured {
+			continue
+		}
+		if link, _ := ruleVerdict(rules, name, tags, p.Name); link {
+			kept = append(kept, p)
+		}
+	}
+	return kept, blocked
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestRuleVerdict(t *testing.T) {
+	rules := []LinkRule{
+		{Tag: "golang", Providers: []string{"claude", "cursor"}},
+		{Skill: "experimental-*", Providers: []string{"copilot", "cursor"}, Never: true},
+	}
+	tests := []struct {
+		name     string
+		tags     []string
+		provider string
+		link     bool
+		never    bool
+	}{
+		{"go-style", []string{"golang"}, "claude", true, false},
+		{"go-style", []string{"golang"}, "copilot", false, false},
+		{"go-style", nil, "claude", false, false},
+		{"experimental-x", nil, "copilot", false, true},
+		{"experimental-go", []string{"golang"}, "cursor", false, true},
+	}
+	for _, tt := range tests {
+		link, never := ruleVerdict(rules, tt.name, tt.tags, tt.provider)
+		if link != tt.link || never != tt.never {
+			t.Errorf("ruleVerdict(%s, %v, %s) = %v, %v; want %v, %v", tt.name, tt.tags, tt.provider, link, never, tt.link, tt.never)
+		}
+	}
+}
+
+func TestLinkRulesOnSyncAndInstall(t *testing.T) {
+	home := setTestHome(t)
+	if err := saveConfigData(&ConfigData{
+		Providers: []string{"claude", "cursor"},
+		LinkRules: []LinkRule{
+			{Skill: "experimental-*", Providers: []string{"cursor"}, Never: true},
+			{Tag: "golang", Providers: []string{"cursor"}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0755)
+	os.MkdirAll(filepath.Join(home, ".cursor", "skills"), 0755)
+	store := skill.NewStore(getSkillsPath())
+	for name, content := range map[string]string{
+		"experimental-x": "---\nname: experimental-x\n---\n",
+		"go-style":       "---\nname: go-style\ntags: [golang]\n---\n",
+	} {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte(content), 0644)
+	}
+
+	for _, a := range planSync(store, detectProviders()) {
+		if a.Provider == "cursor" && a.Name == "experimental-x" {
+			t.Errorf("planSync links experimental-x into cursor despite a never rule")
+		}
+	}
+
+	var claude Provider
+	for _, p := range detectProviders() {
+		if p.Name == "claude" {
+			claude = p
+		}
+	}
+	kept, blocked := applyLinkRules(linkRules(), store, "go-style", []Provider{claude})
+	if len(kept) != 2 || kept[1].Name != "cursor" || len(blocked) != 0 {
+		t.Errorf("go-style targets = %v, blocked %v; want claude and cursor", kept, blocked)
+	}
+	targets, err := linkTargets(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept, blocked = applyLinkRules(linkRules(), store, "experimental-x", targets)
+	for _, p := range kept {
+		if p.Name == "cursor" {
+			t.Errorf("experimental-x still targets cursor")
+		}
+	}
+	if len(blocked) != 1 || blocked[0] != "cursor" {
+		t.Errorf("blocked = %v, want [cursor]", blocked)
+	}
+}
+
+func TestRunEnableByTag(t *testing.T) {
+	home := setTestHome(t)
+	os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0755)
+	store := skill.NewStore(getSkillsPath())
+	for name, content := range map[string]string{
+		"go-style": "---\nname: go-style\ntags: [golang]\n---\n",
+		"py-style": "---\nname: py-style\ntags: [python]\n---\n",
+	} {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte(content), 0644)
+	}
+
+	if err := RunEnable(nil, "golang", []string{"claude"}, true); err != nil {
+		t.Fatalf("RunEnable error: %v", err)
+	}
+	if got := listProviderSkills(Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills")}); len(got) != 1 || got[0] != "go-style" {
+		t.Fatalf("claude skills after enable --tag golang = %v, want [go-style]", got)
+	}
+	if err := RunEnable(nil, "golang", []string{"claude"}, false); err != nil {
+		t.Fatalf("RunEnable(disable) error: %v", err)
+	}
+	if got := listProviderSkills(Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills")}); len(got) != 0 {
+		t.Errorf("claude skills after disable --tag golang = %v, want none", got)
+	}
+	if err := RunEnable(nil, "rust", nil, true); err == nil {
+		t.Error("expected an error for a tag no stored skill has")
+	}
+}
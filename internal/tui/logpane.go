@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// maxLogLines bounds how much subprocess output is kept in memory.
+const maxLogLines = 500
+
+// commandLog collects the output of external commands (npx, hooks, git)
+// while the TUI owns the terminal, so it never corrupts the screen.
+type commandLog struct {
+	mu      sync.Mutex
+	lines   []string
+	partial string
+	send    func(tea.Msg) // notifies the running program, if any
+}
+
+// logUpdatedMsg tells the app that new command output arrived.
+type logUpdatedMsg struct{}
+
+// Write implements io.Writer, splitting output into lines.
+func (l *commandLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	text := l.partial + strings.ReplaceAll(string(p), "\r\n", "\n")
+	parts := strings.Split(text, "\n")
+	l.partial = parts[len(parts)-1]
+	for _, line := range parts[:len(parts)-1] {
+		// Keep the last frame of carriage-return progress bars
+		if i := strings.LastIndex(line, "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		l.lines = append(l.lines, line)
+	}
+	if len(l.lines) > maxLogLines {
+		l.lines = l.lines[len(l.lines)-maxLogLines:]
+	}
+	send := l.send
+	l.mu.Unlock()
+
+	if send != nil {
+		send(logUpdatedMsg{})
+	}
+	return len(p), nil
+}
+
+// Lines returns a copy of the collected lines, including an unterminated one.
+func (l *commandLog) Lines() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lines := append([]string(nil), l.lines...)
+	if l.partial != "" {
+		lines = append(lines, l.partial)
+	}
+	return lines
+}
+
+// attach routes skill and provider command output into l and notifies p.
+// The returned func restores the previous writers.
+func (l *commandLog) attach(p *tea.Program) func() {
+	prevSkill, prevProvider := skill.CommandOutput, provider.CommandOutput
+	l.mu.Lock()
+	l.send = p.Send
+	l.mu.Unlock()
+	skill.CommandOutput = l
+	provider.CommandOutput = l
+	return func() {
+		skill.CommandOutput, provider.CommandOutput = prevSkill, prevProvider
+		l.mu.Lock()
+		l.send = nil
+		l.mu.Unlock()
+	}
+}
+
+// logPane is a scrollable view over a commandLog, toggled with ctrl+o.
+type logPane struct {
+	log      *commandLog
+	viewport viewport.Model
+	visible  bool
+	seen     int // lines already shown to the user
+}
+
+func newLogPane(log *commandLog) logPane {
+	return logPane{log: log, viewport: viewport.New(80, 10)}
+}
+
+// setSize fits the pane to the terminal, using about a third of its height.
+func (p *logPane) setSize(width, height int) {
+	p.viewport.Width = width - 6 // box border and padding
+	p.viewport.Height = height / 3
+	if p.viewport.Height < 5 {
+		p.viewport.Height = 5
+	}
+}
+
+// refresh reloads the pane content, following the tail when visible.
+func (p *logPane) refresh() {
+	lines := p.log.Lines()
+	p.viewport.SetContent(strings.Join(lines, "\n"))
+	if p.visible {
+		p.viewport.GotoBottom()
+		p.seen = len(lines)
+	}
+}
+
+// unseen reports how many lines arrived since the pane was last open.
+func (p logPane) unseen() int {
+	n := len(p.log.Lines()) - p.seen
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+func (p logPane) Update(msg tea.KeyMsg) (logPane, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+o", "esc":
+		p.visible = false
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return p, cmd
+}
+
+func (p logPane) View() string {
+	if !p.visible {
+		if n := p.unseen(); n > 0 {
+			return "\n" + statusMutedStyle.Render(fmt.Sprintf("  %d new lines of command output — [ctrl+o] show", n))
+		}
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(subtitleStyle.Render("Command Output"))
+	b.WriteString("\n")
+	if len(p.log.Lines()) == 0 {
+		b.WriteString(statusMutedStyle.Render("  No external commands have run yet."))
+	} else {
+		b.WriteString(boxStyle.Render(p.viewport.View()))
+	}
+	b.WriteString("\n")
+	b.WriteString(renderHelpBar(p.viewport.Width, []string{"[↑↓/pgup/pgdn] scroll", "[ctrl+o/esc] hide"}))
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCommandLogSplitsLines(t *testing.T) {
+	l := &commandLog{}
+	fmt.Fprint(l, "first\r\nsec")
+	fmt.Fprint(l, "ond\n10%\r50%\r100%\nrest")
+
+	got := strings.Join(l.Lines(), "|")
+	if want := "first|second|100%|rest"; got != want {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+}
+
+func TestCommandLogKeepsTail(t *testing.T) {
+	l := &commandLog{}
+	for i := 0; i < maxLogLines+10; i++ {
+		fmt.Fprintf(l, "line %d\n", i)
+	}
+
+	lines := l.Lines()
+	if len(lines) != maxLogLines {
+		t.Fatalf("kept %d lines, want %d", len(lines), maxLogLines)
+	}
+	if lines[0] != "line 10" {
+		t.Errorf("oldest kept line = %q, want %q", lines[0], "line 10")
+	}
+}
+
+func TestLogPaneUnseen(t *testing.T) {
+	l := &commandLog{}
+	p := newLogPane(l)
+	fmt.Fprint(l, "a\nb\n")
+	if p.unseen() != 2 {
+		t.Fatalf("unseen = %d, want 2", p.unseen())
+	}
+
+	p.visible = true
+	p.refresh()
+	if p.unseen() != 0 {
+		t.Errorf("unseen after viewing = %d, want 0", p.unseen())
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/paginator"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lmarques/efx-skills/internal/provider"
 	"github.com/lmarques/efx-skills/internal/skill"
 )
 
@@ -28,6 +30,35 @@ type SkillEntry struct {
 	Registry string
 	Owner    string
 	Origin   string // "agents" | "local provider" | "" (empty for registry skills)
+	Tokens   int    // estimated context cost, 0 when unknown
+	Bytes    int64  // size of the SKILL.md or asset file
+	Tags     []string
+
+	InstalledAt time.Time // from config/lock metadata, else file time
+	UpdatedAt   time.Time // last update from the lock file, else file time
+}
+
+// manageSort is the ordering of the manage view list.
+type manageSort int
+
+const (
+	sortByGroup     manageSort = iota // grouped by name prefix (default)
+	sortBySize                        // largest first
+	sortByInstalled                   // newest install first
+	sortByUpdated                     // most recently updated first
+)
+
+func (s manageSort) String() string {
+	switch s {
+	case sortBySize:
+		return "size"
+	case sortByInstalled:
+		return "installed"
+	case sortByUpdated:
+		return "updated"
+	default:
+		return "group"
+	}
 }
 
 // SkillGroup represents a group of skills
@@ -44,15 +75,31 @@ type manageModel struct {
 	groups           []SkillGroup
 	displayList      []displayItem // flat list for rendering (groups + skills)
 	selectedIdx      int
+	nav              vimNav
+	restore          *listPosition // position to restore once entries load
 	width            int
 	height           int
 	paginator        paginator.Model
 	loading          bool
 	err              error
-	statusMsg        string // feedback message shown at bottom of view
-	updating         bool   // true while an update operation is in progress
-	confirmingRemove bool   // true while showing remove confirmation dialog
-	removeTarget     string // skill name being confirmed for removal
+	statusMsg        string             // feedback message shown at bottom of view
+	updating         bool               // true while an update operation is in progress
+	confirmingRemove bool               // true while showing remove confirmation dialog
+	removeTarget     string             // skill name being confirmed for removal
+	conflictSkill    string             // skill edited in the store, waiting for a strategy
+	assetType        provider.AssetType // section being managed; "" means skills
+	budget           *Budget            // limits configured for the provider, read once
+	sortMode         manageSort
+	tagFilter        string                // only list skills with this tag; "" lists all
+	onlyNames        map[string]bool       // when set, only list these skills
+	report           applyReport           // results of the last apply, shown until dismissed
+	stats            map[string]skillStats // cached registry stats by skill name
+}
+
+// applyDoneMsg carries the reloaded entries and what applying changed.
+type applyDoneMsg struct {
+	skills []SkillEntry
+	report applyReport
 }
 
 type displayItem struct {
@@ -76,6 +123,7 @@ type verifySkillMsg struct {
 
 type updateSkillMsg struct {
 	skillName string
+	conflict  bool // edited in the store; waiting for a strategy
 	err       error
 }
 
@@ -116,23 +164,54 @@ func newManageModel(provider Provider) manageModel {
 	p.ActiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("#FBBF24")).Render("● ")
 	p.InactiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render("○ ")
 
-	return manageModel{
+	m := manageModel{
 		provider:  provider,
 		loading:   true,
 		paginator: p,
 	}
+	if b, ok := providerBudget(provider.Name); ok {
+		m.budget = &b
+	}
+	return m
 }
 
 func (m manageModel) Init() tea.Cmd {
-	return func() tea.Msg {
-		skills := loadSkillsForProvider(m.provider)
-		return skillsLoadedMsg{skills: skills}
+	return tea.Batch(
+		func() tea.Msg {
+			return skillsLoadedMsg{skills: m.loadEntries()}
+		},
+		loadSkillStatsCmd(),
+		refreshSkillStatsCmd(),
+	)
+}
+
+// managingSkills reports whether the view shows skills rather than another asset type.
+func (m manageModel) managingSkills() bool {
+	return m.assetType == "" || m.assetType == provider.AssetSkills
+}
+
+// loadEntries loads the entries of the section currently being managed.
+func (m manageModel) loadEntries() []SkillEntry {
+	if m.managingSkills() {
+		return loadSkillsForProvider(m.provider)
 	}
+	return loadAssetsForProvider(m.provider, m.assetType)
 }
 
-func loadSkillsForProvider(provider Provider) []SkillEntry {
-	home := os.Getenv("HOME")
-	skillsDir := filepath.Join(home, ".agents", "skills")
+// nextAssetType returns the section after the current one, cycling through
+// the asset types the provider supports.
+func (m manageModel) nextAssetType() provider.AssetType {
+	types := manageableAssetTypes(m.provider)
+	for i, t := range types {
+		if t == m.assetType || (m.managingSkills() && t == provider.AssetSkills) {
+			return types[(i+1)%len(types)]
+		}
+	}
+	return provider.AssetSkills
+}
+
+func loadSkillsForProvider(p Provider) []SkillEntry {
+	skillsDir := getSkillsPath()
 
 	// Load config for metadata enrichment
 	cfg := loadConfigFromFile()
@@ -150,16 +229,16 @@ func loadSkillsForProvider(provider Provider) []SkillEntry {
 		return skills
 	}
 
+	lock, _ := skill.NewStore(skillsDir).ReadLockFile()
+	index := openStoreIndex()
+	defer index.release()
+
 	// Get linked skills for this provider
 	linkedSkills := make(map[string]bool)
-	if provider.Configured {
-		providerEntries, err := os.ReadDir(provider.Path)
-		if err == nil {
-			for _, e := range providerEntries {
-				if e.Name() != ".DS_Store" {
-					linkedSkills[e.Name()] = true
-				}
-			}
+	if p.Configured {
+		names, _ := index.links(p, skillsDir)
+		for _, name := range names {
+			linkedSkills[name] = true
 		}
 	}
 
@@ -167,7 +246,7 @@ func loadSkillsForProvider(provider Provider) []SkillEntry {
 	centralNames := make(map[string]bool)
 	var allNames []string
 	for _, entry := range entries {
-		if entry.IsDir() && entry.Name() != ".DS_Store" {
+		if skill.IsSkillEntry(skillsDir, entry) {
 			allNames = append(allNames, entry.Name())
 			centralNames[entry.Name()] = true
 		}
@@ -188,7 +267,34 @@ func loadSkillsForProvider(provider Provider) []SkillEntry {
 			Linked:   linkedSkills[name],
 			Selected: linkedSkills[name],
 		}
+		dir := ""
+		if centralNames[name] {
+			dir = filepath.Join(skillsDir, name)
+		} else if p.Hook == "" {
+			dir = filepath.Join(p.Path, name)
+		}
+		le, locked := lockEntry(lock, name)
+		if dir != "" {
+			if s, ok := index.skill(dir, le.SkillFolderHash); ok {
+				entry.Tokens = s.Tokens
+				entry.Tags = s.Tags
+				entry.Bytes = s.Size
+				entry.InstalledAt = s.ModTime
+				entry.UpdatedAt = s.ModTime
+			}
+		}
+		if locked {
+			if t, err := time.Parse(time.RFC3339, le.InstalledAt); err == nil {
+				entry.InstalledAt = t
+			}
+			if t, err := time.Parse(time.RFC3339, le.UpdatedAt); err == nil {
+				entry.UpdatedAt = t
+			}
+		}
 		if meta, ok := metaLookup[name]; ok {
+			if t, err := time.Parse(time.RFC3339, meta.Installed); err == nil {
+				entry.InstalledAt = t
+			}
 			entry.Registry = meta.Registry
 			entry.Owner = meta.Owner
 			// Registry skills: Origin stays "" (unused)
@@ -196,6 +302,8 @@ func loadSkillsForProvider(provider Provider) []SkillEntry {
 			entry.Registry = "" // No SkillMeta
 			if centralNames[name] {
 				entry.Origin = "agents"
+			} else if p.Hook == "" && isBrokenLink(filepath.Join(p.Path, name)) {
+				entry.Origin = "broken link"
 			} else {
 				entry.Origin = "local provider"
 			}
@@ -234,6 +342,10 @@ func (m *manageModel) buildDisplayList() {
 		m.groups = nil
 		return
 	}
+	if m.sortMode != sortByGroup {
+		m.buildSortedList()
+		return
+	}
 
 	// Preserve existing collapsed state BEFORE clearing groups
 	oldCollapsed := make(map[string]bool)
@@ -249,6 +361,9 @@ func (m *manageModel) buildDisplayList() {
 	// Group skills
 	groupMap := make(map[string][]int)
 	for i, skill := range m.skills {
+		if !m.shown(i) {
+			continue
+		}
 		groupMap[skill.Group] = append(groupMap[skill.Group], i)
 	}
 
@@ -303,6 +418,45 @@ func (m *manageModel) buildDisplayList() {
 	m.clampPaginator()
 }
 
+// buildSortedList lays the entries out as a flat list ordered by the active
+// sort mode; groups are not shown outside the default ordering.
+func (m *manageModel) buildSortedList() {
+	var order []int
+	for i := range m.skills {
+		if m.shown(i) {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := m.skills[order[a]], m.skills[order[b]]
+		switch m.sortMode {
+		case sortBySize:
+			if x.Bytes != y.Bytes {
+				return x.Bytes > y.Bytes
+			}
+		case sortByInstalled:
+			if !x.InstalledAt.Equal(y.InstalledAt) {
+				return x.InstalledAt.After(y.InstalledAt)
+			}
+		case sortByUpdated:
+			if !x.UpdatedAt.Equal(y.UpdatedAt) {
+				return x.UpdatedAt.After(y.UpdatedAt)
+			}
+		}
+		return x.Name < y.Name
+	})
+
+	m.groups = nil
+	m.displayList = nil
+	for _, idx := range order {
+		m.displayList = append(m.displayList, displayItem{skillIdx: idx})
+	}
+
+	m.paginator.PerPage = m.effectivePerPage()
+	m.paginator.SetTotalPages(len(m.displayList))
+	m.clampPaginator()
+}
+
 // clampPaginator ensures the paginator Page and selectedIdx stay within valid
 // bounds after any display list change (e.g., collapse/expand, skill removal).
 func (m *manageModel) clampPaginator() {
@@ -372,6 +526,14 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 		m.skills = msg.skills
 		m.buildDisplayList()
 		m.selectedIdx = 0
+		if m.restore != nil {
+			m.selectedIdx = clampIndex(m.restore.selected, len(m.displayList))
+			m.paginator.Page = m.selectedIdx / m.effectivePerPage()
+			m.restore = nil
+		}
+
+	case skillStatsMsg:
+		m.stats = mergeSkillStats(m.stats, msg.stats)
 
 	case verifySkillMsg:
 		m.updating = false
@@ -385,7 +547,10 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 
 	case updateSkillMsg:
 		m.updating = false
-		if msg.err != nil {
+		if msg.conflict {
+			m.conflictSkill = msg.skillName
+			m.statusMsg = conflictQuestion([]string{msg.skillName})
+		} else if msg.err != nil {
 			m.statusMsg = fmt.Sprintf("Error updating %s: %v", msg.skillName, msg.err)
 		} else {
 			m.statusMsg = fmt.Sprintf("Updated %s successfully", msg.skillName)
@@ -407,7 +572,35 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 		m.buildDisplayList()
 		return m, nil
 
+	case applyDoneMsg:
+		if len(msg.report) == 0 {
+			m.statusMsg = "No changes to apply"
+		} else {
+			m.report = msg.report
+		}
+		return m.Update(skillsLoadedMsg{skills: msg.skills})
+
 	case tea.KeyMsg:
+		// The apply report stays up until dismissed or failures are retried
+		if m.report != nil {
+			switch msg.String() {
+			case "r":
+				if _, _, failed := m.report.counts(); failed > 0 {
+					report := m.report
+					m.report = nil
+					m.statusMsg = fmt.Sprintf("Retrying %d change(s)...", failed)
+					return m, runTask(fmt.Sprintf("Retrying %d change(s)", failed), func() tea.Msg {
+						report, retried := report.retryFailed()
+						recordSyncOutcomes(skill.NewStore(getSkillsPath()), reportOutcomes("apply", retried))
+						return applyDoneMsg{skills: m.loadEntries(), report: report}
+					})
+				}
+			case "enter", "esc", "q", "s":
+				m.report = nil
+			}
+			return m, nil
+		}
+
 		// Handle confirmation dialog first (intercepts all keys when active)
 		if m.confirmingRemove {
 			switch msg.String() {
@@ -416,8 +609,12 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 				skillName := m.removeTarget
 				m.removeTarget = ""
 				return m, func() tea.Msg {
-					removeSkillFully(skillName)
-					return skillsLoadedMsg{skills: loadSkillsForProvider(m.provider)}
+					if m.managingSkills() {
+						removeSkill(skillName)
+					} else {
+						removeAssetFully(m.assetType, skillName)
+					}
+					return skillsLoadedMsg{skills: m.loadEntries()}
 				}
 			case "n", "esc":
 				m.confirmingRemove = false
@@ -430,7 +627,46 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 			}
 		}
 
+		if m.conflictSkill != "" {
+			return m.updateConflict(msg)
+		}
+
+		if idx, ok := m.nav.handle(msg.String(), m.selectedIdx, len(m.displayList), m.effectivePerPage()); ok {
+			m.selectedIdx = idx
+			m.paginator.Page = m.selectedIdx / m.effectivePerPage()
+			return m, nil
+		}
+
 		switch msg.String() {
+		case "[", "]":
+			// Jump to the previous/next group header
+			dir := 1
+			if msg.String() == "[" {
+				dir = -1
+			}
+			if idx := m.groupHeaderIndex(m.selectedIdx, dir); idx >= 0 {
+				m.selectedIdx = idx
+				m.paginator.Page = m.selectedIdx / m.effectivePerPage()
+			}
+		case "S":
+			// Cycle list ordering: group -> size -> installed -> updated
+			m.sortMode = (m.sortMode + 1) % (sortByUpdated + 1)
+			m.selectedIdx = 0
+			m.paginator.Page = 0
+			m.buildDisplayList()
+			m.statusMsg = "Sorted by " + m.sortMode.String()
+		case "tab":
+			// Switch between skills, commands and other supported asset types
+			if next := m.nextAssetType(); next != m.assetType {
+				m.assetType = next
+				m.loading = true
+				m.statusMsg = ""
+				m.tagFilter = ""
+				m.groups = nil
+				m.selectedIdx = 0
+				m.paginator.Page = 0
+				return m, m.Init()
+			}
 		case "up", "k":
 			if m.selectedIdx > 0 {
 				m.selectedIdx--
@@ -459,8 +695,10 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 				item := m.displayList[m.selectedIdx]
 				if !item.isGroup {
 					skillName := m.skills[item.skillIdx]
-					home := os.Getenv("HOME")
-					skillPath := filepath.Join(home, ".agents", "skills", skillName.Name, "SKILL.md")
+					skillPath := filepath.Join(getSkillsPath(), skillName.Name, "SKILL.md")
+					if !m.managingSkills() {
+						skillPath = assetFilePath(m.assetType, skillName.Name)
+					}
 					return m, func() tea.Msg {
 						data, err := os.ReadFile(skillPath)
 						if err != nil {
@@ -517,22 +755,48 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 				}
 			}
 		case "a":
-			// Select all
+			// Select all, or all those with the filtered tag
 			for i := range m.skills {
-				m.skills[i].Selected = true
+				if m.shown(i) {
+					m.skills[i].Selected = true
+				}
 			}
 		case "n":
-			// Select none
+			// Select none, or none of those with the filtered tag
 			for i := range m.skills {
-				m.skills[i].Selected = false
+				if m.shown(i) {
+					m.skills[i].Selected = false
+				}
+			}
+		case "#":
+			// Cycle the tag filter: all -> each tag -> all
+			if tags := m.allTags(); len(tags) > 0 {
+				m.tagFilter = nextTag(tags, m.tagFilter)
+				m.selectedIdx = 0
+				m.paginator.Page = 0
+				m.buildDisplayList()
+				if m.tagFilter == "" {
+					m.statusMsg = "Showing all skills"
+				} else {
+					m.statusMsg = "Showing skills tagged " + m.tagFilter
+				}
 			}
+		case "c":
+			// Configuration; esc there comes back to this list as it was
+			return m, func() tea.Msg { return openConfigMsg{provider: m.provider} }
 		case "s":
 			// Apply/save changes
 			return m, func() tea.Msg {
-				if err := applySkillChanges(m.provider, m.skills); err != nil {
-					return errMsg{err: err}
+				var report applyReport
+				if m.managingSkills() {
+					var err error
+					if report, err = applySkillChanges(m.provider, m.skills); err != nil {
+						return errMsg{err: err}
+					}
+				} else {
+					report = applyAssetChanges(m.provider, m.assetType, m.skills)
 				}
-				return skillsLoadedMsg{skills: loadSkillsForProvider(m.provider)}
+				return applyDoneMsg{skills: m.loadEntries(), report: report}
 			}
 		case "o":
 			// Open selected skill or group URL in browser
@@ -563,13 +827,13 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 			}
 		case "v":
 			// Verify selected skill -- check for upstream update
-			if !m.updating && len(m.displayList) > 0 && m.selectedIdx < len(m.displayList) {
+			if m.managingSkills() && !m.updating && len(m.displayList) > 0 && m.selectedIdx < len(m.displayList) {
 				item := m.displayList[m.selectedIdx]
 				if !item.isGroup {
 					skillName := m.skills[item.skillIdx].Name
 					m.updating = true
 					m.statusMsg = "Checking for updates..."
-					return m, func() tea.Msg {
+					return m, runTask("Checking "+skillName, func() tea.Msg {
 						store := skill.NewStore(getSkillsPath())
 						hasUpdate, currentHash, latestHash, err := store.CheckForUpdate(skillName)
 						return verifySkillMsg{
@@ -579,40 +843,40 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 							latestHash:  latestHash,
 							err:         err,
 						}
-					}
+					})
 				}
 			}
 		case "u":
 			// Update selected skill
-			if !m.updating && len(m.displayList) > 0 && m.selectedIdx < len(m.displayList) {
+			if m.managingSkills() && !m.updating && len(m.displayList) > 0 && m.selectedIdx < len(m.displayList) {
 				item := m.displayList[m.selectedIdx]
 				if !item.isGroup {
 					skillName := m.skills[item.skillIdx].Name
 					m.updating = true
 					m.statusMsg = fmt.Sprintf("Updating %s...", skillName)
-					return m, func() tea.Msg {
-						store := skill.NewStore(getSkillsPath())
-						err := store.UpdateSkill(skillName)
-						return updateSkillMsg{
-							skillName: skillName,
-							err:       err,
-						}
-					}
+					return m, updateSkillTask(skillName, skill.StrategyRefuse)
 				}
 			}
-		case "g":
+		case "U":
 			// Global update all skills
-			if !m.updating {
+			if m.managingSkills() && !m.updating {
 				m.updating = true
 				m.statusMsg = "Updating all skills..."
-				return m, func() tea.Msg {
+				return m, runTask("Updating all skills", func() tea.Msg {
 					store := skill.NewStore(getSkillsPath())
 					updated, err := store.UpdateAllSkills()
+					values := configTemplateValues()
+					for _, name := range updated {
+						_ = renderSkill(store, name, values)
+						if cerr := refreshCopies(store, name); cerr != nil && err == nil {
+							err = cerr
+						}
+					}
 					return updateAllMsg{
 						updated: updated,
 						err:     err,
 					}
-				}
+				})
 			}
 		}
 		// Don't pass key messages to paginator (we handle pagination manually)
@@ -622,59 +886,40 @@ func (m manageModel) Update(msg tea.Msg) (manageModel, tea.Cmd) {
 	return m, cmd
 }
 
-func applySkillChanges(provider Provider, skills []SkillEntry) error {
+// applySkillChanges links and unlinks skills so the provider matches the
+// selection, enabling the provider first if needed. The error is only set
+// when the provider could not be enabled; failed changes are in the report.
+func applySkillChanges(provider Provider, skills []SkillEntry) (applyReport, error) {
 	if !provider.Configured {
-		if err := os.MkdirAll(provider.Path, 0755); err != nil {
-			return err
-		}
-		cfg := loadConfigFromFile()
-		if cfg == nil {
-			cfg = &ConfigData{
-				Registries: defaultRegistries(),
-				Repos:      defaultRepos(),
-				SkillsPath: defaultSkillsPath(),
-				Skills:     []SkillMeta{},
-			}
-		}
-		if len(cfg.Providers) == 0 {
-			for _, p := range detectProviders() {
-				if p.Configured && !providerListContains(cfg.Providers, p.Name) {
-					cfg.Providers = append(cfg.Providers, p.Name)
-				}
+		if provider.Hook == "" {
+			if err := os.MkdirAll(provider.Path, 0755); err != nil {
+				return nil, err
 			}
 		}
-		if !providerListContains(cfg.Providers, provider.Name) {
-			cfg.Providers = append(cfg.Providers, provider.Name)
-		}
-		if err := saveConfigData(cfg); err != nil {
-			return err
+		if err := setProviderEnabled(provider.Name, true); err != nil {
+			return nil, err
 		}
 		provider.Configured = true
 	}
 
-	home := os.Getenv("HOME")
-	skillsDir := filepath.Join(home, ".agents", "skills")
-
-	for _, skill := range skills {
-		linkPath := filepath.Join(provider.Path, skill.Name)
-		targetPath := filepath.Join(skillsDir, skill.Name)
-		relPath, err := filepath.Rel(provider.Path, targetPath)
-		if err != nil {
-			return err
-		}
+	store := skill.NewStore(getSkillsPath())
 
-		if skill.Selected && !skill.Linked {
-			if err := os.Symlink(relPath, linkPath); err != nil {
-				return err
-			}
-		} else if !skill.Selected && skill.Linked {
-			if err := os.RemoveAll(linkPath); err != nil {
-				return err
-			}
+	var ops []applyOp
+	for _, s := range skills {
+		if s.Selected && !s.Linked {
+			ops = append(ops, linkSkillOp(store, provider, s.Name))
+		} else if !s.Selected && s.Linked {
+			ops = append(ops, unlinkSkillOp(provider, s.Name))
 		}
 	}
 
-	return nil
+	report := applyConcurrently(map[string][]applyOp{provider.Name: ops})
+	outcomes := reportOutcomes("apply", report)
+	if _, ok := outcomes[provider.Name]; !ok {
+		outcomes[provider.Name] = syncOutcome{Action: "apply"}
+	}
+	recordSyncOutcomes(store, outcomes)
+	return report, nil
 }
 
 func providerListContains(providers []string, target string) bool {
@@ -686,24 +931,6 @@ func providerListContains(providers []string, target string) bool {
 	return false
 }
 
-func removeSkillFully(skillName string) {
-	// 1. Unlink from ALL configured providers
-	for _, p := range detectProviders() {
-		if p.Configured {
-			linkPath := filepath.Join(p.Path, skillName)
-			os.RemoveAll(linkPath) // handles both symlinks and directories
-		}
-	}
-	// 2. Remove from config.json
-	removeSkillFromConfig(skillName)
-	// 3. Remove from lock file
-	store := skill.NewStore(getSkillsPath())
-	store.RemoveFromLock(skillName)
-	// 4. Physically delete skill directory from central storage
-	skillsPath := getSkillsPath()
-	os.RemoveAll(filepath.Join(skillsPath, skillName))
-}
-
 func (m manageModel) View() string {
 	var b strings.Builder
 
@@ -713,31 +940,125 @@ func (m manageModel) View() string {
 	}
 
 	// Title
-	b.WriteString(renderTitleBox(fmt.Sprintf("Manage Provider: %s", m.provider.Name)))
+	title := fmt.Sprintf("Manage Provider: %s", m.provider.Name)
+	if !m.managingSkills() {
+		title += " · " + assetTypeLabel(m.assetType)
+	}
+	b.WriteString(renderTitleBox(title))
 	b.WriteString("\n")
 
 	if m.loading {
-		b.WriteString(spinnerStyle.Render("Loading..."))
+		b.WriteString(spinnerStyle.Render(withSpinner("Loading...")))
 		return b.String()
 	}
 
 	if m.err != nil {
 		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString(renderErrorHint(m.err))
+		return b.String()
+	}
+
+	if m.report != nil {
+		b.WriteString(renderApplyReport(m.report))
+		b.WriteString("\n")
+		help := []string{"[enter/esc] close"}
+		if _, _, failed := m.report.counts(); failed > 0 {
+			help = []string{"[r] retry failed", "[s/esc] skip"}
+		}
+		b.WriteString(renderHelpBar(w, help))
 		return b.String()
 	}
 
 	// Count selected
 	selected := 0
+	selectedTokens := 0
 	for _, s := range m.skills {
 		if s.Selected {
 			selected++
+			selectedTokens += s.Tokens
 		}
 	}
 
 	// Section header
 	b.WriteString("\n")
-	b.WriteString(subtitleStyle.Render(fmt.Sprintf("Skills (%d selected of %d)", selected, len(m.skills))))
+	section := "Skills"
+	if !m.managingSkills() {
+		section = assetTypeLabel(m.assetType)
+	}
+	subtitle := fmt.Sprintf("%s (%d selected of %d · ~%s tokens)", section, selected, len(m.skills), formatTokens(selectedTokens))
+	if m.sortMode != sortByGroup {
+		subtitle += " · sorted by " + m.sortMode.String()
+	}
+	if m.tagFilter != "" {
+		subtitle += " · tag: " + m.tagFilter
+	}
+	b.WriteString(subtitleStyle.Render(subtitle))
 	b.WriteString("\n")
+	if m.managingSkills() && m.budget != nil {
+		if over := m.budget.exceeded(selectionFootprint(m.skills)); len(over) > 0 {
+			b.WriteString(statusWarnStyle.Render("⚠ Over budget: " + strings.Join(over, ", ")))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(m.listView(w, linkChangeSuffix))
+
+	// Status message
+	if m.confirmingRemove || m.conflictSkill != "" {
+		b.WriteString("\n")
+		alertStyle := statusWarnStyle.Width(w - 4)
+		b.WriteString(alertStyle.Render("  " + m.statusMsg))
+	} else if m.updating {
+		b.WriteString("\n")
+		b.WriteString(spinnerStyle.Render("  " + withSpinner(m.statusMsg)))
+	} else if m.statusMsg != "" {
+		b.WriteString("\n")
+		switch {
+		case strings.HasPrefix(m.statusMsg, "Error"):
+			b.WriteString(errorStyle.Render("  " + m.statusMsg))
+		case strings.HasPrefix(m.statusMsg, "Update available"):
+			b.WriteString(statusWarnStyle.Render("  " + m.statusMsg))
+		case strings.HasPrefix(m.statusMsg, "Updated"),
+			strings.HasPrefix(m.statusMsg, "All skills"),
+			strings.Contains(m.statusMsg, "up to date"):
+			b.WriteString(statusOkStyle.Render("  " + m.statusMsg))
+		default:
+			b.WriteString(statusMutedStyle.Render("  " + m.statusMsg))
+		}
+	}
+
+	// Help
+	helpItems := []string{
+		"[space] preview", "[o] open", "[v] verify", "[u] update", "[U] update all",
+		"[t] toggle", "[r] remove", "[enter] collapse/expand", "[[/]] prev/next group", "[S] sort: " + m.sortMode.String(),
+		"[a] all", "[n] none", "[s] apply/save", "[c] config", "[<-/->] page", "[esc] back",
+	}
+	if !m.managingSkills() {
+		helpItems = []string{
+			"[space] preview", "[t] toggle", "[r] remove", "[enter] collapse/expand", "[[/]] prev/next group", "[S] sort: " + m.sortMode.String(),
+			"[a] all", "[n] none", "[s] apply/save", "[<-/->] page", "[esc] back",
+		}
+	}
+	if m.managingSkills() && len(m.allTags()) > 0 {
+		tag := m.tagFilter
+		if tag == "" {
+			tag = "all"
+		}
+		helpItems = append(helpItems, "[#] tag: "+tag)
+	}
+	if len(manageableAssetTypes(m.provider)) > 1 {
+		helpItems = append([]string{"[tab] section"}, helpItems...)
+	}
+	b.WriteString(renderHelpBar(m.width, helpItems))
+
+	return b.String()
+}
+
+// listView renders the current page of the display list: group headers,
+// skills and the pagination dots. suffix is appended to each skill row,
+// styled unless the row is highlighted.
+func (m manageModel) listView(w int, suffix func(s SkillEntry, styled bool) string) string {
+	var b strings.Builder
 
 	// Get page bounds
 	start, end := m.paginator.GetSliceBounds(len(m.displayList))
@@ -805,33 +1126,44 @@ func (m manageModel) View() string {
 
 			// Show skill name without group prefix for cleaner display
 			displayName := skill.Name
-			if strings.HasPrefix(skill.Name, skill.Group+"-") {
+			if m.sortMode == sortByGroup && strings.HasPrefix(skill.Name, skill.Group+"-") {
 				displayName = strings.TrimPrefix(skill.Name, skill.Group+"-")
 			}
 			if skill.Registry != "" {
 				displayName += " (" + registryDisplayName(skill.Registry) + ")"
+			} else if skill.Origin == "broken link" {
+				displayName += errorStyle.Render(" (broken link)")
 			} else if skill.Origin != "" {
 				displayName += " (" + skill.Origin + ")"
 			}
 
-			status := ""
-			if skill.Linked && !skill.Selected {
-				status = " (remove)"
-			} else if !skill.Linked && skill.Selected {
-				status = " (add)"
+			if skill.Tokens > 0 {
+				displayName += statusMutedStyle.Render(" ~" + formatTokens(skill.Tokens))
+			}
+			if m.managingSkills() {
+				if summary := m.stats[skill.Name].summary(); summary != "" {
+					displayName += statusMutedStyle.Render(" " + summary)
+				}
+			}
+			switch m.sortMode {
+			case sortBySize:
+				displayName += statusMutedStyle.Render(" " + formatBytes(skill.Bytes))
+			case sortByInstalled:
+				if !skill.InstalledAt.IsZero() {
+					displayName += statusMutedStyle.Render(" installed " + skill.InstalledAt.Format("2006-01-02"))
+				}
+			case sortByUpdated:
+				if !skill.UpdatedAt.IsZero() {
+					displayName += statusMutedStyle.Render(" updated " + skill.UpdatedAt.Format("2006-01-02"))
+				}
 			}
 
 			if i == m.selectedIdx {
 				plainBullet := "●"
-				line := fmt.Sprintf("    %s %s%s", plainBullet, displayName, status)
+				line := fmt.Sprintf("    %s %s%s", plainBullet, displayName, suffix(skill, false))
 				b.WriteString(getSelectedRowStyle(w).Render(line))
 			} else {
-				line := fmt.Sprintf("    %s %s%s", bullet, displayName, status)
-				if skill.Linked && !skill.Selected {
-					line = fmt.Sprintf("    %s %s%s", bullet, displayName, statusWarnStyle.Render(" (remove)"))
-				} else if !skill.Linked && skill.Selected {
-					line = fmt.Sprintf("    %s %s%s", bullet, displayName, statusOkStyle.Render(" (add)"))
-				}
+				line := fmt.Sprintf("    %s %s%s", bullet, displayName, suffix(skill, true))
 				b.WriteString(tableRowStyle.Render(line))
 			}
 			b.WriteString("\n")
@@ -846,36 +1178,122 @@ func (m manageModel) View() string {
 		b.WriteString("\n")
 	}
 
-	// Status message
-	if m.confirmingRemove {
-		b.WriteString("\n")
-		alertStyle := statusWarnStyle.Width(w - 4)
-		b.WriteString(alertStyle.Render("  " + m.statusMsg))
-	} else if m.updating {
-		b.WriteString("\n")
-		b.WriteString(spinnerStyle.Render("  " + m.statusMsg))
-	} else if m.statusMsg != "" {
-		b.WriteString("\n")
-		switch {
-		case strings.HasPrefix(m.statusMsg, "Error"):
-			b.WriteString(errorStyle.Render("  " + m.statusMsg))
-		case strings.HasPrefix(m.statusMsg, "Update available"):
-			b.WriteString(statusWarnStyle.Render("  " + m.statusMsg))
-		case strings.HasPrefix(m.statusMsg, "Updated"),
-			strings.HasPrefix(m.statusMsg, "All skills"),
-			strings.Contains(m.statusMsg, "up to date"):
-			b.WriteString(statusOkStyle.Render("  " + m.statusMsg))
-		default:
-			b.WriteString(statusMutedStyle.Render("  " + m.statusMsg))
+	return b.String()
+}
+
+// linkChangeSuffix marks skills whose selection differs from the provider.
+func linkChangeSuffix(s SkillEntry, styled bool) string {
+	var text string
+	style := statusOkStyle
+	switch {
+	case s.Linked && !s.Selected:
+		text, style = " (remove)", statusWarnStyle
+	case !s.Linked && s.Selected:
+		text = " (add)"
+	default:
+		return ""
+	}
+	if !styled {
+		return text
+	}
+	return style.Render(text)
+}
+
+// shown reports whether the skill at i passes the tag and name filters.
+func (m manageModel) shown(i int) bool {
+	if m.onlyNames != nil && !m.onlyNames[m.skills[i].Name] {
+		return false
+	}
+	return m.tagFilter == "" || hasTag(m.skills[i].Tags, m.tagFilter)
+}
+
+// allTags lists the tags of the loaded skills, sorted.
+func (m manageModel) allTags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, s := range m.skills {
+		for _, t := range s.Tags {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
 		}
 	}
+	sort.Strings(tags)
+	return tags
+}
 
-	// Help
-	b.WriteString(renderHelpBar(m.width, []string{
-		"[space] preview", "[o] open", "[v] verify", "[u] update", "[g] update all",
-		"[t] toggle", "[r] remove", "[enter] collapse/expand",
-		"[a] all", "[n] none", "[s] apply/save", "[<-/->] page", "[esc] back",
-	}))
+// nextTag returns the tag after current in tags, cycling back to "" (no
+// filter) after the last one.
+func nextTag(tags []string, current string) string {
+	if current == "" {
+		return tags[0]
+	}
+	for i, t := range tags {
+		if t == current && i+1 < len(tags) {
+			return tags[i+1]
+		}
+	}
+	return ""
+}
 
-	return b.String()
+// groupHeaderIndex returns the index of the nearest group header before
+// (dir -1) or after (dir 1) from, or -1 when there is none.
+func (m manageModel) groupHeaderIndex(from, dir int) int {
+	for i := from + dir; i >= 0 && i < len(m.displayList); i += dir {
+		if m.displayList[i].isGroup {
+			return i
+		}
+	}
+	return -1
+}
+
+// updateSkillTask updates skillName from upstream, re-applying the saved
+// template values and refreshing provider copies. With StrategyRefuse a
+// skill edited in the store is not touched and comes back as a conflict,
+// so the view can ask what to do.
+func updateSkillTask(skillName string, strategy skill.ConflictStrategy) tea.Cmd {
+	return runTask("Updating "+skillName, func() tea.Msg {
+		store := skill.NewStore(getSkillsPath())
+		if modified, _ := store.LocallyModified(skillName); modified && strategy == skill.StrategyRefuse {
+			return updateSkillMsg{skillName: skillName, conflict: true}
+		}
+		err := store.UpdateSkillWith(skillName, strategy)
+		if err == nil {
+			// Re-apply saved template values to the fresh copy
+			err = renderSkill(store, skillName, configTemplateValues())
+		}
+		if err == nil {
+			err = refreshCopies(store, skillName)
+		}
+		return updateSkillMsg{skillName: skillName, err: err}
+	})
+}
+
+// updateConflict handles the answer to the local-changes prompt.
+func (m manageModel) updateConflict(msg tea.KeyMsg) (manageModel, tea.Cmd) {
+	name := m.conflictSkill
+	strategy, ok := conflictKey(msg.String())
+	switch {
+	case msg.String() == "n" || msg.String() == "esc" || msg.String() == "q":
+		m.conflictSkill, m.statusMsg = "", ""
+		return m, nil
+	case !ok:
+		return m, nil
+	}
+	m.conflictSkill = ""
+	if strategy == skill.KeepLocal {
+		m.statusMsg = name + " kept with its local changes"
+		return m, nil
+	}
+	m.updating = true
+	m.statusMsg = fmt.Sprintf("Updating %s...", name)
+	return m, updateSkillTask(name, strategy)
+}
+
+// capturesKeys reports whether the local-changes prompt, the apply report
+// or the remove confirmation is open, so esc and q answer it instead of
+// leaving the view.
+func (m manageModel) capturesKeys() bool {
+	return m.conflictSkill != "" || m.report != nil || m.confirmingRemove
 }
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestManageSortModes(t *testing.T) {
+	now := time.Now()
+	m := newManageModel(Provider{Name: "claude"})
+	m.skills = []SkillEntry{
+		{Name: "small-old", Group: "small", Bytes: 10, InstalledAt: now.Add(-48 * time.Hour), UpdatedAt: now},
+		{Name: "big-new", Group: "big", Bytes: 900, InstalledAt: now, UpdatedAt: now.Add(-time.Hour)},
+	}
+
+	m.buildDisplayList()
+	if !m.displayList[0].isGroup {
+		t.Fatal("default ordering should start with a group header")
+	}
+
+	want := map[manageSort]string{
+		sortBySize:      "big-new",
+		sortByInstalled: "big-new",
+		sortByUpdated:   "small-old",
+	}
+	for mode, first := range want {
+		m.sortMode = mode
+		m.buildDisplayList()
+		if len(m.displayList) != 2 || m.displayList[0].isGroup {
+			t.Fatalf("%s: displayList = %+v, want flat list of skills", mode, m.displayList)
+		}
+		if got := m.skills[m.displayList[0].skillIdx].Name; got != first {
+			t.Errorf("%s: first entry = %s, want %s", mode, got, first)
+		}
+	}
+}
+
+func TestManageJumpToGroup(t *testing.T) {
+	m := newManageModel(Provider{Name: "claude"})
+	m.skills = []SkillEntry{
+		{Name: "a1", Group: "alpha"}, {Name: "a2", Group: "alpha"},
+		{Name: "b1", Group: "beta"}, {Name: "c1", Group: "gamma"},
+	}
+	m.buildDisplayList()
+
+	press := func(key string) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+
+	var headers []int
+	for i, d := range m.displayList {
+		if d.isGroup {
+			headers = append(headers, i)
+		}
+	}
+	if len(headers) != 3 {
+		t.Fatalf("expected 3 group headers, got %d", len(headers))
+	}
+
+	press("]")
+	if m.selectedIdx != headers[1] {
+		t.Errorf("] moved to %d, want %d", m.selectedIdx, headers[1])
+	}
+	press("]")
+	press("]") // no further group, stays put
+	if m.selectedIdx != headers[2] {
+		t.Errorf("] at the last group moved to %d, want %d", m.selectedIdx, headers[2])
+	}
+	press("[")
+	if m.selectedIdx != headers[1] {
+		t.Errorf("[ moved to %d, want %d", m.selectedIdx, headers[1])
+	}
+}
+
+func TestManageTagFilter(t *testing.T) {
+	m := newManageModel(Provider{Name: "claude"})
+	m.skills = []SkillEntry{
+		{Name: "go-style", Group: "go", Tags: []string{"golang"}},
+		{Name: "go-test", Group: "go", Tags: []string{"golang", "testing"}},
+		{Name: "py-test", Group: "py", Tags: []string{"testing"}},
+	}
+	m.buildDisplayList()
+
+	press := func(key string) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+	shownNames := func() []string {
+		var names []string
+		for _, d := range m.displayList {
+			if !d.isGroup {
+				names = append(names, m.skills[d.skillIdx].Name)
+			}
+		}
+		return names
+	}
+
+	press("#")
+	if m.tagFilter != "golang" || len(shownNames()) != 2 {
+		t.Fatalf("tag %q shows %v, want the two golang skills", m.tagFilter, shownNames())
+	}
+	press("a")
+	if !m.skills[0].Selected || !m.skills[1].Selected || m.skills[2].Selected {
+		t.Errorf("select all with a tag filter selected %+v", m.skills)
+	}
+	press("#")
+	if got := shownNames(); m.tagFilter != "testing" || len(got) != 2 || got[1] != "py-test" {
+		t.Fatalf("tag %q shows %v, want go-test and py-test", m.tagFilter, got)
+	}
+	press("#")
+	if m.tagFilter != "" || len(shownNames()) != 3 {
+		t.Errorf("filter after the last tag = %q showing %v, want every skill", m.tagFilter, shownNames())
+	}
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/mcp"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// mcpTargets returns the config file of each provider of linkTargets
+// supporting MCP.
+func mcpTargets(providerNames []string) (map[string]string, error) {
+	providers, err := linkTargets(providerNames)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]string)
+	for _, p := range providers {
+		if path := providerAssetPath(p, provider.AssetMCP); path != "" {
+			targets[p.Name] = path
+		}
+	}
+
+	for _, name := range providerNames {
+		if _, ok := targets[name]; !ok {
+			return nil, fmt.Errorf("provider %s does not support MCP servers", name)
+		}
+	}
+	return targets, nil
+}
+
+// parseEnvPairs turns KEY=VALUE arguments into a map.
+func parseEnvPairs(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	env := make(map[string]string)
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid env %q, expected KEY=VALUE", pair)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// RunMCPList prints stored MCP servers and which providers have each enabled.
+func RunMCPList() error {
+	store := skill.NewStore(getSkillsPath())
+	servers, err := mcp.LoadServers(store.AssetDir(provider.AssetMCP))
+	if err != nil {
+		return err
+	}
+
+	// Frozen providers are listed too, so they are not read through mcpTargets
+	enabled := make(map[string]map[string]mcp.Server)
+	for _, p := range detectProviders() {
+		if path := providerAssetPath(p, provider.AssetMCP); p.Configured && path != "" {
+			enabled[p.Name], _ = mcp.ReadProviderServers(path)
+		}
+	}
+
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("MCP servers (%s): %d\n", store.AssetDir(provider.AssetMCP), len(names))
+	for _, name := range names {
+		s := servers[name]
+		var in []string
+		for p, have := range enabled {
+			if current, ok := have[name]; ok && current.Equal(s) {
+				in = append(in, p)
+			}
+		}
+		sort.Strings(in)
+
+		status := "not enabled"
+		if len(in) > 0 {
+			status = strings.Join(in, ", ")
+		}
+		fmt.Printf("  %s  %s %s  → %s\n", name, s.Command, strings.Join(s.Args, " "), status)
+	}
+	return nil
+}
+
+// RunMCPAdd stores an MCP server definition and enables it in providers.
+func RunMCPAdd(name, command string, args, envPairs, providerNames []string) error {
+	env, err := parseEnvPairs(envPairs)
+	if err != nil {
+		return err
+	}
+	targets, err := mcpTargets(providerNames)
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	server := mcp.Server{Command: command, Args: args, Env: env}
+	if err := mcp.SaveServer(store.AssetDir(provider.AssetMCP), name, server); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Saved MCP server %s\n", name)
+
+	return forEachTarget(targets, func(path string) error {
+		return mcp.MergeIntoProvider(path, map[string]mcp.Server{name: server})
+	}, "enabled")
+}
+
+// RunMCPRemove disables an MCP server in providers. Without explicit
+// providers the stored definition is deleted as well.
+func RunMCPRemove(name string, providerNames []string) error {
+	targets, err := mcpTargets(providerNames)
+	if err != nil {
+		return err
+	}
+	if err := forEachTarget(targets, func(path string) error {
+		return mcp.RemoveFromProvider(path, name)
+	}, "disabled"); err != nil {
+		return err
+	}
+
+	if len(providerNames) == 0 {
+		store := skill.NewStore(getSkillsPath())
+		if err := store.RemoveAsset(provider.AssetMCP, name); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Removed MCP server %s\n", name)
+	}
+	return nil
+}
+
+// forEachTarget applies fn to every provider config in sorted order,
+// reporting each result.
+func forEachTarget(targets map[string]string, fn func(path string) error, verb string) error {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var failed []string
+	for _, name := range names {
+		if err := fn(targets[name]); err != nil {
+			fmt.Printf("  ✗ %s: %v\n", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		fmt.Printf("  ✓ %s: %s\n", name, verb)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed for: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
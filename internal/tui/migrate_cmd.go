@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// upstreamLockPath is where the npx skills CLI keeps its global lock file.
+func upstreamLockPath() string {
+	return filepath.Join(os.Getenv("HOME"), ".agents", ".skill-lock.json")
+}
+
+// RunMigrate imports a setup created by the upstream skills CLI: entries of
+// its lock file (lockPath, or the upstream default) are merged into the
+// efx-skills lock, skill folders living next to it are copied into the
+// store when the store path differs, entries written by older CLI versions
+// are normalized, and missing config metadata is backfilled.
+func RunMigrate(lockPath string, dryRun bool) error {
+	if lockPath == "" {
+		lockPath = upstreamLockPath()
+	}
+	if _, err := os.Stat(lockPath); err != nil {
+		return fmt.Errorf("no skills CLI lock file found at %s", lockPath)
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	store, unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	lock, err := store.ReadLockFile()
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", store.LockFile, err)
+	}
+	fmt.Printf("Migrating %s into %s\n", lockPath, store.BaseDir)
+
+	// Merge a lock kept outside the efx-skills store (custom skills-path)
+	var imported []string
+	if filepath.Clean(lockPath) != filepath.Clean(store.LockFile) {
+		upstream := skill.NewStore(filepath.Join(filepath.Dir(lockPath), "skills"))
+		upstream.LockFile = lockPath
+		foreign, err := upstream.ReadLockFile()
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", lockPath, err)
+		}
+
+		names := make([]string, 0, len(foreign.Skills))
+		for name := range foreign.Skills {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if _, ok := lock.Skills[name]; ok {
+				continue
+			}
+			src := filepath.Join(upstream.BaseDir, name)
+			if _, err := os.Stat(src); err == nil && !store.IsInstalled(name) && !dryRun {
+				if err := store.ImportDir(src, name); err != nil {
+					fmt.Printf("  ✗ %s: %v\n", name, err)
+					continue
+				}
+			}
+			lock.Skills[name] = foreign.Skills[name]
+			imported = append(imported, name)
+			if dryRun {
+				fmt.Printf("  • would import %s\n", name)
+			} else {
+				fmt.Printf("  ✓ imported %s\n", name)
+			}
+		}
+	}
+
+	normalized := store.NormalizeLock(lock)
+	for _, name := range normalized {
+		fmt.Printf("  ✓ normalized lock entry %s\n", name)
+	}
+	if dryRun {
+		fmt.Println("\nDry run: nothing written.")
+		return nil
+	}
+	if err := store.WriteLockFile(lock); err != nil {
+		return err
+	}
+
+	// Config metadata for skills the upstream CLI installed
+	tracked := make(map[string]bool)
+	if cfg := loadConfigFromFile(); cfg != nil {
+		for _, meta := range cfg.Skills {
+			tracked[meta.Name] = true
+		}
+	}
+	var untracked []string
+	for name := range lock.Skills {
+		if !tracked[name] {
+			untracked = append(untracked, name)
+		}
+	}
+	sort.Strings(untracked)
+	backfilled, err := BackfillLegacySkills(untracked, store.BaseDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d imported, %d normalized, %d added to config\n", len(imported), len(normalized), len(backfilled))
+	return nil
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestRunMigrateFromUpstreamStore(t *testing.T) {
+	home := setTestHome(t)
+	upstreamDir := filepath.Join(home, ".agents", "skills", "demo")
+	os.MkdirAll(upstreamDir, 0755)
+	os.WriteFile(filepath.Join(upstreamDir, "SKILL.md"), []byte("# Demo"), 0644)
+	os.WriteFile(upstreamLockPath(), []byte(`{"version":1,"skills":{"demo":{"source":"https://github.com/owner/repo.git"}}}`), 0644)
+
+	customPath := filepath.Join(home, "my-skills", "skills")
+	saveConfigData(&ConfigData{SkillsPath: customPath})
+
+	if err := RunMigrate("", false); err != nil {
+		t.Fatalf("RunMigrate error: %v", err)
+	}
+
+	store := skill.NewStore(customPath)
+	if !store.IsInstalled("demo") {
+		t.Fatal("demo not copied into the custom store")
+	}
+	lock, _ := store.ReadLockFile()
+	if e := lock.Skills["demo"]; e.Source != "owner/repo" || lock.Version != skill.LockVersion {
+		t.Fatalf("lock = %+v, want normalized demo entry", lock)
+	}
+	cfg := loadConfigFromFile()
+	if cfg == nil || len(cfg.Skills) != 1 || cfg.Skills[0].Owner != "owner/repo" {
+		t.Fatalf("config skills = %+v, want demo from owner/repo", cfg)
+	}
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"regexp"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// repoQueryPattern matches search queries naming a repository ("owner/repo"),
+// which are browsed instead of sent to the registries.
+var repoQueryPattern = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// browseRepo lists every skill in an owner/repo with one git trees call.
+func browseRepo(source string) ([]Skill, error) {
+	src, err := skill.ParseSource(source)
+	if err != nil {
+		return nil, err
+	}
+	found, err := skill.DiscoverSkills(src.Owner, src.Repo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Skill, 0, len(found))
+	for _, rs := range found {
+		results = append(results, Skill{
+			Name:     rs.Name,
+			Source:   source,
+			Path:     rs.Path,
+			Registry: "github",
+		})
+	}
+	return results, nil
+}
+
+// skillRef returns the owner/repo/path reference used to preview s.
+func skillRef(s Skill) string {
+	if s.Path != "" {
+		return s.Source + "/" + s.Path
+	}
+	return s.Source + "/" + s.Name
+}
+
+// repoGroup returns the repository folder s is nested in, if any.
+func repoGroup(s Skill) string {
+	return skill.RepoSkill{Name: s.Name, Path: s.Path}.Group()
+}
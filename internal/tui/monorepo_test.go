@@ -0,0 +1,30 @@
+package tui
+
+import "testing"
+
+func TestRepoQueryPattern(t *testing.T) {
+	for q, want := range map[string]bool{
+		"owner/repo":         true,
+		"my-org/skills.pack": true,
+		"react hooks":        false,
+		"react":              false,
+		"owner/repo/skill":   false,
+	} {
+		if got := repoQueryPattern.MatchString(q); got != want {
+			t.Errorf("repoQueryPattern(%q) = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestSkillRefUsesRepoPath(t *testing.T) {
+	nested := Skill{Name: "react", Source: "owner/mono", Path: "skills/web/react"}
+	if got := skillRef(nested); got != "owner/mono/skills/web/react" {
+		t.Errorf("skillRef = %q", got)
+	}
+	if got := repoGroup(nested); got != "skills/web" {
+		t.Errorf("repoGroup = %q", got)
+	}
+	if got := skillRef(Skill{Name: "find", Source: "owner/repo"}); got != "owner/repo/find" {
+		t.Errorf("skillRef without path = %q", got)
+	}
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// isFirstRun reports whether efx-skills has never been set up: there is no
+// config.json and the store holds no skill.
+func isFirstRun() bool {
+	if _, err := os.Stat(configFilePath()); err == nil {
+		return false
+	}
+	names, _ := skill.NewStore(getSkillsPath()).ListInstalled()
+	return len(names) == 0
+}
+
+// RunInstallPack installs every skill of a starter pack, as
+// RunCollectionInstall does for collections.
+func RunInstallPack(id string, providerNames []string) error {
+	pack, ok := api.FindCollection(api.ListPacks(), id)
+	if !ok {
+		return fmt.Errorf("unknown pack: %s (see efx-skills install --list-packs)", id)
+	}
+	return installCollectionReport(pack, providerNames)
+}
+
+// RunListPacks prints the starter packs.
+func RunListPacks() error {
+	for _, p := range api.ListPacks() {
+		fmt.Printf("%s %s %s\n", padRight(p.ID, 12), padRight(fmt.Sprintf("%d skills", len(p.Skills)), 10), p.Name)
+		if p.Description != "" {
+			fmt.Printf("  %s\n", statusMutedStyle.Render(p.Description))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// parityGap is a stored skill linked into some configured providers but not
+// into others.
+type parityGap struct {
+	Name    string
+	Linked  []string // providers linking it
+	Missing []string // providers lacking it
+}
+
+// providerLinks maps each skill linked into one of providers to the names of
+// the providers linking it.
+func providerLinks(providers []Provider) map[string][]string {
+	linkedBy := make(map[string][]string)
+	for _, p := range providers {
+		for _, name := range listProviderSkills(p) {
+			linkedBy[name] = append(linkedBy[name], p.Name)
+		}
+	}
+	return linkedBy
+}
+
+// parityGaps lists the stored skills that providers do not all link, in
+// name order. A provider a link rule keeps a skill out of does not count as
+// lacking it.
+func parityGaps(store *skill.Store, providers []Provider, linkedBy map[string][]string) []parityGap {
+	rules := linkRules()
+	var gaps []parityGap
+	for name, linked := range linkedBy {
+		if !store.IsInstalled(name) {
+			continue
+		}
+		var tags []string
+		if len(rules) > 0 {
+			tags = skill.Tags(filepath.Join(store.BaseDir, name))
+		}
+		gap := parityGap{Name: name, Linked: linked}
+		for _, p := range providers {
+			if slices.Contains(linked, p.Name) {
+				continue
+			}
+			if _, never := ruleVerdict(rules, name, tags, p.Name); !never {
+				gap.Missing = append(gap.Missing, p.Name)
+			}
+		}
+		if len(gap.Missing) > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i].Name < gaps[j].Name })
+	return gaps
+}
+
+// harmonizeOps links each gap's skill into the providers lacking it,
+// grouped by provider for applyConcurrently.
+func harmonizeOps(store *skill.Store, providers []Provider, gaps []parityGap) map[string][]applyOp {
+	ops := make(map[string][]applyOp)
+	for _, p := range providers {
+		for _, g := range gaps {
+			if slices.Contains(g.Missing, p.Name) {
+				ops[p.Name] = append(ops[p.Name], linkSkillOp(store, p, g.Name))
+			}
+		}
+	}
+	return ops
+}
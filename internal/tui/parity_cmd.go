@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunParity prints the skills linked into some of the providers but not
+// others, with the command that evens each one out. The providers compared
+// are the named ones, or every configured provider. With link, the skills
+// are linked into the providers lacking them instead. Without it, RunParity
+// fails with ExitOutOfSync when providers differ.
+func RunParity(providerNames []string, link bool) error {
+	providers, err := linkTargets(providerNames)
+	if err != nil {
+		return err
+	}
+	if len(providers) < 2 {
+		return fmt.Errorf("parity needs at least two providers, found %d", len(providers))
+	}
+	store := skill.NewStore(getSkillsPath())
+	gaps := parityGaps(store, providers, providerLinks(providers))
+	if len(gaps) == 0 {
+		fmt.Println("Every provider links the same skills.")
+		return nil
+	}
+
+	if !link {
+		fmt.Printf("Skills not linked everywhere (%d):\n", len(gaps))
+		for _, g := range gaps {
+			fmt.Printf("  ✗ %s: in %s, missing in %s\n", g.Name, strings.Join(g.Linked, ", "), strings.Join(g.Missing, ", "))
+			fmt.Printf("      fix: efx-skills enable %s -p %s\n", g.Name, strings.Join(g.Missing, ","))
+		}
+		return outOfSync("%d skill(s) not linked everywhere; run efx-skills parity --link", len(gaps))
+	}
+
+	store, unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	report := applyConcurrently(harmonizeOps(store, providers, gaps))
+	recordSyncOutcomes(store, reportOutcomes("parity", report))
+	for _, line := range report.lines() {
+		fmt.Println("  " + line)
+	}
+	done, skipped, failed := report.counts()
+	fmt.Printf("\n%d done, %d skipped, %d failed\n", done, skipped, failed)
+	return partialFailure(done, report.err())
+}
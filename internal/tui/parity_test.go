@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParityViewLinksEverywhere(t *testing.T) {
+	m, store, _ := installedFixture(t)
+	saveConfigData(&ConfigData{Providers: []string{"claude", "cursor"}})
+	m, _ = m.Update(loadInstalled())
+
+	if len(m.gaps) != 1 || len(m.gaps["go-test"].Missing) != 1 || m.gaps["go-test"].Missing[0] != "cursor" {
+		t.Fatalf("gaps = %+v, want go-test missing in cursor", m.gaps)
+	}
+
+	// Only go-test is uneven; go-lint is linked nowhere
+	m, _ = m.Update(key("P"))
+	var listed []string
+	for _, item := range m.displayList {
+		if !item.isGroup {
+			listed = append(listed, m.skills[item.skillIdx].Name)
+		}
+	}
+	if len(listed) != 1 || listed[0] != "go-test" {
+		t.Fatalf("parity view lists %v, want only go-test", listed)
+	}
+	for m.displayList[m.selectedIdx].isGroup {
+		m, _ = m.Update(key("j"))
+	}
+
+	m, cmd := m.Update(key("L"))
+	if cmd == nil {
+		t.Fatal("L should link go-test into cursor")
+	}
+	res := runTaskCmd(cmd).(installedActionMsg)
+	if len(res.done) != 1 || len(res.failed) != 0 {
+		t.Fatalf("link result = %+v", res)
+	}
+	home, _ := os.UserHomeDir()
+	if _, err := os.Lstat(filepath.Join(home, ".cursor", "skills", "go-test")); err != nil {
+		t.Errorf("go-test not linked into cursor: %v", err)
+	}
+
+	providers := m.providers
+	if gaps := parityGaps(store, providers, providerLinks(providers)); len(gaps) != 0 {
+		t.Errorf("gaps after linking = %+v, want none", gaps)
+	}
+}
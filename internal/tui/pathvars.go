@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// expandPath resolves "~/" and the variables allowed in configured paths,
+// so one config file works across machines:
+//
+//	{home}        $HOME
+//	{xdg_config}  $XDG_CONFIG_HOME, or ~/.config
+//	{project}     the enclosing git repository, or the working directory
+//
+// Unknown variables are left as they are.
+func expandPath(path string) string {
+	if !strings.Contains(path, "{") {
+		return expandHome(path)
+	}
+	home := os.Getenv("HOME")
+	xdgConfig := os.Getenv("XDG_CONFIG_HOME")
+	if xdgConfig == "" {
+		xdgConfig = filepath.Join(home, ".config")
+	}
+	vars := []string{"{home}", home, "{xdg_config}", xdgConfig}
+	if strings.Contains(path, "{project}") {
+		vars = append(vars, "{project}", projectRoot())
+	}
+	return filepath.Clean(expandHome(strings.NewReplacer(vars...).Replace(path)))
+}
+
+// projectRoot returns the nearest ancestor of the working directory holding
+// a .git entry, or the working directory itself outside a repository.
+func projectRoot() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	for dir := cwd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return cwd
+		}
+	}
+}
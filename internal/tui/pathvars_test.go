@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	home := setTestHome(t)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	repo := filepath.Join(home, "work", "app")
+	os.MkdirAll(filepath.Join(repo, ".git"), 0755)
+	os.MkdirAll(filepath.Join(repo, "src"), 0755)
+	wd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(wd) })
+	os.Chdir(filepath.Join(repo, "src"))
+
+	// Resolve symlinked temp dirs (macOS) the same way Getwd does
+	cwd, _ := os.Getwd()
+	repo = filepath.Dir(cwd)
+
+	tests := map[string]string{
+		"~/.claude/skills":             filepath.Join(home, ".claude", "skills"),
+		"{home}/.claude/skills":        filepath.Join(home, ".claude", "skills"),
+		"{xdg_config}/opencode/skills": filepath.Join(home, ".config", "opencode", "skills"),
+		"{project}/.agent/skills":      filepath.Join(repo, ".agent", "skills"),
+		"/opt/{unknown}/skills":        "/opt/{unknown}/skills",
+		"":                             "",
+	}
+	for in, want := range tests {
+		if got := expandPath(in); got != want {
+			t.Errorf("expandPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got := expandPath("{xdg_config}/efx"); got != "/xdg/efx" {
+		t.Errorf("expandPath with XDG_CONFIG_HOME = %q, want /xdg/efx", got)
+	}
+}
+
+func TestCustomProviderPathUsesVariables(t *testing.T) {
+	home := setTestHome(t)
+	if err := saveConfigData(&ConfigData{
+		CustomProviders: []CustomProvider{{Name: "devbox", Path: "{home}/devbox/skills"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range detectProviders() {
+		if p.Name == "devbox" && p.Path != filepath.Join(home, "devbox", "skills") {
+			t.Errorf("devbox path = %q, want it expanded", p.Path)
+		}
+	}
+}
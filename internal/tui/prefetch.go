@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prefetchDelay is how long a result must stay highlighted before its
+// SKILL.md is fetched, so scrolling through a page does not fire a request
+// per row.
+const prefetchDelay = 300 * time.Millisecond
+
+// previewCacheSize bounds how many prefetched documents are kept.
+const previewCacheSize = 50
+
+// previewCache keeps SKILL.md content fetched ahead of time, keyed by
+// skillRef. It is shared by the whole session and safe for concurrent use.
+type previewCache struct {
+	mu      sync.Mutex
+	content map[string]string
+	order   []string // oldest first
+}
+
+func newPreviewCache() *previewCache {
+	return &previewCache{content: make(map[string]string)}
+}
+
+// sessionPreviewCache is filled by search prefetches and read when a
+// preview opens.
+var sessionPreviewCache = newPreviewCache()
+
+func (c *previewCache) get(ref string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	content, ok := c.content[ref]
+	return content, ok
+}
+
+func (c *previewCache) put(ref, content string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.content[ref]; !ok {
+		c.order = append(c.order, ref)
+	}
+	c.content[ref] = content
+	if len(c.order) > previewCacheSize {
+		delete(c.content, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// prefetchTickMsg fires once ref has been highlighted for prefetchDelay.
+type prefetchTickMsg struct {
+	ref string
+}
+
+// highlightedRef returns the preview reference of the selected result, or
+// "" when no result is selected.
+func (m searchModel) highlightedRef() string {
+	if m.loading || m.selectedIdx < 0 || m.selectedIdx >= len(m.results) {
+		return ""
+	}
+	return skillRef(m.results[m.selectedIdx])
+}
+
+// schedulePrefetch starts the prefetch timer when the highlighted result
+// changed and its content is not cached yet.
+func (m *searchModel) schedulePrefetch() tea.Cmd {
+	ref := m.highlightedRef()
+	if m.previews == nil || ref == m.prefetchRef {
+		return nil
+	}
+	m.prefetchRef = ref
+	if ref == "" {
+		return nil
+	}
+	if _, ok := m.previews.get(ref); ok {
+		return nil
+	}
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return prefetchTickMsg{ref: ref}
+	})
+}
+
+// prefetch fetches ref's content into the cache in the background, unless
+// the selection moved on while the timer ran. Failures are not cached, so
+// the preview reports them when opened.
+func (m searchModel) prefetch(ref string) tea.Cmd {
+	if ref != m.prefetchRef {
+		return nil
+	}
+	if _, ok := m.previews.get(ref); ok {
+		return nil
+	}
+	cache := m.previews
+	return func() tea.Msg {
+		if content, err := fetchSkillContent(ref); err == nil {
+			cache.put(ref, content)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSearchPrefetchesHighlightedResult(t *testing.T) {
+	home := setTestHome(t)
+	dir := filepath.Join(home, ".agents", "skills", "skill-1")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# skill-1"), 0644)
+
+	m := newSearchModel()
+	m.previews = newPreviewCache()
+	m.showResults("demo", testSkills(3), "", 0, 0)
+
+	if cmd := m.schedulePrefetch(); cmd == nil {
+		t.Fatal("expected a prefetch timer for the first result")
+	}
+	if cmd := m.schedulePrefetch(); cmd != nil {
+		t.Error("expected no new timer while the selection is unchanged")
+	}
+
+	// A timer for a result the user already moved past is dropped
+	m.selectedIdx = 1
+	m.schedulePrefetch()
+	if cmd := m.prefetch("owner/repo/skill-0"); cmd != nil {
+		t.Error("expected a stale prefetch to be skipped")
+	}
+
+	cmd := m.prefetch("owner/repo/skill-1")
+	if cmd == nil {
+		t.Fatal("expected a fetch for the highlighted result")
+	}
+	cmd()
+	if content, ok := m.previews.get("owner/repo/skill-1"); !ok || content != "# skill-1" {
+		t.Errorf("cached content = %q, %v; want the SKILL.md", content, ok)
+	}
+}
+
+func TestOpenPreviewUsesPrefetchedContent(t *testing.T) {
+	sessionPreviewCache.put("owner/repo/cached", "# cached")
+	t.Cleanup(func() { sessionPreviewCache = newPreviewCache() })
+
+	m := initialModel()
+	next, _ := m.Update(openPreviewMsg{skill: Skill{Name: "cached", Source: "owner/repo"}})
+	preview := next.(model).previewModel
+	if preview.loading || preview.preloadedContent != "# cached" {
+		t.Errorf("preview loading=%v content=%q, want the prefetched content", preview.loading, preview.preloadedContent)
+	}
+}
+
+func TestPreviewCacheEvictsOldest(t *testing.T) {
+	c := newPreviewCache()
+	for i := 0; i <= previewCacheSize; i++ {
+		c.put(string(rune('a'+i%26))+string(rune('0'+i/26)), "x")
+	}
+	if _, ok := c.get("a0"); ok {
+		t.Error("expected the oldest entry to be evicted")
+	}
+	if len(c.content) != previewCacheSize {
+		t.Errorf("cache holds %d entries, want %d", len(c.content), previewCacheSize)
+	}
+}
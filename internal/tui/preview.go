@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,23 +13,31 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
 )
 
 // previewModel handles the preview view
 type previewModel struct {
 	skillName        string
 	content          string
+	raw              string // markdown before rendering, for saving
 	preloadedContent string
 	viewport         viewport.Model
 	ready            bool
 	loading          bool
 	localOnly        bool
+	badge            string // official/verified label shown in the header
+	skill            Skill  // registry entry being previewed, if any
+	details          *api.SkillDetails
+	notice           string // result of the last save or pager run
 	err              error
 }
 
 // Message types for preview
 type previewContentMsg struct {
 	content string
+	details *api.SkillDetails // set when the registry details were fetched too
 }
 
 type previewErrMsg struct {
@@ -81,14 +90,17 @@ func (m previewModel) Init() tea.Cmd {
 	if m.preloadedContent != "" {
 		// Content already loaded, just render it
 		content := m.preloadedContent
-		return func() tea.Msg {
+		loaded := func() tea.Msg {
 			return previewContentMsg{content: content}
 		}
+		if hasDetails(m.skill) {
+			return tea.Batch(loaded, detailsCmd(m.skill))
+		}
+		return loaded
 	}
 	if m.localOnly {
 		return func() tea.Msg {
-			home := os.Getenv("HOME")
-			localPath := filepath.Join(home, ".agents", "skills", m.skillName, "SKILL.md")
+			localPath := filepath.Join(getSkillsPath(), m.skillName, "SKILL.md")
 			data, err := os.ReadFile(localPath)
 			if err != nil {
 				return previewErrMsg{err: fmt.Errorf("skill file not found: %s", localPath)}
@@ -96,6 +108,9 @@ func (m previewModel) Init() tea.Cmd {
 			return previewContentMsg{content: string(data)}
 		}
 	}
+	if hasDetails(m.skill) {
+		return detailedContentCmd(m.skill, m.skillName)
+	}
 	return func() tea.Msg {
 		content, err := fetchSkillContent(m.skillName)
 		if err != nil {
@@ -114,6 +129,11 @@ func (m previewModel) Update(msg tea.Msg) (previewModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case previewContentMsg:
 		m.loading = false
+		m.raw = msg.content
+		if msg.details != nil {
+			m.details = msg.details
+		}
+		m.details = withLicense(m.details, msg.content)
 		// Render markdown with glamour
 		width := m.viewport.Width
 		if width < 40 {
@@ -134,20 +154,33 @@ func (m previewModel) Update(msg tea.Msg) (previewModel, tea.Cmd) {
 			m.content = msg.content
 		}
 		// Set content in viewport
-		m.viewport.SetContent(m.content)
+		m.viewport.SetContent(m.body())
 		m.viewport.GotoTop()
 
+	case previewDetailsMsg:
+		m.details = withLicense(msg.details, m.raw)
+		if m.content != "" {
+			m.viewport.SetContent(m.body())
+		}
+
 	case previewErrMsg:
 		m.loading = false
 		m.err = msg.err
 
+	case pagerDoneMsg:
+		m.notice = ""
+		if msg.err != nil {
+			m.notice = errorStyle.Render(fmt.Sprintf("Pager failed: %v", msg.err))
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		headerHeight := 3
 		footerHeight := 2
 		m.viewport.Width = msg.Width
 		m.viewport.Height = msg.Height - headerHeight - footerHeight
 		if m.content != "" {
-			m.viewport.SetContent(m.content)
+			m.viewport.SetContent(m.body())
 		}
 
 	case tea.KeyMsg:
@@ -158,6 +191,21 @@ func (m previewModel) Update(msg tea.Msg) (previewModel, tea.Cmd) {
 		case "G":
 			m.viewport.GotoBottom()
 			return m, nil
+		case "w":
+			if m.raw == "" {
+				return m, nil
+			}
+			if p, err := saveRawPreview(m.skillName, m.raw); err != nil {
+				m.notice = errorStyle.Render(fmt.Sprintf("Save failed: %v", err))
+			} else {
+				m.notice = statusOkStyle.Render("✓ Saved SKILL.md to " + displayPath(p))
+			}
+			return m, nil
+		case "p":
+			if m.content == "" {
+				return m, nil
+			}
+			return m, openPager(m.content)
 		}
 	}
 
@@ -168,86 +216,145 @@ func (m previewModel) Update(msg tea.Msg) (previewModel, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// body is the viewport content: the details pane, if any, then the
+// rendered SKILL.md.
+func (m previewModel) body() string {
+	return detailsView(m.details, m.viewport.Width) + m.content
+}
+
 func (m previewModel) headerView() string {
 	title := renderTitleBox(fmt.Sprintf("Preview: %s", m.skillName))
+	if m.badge != "" {
+		title += "\n" + statusOkStyle.Render("  "+m.badge)
+	}
 	return title
 }
 
 func (m previewModel) footerView() string {
 	scrollPct := fmt.Sprintf("%3.0f%%", m.viewport.ScrollPercent()*100)
-	return renderHelpBar(m.viewport.Width, []string{scrollPct, "[j/k/up/down] scroll", "[space/b] page", "[g/G] top/bottom", "[esc] back"})
+	help := renderHelpBar(m.viewport.Width, []string{scrollPct, "[j/k/up/down] scroll", "[space/b] page", "[g/G] top/bottom", "[w] save", "[p] pager", "[esc] back"})
+	if m.notice != "" {
+		return "  " + m.notice + "\n" + help
+	}
+	return help
 }
 
 func (m previewModel) View() string {
 	if m.loading {
-		return fmt.Sprintf("%s\n\n   Loading...", m.headerView())
+		return fmt.Sprintf("%s\n\n   %s", m.headerView(), spinnerStyle.Render(withSpinner("Loading...")))
 	}
 
 	if m.err != nil {
 		return fmt.Sprintf("%s\n\n   %s", 
 			m.headerView(),
-			errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+			errorStyle.Render(fmt.Sprintf("Error: %v", m.err))) + renderErrorHint(m.err)
 	}
 
 	return fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView())
 }
 
-// fetchSkillContent fetches SKILL.md content (local first, then GitHub)
+// fetchSkillContent fetches SKILL.md content (local first, then GitHub).
+// skillName is any source skill.ParseSource reads, or a bare skill name
+// looked up in the store.
 func fetchSkillContent(skillName string) (string, error) {
+	src, err := skill.ParseSource(skillName)
+	if err == nil && src.Kind == skill.SourceLocal {
+		data, err := os.ReadFile(filepath.Join(src.URL, "SKILL.md"))
+		if err != nil {
+			return "", fmt.Errorf("skill documentation not found for %s", skillName)
+		}
+		return string(data), nil
+	}
+
 	// Try local disk first (~/.agents/skills/{name}/SKILL.md)
 	parts := strings.Split(skillName, "/")
 	localName := parts[len(parts)-1]
-	home := os.Getenv("HOME")
-	localPath := filepath.Join(home, ".agents", "skills", localName, "SKILL.md")
+	if err == nil && src.Name() != "" {
+		localName = src.Name()
+	}
+	localPath := filepath.Join(getSkillsPath(), localName, "SKILL.md")
 	if data, err := os.ReadFile(localPath); err == nil {
 		return string(data), nil
 	}
 
-	if len(parts) >= 2 {
-		owner := parts[0]
-		repo := parts[1]
-		skillPath := ""
-		
-		// If format is "owner/repo/skillname", extract skillname
-		if len(parts) >= 3 {
-			skillPath = strings.Join(parts[2:], "/")
-		} else {
+	if err == nil && src.Kind == skill.SourceGitHub {
+		owner, repo := src.Owner, src.Repo
+		skillPath := src.Path
+		if skillPath == "" {
 			skillPath = repo
 		}
+		branches := []string{"main", "master"}
+		if src.Ref != "" {
+			branches = []string{src.Ref}
+		}
 
 		// Try multiple path patterns for GitHub
-		paths := []string{
-			fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/%s/SKILL.md", owner, repo, skillPath),
-			fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/skills/%s/SKILL.md", owner, repo, skillPath),
-			fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/SKILL.md", owner, repo),
-			fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/README.md", owner, repo),
-			fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/master/%s/SKILL.md", owner, repo, skillPath),
-			fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/master/skills/%s/SKILL.md", owner, repo, skillPath),
-			fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/master/SKILL.md", owner, repo),
-			fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/master/README.md", owner, repo),
+		var paths []string
+		for _, branch := range branches {
+			paths = append(paths,
+				skill.RawURL(owner, repo, branch, skillPath+"/SKILL.md"),
+				skill.RawURL(owner, repo, branch, "skills/"+skillPath+"/SKILL.md"),
+				skill.RawURL(owner, repo, branch, "SKILL.md"),
+				skill.RawURL(owner, repo, branch, "README.md"),
+			)
 		}
 
-		client := &http.Client{Timeout: 10 * time.Second}
-		for _, path := range paths {
-			resp, err := client.Get(path)
-			if err != nil {
-				continue
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode == http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					continue
-				}
-				return string(body), nil
-			}
+		if body, ok := fetchFirstFound(paths); ok {
+			return body, nil
 		}
 	}
 
 	return "", fmt.Errorf("skill documentation not found for %s", skillName)
 }
 
+// fetchFirstFound requests every URL at once and returns the body of the
+// earliest one in the list that answers 200 OK, so a skill's own SKILL.md
+// wins over the repository README even when the README answers first. The
+// requests still running are then cancelled. A missing skill fails within
+// one timeout instead of one per URL.
+func fetchFirstFound(urls []string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	type found struct {
+		body string
+		ok   bool
+	}
+	results := make([]chan found, len(urls))
+	for i, u := range urls {
+		results[i] = make(chan found, 1)
+		go func(u string, out chan<- found) {
+			body, ok := fetchCandidate(ctx, u)
+			out <- found{body, ok}
+		}(u, results[i])
+	}
+	for _, ch := range results {
+		if r := <-ch; r.ok {
+			return r.body, true
+		}
+	}
+	return "", false
+}
+
+// fetchCandidate fetches one candidate URL, reporting whether it answered
+// 200 OK.
+func fetchCandidate(ctx context.Context, url string) (string, bool) {
+	req, err := skill.NewGitHubRequest(url)
+	if err != nil {
+		return "", false
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", false
+	}
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err == nil
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// previewDetailsMsg carries the registry details of the previewed skill.
+type previewDetailsMsg struct {
+	details *api.SkillDetails
+}
+
+// hasDetails reports whether the registry of s serves more than its list
+// entries.
+func hasDetails(s Skill) bool {
+	if s.ID == "" {
+		return false
+	}
+	return s.Registry == "playbooks.com" || s.Registry == "skills.sh" || api.FindRegistryPlugin(s.Registry) != nil
+}
+
+// detailsCmd fetches the previewed skill's details. Failures leave the
+// preview without a details pane.
+func detailsCmd(s Skill) tea.Cmd {
+	return func() tea.Msg {
+		details, err := fetchSkillDetails(s)
+		if err != nil {
+			return nil
+		}
+		return previewDetailsMsg{details: details}
+	}
+}
+
+// detailedContentCmd fetches the skill's details, then its SKILL.md from
+// the folder they name, falling back to ref when that path has none.
+func detailedContentCmd(s Skill, ref string) tea.Cmd {
+	return func() tea.Msg {
+		details, err := fetchSkillDetails(s)
+		if err != nil {
+			details = nil
+		}
+		if details != nil && details.Path != "" && details.Source != "" {
+			if content, err := fetchSkillContent(details.Source + "/" + details.Path); err == nil {
+				return previewContentMsg{content: content, details: details}
+			}
+		}
+		content, err := fetchSkillContent(ref)
+		if err != nil {
+			return previewErrMsg{err: err}
+		}
+		return previewContentMsg{content: content, details: details}
+	}
+}
+
+// withLicense fills in the license a SKILL.md declares in its frontmatter
+// when the registry named none, so skills from repos show it too.
+func withLicense(d *api.SkillDetails, content string) *api.SkillDetails {
+	if d != nil && d.License != "" {
+		return d
+	}
+	fields, _ := skill.ParseFrontmatter(content)
+	if fields["license"] == "" {
+		return d
+	}
+	filled := api.SkillDetails{}
+	if d != nil {
+		filled = *d
+	}
+	filled.License = fields["license"]
+	return &filled
+}
+
+// detailsView renders the details pane shown above the SKILL.md: the full
+// description and a line of metadata.
+func detailsView(d *api.SkillDetails, width int) string {
+	if d == nil {
+		return ""
+	}
+	var meta []string
+	if d.Stars > 0 {
+		meta = append(meta, fmt.Sprintf("%d stars", d.Stars))
+	}
+	if d.License != "" {
+		meta = append(meta, d.License)
+	}
+	if d.UpdatedAt != "" {
+		meta = append(meta, "updated "+d.UpdatedAt)
+	}
+	if d.Path != "" {
+		meta = append(meta, d.Source+"/"+d.Path)
+	}
+	if others := otherSources(d); len(others) > 0 {
+		meta = append(meta, "also in "+strings.Join(others, ", "))
+	}
+	if len(d.Tags) > 0 {
+		meta = append(meta, "#"+strings.Join(d.Tags, " #"))
+	}
+	if d.Description == "" && len(meta) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if d.Description != "" {
+		b.WriteString(lipgloss.NewStyle().Width(max(width-4, 20)).PaddingLeft(2).Render(d.Description))
+		b.WriteString("\n")
+	}
+	if len(meta) > 0 {
+		b.WriteString(statusMutedStyle.Render("  " + strings.Join(meta, " · ")))
+		b.WriteString("\n")
+	}
+	b.WriteString("  " + strings.Repeat("─", max(width-4, 0)) + "\n")
+	return b.String()
+}
+
+// otherSources lists the repositories publishing the skill besides the one
+// it resolves to.
+func otherSources(d *api.SkillDetails) []string {
+	var others []string
+	for _, src := range d.Sources {
+		if src.Source != d.Source {
+			others = append(others, src.Source)
+		}
+	}
+	return others
+}
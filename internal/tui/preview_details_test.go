@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/api"
+)
+
+func TestPreviewShowsDetailsPane(t *testing.T) {
+	m := newPreviewModelWithContent("acme/skills/go-style", "# Go style", 80, 30)
+	m, _ = m.Update(previewContentMsg{content: "# Go style"})
+	if strings.Contains(m.View(), "MIT") {
+		t.Fatal("details pane shown before the details arrived")
+	}
+
+	details := &api.SkillDetails{
+		Skill:   Skill{Name: "go-style", Source: "acme/skills", Path: "tools/go-style", Description: "The full description."},
+		License: "MIT",
+	}
+	m, _ = m.Update(previewDetailsMsg{details: details})
+	view := m.View()
+	for _, want := range []string{"The full description.", "MIT", "acme/skills/tools/go-style"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view lacks %q:\n%s", want, view)
+		}
+	}
+	if m.raw != "# Go style" {
+		t.Errorf("raw = %q, want the SKILL.md alone so saving is unchanged", m.raw)
+	}
+}
+
+func TestHasDetails(t *testing.T) {
+	for _, registry := range []string{"playbooks.com", "skills.sh"} {
+		if !hasDetails(Skill{Registry: registry, ID: "acme/skills/go-style"}) {
+			t.Errorf("%s skill with an id has no details", registry)
+		}
+	}
+	if hasDetails(Skill{Registry: "github", ID: "x"}) || hasDetails(Skill{Registry: "playbooks.com"}) {
+		t.Error("details reported for a skill the detail endpoint cannot serve")
+	}
+}
+
+func TestResolveSourceUsesRegistryDetails(t *testing.T) {
+	old := fetchSkillDetails
+	defer func() { fetchSkillDetails = old }()
+	fetchSkillDetails = func(s Skill) (*api.SkillDetails, error) {
+		return &api.SkillDetails{Skill: Skill{Name: s.Name, Source: "acme/skills", Path: "skills/lint"}}, nil
+	}
+
+	got := resolveSource(Skill{ID: "lint", Name: "lint", Source: "fork/skills", Registry: "skills.sh"})
+	if got.Source != "acme/skills" || got.Path != "skills/lint" {
+		t.Errorf("resolveSource = %s at %q, want acme/skills at skills/lint", got.Source, got.Path)
+	}
+
+	fetchSkillDetails = func(Skill) (*api.SkillDetails, error) {
+		return nil, errors.New("offline")
+	}
+	listed := Skill{ID: "lint", Name: "lint", Source: "fork/skills", Registry: "skills.sh"}
+	if got := resolveSource(listed); got.Source != listed.Source || got.Path != "" {
+		t.Errorf("resolveSource = %+v, want the listed skill when the lookup fails", got)
+	}
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pagerDoneMsg reports that the external pager was closed.
+type pagerDoneMsg struct {
+	err error
+}
+
+// exportPath returns a file name in dir for a skill's raw SKILL.md that does
+// not overwrite an earlier export: "<skill>.md", then "<skill>-2.md"...
+func exportPath(dir, skillName string) string {
+	base := path.Base(skillName)
+	p := filepath.Join(dir, base+".md")
+	for i := 2; ; i++ {
+		if _, err := os.Stat(p); os.IsNotExist(err) {
+			return p
+		}
+		p = filepath.Join(dir, fmt.Sprintf("%s-%d.md", base, i))
+	}
+}
+
+// saveRawPreview writes the raw markdown of a previewed skill to the
+// current directory and returns the file written.
+func saveRawPreview(skillName, raw string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	p := exportPath(cwd, skillName)
+	return p, os.WriteFile(p, []byte(raw), 0644)
+}
+
+// pagerCommand builds the $PAGER command (less -R by default) reading
+// content on stdin.
+func pagerCommand(content string) *exec.Cmd {
+	args := strings.Fields(os.Getenv("PAGER"))
+	if len(args) == 0 {
+		args = []string{"less", "-R"}
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	// Keep the rendered colors when less is the pager
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(os.Environ(), "LESS=R")
+	}
+	return cmd
+}
+
+// openPager hands the terminal to the pager until it exits.
+func openPager(content string) tea.Cmd {
+	return tea.ExecProcess(pagerCommand(content), func(err error) tea.Msg {
+		return pagerDoneMsg{err: err}
+	})
+}
+
+// RunPreviewExport prints the raw SKILL.md of a skill, or writes it to out,
+// without starting the TUI.
+func RunPreviewExport(skillName, out string) error {
+	content, err := fetchSkillContent(skillName)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		fmt.Print(content)
+		return nil
+	}
+	if err := os.WriteFile(out, []byte(content), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Saved %s to %s\n", skillName, out)
+	return nil
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExportPathDoesNotOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	first := exportPath(dir, "owner/repo/find-skills")
+	if filepath.Base(first) != "find-skills.md" {
+		t.Fatalf("exportPath = %s, want find-skills.md", first)
+	}
+	os.WriteFile(first, []byte("x"), 0644)
+	if second := exportPath(dir, "find-skills"); filepath.Base(second) != "find-skills-2.md" {
+		t.Fatalf("exportPath after an export = %s, want find-skills-2.md", second)
+	}
+}
+
+func TestPagerCommandUsesPagerEnv(t *testing.T) {
+	t.Setenv("PAGER", "more -s")
+	cmd := pagerCommand("hello")
+	if len(cmd.Args) != 2 || cmd.Args[0] != "more" || cmd.Args[1] != "-s" {
+		t.Fatalf("pager args = %v", cmd.Args)
+	}
+	if data, _ := io.ReadAll(cmd.Stdin); string(data) != "hello" {
+		t.Errorf("pager stdin = %q", data)
+	}
+
+	t.Setenv("PAGER", "")
+	if cmd := pagerCommand(""); cmd.Args[0] != "less" {
+		t.Errorf("default pager = %v, want less", cmd.Args)
+	}
+}
+
+func TestPreviewSaveKeyWritesRawMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	cwd, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	m := newPreviewModel("demo", 80, 24)
+	m, _ = m.Update(previewContentMsg{content: "# Demo\n"})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+
+	if data, err := os.ReadFile(filepath.Join(dir, "demo.md")); err != nil || string(data) != "# Demo\n" {
+		t.Fatalf("saved file = %q, %v", data, err)
+	}
+	if m.notice == "" {
+		t.Error("no confirmation shown after saving")
+	}
+}
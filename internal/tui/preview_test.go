@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchFirstFoundPrefersEarlierCandidates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			time.Sleep(50 * time.Millisecond)
+			http.NotFound(w, r)
+		case "/skill":
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte("# Skill"))
+		case "/readme":
+			w.Write([]byte("# Readme"))
+		case "/hang":
+			// Answers only once the request is cancelled
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	// The README answers first, but the skill comes earlier in the list
+	body, ok := fetchFirstFound([]string{server.URL + "/missing", server.URL + "/skill", server.URL + "/readme"})
+	if !ok || body != "# Skill" {
+		t.Fatalf("fetchFirstFound = %q, %v; want the skill", body, ok)
+	}
+
+	// Candidates after the winner are cancelled, not waited for
+	start := time.Now()
+	if body, ok := fetchFirstFound([]string{server.URL + "/readme", server.URL + "/hang"}); !ok || body != "# Readme" {
+		t.Fatalf("fetchFirstFound = %q, %v; want the readme", body, ok)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("fetchFirstFound waited %v for a cancelled candidate", elapsed)
+	}
+
+	if _, ok := fetchFirstFound([]string{server.URL + "/missing", server.URL + "/missing"}); ok {
+		t.Error("fetchFirstFound found something among missing candidates")
+	}
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// findProvider returns the built-in or custom provider called name.
+func findProvider(name string) (Provider, error) {
+	for _, p := range detectProviders() {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Provider{}, fmt.Errorf("unknown provider: %s (register it with \"efx-skills provider add\")", name)
+}
+
+// RunProviderEnable enables or disables providers in config.json, as
+// toggling them in the config view does. Enabling creates the provider's
+// skills folder when it has none.
+func RunProviderEnable(names []string, enable bool) error {
+	for _, name := range names {
+		p, err := findProvider(name)
+		if err != nil {
+			return err
+		}
+		if p.Configured == enable {
+			fmt.Printf("- %s is already %s\n", name, enabledWord(enable))
+			continue
+		}
+		if enable && p.Hook == "" {
+			if err := os.MkdirAll(p.Path, 0755); err != nil {
+				return err
+			}
+		}
+		if err := setProviderEnabled(name, enable); err != nil {
+			return err
+		}
+		fmt.Printf("✓ %s %s\n", enabledWord(enable), name)
+	}
+	return nil
+}
+
+func enabledWord(enable bool) string {
+	if enable {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// RunProviderAdd registers a custom provider under custom_providers, or
+// overrides the path, hook or link mode of a built-in one, and enables it.
+func RunProviderAdd(name, path, hook, linkMode string) error {
+	if name == "" || strings.ContainsAny(name, `/\ `) {
+		return fmt.Errorf("invalid provider name %q", name)
+	}
+	if _, err := skill.ParseLinkMode(linkMode); err != nil {
+		return err
+	}
+	builtIn := false
+	for _, def := range provider.Definitions() {
+		builtIn = builtIn || def.Name == name
+	}
+	if path == "" && hook == "" && !builtIn {
+		return fmt.Errorf("a custom provider needs --path or --hook")
+	}
+
+	cfg := loadOrDefaultConfig()
+	entry := CustomProvider{Name: name, Path: path, Hook: hook, LinkMode: linkMode}
+	replaced := false
+	for i, c := range cfg.CustomProviders {
+		if c.Name == name {
+			// Exclusions and freezing are edited in config.json; keep them
+			entry.Exclude, entry.Frozen = c.Exclude, c.Frozen
+			cfg.CustomProviders[i], replaced = entry, true
+		}
+	}
+	if !replaced {
+		cfg.CustomProviders = append(cfg.CustomProviders, entry)
+	}
+	if err := saveConfigData(cfg); err != nil {
+		return err
+	}
+
+	verb := "Registered"
+	if builtIn || replaced {
+		verb = "Updated"
+	}
+	fmt.Printf("✓ %s provider %s\n", verb, name)
+	return RunProviderEnable([]string{name}, true)
+}
+
+// RunProviderShow prints the details of one provider: where it links
+// skills, how, what it holds and how its last sync went.
+func RunProviderShow(name string) error {
+	p, err := findProvider(name)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	fmt.Println(p.Name)
+	fmt.Println(strings.Repeat("=", len(p.Name)))
+	fmt.Println()
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Printf("  %s %s\n", padRight(label+":", 14), value)
+		}
+	}
+
+	kind := "custom"
+	for _, def := range provider.Definitions() {
+		if def.Name == p.Name {
+			kind = "built-in"
+		}
+	}
+	if cfg := loadConfigFromFile(); cfg != nil && kind == "built-in" {
+		for _, c := range cfg.CustomProviders {
+			if c.Name == p.Name {
+				kind = "built-in, overridden in config.json"
+			}
+		}
+	}
+	status, _ := providerStatus(p, now)
+	mode := p.LinkMode
+	if mode == "" {
+		mode = skill.LinkSymlink
+	}
+
+	row("Kind", kind)
+	row("Enabled", fmt.Sprintf("%v", p.Configured))
+	if p.Hook != "" {
+		row("Hook", p.Hook)
+	} else {
+		row("Path", displayPath(p.Path))
+		row("Link mode", string(mode))
+	}
+	if p.Frozen {
+		row("Frozen", "yes; sync, apply and install leave it untouched")
+	}
+	if len(p.Exclude) > 0 {
+		row("Excludes", strings.Join(p.Exclude, ", "))
+	}
+	row("Status", status)
+	if p.Configured {
+		row("Skills", fmt.Sprintf("%d", p.SkillCount))
+		if p.AgentCount > 0 {
+			row("Agents", fmt.Sprintf("%d", p.AgentCount))
+		}
+		if len(p.Broken) > 0 {
+			row("Broken links", strings.Join(p.Broken, ", "))
+		}
+	}
+	if b, ok := providerBudget(p.Name); ok {
+		row("Budget", budgetSummary(b))
+	}
+	if o := p.LastResult; !o.At.IsZero() {
+		row("Last "+o.Action, formatAgo(o.At, now)+": "+o.summary())
+		for _, e := range o.Errors {
+			fmt.Printf("  %s ✗ %s\n", padRight("", 14), e)
+		}
+	}
+	return nil
+}
+
+// budgetSummary lists the limits a budget sets.
+func budgetSummary(b Budget) string {
+	var limits []string
+	if b.MaxSkills > 0 {
+		limits = append(limits, fmt.Sprintf("%d skills", b.MaxSkills))
+	}
+	if b.MaxTokens > 0 {
+		limits = append(limits, fmt.Sprintf("~%s tokens", formatTokens(b.MaxTokens)))
+	}
+	if b.MaxBytes > 0 {
+		limits = append(limits, formatBytes(b.MaxBytes))
+	}
+	return strings.Join(limits, ", ")
+}
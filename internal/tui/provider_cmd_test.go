@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestProviderCommandsPersistConfig(t *testing.T) {
+	home := setTestHome(t)
+	os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0755)
+
+	if err := RunProviderAdd("opencode", "{home}/.opencode/skills", "", "copy"); err != nil {
+		t.Fatalf("RunProviderAdd error: %v", err)
+	}
+	p, err := findProvider("opencode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Configured || p.Path != filepath.Join(home, ".opencode", "skills") || p.LinkMode != skill.LinkCopy {
+		t.Errorf("opencode = %+v, want an enabled copy provider under ~/.opencode", p)
+	}
+	if _, err := os.Stat(p.Path); err != nil {
+		t.Errorf("skills folder not created: %v", err)
+	}
+	// claude was detected from its folder and stays enabled
+	cfg := loadConfigFromFile()
+	if !slices.Contains(cfg.Providers, "claude") || !slices.Contains(cfg.Providers, "opencode") {
+		t.Errorf("enabled providers = %v", cfg.Providers)
+	}
+
+	if err := RunProviderEnable([]string{"claude", "opencode"}, false); err != nil {
+		t.Fatalf("disable error: %v", err)
+	}
+	if cfg := loadConfigFromFile(); cfg.Providers == nil || len(cfg.Providers) != 0 {
+		t.Errorf("enabled providers after disabling all = %v, want an empty list", cfg.Providers)
+	}
+	if p, _ := findProvider("claude"); p.Configured {
+		t.Error("claude still enabled")
+	}
+
+	if err := RunProviderAdd("broken", "", "", ""); err == nil {
+		t.Error("expected an error for a custom provider without a path or hook")
+	}
+	if err := RunProviderAdd("odd", "~/odd", "", "junction"); err == nil {
+		t.Error("expected an error for an unknown link mode")
+	}
+	if err := RunProviderEnable([]string{"nope"}, true); err == nil {
+		t.Error("expected an error for an unknown provider")
+	}
+	if err := RunProviderShow("opencode"); err != nil {
+		t.Errorf("RunProviderShow error: %v", err)
+	}
+}
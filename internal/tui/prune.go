@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// pruneKind classifies an entry prune can delete.
+type pruneKind string
+
+const (
+	pruneDangling  pruneKind = "dangling link"    // symlink whose target is gone
+	pruneUnmanaged pruneKind = "unmanaged"        // provider entry not backed by the store
+	pruneStaleLock pruneKind = "stale lock entry" // lock entry without a store directory
+)
+
+// pruneCandidate is one entry proposed for deletion.
+type pruneCandidate struct {
+	Kind     pruneKind
+	Provider string // "" for lock entries
+	Name     string
+	Path     string // file or link to delete; "" for lock entries
+}
+
+// findPruneCandidates lists dangling links and unmanaged entries in the
+// skills directories of configured providers, plus lock entries whose skill
+// directory no longer exists. Hook-managed providers are skipped.
+func findPruneCandidates(store *skill.Store, providers []Provider) []pruneCandidate {
+	var candidates []pruneCandidate
+	others := otherWorkspaceStores()
+
+	for _, p := range providers {
+		if !p.Configured || p.Hook != "" {
+			continue
+		}
+		entries, err := os.ReadDir(p.Path)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			path := filepath.Join(p.Path, name)
+			switch {
+			case provider.Ignored(name), linksIntoAny(path, others):
+				// Links of other workspaces share the provider folders
+				continue
+			case isBrokenLink(path):
+				candidates = append(candidates, pruneCandidate{Kind: pruneDangling, Provider: p.Name, Name: name, Path: path})
+			case !linkPointsTo(path, filepath.Join(store.BaseDir, name)) && !store.IsInstalled(name):
+				candidates = append(candidates, pruneCandidate{Kind: pruneUnmanaged, Provider: p.Name, Name: name, Path: path})
+			}
+		}
+	}
+
+	if lock, err := store.ReadLockFile(); err == nil {
+		var stale []string
+		for name := range lock.Skills {
+			if _, err := os.Stat(filepath.Join(store.BaseDir, name)); os.IsNotExist(err) {
+				stale = append(stale, name)
+			}
+		}
+		sort.Strings(stale)
+		for _, name := range stale {
+			candidates = append(candidates, pruneCandidate{Kind: pruneStaleLock, Name: name})
+		}
+	}
+	return candidates
+}
+
+// applyPrune deletes a single candidate.
+func applyPrune(store *skill.Store, c pruneCandidate) error {
+	if c.Kind == pruneStaleLock {
+		return store.RemoveFromLock(c.Name)
+	}
+	return os.RemoveAll(c.Path)
+}
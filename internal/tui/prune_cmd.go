@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunPrune finds dangling links, unmanaged provider entries and stale lock
+// entries, then asks about each one before deleting it. yes skips the
+// review; dryRun only lists what would be removed.
+func RunPrune(yes, dryRun bool) error {
+	store := skill.NewStore(getSkillsPath())
+	candidates := findPruneCandidates(store, detectProviders())
+	if len(candidates) == 0 {
+		fmt.Println("Nothing to prune.")
+		return nil
+	}
+
+	fmt.Printf("Found %d entries to prune:\n", len(candidates))
+	for _, c := range candidates {
+		fmt.Printf("  • %s\n", describePrune(c))
+	}
+	if dryRun {
+		return nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	removed, failed := 0, 0
+	all := yes
+	for _, c := range candidates {
+		if !all {
+			fmt.Printf("Remove %s? [y]es/[n]o/[a]ll/[q]uit: ", describePrune(c))
+			answer, _ := reader.ReadString('\n')
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "y", "yes":
+			case "a", "all":
+				all = true
+			case "q", "quit":
+				fmt.Printf("\n%d removed, %d failed\n", removed, failed)
+				return nil
+			default:
+				continue
+			}
+		}
+
+		if err := applyPrune(store, c); err != nil {
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", c.Name, err)
+			continue
+		}
+		removed++
+		fmt.Printf("  ✓ removed %s\n", describePrune(c))
+	}
+
+	fmt.Printf("\n%d removed, %d failed\n", removed, failed)
+	if failed > 0 {
+		return fmt.Errorf("prune finished with %d error(s)", failed)
+	}
+	return nil
+}
+
+// describePrune renders a candidate as "<kind> <provider>/<name>".
+func describePrune(c pruneCandidate) string {
+	if c.Provider == "" {
+		return fmt.Sprintf("%s %s", c.Kind, c.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", c.Kind, c.Provider, c.Name)
+}
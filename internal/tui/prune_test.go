@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestFindAndApplyPruneCandidates(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "managed"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "managed", "SKILL.md"), []byte("# Managed"), 0644)
+	store.AddToLock("managed", "owner/repo", "")
+	store.AddToLock("deleted", "owner/repo", "")
+
+	claudeDir := filepath.Join(home, ".claude", "skills")
+	store.LinkToProvider("managed", claudeDir)
+	os.Symlink(filepath.Join(home, "nowhere"), filepath.Join(claudeDir, "dangling"))
+	os.MkdirAll(filepath.Join(claudeDir, "foreign"), 0755)
+
+	claude := Provider{Name: "claude", Path: claudeDir, Configured: true}
+	candidates := findPruneCandidates(store, []Provider{claude})
+
+	kinds := make(map[string]pruneKind)
+	for _, c := range candidates {
+		kinds[c.Name] = c.Kind
+	}
+	want := map[string]pruneKind{"dangling": pruneDangling, "foreign": pruneUnmanaged, "deleted": pruneStaleLock}
+	if len(kinds) != len(want) {
+		t.Fatalf("candidates = %+v, want %v", candidates, want)
+	}
+	for name, kind := range want {
+		if kinds[name] != kind {
+			t.Errorf("%s kind = %q, want %q", name, kinds[name], kind)
+		}
+	}
+
+	for _, c := range candidates {
+		if err := applyPrune(store, c); err != nil {
+			t.Fatalf("applyPrune(%s) error: %v", c.Name, err)
+		}
+	}
+	if again := findPruneCandidates(store, []Provider{claude}); len(again) != 0 {
+		t.Fatalf("candidates after prune = %+v, want none", again)
+	}
+	if _, err := os.Stat(filepath.Join(claudeDir, "managed")); err != nil {
+		t.Fatalf("managed link was pruned: %v", err)
+	}
+}
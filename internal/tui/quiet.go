@@ -0,0 +1,21 @@
+package tui
+
+import "os"
+
+// quietMode is set by --quiet, which leaves only errors on stderr.
+var quietMode bool
+
+// EnableQuiet discards standard output for the rest of the run. The TUI
+// still draws on the terminal, kept in terminalOut, but no screen asks
+// anything in place of a command's default.
+func EnableQuiet() {
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	quietMode = true
+	if terminalOut == nil {
+		terminalOut = os.Stdout
+	}
+	os.Stdout = null
+}
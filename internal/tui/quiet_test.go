@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestQuietKeepsTheTerminalForTheTUI(t *testing.T) {
+	out, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = out
+	t.Cleanup(func() {
+		os.Stdout, terminalOut, quietMode = stdout, nil, false
+	})
+
+	EnableQuiet()
+	if terminalOut != out {
+		t.Error("the TUI should still draw on the original standard output")
+	}
+	if os.Stdout == out {
+		t.Error("standard output should be discarded")
+	}
+	if interactive() {
+		t.Error("nothing should be asked under --quiet")
+	}
+}
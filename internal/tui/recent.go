@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// recentEntry is a locked skill with its latest install or update.
+type recentEntry struct {
+	Name    string
+	Source  string
+	Channel string
+	Event   string // "installed" or "updated"
+	At      time.Time
+}
+
+// recentEntries orders lock entries by their last install or update, newest
+// first. Entries without timestamps sort last.
+func recentEntries(lock *skill.LockFile) []recentEntry {
+	if lock == nil {
+		return nil
+	}
+
+	entries := make([]recentEntry, 0, len(lock.Skills))
+	for name, e := range lock.Skills {
+		installed, _ := time.Parse(time.RFC3339, e.InstalledAt)
+		updated, _ := time.Parse(time.RFC3339, e.UpdatedAt)
+		r := recentEntry{Name: name, Source: e.Source, Channel: e.Channel(), Event: "installed", At: installed}
+		// Installs set both stamps; only a later UpdatedAt is an update
+		if updated.Sub(installed) > time.Minute {
+			r.Event, r.At = "updated", updated
+		}
+		entries = append(entries, r)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].At.Equal(entries[j].At) {
+			return entries[i].At.After(entries[j].At)
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// formatRecentTime renders a timestamp for the recent list.
+func formatRecentTime(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// recentModel lists skills by their latest install or update.
+type recentModel struct {
+	entries     []recentEntry
+	selectedIdx int
+	nav         vimNav
+	width       int
+	height      int
+	loading     bool
+	err         error
+}
+
+type recentLoadedMsg struct {
+	entries []recentEntry
+	err     error
+}
+
+type openRecentMsg struct{}
+
+func newRecentModel() recentModel {
+	return recentModel{loading: true}
+}
+
+func (m recentModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		lock, err := skill.NewStore(getSkillsPath()).ReadLockFile()
+		return recentLoadedMsg{entries: recentEntries(lock), err: err}
+	}
+}
+
+// visibleRows is how many entries fit on screen.
+func (m recentModel) visibleRows() int {
+	if m.height <= 0 {
+		return 15
+	}
+	if rows := m.height - 12; rows > 5 {
+		return rows
+	}
+	return 5
+}
+
+func (m recentModel) Update(msg tea.Msg) (recentModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case recentLoadedMsg:
+		m.loading = false
+		m.entries = msg.entries
+		m.err = msg.err
+
+	case tea.KeyMsg:
+		if idx, ok := m.nav.handle(msg.String(), m.selectedIdx, len(m.entries), m.visibleRows()); ok {
+			m.selectedIdx = idx
+			return m, nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			if m.selectedIdx > 0 {
+				m.selectedIdx--
+			}
+		case "down", "j":
+			if m.selectedIdx < len(m.entries)-1 {
+				m.selectedIdx++
+			}
+		case " ", "enter":
+			if len(m.entries) > 0 {
+				name := m.entries[m.selectedIdx].Name
+				return m, func() tea.Msg {
+					return openLocalPreviewMsg{skillName: name}
+				}
+			}
+		case "r":
+			m.loading = true
+			return m, m.Init()
+		}
+	}
+	return m, nil
+}
+
+func (m recentModel) View() string {
+	var b strings.Builder
+
+	w := m.width
+	if w <= 0 {
+		w = 80
+	}
+
+	b.WriteString(renderTitleBox("Recent Changes"))
+	b.WriteString("\n")
+
+	if m.loading {
+		b.WriteString(spinnerStyle.Render("  " + withSpinner("Loading...")))
+		return b.String()
+	}
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("  Error: %v", m.err)))
+		b.WriteString(renderErrorHint(m.err))
+		return b.String()
+	}
+	if len(m.entries) == 0 {
+		b.WriteString(statusMutedStyle.Render("  No skills in the lock file yet"))
+		b.WriteString(renderHelpBar(m.width, []string{"[esc] back", "[q] quit"}))
+		return b.String()
+	}
+
+	t := recentTable()
+	widths := t.layout(w - 4)
+	b.WriteString(getTableHeaderStyle(w).Render("  " + t.header(widths)))
+	b.WriteString("\n")
+
+	// Keep the cursor in the visible window
+	rows := m.visibleRows()
+	start := 0
+	if m.selectedIdx >= rows {
+		start = m.selectedIdx - rows + 1
+	}
+	end := start + rows
+	if end > len(m.entries) {
+		end = len(m.entries)
+	}
+
+	for i := start; i < end; i++ {
+		e := m.entries[i]
+		source := e.Source
+		if e.Channel != "default" {
+			source += " [" + e.Channel + "]"
+		}
+		row := "  " + t.row(widths, e.Name, e.Event, formatRecentTime(e.At), source)
+		if i == m.selectedIdx {
+			b.WriteString(getSelectedRowStyle(w).Render(row))
+		} else {
+			b.WriteString(tableRowStyle.Render(row))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(statusMutedStyle.Render(fmt.Sprintf("\n  %d of %d", m.selectedIdx+1, len(m.entries))))
+	b.WriteString(renderHelpBar(m.width, []string{"[space/enter] preview", "[up/down] navigate", "[gg/G] top/bottom", "[r] refresh", "[esc] back", "[q] quit"}))
+	return b.String()
+}
+
+// recentTable lays out the recent changes list; the source takes the
+// remaining width and the time is dropped first on narrow terminals.
+func recentTable() table {
+	return table{gap: 2, columns: []tableColumn{
+		{Title: "Skill", Width: 28},
+		{Title: "Event", Width: 10, Priority: 2},
+		{Title: "When", Width: 16, Priority: 1},
+		{Title: "Source", Min: 10},
+	}}
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunListRecent prints locked skills ordered by their latest install or
+// update, newest first.
+func RunListRecent() error {
+	lock, err := skill.NewStore(getSkillsPath()).ReadLockFile()
+	if err != nil {
+		return err
+	}
+
+	entries := recentEntries(lock)
+	if len(entries) == 0 {
+		fmt.Println("No skills in the lock file yet.")
+		return nil
+	}
+
+	fmt.Println("Recent Changes")
+	fmt.Println("==============")
+	for _, e := range entries {
+		fmt.Printf("  %s  %s  %s %s [%s]\n", formatRecentTime(e.At), padRight(e.Event, 9), padRight(e.Name, 30), e.Source, e.Channel)
+	}
+	return nil
+}
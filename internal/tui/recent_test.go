@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestRecentEntriesOrder(t *testing.T) {
+	lock := &skill.LockFile{Skills: map[string]skill.LockEntry{
+		"old":     {Source: "a/b", InstalledAt: "2026-01-01T10:00:00Z", UpdatedAt: "2026-01-01T10:00:00Z"},
+		"updated": {Source: "a/b", InstalledAt: "2026-01-02T10:00:00Z", UpdatedAt: "2026-03-01T10:00:00Z"},
+		"new":     {Source: "a/b", Branch: "next", InstalledAt: "2026-02-01T10:00:00Z", UpdatedAt: "2026-02-01T10:00:00Z"},
+		"legacy":  {Source: "a/b"},
+	}}
+
+	entries := recentEntries(lock)
+	var order []string
+	for _, e := range entries {
+		order = append(order, e.Name)
+	}
+	want := []string{"updated", "new", "old", "legacy"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+
+	if entries[0].Event != "updated" || entries[1].Event != "installed" {
+		t.Errorf("events = %s, %s; want updated, installed", entries[0].Event, entries[1].Event)
+	}
+	if entries[1].Channel != "next" {
+		t.Errorf("channel = %q, want next", entries[1].Channel)
+	}
+	if got := formatRecentTime(entries[3].At); got != "unknown" {
+		t.Errorf("missing timestamp renders as %q, want unknown", got)
+	}
+}
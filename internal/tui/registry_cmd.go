@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/api"
+)
+
+// RunRegistryList prints the registries from config.json, whether each is
+// searched, and the registry plugins found on PATH.
+func RunRegistryList() error {
+	cfg := loadOrDefaultConfig()
+	for _, r := range cfg.Registries {
+		mark := "○"
+		if r.Enabled {
+			mark = "●"
+		}
+		detail := r.URL
+		if r.API != "" {
+			detail += "  (" + r.API + " API)"
+		}
+		fmt.Printf("  %s %s %s\n", mark, padRight(r.Name, 20), detail)
+	}
+	for _, p := range api.DiscoverRegistryPlugins() {
+		fmt.Printf("  ● %s plugin %s\n", padRight(p.Name, 20), displayPath(p.Path))
+	}
+	return nil
+}
+
+// RunRegistryAdd adds a custom registry endpoint serving the skills.sh or
+// playbooks.com API at rawURL, or updates the one named name, and enables
+// it.
+func RunRegistryAdd(name, rawURL, apiName string) error {
+	if name == "" {
+		return fmt.Errorf("registry name is empty")
+	}
+	if name == "skills.sh" || name == "playbooks.com" {
+		return fmt.Errorf("%s is built in; enable or disable it instead", name)
+	}
+	if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an http(s) URL", rawURL)
+	}
+	if apiName != "" && !slices.Contains(api.RegistryAPIs, apiName) {
+		return fmt.Errorf("unknown registry API %q (expected %s)", apiName, strings.Join(api.RegistryAPIs, " or "))
+	}
+
+	cfg := loadOrDefaultConfig()
+	entry := Registry{Name: name, URL: api.RegistryBaseURL(rawURL), API: apiName, Enabled: true}
+	for i, r := range cfg.Registries {
+		if r.Name == name {
+			entry.Mirrors, entry.Token = r.Mirrors, r.Token
+			cfg.Registries[i] = entry
+			fmt.Printf("✓ Updated registry %s\n", name)
+			return saveConfigData(cfg)
+		}
+	}
+	cfg.Registries = append(cfg.Registries, entry)
+	fmt.Printf("✓ Added registry %s\n", name)
+	return saveConfigData(cfg)
+}
+
+// RunRegistryEnable enables or disables registries, as toggling them in
+// the config view does. Disabled registries are left out of searches.
+func RunRegistryEnable(names []string, enable bool) error {
+	cfg := loadOrDefaultConfig()
+	changed := false
+	for _, name := range names {
+		i := slices.IndexFunc(cfg.Registries, func(r Registry) bool { return r.Name == name })
+		if i < 0 {
+			return fmt.Errorf("unknown registry: %s (add it with \"efx-skills registry add\")", name)
+		}
+		if cfg.Registries[i].Enabled == enable {
+			fmt.Printf("- %s is already %s\n", name, enabledWord(enable))
+			continue
+		}
+		cfg.Registries[i].Enabled = enable
+		changed = true
+		fmt.Printf("✓ %s %s\n", enabledWord(enable), name)
+	}
+	if !changed {
+		return nil
+	}
+	return saveConfigData(cfg)
+}
+
+// RunRegistryRemove removes registries from config.json. Registries that
+// are not listed are reported but not an error, so scripts can run it
+// again.
+func RunRegistryRemove(names []string) error {
+	cfg := loadOrDefaultConfig()
+	removed := 0
+	for _, name := range names {
+		i := slices.IndexFunc(cfg.Registries, func(r Registry) bool { return r.Name == name })
+		if i < 0 {
+			fmt.Printf("- %s is not configured\n", name)
+			continue
+		}
+		cfg.Registries = slices.Delete(cfg.Registries, i, i+1)
+		removed++
+		fmt.Printf("✓ removed %s\n", name)
+	}
+	if removed == 0 {
+		return nil
+	}
+	return saveConfigData(cfg)
+}
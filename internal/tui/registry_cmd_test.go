@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"testing"
+)
+
+func TestRegistryCommandsPersistConfig(t *testing.T) {
+	setTestHome(t)
+
+	if err := RunRegistryAdd("corp", "https://skills.mycorp.com/api/search", ""); err != nil {
+		t.Fatalf("RunRegistryAdd error: %v", err)
+	}
+	if err := RunRegistryEnable([]string{"playbooks.com"}, false); err != nil {
+		t.Fatalf("RunRegistryEnable error: %v", err)
+	}
+	cfg := loadConfigFromFile()
+	if len(cfg.Registries) != 3 {
+		t.Fatalf("registries = %+v, want the defaults and corp", cfg.Registries)
+	}
+	if corp := cfg.Registries[2]; corp.Name != "corp" || corp.URL != "https://skills.mycorp.com" || !corp.Enabled {
+		t.Errorf("corp = %+v", corp)
+	}
+	if cfg.Registries[1].Name != "playbooks.com" || cfg.Registries[1].Enabled {
+		t.Errorf("playbooks.com = %+v, want it disabled", cfg.Registries[1])
+	}
+
+	if err := RunRegistryAdd("skills.sh", "https://example.com", ""); err == nil {
+		t.Error("expected an error replacing a built-in registry")
+	}
+	if err := RunRegistryAdd("bad", "https://example.com", "npm"); err == nil {
+		t.Error("expected an error for an unknown API")
+	}
+	if err := RunRegistryEnable([]string{"nope"}, true); err == nil {
+		t.Error("expected an error for an unknown registry")
+	}
+
+	if err := RunRegistryRemove([]string{"corp", "corp"}); err != nil {
+		t.Fatalf("RunRegistryRemove error: %v", err)
+	}
+	if got := len(loadConfigFromFile().Registries); got != 2 {
+		t.Errorf("registries after removing corp = %d, want 2", got)
+	}
+}
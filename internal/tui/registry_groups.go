@@ -0,0 +1,28 @@
+package tui
+
+import "sort"
+
+// groupByRegistry orders results registry by registry, keeping the order in
+// which the registries first appear and each registry's own ranking.
+func groupByRegistry(results []Skill) []Skill {
+	order := make(map[string]int)
+	for _, s := range results {
+		if _, ok := order[s.Registry]; !ok {
+			order[s.Registry] = len(order)
+		}
+	}
+	grouped := append([]Skill(nil), results...)
+	sort.SliceStable(grouped, func(i, j int) bool {
+		return order[grouped[i].Registry] < order[grouped[j].Registry]
+	})
+	return grouped
+}
+
+// registryCounts counts the results of each registry.
+func registryCounts(results []Skill) map[string]int {
+	counts := make(map[string]int)
+	for _, s := range results {
+		counts[s.Registry]++
+	}
+	return counts
+}
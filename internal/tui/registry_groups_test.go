@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/api"
+)
+
+func TestGroupByRegistryKeepsRanking(t *testing.T) {
+	results := []Skill{
+		{Name: "a", Registry: "skills.sh"},
+		{Name: "b", Registry: "playbooks.com"},
+		{Name: "c", Registry: "skills.sh"},
+		{Name: "d", Registry: "acme"},
+		{Name: "e", Registry: "playbooks.com"},
+	}
+	var names []string
+	for _, s := range groupByRegistry(results) {
+		names = append(names, s.Name)
+	}
+	if want := []string{"a", "c", "b", "e", "d"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("grouped = %v, want %v", names, want)
+	}
+	if results[1].Name != "b" {
+		t.Error("groupByRegistry reordered its input")
+	}
+	counts := registryCounts(results)
+	if counts["skills.sh"] != 2 || counts["playbooks.com"] != 2 || counts["acme"] != 1 {
+		t.Errorf("counts = %v", counts)
+	}
+}
+
+func TestSearchShowsRegistryFailures(t *testing.T) {
+	m := newSearchModel()
+	m.cache = newSearchCache()
+	m.width = 100
+
+	failure := api.RegistryError{Registry: "playbooks.com", Err: errors.New("timeout")}
+	m, _ = m.Update(searchResultsMsg{query: "react", results: testSkills(3), failures: []api.RegistryError{failure}})
+	if !strings.Contains(m.View(), "playbooks.com: timeout") {
+		t.Error("view does not name the failed registry")
+	}
+	if _, ok := m.cache.get("react"); ok {
+		t.Error("partial results were cached")
+	}
+
+	m, _ = m.Update(searchResultsMsg{query: "vue", results: testSkills(2)})
+	if strings.Contains(m.View(), "playbooks.com: timeout") {
+		t.Error("failure is still shown for a complete search")
+	}
+}
+
+func TestSearchGroupsResultsByRegistry(t *testing.T) {
+	m := newSearchModel()
+	m.cache = newSearchCache()
+	m.width = 100
+	m.grouped = true
+
+	results := []Skill{
+		{Name: "one", Registry: "skills.sh"},
+		{Name: "two", Registry: "playbooks.com"},
+		{Name: "three", Registry: "skills.sh"},
+	}
+	m, _ = m.Update(searchResultsMsg{query: "x", results: results})
+	if m.results[1].Name != "three" {
+		t.Errorf("results = %v, want skills.sh results together", m.results)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Vercel (2)") || !strings.Contains(view, "Playbooks (1)") {
+		t.Errorf("view lacks registry headers with counts:\n%s", view)
+	}
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// maxRelated is how many suggestions are shown after an install.
+const maxRelated = 5
+
+// relatedMsg carries suggestions for a freshly installed skill.
+type relatedMsg struct {
+	skillName string
+	results   []Skill
+}
+
+// fetchRelated looks for skills to suggest after installing s: siblings in
+// its repository first, then registry matches by author and keywords.
+// Installed skills are left out.
+func fetchRelated(s Skill) tea.Cmd {
+	return func() tea.Msg {
+		var candidates []Skill
+		if repoQueryPattern.MatchString(s.Source) {
+			if siblings, err := browseRepo(s.Source); err == nil {
+				candidates = append(candidates, siblings...)
+			}
+		}
+		if found, err := api.Related(s, maxRelated*2); err == nil {
+			candidates = append(candidates, found...)
+		}
+
+		store := skill.NewStore(getSkillsPath())
+		seen := map[string]bool{s.Name: true}
+		var results []Skill
+		for _, c := range candidates {
+			if seen[c.Name] || store.IsInstalled(c.Name) {
+				continue
+			}
+			seen[c.Name] = true
+			results = append(results, c)
+			if len(results) == maxRelated {
+				break
+			}
+		}
+		return relatedMsg{skillName: s.Name, results: results}
+	}
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSearchShowsRelatedAfterInstall(t *testing.T) {
+	m := newSearchModel()
+	m.cache = newSearchCache()
+	m.showResults("react", testSkills(3), "", 0, 0)
+
+	m, cmd := m.Update(installDoneMsg{skill: Skill{Name: "skill-0"}, skillName: "skill-0"})
+	if cmd == nil {
+		t.Fatal("an install should fetch related skills")
+	}
+
+	// Suggestions for another skill are stale and ignored
+	m, _ = m.Update(relatedMsg{skillName: "other", results: testSkills(2)})
+	if len(m.related) != 0 {
+		t.Fatal("stale suggestions should be ignored")
+	}
+
+	suggestions := []Skill{{Name: "react-testing", Source: "a/b"}, {Name: "hooks-lint", Source: "a/b"}}
+	m, _ = m.Update(relatedMsg{skillName: "skill-0", results: suggestions})
+	if len(m.related) != 2 {
+		t.Fatalf("related = %+v, want 2 suggestions", m.related)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if len(m.results) != 2 || m.results[0].Name != "react-testing" {
+		t.Errorf("results after R = %+v, want the suggestions", m.results)
+	}
+}
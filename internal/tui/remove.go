@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lmarques/efx-skills/internal/fsutil"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// removalReport records what removeSkill deleted and where.
+type removalReport struct {
+	Skill     string
+	Unlinked  []string // providers the skill was unlinked from
+	Orphans   []string // disabled providers whose leftover links were removed
+	Kept      []string // providers holding their own, unmanaged entry of the same name
+	StoreDir  string   // deleted store directory, "" when there was none
+	LockEntry bool     // a lock file entry was removed
+	Config    bool     // a config.json skills entry was removed
+}
+
+// removeSkill deletes a skill everywhere: provider links, config and lock
+// metadata, then the store directory. Provider unlinks happen first and are
+// rolled back if any fails, so a failed removal leaves the skill usable.
+func removeSkill(skillName string) (removalReport, error) {
+	store := skill.NewStore(getSkillsPath())
+	report := removalReport{Skill: skillName}
+	if err := fsutil.ValidName(skillName); err != nil {
+		return report, err
+	}
+	store, unlock, err := store.Lock()
+	if err != nil {
+		return report, err
+	}
+	defer unlock()
+
+	storeDir := filepath.Join(store.BaseDir, skillName)
+	_, statErr := os.Stat(storeDir)
+	inStore := statErr == nil
+
+	inLock := false
+	if lock, err := store.ReadLockFile(); err == nil {
+		_, inLock = lock.Skills[skillName]
+	}
+	inConfig := false
+	if cfg := loadConfigFromFile(); cfg != nil {
+		for _, meta := range cfg.Skills {
+			if meta.Name == skillName {
+				inConfig = true
+			}
+		}
+	}
+
+	// 1. Unlink from every configured provider that has it
+	var done []Provider
+	for _, p := range detectProviders() {
+		if !p.Configured || p.Frozen || !providerHasSkill(p, skillName) {
+			continue
+		}
+		if !managedEntry(store, p, skillName) {
+			report.Kept = append(report.Kept, p.Name)
+			continue
+		}
+		if err := unlinkSkillFromProvider(p, skillName); err != nil {
+			if inStore {
+				for _, prev := range done {
+					linkSkillToProvider(store, prev, skillName)
+				}
+			}
+			return report, fmt.Errorf("unlinking %s from %s: %w", skillName, p.Name, err)
+		}
+		done = append(done, p)
+		report.Unlinked = append(report.Unlinked, p.Name)
+	}
+
+	// 2. Links left in disabled providers
+	report.Orphans = removeOrphanLinks(store, skillName)
+
+	if !inStore && !inLock && !inConfig && len(report.Unlinked) == 0 && len(report.Orphans) == 0 {
+		return report, fmt.Errorf("skill %q is not installed", skillName)
+	}
+
+	// 3. Metadata
+	if inConfig {
+		if err := removeSkillFromConfig(skillName); err != nil {
+			return report, fmt.Errorf("updating config: %w", err)
+		}
+		report.Config = true
+	}
+	if inLock {
+		if err := store.RemoveFromLock(skillName); err != nil {
+			return report, fmt.Errorf("updating lock file: %w", err)
+		}
+		report.LockEntry = true
+	}
+
+	// 4. Store directory
+	if inStore {
+		if err := os.RemoveAll(storeDir); err != nil {
+			return report, fmt.Errorf("deleting %s: %w", storeDir, err)
+		}
+		report.StoreDir = storeDir
+	}
+	return report, nil
+}
+
+// managedEntry reports whether the entry for skillName in p was placed by
+// efx-skills: a link into the store, or a copy of a stored skill in a
+// provider that copies. Anything else belongs to the user or another tool.
+func managedEntry(store *skill.Store, p Provider, skillName string) bool {
+	if p.Hook != "" {
+		return true
+	}
+	path := filepath.Join(p.Path, skillName)
+	if linkPointsTo(path, filepath.Join(store.BaseDir, skillName)) {
+		return true
+	}
+	info, err := os.Lstat(path)
+	return err == nil && info.IsDir() && p.LinkMode.Copied() && store.IsInstalled(skillName)
+}
+
+// providerHasSkill reports whether a provider currently holds skillName.
+func providerHasSkill(p Provider, skillName string) bool {
+	for _, name := range listProviderSkills(p) {
+		if name == skillName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package tui
+
+import "fmt"
+
+// RunRemove deletes a skill from every provider, the store, the lock file
+// and config, printing each location it was removed from.
+func RunRemove(skillName string) error {
+	report, err := removeSkill(skillName)
+	for _, name := range report.Unlinked {
+		fmt.Printf("  ✓ unlinked from %s\n", name)
+	}
+	for _, name := range report.Orphans {
+		fmt.Printf("  ✓ removed leftover link in %s (disabled)\n", name)
+	}
+	for _, name := range report.Kept {
+		fmt.Printf("  • left the %s folder in %s alone (not managed by efx-skills)\n", skillName, name)
+	}
+	if report.Config {
+		fmt.Println("  ✓ removed from config.json")
+	}
+	if report.LockEntry {
+		fmt.Println("  ✓ removed lock entry")
+	}
+	if report.StoreDir != "" {
+		fmt.Printf("  ✓ deleted %s\n", report.StoreDir)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed %s\n", skillName)
+	return nil
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestRemoveSkillCleansDisabledProviders(t *testing.T) {
+	home := setTestHome(t)
+	skillDir := filepath.Join(home, ".agents", "skills", "demo")
+	os.MkdirAll(skillDir, 0755)
+	os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("# Demo"), 0644)
+
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	claudeDir := filepath.Join(home, ".claude", "skills")
+	cursorDir := filepath.Join(home, ".cursor", "skills")
+	store.LinkToProvider("demo", claudeDir)
+	store.LinkToProvider("demo", cursorDir)
+	os.MkdirAll(filepath.Join(cursorDir, "own"), 0755) // provider's own skill, must survive
+
+	if err := saveConfigData(&ConfigData{Providers: []string{"claude"}}); err != nil {
+		t.Fatalf("saveConfigData error: %v", err)
+	}
+
+	removeSkill("demo")
+
+	for _, link := range []string{filepath.Join(claudeDir, "demo"), filepath.Join(cursorDir, "demo"), skillDir} {
+		if _, err := os.Lstat(link); !os.IsNotExist(err) {
+			t.Errorf("%s still present after removal", link)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cursorDir, "own")); err != nil {
+		t.Errorf("unrelated provider skill removed: %v", err)
+	}
+}
+
+func TestRemoveSkillReportsLocations(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "demo"), 0755)
+	store.AddToLock("demo", "owner/repo", "abc")
+	store.LinkToProvider("demo", filepath.Join(home, ".claude", "skills"))
+	addSkillToConfig(SkillMeta{Name: "demo", Owner: "owner/repo"})
+
+	report, err := removeSkill("demo")
+	if err != nil {
+		t.Fatalf("removeSkill error: %v", err)
+	}
+	if len(report.Unlinked) != 1 || report.Unlinked[0] != "claude" || !report.LockEntry || !report.Config || report.StoreDir == "" {
+		t.Fatalf("report = %+v, want claude unlink, lock, config and store removal", report)
+	}
+
+	if _, err := removeSkill("demo"); err == nil {
+		t.Fatal("removing an absent skill should fail")
+	}
+}
+
+func TestRemoveSkillKeepsUnmanagedProviderFolders(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "demo"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "demo", "SKILL.md"), []byte("# Demo"), 0644)
+	own := filepath.Join(home, ".claude", "skills", "demo")
+	os.MkdirAll(own, 0755)
+	os.WriteFile(filepath.Join(own, "SKILL.md"), []byte("# Hand-made"), 0644)
+	saveConfigData(&ConfigData{Providers: []string{"claude"}})
+
+	report, err := removeSkill("demo")
+	if err != nil {
+		t.Fatalf("removeSkill error: %v", err)
+	}
+	if len(report.Kept) != 1 || report.Kept[0] != "claude" || len(report.Unlinked) != 0 {
+		t.Fatalf("report = %+v, want claude's own folder kept", report)
+	}
+	if _, err := os.Stat(filepath.Join(own, "SKILL.md")); err != nil {
+		t.Errorf("unmanaged provider folder deleted: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunRepoList prints the custom repo sources from config.json.
+func RunRepoList() error {
+	cfg := loadOrDefaultConfig()
+	if len(cfg.Repos) == 0 {
+		fmt.Println("No custom repos. Add one with: efx-skills repo add owner/repo")
+		return nil
+	}
+	for _, r := range cfg.Repos {
+		url := r.URL
+		if url == "" {
+			url = r.DeriveURL()
+		}
+		fmt.Printf("  %s %s\n", padRight(r.Owner+"/"+r.Repo, 36), url)
+	}
+	return nil
+}
+
+// RunRepoAdd adds custom repo sources, written as for the config view:
+// "owner/repo", "host/owner/repo" or a URL. Unless check is false, each
+// repo must exist and hold a SKILL.md. Repos already listed are left as
+// they are, so scripts can run it again.
+func RunRepoAdd(inputs []string, check bool) error {
+	return addRepos(loadOrDefaultConfig(), inputs, check)
+}
+
+// addRepos adds the repos in inputs to cfg and saves it when any was new.
+// Every input is checked before anything is saved or reported, so one bad
+// input adds none of them.
+func addRepos(cfg *ConfigData, inputs []string, check bool) error {
+	listed := configModel{repos: cfg.Repos}
+	added := 0
+	var report []string
+	for _, input := range inputs {
+		repo, err := parseRepoInput(input)
+		if err != nil {
+			return fmt.Errorf("%s: %w", input, err)
+		}
+		if listed.hasRepo(repo) {
+			report = append(report, fmt.Sprintf("  %s/%s is already configured", repo.Owner, repo.Repo))
+			continue
+		}
+		note := "not checked"
+		if check {
+			if repo.Host != "" {
+				skill.SetRepoHost(repo.Owner, repo.Repo, repo.Host)
+			}
+			found, err := discoverRepoSkills(repo.Owner, repo.Repo, "")
+			if err != nil {
+				return fmt.Errorf("checking %s/%s: %w", repo.Owner, repo.Repo, err)
+			}
+			if len(found) == 0 {
+				return fmt.Errorf("no SKILL.md in %s/%s", repo.Owner, repo.Repo)
+			}
+			note = fmt.Sprintf("%d skills", len(found))
+		}
+		repo.URL = repo.DeriveURL()
+		listed.repos = append(listed.repos, repo)
+		added++
+		report = append(report, fmt.Sprintf("  ✓ added %s/%s (%s)", repo.Owner, repo.Repo, note))
+	}
+	if added > 0 {
+		cfg.Repos = listed.repos
+		if err := saveConfigData(cfg); err != nil {
+			return err
+		}
+	}
+	for _, line := range report {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// RunRepoRemove removes custom repo sources. Repos that are not listed are
+// reported but not an error, so scripts can run it again.
+func RunRepoRemove(inputs []string) error {
+	cfg := loadOrDefaultConfig()
+	removed := 0
+	for _, input := range inputs {
+		repo, err := parseRepoInput(input)
+		if err != nil {
+			return fmt.Errorf("%s: %w", input, err)
+		}
+		kept := cfg.Repos[:0]
+		for _, r := range cfg.Repos {
+			if strings.EqualFold(r.Owner, repo.Owner) && strings.EqualFold(r.Repo, repo.Repo) {
+				continue
+			}
+			kept = append(kept, r)
+		}
+		if len(kept) == len(cfg.Repos) {
+			fmt.Printf("  %s/%s is not configured\n", repo.Owner, repo.Repo)
+			continue
+		}
+		cfg.Repos = kept
+		removed++
+		fmt.Printf("  ✓ removed %s/%s\n", repo.Owner, repo.Repo)
+	}
+	if removed == 0 {
+		return nil
+	}
+	return saveConfigData(cfg)
+}
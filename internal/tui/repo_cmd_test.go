@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestRunRepoAddAndRemove(t *testing.T) {
+	setTestHome(t)
+	t.Cleanup(skill.ResetGitHubHosts)
+	stubRepoSkills(t, []skill.RepoSkill{{Name: "lint"}}, nil)
+
+	// A fresh config starts from the default repos
+	if err := RunRepoAdd([]string{"acme/tools", "github.mycorp.com/team/skills"}, true); err != nil {
+		t.Fatalf("RunRepoAdd error: %v", err)
+	}
+	cfg := loadConfigFromFile()
+	listed := configModel{repos: cfg.Repos}
+	if len(cfg.Repos) != len(defaultRepos())+2 || !listed.hasRepo(RepoSource{Owner: "acme", Repo: "tools"}) {
+		t.Fatalf("repos = %+v", cfg.Repos)
+	}
+	if last := cfg.Repos[len(cfg.Repos)-1]; last.Host != "github.mycorp.com" || last.URL != "https://github.mycorp.com/team/skills" {
+		t.Errorf("enterprise repo = %+v", last)
+	}
+
+	// Running it again changes nothing
+	if err := RunRepoAdd([]string{"ACME/tools"}, true); err != nil {
+		t.Fatalf("second RunRepoAdd error: %v", err)
+	}
+	if got := len(loadConfigFromFile().Repos); got != len(cfg.Repos) {
+		t.Errorf("repos after re-adding = %d, want %d", got, len(cfg.Repos))
+	}
+
+	if err := RunRepoRemove([]string{"acme/tools", "nobody/none"}); err != nil {
+		t.Fatalf("RunRepoRemove error: %v", err)
+	}
+	if (configModel{repos: loadConfigFromFile().Repos}).hasRepo(RepoSource{Owner: "acme", Repo: "tools"}) {
+		t.Error("acme/tools still configured")
+	}
+
+	// Repos that do not exist are refused unless the check is skipped
+	stubRepoSkills(t, nil, errors.New("GitHub API returned status 404"))
+	if err := RunRepoAdd([]string{"ghost/repo"}, true); err == nil {
+		t.Error("expected an error for a repo that does not exist")
+	}
+	if err := RunRepoAdd([]string{"ghost/repo"}, false); err != nil {
+		t.Errorf("RunRepoAdd without check error: %v", err)
+	}
+}
+
+func TestRunRepoAddSavesNothingOnABadInput(t *testing.T) {
+	setTestHome(t)
+	if err := RunRepoAdd([]string{"acme/tools", "not-a-repo"}, false); err == nil {
+		t.Fatal("expected an error for an invalid input")
+	}
+	if cfg := loadConfigFromFile(); cfg != nil && (configModel{repos: cfg.Repos}).hasRepo(RepoSource{Owner: "acme", Repo: "tools"}) {
+		t.Error("acme/tools was saved although another input was invalid")
+	}
+}
@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"crypto/rand"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// sbomEntry is what the inventory records about one installed skill.
+type sbomEntry struct {
+	Name       string
+	Source     string
+	SourceType string
+	URL        string // where the skill was downloaded from
+	SkillPath  string // folder inside the repository
+	Ref        string // tag or branch followed, "" for the default branch
+	Commit     string
+	Hash       string // skill.FolderHash of the installed files
+	License    string
+	Providers  []string
+}
+
+// purl returns the package URL of a skill from github.com, "" for other
+// sources.
+func (e sbomEntry) purl() string {
+	if e.SourceType != "" && e.SourceType != "github" {
+		return ""
+	}
+	src, err := skill.ParseSource(e.Source)
+	if err != nil || src.Kind != skill.SourceGitHub || skill.GitHubHost(src.Owner, src.Repo) != "github.com" {
+		return ""
+	}
+	owner, repo := src.Owner, src.Repo
+	purl := "pkg:github/" + strings.ToLower(owner) + "/" + strings.ToLower(repo)
+	if version := e.version(); version != "" {
+		purl += "@" + version
+	}
+	if e.SkillPath != "" {
+		purl += "#" + e.SkillPath
+	}
+	return purl
+}
+
+// version is the commit the skill was installed from, else its ref.
+func (e sbomEntry) version() string {
+	if e.Commit != "" {
+		return e.Commit
+	}
+	return e.Ref
+}
+
+// downloadLocation returns the URL in the form SPDX expects: git
+// repositories over HTTPS as "git+<url>@<commit>#<path>".
+func (e sbomEntry) downloadLocation() string {
+	if e.SourceType == skill.SourceTypeArchive || !strings.HasPrefix(e.URL, "https://") {
+		return e.URL
+	}
+	loc := "git+" + e.URL
+	if version := e.version(); version != "" {
+		loc += "@" + version
+	}
+	if e.SkillPath != "" {
+		loc += "#" + e.SkillPath
+	}
+	return loc
+}
+
+// collectSBOM lists every installed skill, by name.
+func collectSBOM(store *skill.Store) ([]sbomEntry, error) {
+	names, err := store.ListInstalled()
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(names)
+	lock, _ := store.ReadLockFile()
+
+	linked := make(map[string][]string)
+	for _, p := range detectProviders() {
+		if p.Configured {
+			for _, name := range listProviderSkills(p) {
+				linked[name] = append(linked[name], p.Name)
+			}
+		}
+	}
+
+	entries := make([]sbomEntry, 0, len(names))
+	for _, name := range names {
+		dir := filepath.Join(store.BaseDir, name)
+		e := sbomEntry{Name: name, Providers: linked[name]}
+		if l, ok := lockEntry(lock, name); ok {
+			e.Source, e.SourceType, e.URL = l.Source, l.SourceType, l.SourceURL
+			e.SkillPath, e.Commit, e.License = l.SkillPath, l.CommitHash, l.License
+			e.Ref = l.Branch
+			if e.Ref == "" {
+				e.Ref = l.Ref
+			}
+		}
+		if e.License == "" {
+			e.License = skill.License(dir)
+		}
+		if hash, err := skill.FolderHash(dir); err == nil {
+			e.Hash = hash
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// newUUID returns a random (version 4) UUID, used to name each document.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// CycloneDX 1.5 document, with only the fields the inventory fills.
+type (
+	cdxDocument struct {
+		BOMFormat    string         `json:"bomFormat"`
+		SpecVersion  string         `json:"specVersion"`
+		SerialNumber string         `json:"serialNumber"`
+		Version      int            `json:"version"`
+		Metadata     cdxMetadata    `json:"metadata"`
+		Components   []cdxComponent `json:"components"`
+	}
+	cdxMetadata struct {
+		Timestamp string `json:"timestamp"`
+		Tools     struct {
+			Components []cdxComponent `json:"components"`
+		} `json:"tools"`
+	}
+	cdxComponent struct {
+		Type         string        `json:"type"`
+		BOMRef       string        `json:"bom-ref,omitempty"`
+		Name         string        `json:"name"`
+		Version      string        `json:"version,omitempty"`
+		Hashes       []cdxHash     `json:"hashes,omitempty"`
+		Licenses     []cdxLicense  `json:"licenses,omitempty"`
+		PURL         string        `json:"purl,omitempty"`
+		ExternalRefs []cdxRef      `json:"externalReferences,omitempty"`
+		Properties   []cdxProperty `json:"properties,omitempty"`
+	}
+	cdxHash struct {
+		Alg     string `json:"alg"`
+		Content string `json:"content"`
+	}
+	cdxLicense struct {
+		License cdxLicenseID `json:"license"`
+	}
+	cdxLicenseID struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	}
+	cdxRef struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}
+	cdxProperty struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+)
+
+// spdxIDPattern matches what SPDX accepts as a license identifier; other
+// license names are recorded as names, or as LicenseRef- in SPDX.
+var spdxIDPattern = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+
+// cycloneDX builds a CycloneDX document listing entries, made by
+// efx-skills toolVersion at now.
+func cycloneDX(entries []sbomEntry, toolVersion string, now time.Time) cdxDocument {
+	doc := cdxDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + newUUID(),
+		Version:      1,
+		Components:   []cdxComponent{},
+	}
+	doc.Metadata.Timestamp = now.UTC().Format(time.RFC3339)
+	doc.Metadata.Tools.Components = []cdxComponent{{Type: "application", Name: "efx-skills", Version: toolVersion}}
+
+	for _, e := range entries {
+		c := cdxComponent{Type: "data", BOMRef: "skill:" + e.Name, Name: e.Name, Version: e.version(), PURL: e.purl()}
+		if e.Hash != "" {
+			c.Hashes = []cdxHash{{Alg: "SHA-256", Content: e.Hash}}
+		}
+		if e.License != "" {
+			id := cdxLicenseID{ID: e.License}
+			if !spdxIDPattern.MatchString(e.License) {
+				id = cdxLicenseID{Name: e.License}
+			}
+			c.Licenses = []cdxLicense{{License: id}}
+		}
+		if e.URL != "" {
+			refType := "vcs"
+			if e.SourceType == skill.SourceTypeArchive {
+				refType = "distribution"
+			}
+			c.ExternalRefs = []cdxRef{{Type: refType, URL: e.URL}}
+		}
+		property := func(name, value string) {
+			if value != "" {
+				c.Properties = append(c.Properties, cdxProperty{Name: "efx-skills:" + name, Value: value})
+			}
+		}
+		property("source", e.Source)
+		property("path", e.SkillPath)
+		property("ref", e.Ref)
+		property("providers", strings.Join(e.Providers, ","))
+		doc.Components = append(doc.Components, c)
+	}
+	return doc
+}
+
+// SPDX 2.3 document, with only the fields the inventory fills.
+type (
+	spdxDocument struct {
+		SPDXVersion       string        `json:"spdxVersion"`
+		DataLicense       string        `json:"dataLicense"`
+		SPDXID            string        `json:"SPDXID"`
+		Name              string        `json:"name"`
+		DocumentNamespace string        `json:"documentNamespace"`
+		CreationInfo      spdxCreation  `json:"creationInfo"`
+		Packages          []spdxPackage `json:"packages"`
+	}
+	spdxCreation struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	}
+	spdxPackage struct {
+		Name             string         `json:"name"`
+		SPDXID           string         `json:"SPDXID"`
+		VersionInfo      string         `json:"versionInfo,omitempty"`
+		DownloadLocation string         `json:"downloadLocation"`
+		FilesAnalyzed    bool           `json:"filesAnalyzed"`
+		Checksums        []spdxChecksum `json:"checksums,omitempty"`
+		LicenseConcluded string         `json:"licenseConcluded"`
+		LicenseDeclared  string         `json:"licenseDeclared"`
+		CopyrightText    string         `json:"copyrightText"`
+		ExternalRefs     []spdxRef      `json:"externalRefs,omitempty"`
+		Comment          string         `json:"comment,omitempty"`
+	}
+	spdxChecksum struct {
+		Algorithm     string `json:"algorithm"`
+		ChecksumValue string `json:"checksumValue"`
+	}
+	spdxRef struct {
+		Category string `json:"referenceCategory"`
+		Type     string `json:"referenceType"`
+		Locator  string `json:"referenceLocator"`
+	}
+)
+
+// spdxIDChars are the characters SPDX element identifiers may not hold.
+var spdxIDChars = regexp.MustCompile(`[^A-Za-z0-9.-]`)
+
+// spdx builds an SPDX document listing entries, made by efx-skills
+// toolVersion at now.
+func spdx(entries []sbomEntry, toolVersion string, now time.Time) spdxDocument {
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              "efx-skills installed skills",
+		DocumentNamespace: "https://spdx.org/spdxdocs/efx-skills-" + newUUID(),
+		CreationInfo: spdxCreation{
+			Created:  now.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: efx-skills-" + toolVersion},
+		},
+		Packages: []spdxPackage{},
+	}
+	for _, e := range entries {
+		p := spdxPackage{
+			Name:             e.Name,
+			SPDXID:           "SPDXRef-skill-" + spdxIDChars.ReplaceAllString(e.Name, "-"),
+			VersionInfo:      e.version(),
+			DownloadLocation: "NOASSERTION",
+			LicenseConcluded: "NOASSERTION",
+			LicenseDeclared:  "NOASSERTION",
+			CopyrightText:    "NOASSERTION",
+		}
+		if e.URL != "" {
+			p.DownloadLocation = e.downloadLocation()
+		}
+		if e.Hash != "" {
+			p.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: e.Hash}}
+		}
+		if e.License != "" {
+			p.LicenseDeclared = e.License
+			if !spdxIDPattern.MatchString(e.License) {
+				p.LicenseDeclared = "LicenseRef-" + spdxIDChars.ReplaceAllString(e.License, "-")
+			}
+		}
+		if purl := e.purl(); purl != "" {
+			p.ExternalRefs = []spdxRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: purl}}
+		}
+		if len(e.Providers) > 0 {
+			p.Comment = "Linked into " + strings.Join(e.Providers, ", ")
+		}
+		doc.Packages = append(doc.Packages, p)
+	}
+	return doc
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunSBOM prints an inventory of the installed skills, with their sources,
+// refs, hashes and licenses, as a CycloneDX ("cyclonedx") or SPDX ("spdx")
+// JSON document, or writes it to out. toolVersion names the efx-skills
+// release in the document.
+func RunSBOM(format, out, toolVersion string) error {
+	entries, err := collectSBOM(skill.NewStore(getSkillsPath()))
+	if err != nil {
+		return err
+	}
+	var doc any
+	switch format {
+	case "", "cyclonedx":
+		doc = cycloneDX(entries, toolVersion, time.Now())
+	case "spdx":
+		doc = spdx(entries, toolVersion, time.Now())
+	default:
+		return fmt.Errorf("unknown SBOM format %q (expected cyclonedx or spdx)", format)
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if out == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Saved an inventory of %d skills to %s\n", len(entries), out)
+	return nil
+}
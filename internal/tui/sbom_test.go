@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestSBOMDocuments(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	write := func(name, frontmatter string) {
+		os.MkdirAll(filepath.Join(store.BaseDir, name), 0755)
+		os.WriteFile(filepath.Join(store.BaseDir, name, "SKILL.md"), []byte("---\nname: "+name+"\n"+frontmatter+"---\n# "+name), 0644)
+	}
+	write("git-basics", "")
+	write("notes", "license: Proprietary (ACME)\n")
+	os.WriteFile(store.LockFile, []byte(`{"version":3,"skills":{"git-basics":{"source":"Acme/skills","sourceType":"github","sourceUrl":"https://github.com/Acme/skills.git","skillPath":"skills/git-basics","ref":"v1.2.0","license":"MIT","commitHash":"abc123"}}}`), 0644)
+	store.LinkToProvider("git-basics", filepath.Join(home, ".claude", "skills"))
+	saveConfigData(&ConfigData{Providers: []string{"claude"}})
+
+	entries, err := collectSBOM(store)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("collectSBOM = %+v, %v", entries, err)
+	}
+	if entries[0].Hash == "" || entries[0].Hash == entries[1].Hash {
+		t.Errorf("folder hashes = %q, %q", entries[0].Hash, entries[1].Hash)
+	}
+
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	cdx := cycloneDX(entries, "1.0.0", now)
+	git := cdx.Components[0]
+	if git.PURL != "pkg:github/acme/skills@abc123#skills/git-basics" || git.Version != "abc123" {
+		t.Errorf("purl %q, version %q", git.PURL, git.Version)
+	}
+	if len(git.Licenses) != 1 || git.Licenses[0].License.ID != "MIT" || git.ExternalRefs[0].URL != "https://github.com/Acme/skills.git" {
+		t.Errorf("component = %+v", git)
+	}
+	var providers string
+	for _, p := range git.Properties {
+		if p.Name == "efx-skills:providers" {
+			providers = p.Value
+		}
+	}
+	if providers != "claude" {
+		t.Errorf("providers property = %q, want claude", providers)
+	}
+	// A license that is not an SPDX identifier is kept as a name
+	if notes := cdx.Components[1]; notes.PURL != "" || notes.Licenses[0].License.Name != "Proprietary (ACME)" {
+		t.Errorf("local component = %+v", notes)
+	}
+
+	doc := spdx(entries, "1.0.0", now)
+	pkg := doc.Packages[0]
+	if pkg.DownloadLocation != "git+https://github.com/Acme/skills.git@abc123#skills/git-basics" || pkg.LicenseDeclared != "MIT" {
+		t.Errorf("package = %+v", pkg)
+	}
+	if notes := doc.Packages[1]; notes.DownloadLocation != "NOASSERTION" || !strings.HasPrefix(notes.LicenseDeclared, "LicenseRef-") {
+		t.Errorf("local package = %+v", notes)
+	}
+}
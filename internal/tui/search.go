@@ -2,8 +2,8 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/paginator"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -31,11 +31,44 @@ type searchModel struct {
 	focusOnInput bool // true = focus on input, false = focus on results
 	installing   bool
 	installMsg   string // success/error feedback shown briefly
+	installErr   error  // cause of a failed install, for its hint
+	browsedRepo  string // owner/repo whose skills are listed as a tree
+	query        string // query the current results belong to
+	cache        *searchCache
+	historyIdx   int // position while recalling past queries with up/down
+	nav          vimNav
+	restore      *listPosition // position to restore on the next results
+
+	allResults   []Skill  // results before the official filter
+	columns      []string // optional columns after name and source
+	officialOnly bool     // show only official or verified-owner skills
+	grouped      bool     // list results under a header per registry
+
+	// Registries that failed for the current results
+	failures []api.RegistryError
+
+	// Background fetch of the highlighted result's SKILL.md
+	previews    *previewCache
+	prefetchRef string
+
+	// "You might also want" suggestions after the last install
+	related    []Skill
+	relatedFor string
+
+	// Template placeholders still needing a value for the skill being installed
+	varSkill   Skill
+	varPending []string
+	varValues  map[string]string
+	varInput   textinput.Model
 }
 
 // Message types for search
 type searchResultsMsg struct {
+	query   string
 	results []Skill
+	repo    string // set when the results list one repository
+
+	failures []api.RegistryError // registries that failed; results are partial
 }
 
 type searchErrMsg struct {
@@ -51,14 +84,24 @@ type installStartMsg struct {
 }
 
 type installDoneMsg struct {
+	skill     Skill
 	skillName string
 	providers []string
+	warning   string // from the license policy
 }
 
 type installErrMsg struct {
 	err error
 }
 
+// installVarsMsg asks the user for template values before an installed
+// skill is rendered and linked.
+type installVarsMsg struct {
+	skill   Skill
+	values  map[string]string
+	missing []string
+}
+
 func newSearchModel() searchModel {
 	ti := textinput.New()
 	ti.Placeholder = "Search skills..."
@@ -72,10 +115,16 @@ func newSearchModel() searchModel {
 	p.ActiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("#FBBF24")).Render("● ")
 	p.InactiveDot = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render("○ ")
 
+	cfg := loadConfigFromFile()
 	return searchModel{
 		input:        ti,
 		paginator:    p,
 		focusOnInput: true, // Start with focus on input
+		cache:        sessionSearchCache,
+		historyIdx:   len(sessionSearchCache.history),
+		columns:      resultColumns(cfg),
+		grouped:      cfg != nil && cfg.GroupResults,
+		previews:     sessionPreviewCache,
 	}
 }
 
@@ -90,23 +139,28 @@ func (m searchModel) Update(msg tea.Msg) (searchModel, tea.Cmd) {
 	case searchResultsMsg:
 		m.loading = false
 		m.searched = true
-		m.results = msg.results
-		m.selectedIdx = 0
-		m.paginator.SetTotalPages(len(m.results))
-		m.paginator.Page = 0
-		// After search completes, switch focus to results if we have results
-		if len(msg.results) > 0 {
-			m.focusOnInput = false
-			m.input.Blur()
+		// Partial results are not cached so the failed registries are retried
+		if len(msg.failures) == 0 {
+			m.cache.put(msg.query, msg.results, msg.repo)
 		}
+		if m.restore != nil {
+			m.showResults(msg.query, msg.results, msg.repo, m.restore.selected, m.restore.page)
+			m.restore = nil
+		} else {
+			m.showResults(msg.query, msg.results, msg.repo, 0, 0)
+		}
+		m.failures = msg.failures
 
 	case searchErrMsg:
 		m.loading = false
 		m.err = msg.err
 
+	case prefetchTickMsg:
+		return m, m.prefetch(msg.ref)
+
 	case installStartMsg:
 		s := msg.skill
-		return m, func() tea.Msg {
+		return m, runTask("Installing "+s.Name, func() tea.Msg {
 			cfg := loadConfigFromFile()
 			skillsPath := ""
 			if cfg != nil {
@@ -114,51 +168,62 @@ func (m searchModel) Update(msg tea.Msg) (searchModel, tea.Cmd) {
 			}
 			store := skill.NewStore(skillsPath)
 
-			// Install to central storage
-			if err := store.Install(s.Source, s.Name); err != nil {
+			// Install to central storage, lock file and config
+			if err := installSkill(store, s, "", ""); err != nil {
 				return installErrMsg{err: err}
 			}
 
-			// Fetch commit hash and update lock file
-			commitHash := ""
-			if parts := strings.Split(s.Source, "/"); len(parts) >= 2 {
-				commitHash, _ = skill.FetchLatestCommitHash(parts[0], parts[1])
-			}
-			_ = store.AddToLock(s.Name, s.Source, commitHash)
-
-			// Write skill metadata to config (with version and timestamp)
-			meta := skillMetaFromAPISkill(s)
-			meta.Version = commitHash
-			meta.Installed = time.Now().UTC().Format(time.RFC3339)
-			_ = addSkillToConfig(meta)
-
-			// Link to all configured providers
-			providers := detectProviders()
-			var linked []string
-			for _, p := range providers {
-				if p.Configured {
-					if err := store.LinkToProvider(s.Name, p.Path); err == nil {
-						linked = append(linked, p.Name)
-					}
-				}
+			// Prompt for template placeholders not covered by config
+			values := configTemplateValues()
+			if missing := missingTemplateVars(filepath.Join(store.BaseDir, s.Name), values); len(missing) > 0 {
+				return installVarsMsg{skill: s, values: values, missing: missing}
 			}
+			return finishInstall(store, s, values)
+		})
 
-			return installDoneMsg{skillName: s.Name, providers: linked}
-		}
+	case installVarsMsg:
+		m.installing = false
+		m.varSkill = msg.skill
+		m.varValues = msg.values
+		m.varPending = msg.missing
+		m.varInput = textinput.New()
+		m.varInput.Placeholder = msg.missing[0]
+		m.varInput.Focus()
+		return m, textinput.Blink
 
 	case installDoneMsg:
-		m.installing = false
+		m.installing, m.installErr = false, nil
 		if len(msg.providers) > 0 {
 			m.installMsg = fmt.Sprintf("✓ Installed %s → %s", msg.skillName, strings.Join(msg.providers, ", "))
 		} else {
 			m.installMsg = fmt.Sprintf("✓ Installed %s (no providers linked)", msg.skillName)
 		}
+		if msg.warning != "" {
+			m.installMsg += " · ! " + msg.warning
+		}
+		m.related, m.relatedFor = nil, msg.skillName
+		return m, fetchRelated(msg.skill)
+
+	case relatedMsg:
+		if msg.skillName == m.relatedFor {
+			m.related = msg.results
+		}
 
 	case installErrMsg:
-		m.installing = false
+		m.installing, m.installErr = false, msg.err
 		m.installMsg = fmt.Sprintf("✗ Install failed: %v", msg.err)
 
 	case tea.KeyMsg:
+		if m.promptingVars() {
+			return m.updateVarPrompt(msg)
+		}
+		if !m.focusOnInput {
+			if idx, ok := m.nav.handle(msg.String(), m.selectedIdx, len(m.results), searchPerPage); ok {
+				m.selectedIdx = idx
+				m.paginator.Page = m.selectedIdx / searchPerPage
+				return m, nil
+			}
+		}
 		switch msg.String() {
 		case "tab":
 			// Toggle focus between input and results
@@ -176,15 +241,22 @@ func (m searchModel) Update(msg tea.Msg) (searchModel, tea.Cmd) {
 			if m.focusOnInput {
 				// When focused on input: search
 				if !m.loading && m.input.Value() != "" {
-					m.loading = true
 					query := m.input.Value()
-					return m, func() tea.Msg {
-						results, err := searchSkills(query)
-						if err != nil {
-							return searchErrMsg{err: err}
-						}
-						return searchResultsMsg{results: results}
+					if m.query != "" {
+						m.cache.remember(m.query, m.selectedIdx, m.paginator.Page)
 					}
+					m.cache.addHistory(query)
+					m.historyIdx = len(m.cache.history)
+
+					// Going back to a recent query reuses its results
+					if cached, ok := m.cache.get(query); ok {
+						m.err = nil
+						m.showResults(query, cached.results, cached.repo, cached.selectedIdx, cached.page)
+						return m, nil
+					}
+
+					m.loading = true
+					return m, searchCmd(query)
 				}
 			} else if len(m.results) > 0 {
 				// When focused on results: preview
@@ -193,12 +265,20 @@ func (m searchModel) Update(msg tea.Msg) (searchModel, tea.Cmd) {
 				}
 			}
 		case "up", "k":
+			if m.focusOnInput && msg.Type == tea.KeyUp {
+				m.recallHistory(-1)
+				return m, nil
+			}
 			// Only handle navigation when focus is on results
 			if !m.focusOnInput && m.selectedIdx > 0 {
 				m.selectedIdx--
 				m.paginator.Page = m.selectedIdx / searchPerPage
 			}
 		case "down", "j":
+			if m.focusOnInput && msg.Type == tea.KeyDown {
+				m.recallHistory(1)
+				return m, nil
+			}
 			// Only handle navigation when focus is on results
 			if !m.focusOnInput && m.selectedIdx < len(m.results)-1 {
 				m.selectedIdx++
@@ -221,11 +301,33 @@ func (m searchModel) Update(msg tea.Msg) (searchModel, tea.Cmd) {
 			if !m.focusOnInput && len(m.results) > 0 && !m.installing {
 				selected := m.results[m.selectedIdx]
 				m.installing = true
-				m.installMsg = ""
+				m.installMsg, m.installErr = "", nil
 				return m, func() tea.Msg {
 					return installStartMsg{skill: selected}
 				}
 			}
+		case "O":
+			// Toggle the official/verified-only filter
+			if !m.focusOnInput && m.searched {
+				m.officialOnly = !m.officialOnly
+				m.applyFilter(0, 0)
+				return m, nil
+			}
+		case "S":
+			// Toggle the results' registry sections
+			if !m.focusOnInput && m.searched && m.browsedRepo == "" {
+				m.grouped = !m.grouped
+				m.applyFilter(0, 0)
+				return m, nil
+			}
+		case "R":
+			// Browse the suggestions for the last installed skill
+			if !m.focusOnInput && len(m.related) > 0 {
+				m.query = ""
+				m.showResults("", m.related, "", 0, 0)
+				m.related = nil
+				return m, nil
+			}
 		case "p":
 			// Preview selected skill with 'p' key (only when focus is on results)
 			if !m.focusOnInput && len(m.results) > 0 {
@@ -260,12 +362,8 @@ func (m searchModel) View() string {
 		w = 80
 	}
 
-	// Calculate column widths dynamically
-	// Reserve: 8 for popularity, rest split between name, source, registry
-	availableWidth := w - 8
-	nameWidth := availableWidth * 28 / 100     // 28% for name
-	sourceWidth := availableWidth * 40 / 100   // 40% for source
-	registryWidth := availableWidth * 22 / 100 // 22% for registry
+	// Fit the configured columns to the terminal
+	layout := layoutResults(m.columns, w)
 
 	// Title
 	b.WriteString(renderTitleBox("Search Skills"))
@@ -281,59 +379,80 @@ func (m searchModel) View() string {
 	b.WriteString("\n\n")
 
 	if m.loading {
-		b.WriteString(spinnerStyle.Render("  Searching..."))
+		b.WriteString(spinnerStyle.Render("  " + withSpinner("Searching...")))
 		return b.String()
 	}
 
 	if m.err != nil {
 		b.WriteString(errorStyle.Render(fmt.Sprintf("  Error: %v", m.err)))
+		b.WriteString(renderErrorHint(m.err))
 		return b.String()
 	}
 
+	for _, f := range m.failures {
+		b.WriteString(statusWarnStyle.Render("  ⚠ " + f.Error()))
+		b.WriteString("\n")
+	}
+	if len(m.failures) > 0 {
+		b.WriteString("\n")
+	}
+
 	if !m.searched {
 		b.WriteString(statusMutedStyle.Render("  Type a query and press Enter to search"))
 		b.WriteString("\n")
-		b.WriteString(statusMutedStyle.Render("  Searches skills.sh and playbooks.com"))
+		b.WriteString(statusMutedStyle.Render("  Searches skills.sh and playbooks.com, or lists every skill in an owner/repo"))
+	} else if len(m.results) == 0 && m.officialOnly && len(m.allResults) > 0 {
+		b.WriteString(statusMutedStyle.Render("  No official or verified skills in these results — [O] show all"))
 	} else if len(m.results) == 0 {
 		b.WriteString(statusMutedStyle.Render("  No skills found"))
 	} else {
 		// Results header
-		b.WriteString(subtitleStyle.Render(fmt.Sprintf("  Results (%d)", len(m.results))))
+		if m.browsedRepo != "" {
+			b.WriteString(subtitleStyle.Render(fmt.Sprintf("  Skills in %s (%d)", m.browsedRepo, len(m.results))))
+		} else {
+			b.WriteString(subtitleStyle.Render(fmt.Sprintf("  Results (%d)", len(m.results))))
+		}
+		if m.officialOnly {
+			b.WriteString(statusOkStyle.Render("  ✓ official/verified only"))
+		}
 		b.WriteString("\n")
 		b.WriteString("  " + strings.Repeat("─", w-4))
 		b.WriteString("\n\n")
 
 		// Get page bounds
 		start, end := m.paginator.GetSliceBounds(len(m.results))
+		var counts map[string]int
+		if m.grouped {
+			counts = registryCounts(m.results)
+		}
 
 		// Results list
 		for i := start; i < end; i++ {
 			skill := m.results[i]
-			// Format: name (source) - count
-			popularity := ""
-			if skill.Installs > 0 {
-				if skill.Installs >= 1000 {
-					popularity = fmt.Sprintf("%dk", skill.Installs/1000)
+			if m.browsedRepo != "" {
+				// Show repository folders as tree branches
+				group := repoGroup(skill)
+				if group != "" && (i == start || repoGroup(m.results[i-1]) != group) {
+					b.WriteString(groupInactiveStyle.Render("  "+group+"/") + "\n")
+				}
+				name := skill.Name
+				if group != "" {
+					name = "  └ " + name
+				}
+				line := fitCell(name, layout.nameWidth, false) + " " + fitCell(skill.Path, max(w-layout.nameWidth-9, 0), false)
+				if i == m.selectedIdx {
+					b.WriteString(getSelectedRowStyle(w).Render(line))
 				} else {
-					popularity = fmt.Sprintf("%d", skill.Installs)
+					b.WriteString(tableRowStyle.Render(line))
 				}
-			} else if skill.Stars > 0 {
-				popularity = fmt.Sprintf("%d*", skill.Stars)
+				b.WriteString("\n")
+				continue
 			}
-
-			// Registry friendly name
-			registry := registryDisplayName(skill.Registry)
-
-			// Use dynamic column widths
-			nameFmt := fmt.Sprintf("%%-%ds", nameWidth)
-			sourceFmt := fmt.Sprintf("%%-%ds", sourceWidth)
-			registryFmt := fmt.Sprintf("%%-%ds", registryWidth)
-
-			line := fmt.Sprintf(nameFmt+" "+sourceFmt+" "+registryFmt+" %6s",
-				truncate(skill.Name, nameWidth),
-				truncate(skill.Source, sourceWidth),
-				truncate(registry, registryWidth),
-				popularity)
+			if m.grouped && (i == start || m.results[i-1].Registry != skill.Registry) {
+				header := fmt.Sprintf("  %s (%d)", registryDisplayName(skill.Registry), counts[skill.Registry])
+				b.WriteString(groupInactiveStyle.Render(header) + "\n")
+			}
+			line := layout.formatResultRow(skill, matchMarker(m.query, i == m.selectedIdx, w))
 
 			if i == m.selectedIdx {
 				b.WriteString(getSelectedRowStyle(w).Render(line))
@@ -353,23 +472,39 @@ func (m searchModel) View() string {
 	}
 
 	// Install status
-	if m.installing {
+	if m.promptingVars() {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("  %s needs a value for %s:\n", m.varSkill.Name, m.varPending[0]))
+		b.WriteString("  " + m.varInput.View())
+		b.WriteString(renderHelpBar(m.width, []string{"[enter] set value", "[esc] leave remaining placeholders"}))
+		return b.String()
+	} else if m.installing {
 		b.WriteString("\n")
-		b.WriteString(spinnerStyle.Render("  Installing..."))
+		b.WriteString(spinnerStyle.Render("  " + withSpinner("Installing...")))
 	} else if m.installMsg != "" {
 		b.WriteString("\n")
 		if strings.HasPrefix(m.installMsg, "✓") {
 			b.WriteString(statusOkStyle.Render("  " + m.installMsg))
 		} else {
 			b.WriteString(errorStyle.Render("  " + m.installMsg))
+			b.WriteString(renderErrorHint(m.installErr))
 		}
 	}
+	if len(m.related) > 0 && !m.installing {
+		b.WriteString("\n\n")
+		b.WriteString(subtitleStyle.Render("  You might also want"))
+		b.WriteString("\n")
+		for _, r := range m.related {
+			b.WriteString(fmt.Sprintf("    • %s %s\n", r.Name, statusMutedStyle.Render("("+r.Source+")")))
+		}
+		b.WriteString(statusMutedStyle.Render("  [R] browse suggestions"))
+	}
 
 	// Help
 	if m.focusOnInput {
-		b.WriteString(renderHelpBar(m.width, []string{"[enter] search", "[tab] focus results", "[esc] back", "[q] quit"}))
+		b.WriteString(renderHelpBar(m.width, []string{"[enter] search", "[up/down] history", "[tab] focus results", "[esc] back", "[q] quit"}))
 	} else if len(m.results) > 0 {
-		b.WriteString(renderHelpBar(m.width, []string{"[i] install", "[o] open", "[p/enter] preview", "[up/down] navigate", "[<-/->] page", "[tab] focus input", "[esc] back", "[q] quit"}))
+		b.WriteString(renderHelpBar(m.width, []string{"[i] install", "[o] open", "[p/enter] preview", "[O] official only", "[S] group by registry", "[up/down] navigate", "[<-/->] page", "[tab] focus input", "[esc] back", "[q] quit"}))
 	} else {
 		b.WriteString(renderHelpBar(m.width, []string{"[enter] search", "[i] install", "[p] preview", "[<-/->] page", "[esc] back", "[q] quit"}))
 	}
@@ -377,17 +512,142 @@ func (m searchModel) View() string {
 	return b.String()
 }
 
-// searchSkills searches both registries
-func searchSkills(query string) ([]Skill, error) {
-	return api.SearchAll(query, 50)
+// searchCmd runs query against the registries, or browses it as a repo.
+func searchCmd(query string) tea.Cmd {
+	return func() tea.Msg {
+		results, failures, err := searchSkills(query)
+		if err != nil {
+			return searchErrMsg{err: err}
+		}
+		if repoQueryPattern.MatchString(query) {
+			return searchResultsMsg{query: query, results: results, repo: query}
+		}
+		return searchResultsMsg{query: query, results: results, failures: failures}
+	}
 }
 
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// showResults displays results for query, restoring a previous selection
+// and page when coming back to it.
+func (m *searchModel) showResults(query string, results []Skill, repo string, selectedIdx, page int) {
+	m.loading = false
+	m.searched = true
+	m.query = query
+	m.failures = nil
+	m.allResults = results
+	m.browsedRepo = repo
+	m.applyFilter(selectedIdx, page)
+	// After search completes, switch focus to results if we have results
+	if len(m.results) > 0 {
+		m.focusOnInput = false
+		m.input.Blur()
 	}
-	if maxLen <= 3 {
-		return s[:maxLen]
+}
+
+// applyFilter derives the visible results from allResults and moves the
+// selection to selectedIdx on page, when still in range.
+func (m *searchModel) applyFilter(selectedIdx, page int) {
+	m.results = m.allResults
+	if m.officialOnly {
+		m.results = nil
+		for _, s := range m.allResults {
+			if s.Official || s.Verified {
+				m.results = append(m.results, s)
+			}
+		}
+	}
+	if m.grouped && m.browsedRepo == "" {
+		m.results = groupByRegistry(m.results)
+	}
+
+	m.paginator.SetTotalPages(len(m.results))
+	if selectedIdx >= len(m.results) {
+		selectedIdx = 0
+	}
+	m.selectedIdx = selectedIdx
+	m.paginator.Page = page
+	if page >= m.paginator.TotalPages {
+		m.paginator.Page = selectedIdx / searchPerPage
+	}
+}
+
+// recallHistory steps through past queries (-1 older, +1 newer), putting
+// the query in the input without running it.
+func (m *searchModel) recallHistory(step int) {
+	history := m.cache.history
+	idx := m.historyIdx + step
+	if idx < 0 || idx > len(history) {
+		return
+	}
+	m.historyIdx = idx
+	if idx == len(history) {
+		m.input.SetValue("")
+		return
+	}
+	m.input.SetValue(history[idx])
+	m.input.CursorEnd()
+}
+
+// promptingVars reports whether the view is collecting template values.
+func (m searchModel) promptingVars() bool {
+	return len(m.varPending) > 0
+}
+
+// updateVarPrompt handles keys while asking for template values. Enter
+// accepts the current value; esc skips the remaining placeholders.
+func (m searchModel) updateVarPrompt(msg tea.KeyMsg) (searchModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		name := m.varPending[0]
+		m.varValues[name] = m.varInput.Value()
+		_ = rememberTemplateValues(map[string]string{name: m.varInput.Value()})
+		m.varPending = m.varPending[1:]
+		if m.promptingVars() {
+			m.varInput.Reset()
+			m.varInput.Placeholder = m.varPending[0]
+			return m, nil
+		}
+	case "esc":
+		m.varPending = nil
+	default:
+		var cmd tea.Cmd
+		m.varInput, cmd = m.varInput.Update(msg)
+		return m, cmd
+	}
+
+	m.installing = true
+	s, values := m.varSkill, m.varValues
+	return m, runTask("Installing "+s.Name, func() tea.Msg {
+		return finishInstall(skill.NewStore(getSkillsPath()), s, values)
+	})
+}
+
+// finishInstall renders template placeholders in an installed skill and
+// links it to every configured provider.
+func finishInstall(store *skill.Store, s Skill, values map[string]string) tea.Msg {
+	if err := renderSkill(store, s.Name, values); err != nil {
+		return installErrMsg{err: err}
+	}
+
+	var linked []string
+	targets, _ := linkTargets(nil)
+	for _, p := range targets {
+		if !p.excludes(s.Name) {
+			if err := linkSkillToProvider(store, p, s.Name); err == nil {
+				linked = append(linked, p.Name)
+			}
+		}
+	}
+	return installDoneMsg{skill: s, skillName: s.Name, providers: linked, warning: licenseWarning(store, s.Name)}
+}
+
+// searchSkills searches every registry, or lists every skill of a
+// repository when the query is an owner/repo. Registries that fail are
+// returned next to the others' results.
+func searchSkills(query string) ([]Skill, []api.RegistryError, error) {
+	if repoQueryPattern.MatchString(query) {
+		results, err := browseRepo(query)
+		return results, nil, err
 	}
-	return s[:maxLen-3] + "..."
+	results, failures := api.SearchRegistries(query, 50)
+	return results, failures, nil
 }
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// searchCacheSize bounds how many queries keep their results in memory.
+	searchCacheSize = 20
+	// searchCacheTTL is how long cached results are reused before re-querying.
+	searchCacheTTL = 10 * time.Minute
+)
+
+// cachedSearch holds the results of one query along with where the user
+// left off in them.
+type cachedSearch struct {
+	results     []Skill
+	repo        string
+	selectedIdx int
+	page        int
+	fetchedAt   time.Time
+}
+
+// searchCache keeps recent query results and the query history for the
+// session, so going back to a query does not hit the registries again.
+type searchCache struct {
+	entries map[string]*cachedSearch
+	order   []string // keys, oldest first
+	history []string // executed queries, oldest first
+}
+
+// sessionSearchCache is shared by every search view opened in this run.
+var sessionSearchCache = newSearchCache()
+
+func newSearchCache() *searchCache {
+	return &searchCache{entries: make(map[string]*cachedSearch)}
+}
+
+// searchCacheKey normalizes a query so retyped variants share an entry.
+func searchCacheKey(query string) string {
+	return strings.ToLower(strings.TrimSpace(query))
+}
+
+// get returns fresh cached results for query.
+func (c *searchCache) get(query string) (*cachedSearch, bool) {
+	e, ok := c.entries[searchCacheKey(query)]
+	if !ok || time.Since(e.fetchedAt) > searchCacheTTL {
+		return nil, false
+	}
+	return e, true
+}
+
+// put stores the results of query, evicting the oldest entry when full.
+func (c *searchCache) put(query string, results []Skill, repo string) {
+	key := searchCacheKey(query)
+	if _, ok := c.entries[key]; !ok {
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = &cachedSearch{results: results, repo: repo, fetchedAt: time.Now()}
+
+	for len(c.order) > searchCacheSize {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// remember records the selection and page the user left query at.
+func (c *searchCache) remember(query string, selectedIdx, page int) {
+	if e, ok := c.entries[searchCacheKey(query)]; ok {
+		e.selectedIdx, e.page = selectedIdx, page
+	}
+}
+
+// addHistory appends query unless it repeats the last one.
+func (c *searchCache) addHistory(query string) {
+	query = strings.TrimSpace(query)
+	if query == "" || (len(c.history) > 0 && c.history[len(c.history)-1] == query) {
+		return
+	}
+	c.history = append(c.history, query)
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testSkills(n int) []Skill {
+	skills := make([]Skill, n)
+	for i := range skills {
+		skills[i] = Skill{Name: fmt.Sprintf("skill-%d", i), Source: "owner/repo"}
+	}
+	return skills
+}
+
+func TestSearchReusesCachedResults(t *testing.T) {
+	m := newSearchModel()
+	m.cache = newSearchCache()
+
+	m, _ = m.Update(searchResultsMsg{query: "react", results: testSkills(25)})
+	m.selectedIdx = 12
+	m.paginator.Page = 1
+
+	// Search something else, then go back to the first query
+	m.focusOnInput = true
+	m.input.SetValue("vue")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("an uncached query should start a search")
+	}
+	m, _ = m.Update(searchResultsMsg{query: "vue", results: testSkills(3)})
+
+	m.focusOnInput = true
+	m.input.SetValue("  React ")
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Fatal("a cached query should not hit the registries")
+	}
+	if len(m.results) != 25 || m.selectedIdx != 12 || m.paginator.Page != 1 {
+		t.Errorf("restored %d results at %d page %d, want 25 at 12 page 1", len(m.results), m.selectedIdx, m.paginator.Page)
+	}
+}
+
+func TestSearchCacheExpiresAndEvicts(t *testing.T) {
+	c := newSearchCache()
+	c.put("old", testSkills(1), "")
+	c.entries["old"].fetchedAt = time.Now().Add(-searchCacheTTL - time.Minute)
+	if _, ok := c.get("old"); ok {
+		t.Error("expired results should not be reused")
+	}
+
+	for i := 0; i < searchCacheSize+5; i++ {
+		c.put(fmt.Sprintf("q%d", i), nil, "")
+	}
+	if len(c.entries) != searchCacheSize {
+		t.Errorf("cache holds %d entries, want %d", len(c.entries), searchCacheSize)
+	}
+	if _, ok := c.get("q0"); ok {
+		t.Error("oldest query should be evicted")
+	}
+}
+
+func TestSearchHistoryRecall(t *testing.T) {
+	m := newSearchModel()
+	m.cache = newSearchCache()
+	m.cache.addHistory("react")
+	m.cache.addHistory("react")
+	m.cache.addHistory("owner/repo")
+	m.historyIdx = len(m.cache.history)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.input.Value() != "owner/repo" {
+		t.Fatalf("first recall = %q, want owner/repo", m.input.Value())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.input.Value() != "react" {
+		t.Fatalf("oldest recall = %q, want react (duplicates collapsed)", m.input.Value())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.input.Value() != "" {
+		t.Errorf("recalling past the newest entry = %q, want empty input", m.input.Value())
+	}
+}
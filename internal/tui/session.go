@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionState is where the user left the TUI, restored on the next launch
+// when "restore_session" is enabled in config.json.
+type sessionState struct {
+	View     string       `json:"view"`               // status, search, manage or config
+	Provider string       `json:"provider,omitempty"` // selected or managed provider
+	Search   *listSession `json:"search,omitempty"`   // last search, even after leaving the view
+	Manage   *listSession `json:"manage,omitempty"`
+	Config   int          `json:"config_selected,omitempty"`
+}
+
+// listSession is a query and position within a list view.
+type listSession struct {
+	Query    string `json:"query,omitempty"`
+	Page     int    `json:"page,omitempty"`
+	Selected int    `json:"selected,omitempty"`
+}
+
+var viewNames = map[viewState]string{
+	viewStatus:      "status",
+	viewSearch:      "search",
+	viewManage:      "manage",
+	viewConfig:      "config",
+	viewRecent:      "recent",
+	viewCollections: "collections",
+	viewCategories:  "topics",
+	viewInstalled:   "installed",
+}
+
+func sessionFilePath() string {
+	return filepath.Join(filepath.Dir(configFilePath()), "session.json")
+}
+
+// sessionEnabled reports whether the user opted into session restore.
+func sessionEnabled() bool {
+	cfg := loadConfigFromFile()
+	return cfg != nil && cfg.RestoreSession
+}
+
+func loadSession() (sessionState, bool) {
+	var st sessionState
+	data, err := os.ReadFile(sessionFilePath())
+	if err != nil || json.Unmarshal(data, &st) != nil {
+		return st, false
+	}
+	return st, true
+}
+
+func saveSession(st sessionState) error {
+	path := sessionFilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// sessionFromModel captures the view the user is in, plus the last search
+// so it can be resumed later. A preview counts as the view it was opened
+// from.
+func sessionFromModel(m model) sessionState {
+	state := m.state
+	if state == viewPreview && len(m.stack) > 0 {
+		state = m.stack[len(m.stack)-1]
+	}
+
+	st := sessionState{View: viewNames[state]}
+	if m.statusModel.selectedIdx < len(m.statusModel.providers) {
+		st.Provider = m.statusModel.providers[m.statusModel.selectedIdx].Name
+	}
+	if m.searchModel.query != "" {
+		st.Search = &listSession{
+			Query:    m.searchModel.query,
+			Page:     m.searchModel.paginator.Page,
+			Selected: m.searchModel.selectedIdx,
+		}
+	}
+	switch state {
+	case viewManage:
+		st.Provider = m.manageModel.provider.Name
+		st.Manage = &listSession{Page: m.manageModel.paginator.Page, Selected: m.manageModel.selectedIdx}
+	case viewConfig:
+		st.Config = m.configModel.selectedIdx
+	}
+	return st
+}
+
+// restoreSession prepares m to reopen the saved view. Selections are put
+// back once the view has loaded its rows; a saved search not open at exit
+// is resumed the next time search is opened.
+func restoreSession(m model, st sessionState) model {
+	m.statusModel.restoreProvider = st.Provider
+	m.resumeSearch = st.Search
+	switch st.View {
+	case "search":
+		m.state = viewSearch
+		m.searchModel = m.newSearch()
+	case "manage":
+		for _, p := range detectProviders() {
+			if p.Name == st.Provider {
+				m.state = viewManage
+				m.manageModel = newManageModel(p)
+				if st.Manage != nil {
+					m.manageModel.restore = &listPosition{selected: st.Manage.Selected, page: st.Manage.Page}
+				}
+			}
+		}
+	case "config":
+		m.state = viewConfig
+		m.configModel = newConfigModel()
+		m.configModel.selectedIdx = st.Config
+	case "recent":
+		m.state = viewRecent
+		m.recentModel = newRecentModel()
+	case "collections":
+		m.state = viewCollections
+		m.collectionsModel = newCollectionsModel()
+	case "topics":
+		m.state = viewCategories
+		m.categoriesModel = newCategoriesModel()
+	case "installed":
+		m.state = viewInstalled
+		m.installedModel = newInstalledModel()
+	}
+	return m
+}
+
+// newSearch opens a search view, resuming the saved search once.
+func (m *model) newSearch() searchModel {
+	sm := newSearchModel()
+	if m.resumeSearch != nil {
+		sm.input.SetValue(m.resumeSearch.Query)
+		sm.restore = &listPosition{selected: m.resumeSearch.Selected, page: m.resumeSearch.Page}
+		m.resumeSearch = nil
+	}
+	return sm
+}
+
+// listPosition is a selection and page to put back once a list has loaded.
+type listPosition struct {
+	selected int
+	page     int
+}
+
+// restoreCmd loads whatever the restored view needs.
+func (m model) restoreCmd() tea.Cmd {
+	switch m.state {
+	case viewSearch:
+		if q := m.searchModel.input.Value(); q != "" {
+			return searchCmd(q)
+		}
+	case viewManage:
+		return m.manageModel.Init()
+	case viewConfig:
+		return m.configModel.Init()
+	case viewRecent:
+		return m.recentModel.Init()
+	case viewCollections:
+		return m.collectionsModel.Init()
+	case viewCategories:
+		return m.categoriesModel.Init()
+	case viewInstalled:
+		return m.installedModel.Init()
+	}
+	return nil
+}
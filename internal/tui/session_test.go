@@ -0,0 +1,68 @@
+package tui
+
+import "testing"
+
+func TestSessionRoundTrip(t *testing.T) {
+	setTestHome(t)
+
+	m := initialModel()
+	m.statusModel.providers = []Provider{{Name: "claude"}, {Name: "cursor"}}
+	m.statusModel.selectedIdx = 1
+	m.searchModel = newSearchModel()
+	m.searchModel.cache = newSearchCache()
+	m.searchModel.showResults("react", testSkills(30), "", 21, 2)
+
+	st := sessionFromModel(m)
+	if st.View != "status" || st.Provider != "cursor" {
+		t.Fatalf("session = %+v, want status view on cursor", st)
+	}
+	if st.Search == nil || st.Search.Query != "react" || st.Search.Selected != 21 || st.Search.Page != 2 {
+		t.Fatalf("search session = %+v", st.Search)
+	}
+
+	if err := saveSession(st); err != nil {
+		t.Fatal(err)
+	}
+	loaded, ok := loadSession()
+	if !ok || loaded.Provider != "cursor" || loaded.Search.Query != "react" {
+		t.Fatalf("loadSession = %+v, %v", loaded, ok)
+	}
+
+	// The saved search is resumed once, the next time search opens
+	restored := restoreSession(initialModel(), loaded)
+	if restored.state != viewStatus || restored.statusModel.restoreProvider != "cursor" {
+		t.Fatalf("restored state = %v, provider %q", restored.state, restored.statusModel.restoreProvider)
+	}
+	sm := restored.newSearch()
+	if sm.input.Value() != "react" || sm.restore == nil || sm.restore.selected != 21 {
+		t.Errorf("resumed search = %q, %+v", sm.input.Value(), sm.restore)
+	}
+	if again := restored.newSearch(); again.input.Value() != "" {
+		t.Errorf("search resumed twice with %q", again.input.Value())
+	}
+}
+
+func TestSessionPreviewSavesParentView(t *testing.T) {
+	m := initialModel()
+	m.state = viewPreview
+	m.stack = []viewState{viewStatus, viewConfig}
+	m.configModel.selectedIdx = 3
+
+	st := sessionFromModel(m)
+	if st.View != "config" || st.Config != 3 {
+		t.Errorf("session = %+v, want config view at row 3", st)
+	}
+}
+
+func TestSessionDisabledByDefault(t *testing.T) {
+	setTestHome(t)
+	if sessionEnabled() {
+		t.Fatal("session restore should be opt-in")
+	}
+	if err := saveConfigData(&ConfigData{RestoreSession: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !sessionEnabled() {
+		t.Error("restore_session: true should enable it")
+	}
+}
@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/api"
+	"github.com/lmarques/efx-skills/internal/config"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+const (
+	// skillStatsTTL is how long registry stats are shown before refreshing.
+	skillStatsTTL = 24 * time.Hour
+	// skillStatsConcurrency caps the registry lookups running at once.
+	skillStatsConcurrency = 4
+)
+
+// skillStats are the registry figures of an installed skill, as last
+// fetched. They are kept apart from the config and lock file, which only
+// record where skills come from.
+type skillStats struct {
+	Registry  string    `json:"registry,omitempty"`
+	Installs  int       `json:"installs"`
+	Stars     int       `json:"stars"`
+	Unlisted  bool      `json:"unlisted,omitempty"` // no registry lists the skill
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// skillStatsCache is the metadata cache, keyed by skill name.
+type skillStatsCache struct {
+	Skills map[string]skillStats `json:"skills"`
+}
+
+// statsTarget is an installed skill whose origin is known well enough to
+// find it in a registry.
+type statsTarget struct {
+	Name     string
+	Registry string // "" when only the source repository is known
+	Source   string // owner/repo
+}
+
+// lookupSkill is swapped in tests to avoid network calls.
+var lookupSkill = api.LookupSkill
+
+// summary renders the stats compactly, e.g. "12k installs, 42*".
+func (s skillStats) summary() string {
+	var parts []string
+	switch {
+	case s.Installs >= 1000:
+		parts = append(parts, fmt.Sprintf("%dk installs", s.Installs/1000))
+	case s.Installs > 0:
+		parts = append(parts, fmt.Sprintf("%d installs", s.Installs))
+	}
+	if s.Stars > 0 {
+		parts = append(parts, fmt.Sprintf("%d*", s.Stars))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return parts[0] + ", " + parts[1]
+}
+
+func skillStatsFilePath() string {
+	return filepath.Join(filepath.Dir(configFilePath()), "skill-stats.json")
+}
+
+// loadSkillStats reads the cache. A missing or unreadable file is an empty
+// cache.
+func loadSkillStats() skillStatsCache {
+	var c skillStatsCache
+	if data, err := os.ReadFile(skillStatsFilePath()); err == nil {
+		json.Unmarshal(data, &c)
+	}
+	if c.Skills == nil {
+		c.Skills = make(map[string]skillStats)
+	}
+	return c
+}
+
+func (c skillStatsCache) save() error {
+	return config.WriteCache(skillStatsFilePath(), c)
+}
+
+// stale reports whether name has no stats or they are older than the TTL.
+func (c skillStatsCache) stale(name string, now time.Time) bool {
+	s, ok := c.Skills[name]
+	return !ok || now.Sub(s.FetchedAt) > skillStatsTTL
+}
+
+// statsTargets lists the installed skills that can be looked up, from the
+// provenance recorded in config.json, else the lock file's GitHub source.
+func statsTargets() []statsTarget {
+	store := skill.NewStore(getSkillsPath())
+	names, err := store.ListInstalled()
+	if err != nil {
+		return nil
+	}
+	metas := make(map[string]SkillMeta)
+	if cfg := loadConfigFromFile(); cfg != nil {
+		for _, m := range cfg.Skills {
+			metas[m.Name] = m
+		}
+	}
+	lock, _ := store.ReadLockFile()
+
+	var targets []statsTarget
+	for _, name := range names {
+		if m, ok := metas[name]; ok && (m.Registry == "skills.sh" || m.Registry == "playbooks.com") {
+			targets = append(targets, statsTarget{Name: name, Registry: m.Registry, Source: m.Owner})
+		} else if e, ok := lockEntry(lock, name); ok && e.Source != "" && e.SourceType == "github" {
+			targets = append(targets, statsTarget{Name: name, Source: e.Source})
+		}
+	}
+	return targets
+}
+
+// refreshSkillStats looks up the targets whose stats are stale, or all of
+// them with force, and stores the results in c. Failed lookups keep the
+// previous stats; their errors are returned.
+func refreshSkillStats(c skillStatsCache, targets []statsTarget, now time.Time, force bool) []error {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	sem := make(chan struct{}, skillStatsConcurrency)
+	for _, t := range targets {
+		if !force && !c.stale(t.Name, now) {
+			continue
+		}
+		wg.Add(1)
+		go func(t statsTarget) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			found, err := lookupSkill(t.Registry, t.Name, t.Source)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				errs = append(errs, fmt.Errorf("%s: %w", t.Name, err))
+			case found == nil:
+				c.Skills[t.Name] = skillStats{Unlisted: true, FetchedAt: now}
+			default:
+				c.Skills[t.Name] = skillStats{Registry: found.Registry, Installs: found.Installs, Stars: found.Stars, FetchedAt: now}
+			}
+		}(t)
+	}
+	wg.Wait()
+	return errs
+}
+
+// cachedSkillStats returns the cache after refreshing stale entries of the
+// installed skills, saving it when anything was fetched. Lookup failures
+// are not fatal: the stats shown are then older.
+func cachedSkillStats(force bool) skillStatsCache {
+	c := loadSkillStats()
+	targets := statsTargets()
+	now := time.Now().UTC()
+	if !force {
+		fresh := true
+		for _, t := range targets {
+			fresh = fresh && !c.stale(t.Name, now)
+		}
+		if fresh {
+			return c
+		}
+	}
+	refreshSkillStats(c, targets, now, force)
+	c.save()
+	return c
+}
+
+// skillStatsMsg carries stats for the manage view, either read from the
+// cache or freshly fetched.
+type skillStatsMsg struct {
+	stats map[string]skillStats
+}
+
+// loadSkillStatsCmd reads the cache without touching the network.
+func loadSkillStatsCmd() tea.Cmd {
+	return func() tea.Msg {
+		return skillStatsMsg{stats: loadSkillStats().Skills}
+	}
+}
+
+// refreshSkillStatsCmd refreshes stale stats in the background.
+func refreshSkillStatsCmd() tea.Cmd {
+	return func() tea.Msg {
+		return skillStatsMsg{stats: cachedSkillStats(false).Skills}
+	}
+}
+
+// mergeSkillStats keeps the most recently fetched stats of each skill, so
+// a cache read arriving after a refresh does not undo it.
+func mergeSkillStats(dst, src map[string]skillStats) map[string]skillStats {
+	if dst == nil {
+		dst = make(map[string]skillStats, len(src))
+	}
+	for name, s := range src {
+		if cur, ok := dst[name]; !ok || s.FetchedAt.After(cur.FetchedAt) {
+			dst[name] = s
+		}
+	}
+	return dst
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/api"
+)
+
+func stubLookupSkill(t *testing.T, fn func(registry, name, source string) (*api.Skill, error)) {
+	t.Helper()
+	old := lookupSkill
+	lookupSkill = fn
+	t.Cleanup(func() { lookupSkill = old })
+}
+
+func TestRefreshSkillStats(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var looked []string
+	stubLookupSkill(t, func(registry, name, source string) (*api.Skill, error) {
+		looked = append(looked, name)
+		switch name {
+		case "lint":
+			return &api.Skill{Name: name, Registry: registry, Installs: 4200, Stars: 12}, nil
+		case "broken":
+			return nil, errors.New("timeout")
+		}
+		return nil, nil
+	})
+
+	c := skillStatsCache{Skills: map[string]skillStats{
+		"fresh":  {Installs: 5, FetchedAt: now.Add(-time.Hour)},
+		"broken": {Installs: 7, FetchedAt: now.Add(-48 * time.Hour)},
+	}}
+	targets := []statsTarget{
+		{Name: "lint", Registry: "skills.sh", Source: "acme/skills"},
+		{Name: "fresh", Source: "acme/skills"},
+		{Name: "broken", Source: "acme/skills"},
+		{Name: "gone", Source: "acme/skills"},
+	}
+	errs := refreshSkillStats(c, targets, now, false)
+
+	if len(looked) != 3 {
+		t.Errorf("looked up %v, want only the stale skills", looked)
+	}
+	if len(errs) != 1 {
+		t.Errorf("errors = %v, want the failed lookup", errs)
+	}
+	if got := c.Skills["lint"]; got.Installs != 4200 || got.Registry != "skills.sh" || !got.FetchedAt.Equal(now) {
+		t.Errorf("lint stats = %+v", got)
+	}
+	if got := c.Skills["broken"]; got.Installs != 7 {
+		t.Errorf("a failed lookup replaced the previous stats: %+v", got)
+	}
+	if !c.Skills["gone"].Unlisted {
+		t.Error("a skill no registry lists should be recorded as unlisted")
+	}
+
+	looked = nil
+	refreshSkillStats(c, targets, now, true)
+	if len(looked) != 4 {
+		t.Errorf("forced refresh looked up %v, want every target", looked)
+	}
+}
+
+func TestSkillStatsSummary(t *testing.T) {
+	tests := []struct {
+		stats skillStats
+		want  string
+	}{
+		{skillStats{Installs: 4200, Stars: 12}, "4k installs, 12*"},
+		{skillStats{Installs: 35}, "35 installs"},
+		{skillStats{Stars: 3}, "3*"},
+		{skillStats{Unlisted: true}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.stats.summary(); got != tt.want {
+			t.Errorf("summary(%+v) = %q, want %q", tt.stats, got, tt.want)
+		}
+	}
+}
+
+func TestStatsTargets(t *testing.T) {
+	home := setTestHome(t)
+	store := filepath.Join(home, ".agents", "skills")
+	for _, name := range []string{"lint", "locked", "local"} {
+		os.MkdirAll(filepath.Join(store, name), 0755)
+		os.WriteFile(filepath.Join(store, name, "SKILL.md"), []byte("# "+name), 0644)
+	}
+	os.WriteFile(filepath.Join(home, ".agents", ".skill-lock.json"),
+		[]byte(`{"version":3,"skills":{"locked":{"source":"acme/tools","sourceType":"github"}}}`), 0644)
+	saveConfigData(&ConfigData{Skills: []SkillMeta{{Name: "lint", Owner: "acme/skills", Registry: "playbooks.com"}}})
+
+	got := statsTargets()
+	want := []statsTarget{
+		{Name: "lint", Registry: "playbooks.com", Source: "acme/skills"},
+		{Name: "locked", Source: "acme/tools"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("statsTargets() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("target %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMergeSkillStatsKeepsNewest(t *testing.T) {
+	now := time.Now()
+	stats := mergeSkillStats(nil, map[string]skillStats{"lint": {Installs: 10, FetchedAt: now}})
+	stats = mergeSkillStats(stats, map[string]skillStats{"lint": {Installs: 1, FetchedAt: now.Add(-time.Hour)}})
+	if stats["lint"].Installs != 10 {
+		t.Errorf("older cache read replaced refreshed stats: %+v", stats["lint"])
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// formatTokens renders a token count compactly, e.g. "850" or "12.4k".
+func formatTokens(n int) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d", n)
+	}
+	s := fmt.Sprintf("%.1f", float64(n)/1000)
+	if s[len(s)-2:] == ".0" {
+		s = s[:len(s)-2]
+	}
+	return s + "k"
+}
+
+// RunStats prints the estimated token footprint and registry stats of every
+// stored skill and the total each configured provider loads. Stale registry
+// stats are refreshed, or all of them with refreshStats.
+func RunStats(refreshStats bool) error {
+	store := skill.NewStore(getSkillsPath())
+	names, err := store.ListInstalled()
+	if err != nil {
+		return fmt.Errorf("failed to read skills directory: %w", err)
+	}
+
+	tokens := make(map[string]int, len(names))
+	total := 0
+	for _, name := range names {
+		tokens[name] = skill.SkillTokens(filepath.Join(store.BaseDir, name))
+		total += tokens[name]
+	}
+	sort.SliceStable(names, func(i, j int) bool { return tokens[names[i]] > tokens[names[j]] })
+
+	fmt.Println("Skill Token Estimates")
+	fmt.Println("=====================")
+	fmt.Printf("\n%d skills, ~%s tokens in %s\n\n", len(names), formatTokens(total), store.BaseDir)
+	stats := cachedSkillStats(refreshStats)
+	for _, name := range names {
+		if summary := stats.Skills[name].summary(); summary != "" {
+			fmt.Printf("  %8s  %s %s\n", "~"+formatTokens(tokens[name]), padRight(name, 30), statusMutedStyle.Render(summary))
+		} else {
+			fmt.Printf("  %8s  %s\n", "~"+formatTokens(tokens[name]), name)
+		}
+	}
+
+	fmt.Println("\nPer Provider:")
+	for _, p := range detectProviders() {
+		if !p.Configured {
+			continue
+		}
+		sum := 0
+		linked := listProviderSkills(p)
+		for _, name := range linked {
+			if n, ok := tokens[name]; ok {
+				sum += n
+			} else if p.Hook == "" {
+				sum += skill.SkillTokens(filepath.Join(p.Path, name))
+			}
+		}
+		fmt.Printf("  %s %s: %d skills, ~%s tokens\n", renderProviderIcon(true), p.Name, len(linked), formatTokens(sum))
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package tui
+
+import "testing"
+
+func TestFormatTokens(t *testing.T) {
+	for n, want := range map[int]string{0: "0", 850: "850", 1000: "1k", 1234: "1.2k", 12400: "12.4k"} {
+		if got := formatTokens(n); got != want {
+			t.Errorf("formatTokens(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
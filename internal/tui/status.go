@@ -4,12 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
 )
 
 // Provider represents an AI coding agent provider
@@ -18,23 +19,51 @@ type Provider struct {
 	Path       string
 	Configured bool
 	SkillCount int
+	AgentCount int      // subagent definitions linked for this provider
+	Broken     []string // dangling skill symlinks, not counted in SkillCount
 	Synced     bool
+	LastSync   time.Time   // last sync without errors, zero when never synced
+	LastResult syncOutcome // latest entry of the sync journal, zero when none
+	Hook       string      // external command managing this provider, if any
+	LinkMode   skill.LinkMode
+	Exclude    []string // skill name globs kept out, from config and the folder's .skillignore
+	Frozen     bool     // left untouched by sync, apply and install
 }
 
 // statusModel handles the status view
 type statusModel struct {
 	providers   []Provider
 	totalSkills int
+	assetCounts map[provider.AssetType]int // stored commands, agents and MCP servers
 	selectedIdx int
-	width       int
-	loading     bool
-	err         error
+	nav         vimNav
+	// provider to select once providers load, from a restored session
+	restoreProvider string
+	// workspace switcher, opened with W
+	workspaces   []string
+	pickingSpace bool
+	spaceIdx     int
+	// provider picker for cloning the selected provider, opened with C
+	pickingClone bool
+	cloneIdx     int
+	width        int
+	loading      bool
+	err          error
+	// signature of the watched paths at the last load, and the load's
+	// generation, which identifies the running watch
+	watchSig string
+	watchGen int
+	// provider whose out-of-sync state is explained, opened with e
+	explaining  string
+	explanation []explainFinding
 }
 
 // Message types
 type providersLoadedMsg struct {
 	providers   []Provider
 	totalSkills int
+	assetCounts map[provider.AssetType]int
+	watchSig    string
 }
 
 type errMsg struct {
@@ -63,70 +92,117 @@ func loadProviders() tea.Msg {
 	providers := detectProviders()
 
 	// Count total skills in central storage
-	skillsDir := filepath.Join(os.Getenv("HOME"), ".agents", "skills")
+	skillsDir := getSkillsPath()
 	totalSkills := 0
 	if entries, err := os.ReadDir(skillsDir); err == nil {
 		for _, e := range entries {
-			if e.IsDir() {
+			if skill.IsSkillEntry(skillsDir, e) {
 				totalSkills++
 			}
 		}
 	}
 
+	// Count the other asset types kept next to the skills
+	store := skill.NewStore(getSkillsPath())
+	assetCounts := make(map[provider.AssetType]int)
+	for _, t := range provider.AssetTypes() {
+		if t == provider.AssetSkills {
+			continue
+		}
+		names, _ := store.ListAssets(t)
+		assetCounts[t] = len(names)
+	}
+
 	return providersLoadedMsg{
 		providers:   providers,
 		totalSkills: totalSkills,
+		assetCounts: assetCounts,
+		watchSig:    watchSignature(watchedPaths(providers)),
 	}
 }
 
 func detectProviders() []Provider {
 	home := os.Getenv("HOME")
 
-	// Load config to get enabled provider state
-	configFile := filepath.Join(home, ".config", "efx-skills", "config.json")
+	// Load config to get enabled provider state and custom providers
+	configFile := configFilePath()
 	var enabledSet map[string]bool
+	var custom []CustomProvider
 	if data, err := os.ReadFile(configFile); err == nil {
 		var raw struct {
-			Providers []string `json:"enabled_providers"`
+			Providers       []string         `json:"enabled_providers"`
+			CustomProviders []CustomProvider `json:"custom_providers"`
 		}
-		if json.Unmarshal(data, &raw) == nil && raw.Providers != nil {
-			enabledSet = make(map[string]bool)
-			for _, name := range raw.Providers {
-				enabledSet[name] = true
+		if json.Unmarshal(data, &raw) == nil {
+			if raw.Providers != nil {
+				enabledSet = make(map[string]bool)
+				for _, name := range raw.Providers {
+					enabledSet[name] = true
+				}
 			}
+			custom = raw.CustomProviders
 		}
 	}
 
-	var providers []Provider
-
+	// Built-in catalog first, with custom entries overriding matching names
+	var candidates []Provider
 	for _, def := range provider.Definitions() {
-		path := def.Path(home)
-		p := Provider{
-			Name: def.Name,
-			Path: path,
+		candidates = append(candidates, Provider{Name: def.Name, Path: def.Path(home)})
+	}
+	for _, c := range custom {
+		found := false
+		for i := range candidates {
+			if candidates[i].Name == c.Name {
+				if c.Path != "" {
+					candidates[i].Path = expandPath(c.Path)
+				}
+				candidates[i].Hook = c.Hook
+				candidates[i].LinkMode = skill.LinkMode(c.LinkMode)
+				candidates[i].Exclude = c.Exclude
+				candidates[i].Frozen = c.Frozen
+				found = true
+				break
+			}
+		}
+		if !found {
+			candidates = append(candidates, Provider{Name: c.Name, Path: expandPath(c.Path), Hook: c.Hook, LinkMode: skill.LinkMode(c.LinkMode), Exclude: c.Exclude, Frozen: c.Frozen})
 		}
+	}
+
+	var providers []Provider
+	journal := loadSyncState()
+	index := openStoreIndex()
+	defer index.release()
+	skillsDir := getSkillsPath()
 
+	for _, p := range candidates {
 		dirExists := false
-		if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if p.Hook != "" {
+			dirExists = true // hook-managed providers have no local directory to check
+		} else if info, err := os.Stat(p.Path); err == nil && info.IsDir() {
 			dirExists = true
+			if patterns := readSkillIgnore(p.Path); len(patterns) > 0 {
+				p.Exclude = append(append([]string(nil), p.Exclude...), patterns...)
+			}
 		}
 
 		if enabledSet != nil {
-			p.Configured = enabledSet[def.Name]
+			p.Configured = enabledSet[p.Name]
 		} else {
 			p.Configured = dirExists
 		}
 
 		if dirExists && p.Configured {
-			if entries, err := os.ReadDir(path); err == nil {
-				for _, e := range entries {
-					if e.Name() != ".DS_Store" {
-						p.SkillCount++
-					}
-				}
+			names, broken := index.links(p, skillsDir)
+			p.Broken = broken
+			p.SkillCount = len(names) - len(p.Broken)
+			if dir := providerAssetPath(p, provider.AssetAgents); dir != "" {
+				p.AgentCount = len(linkedAssetNames(dir))
 			}
-			p.Synced = true
+			p.Synced = len(p.Broken) == 0
 		}
+		p.LastSync = journal.Providers[p.Name].LastSuccess
+		p.LastResult, _ = journal.Providers[p.Name].last()
 
 		providers = append(providers, p)
 	}
@@ -134,18 +210,56 @@ func detectProviders() []Provider {
 	return providers
 }
 
+// capturesKeys reports whether the explanation panel or a picker is open,
+// so q and s close or pick in it instead of quitting or opening search.
+func (m statusModel) capturesKeys() bool {
+	return m.explaining != "" || m.pickingSpace || m.pickingClone
+}
+
 func (m statusModel) Update(msg tea.Msg) (statusModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case providersLoadedMsg:
 		m.loading = false
 		m.providers = msg.providers
 		m.totalSkills = msg.totalSkills
+		m.assetCounts = msg.assetCounts
+		if m.restoreProvider != "" {
+			for i, p := range m.providers {
+				if p.Name == m.restoreProvider {
+					m.selectedIdx = i
+				}
+			}
+			m.restoreProvider = ""
+		}
+		// Watch for changes made elsewhere from this load on
+		m.watchSig = msg.watchSig
+		m.watchGen++
+		return m, m.watchTick()
+
+	case statusWatchTickMsg, statusWatchMsg:
+		return m.updateWatch(msg)
+
+	case explainMsg:
+		m.explaining, m.explanation = msg.provider, msg.findings
 
 	case errMsg:
 		m.loading = false
 		m.err = msg.err
 
 	case tea.KeyMsg:
+		if m.pickingSpace {
+			return m.updateWorkspacePicker(msg)
+		}
+		if m.pickingClone {
+			return m.updateClonePicker(msg)
+		}
+		if m.explaining != "" {
+			return m.updateExplain(msg)
+		}
+		if idx, ok := m.nav.handle(msg.String(), m.selectedIdx, len(m.providers), len(m.providers)); ok {
+			m.selectedIdx = idx
+			return m, nil
+		}
 		switch msg.String() {
 		case "up", "k":
 			if m.selectedIdx > 0 {
@@ -170,10 +284,70 @@ func (m statusModel) Update(msg tea.Msg) (statusModel, tea.Cmd) {
 					return openConfigMsg{provider: m.providers[m.selectedIdx]}
 				}
 			}
+		case "C":
+			// Clone the selected provider's skills into another one
+			if len(m.providers) > 1 {
+				m.cloneIdx = 0
+				m.pickingClone = true
+			}
+			return m, nil
+		case "e":
+			// Explain why the selected provider is out of sync
+			if len(m.providers) > 0 && m.providers[m.selectedIdx].Configured {
+				return m, explainCmd(m.providers[m.selectedIdx])
+			}
+		case "R":
+			// Recently installed/updated skills
+			return m, func() tea.Msg { return openRecentMsg{} }
+		case "i":
+			// Every stored skill with the providers linking it
+			return m, func() tea.Msg { return openInstalledMsg{} }
+		case "P":
+			// Skills linked into some providers but not others
+			return m, func() tea.Msg { return openInstalledMsg{parity: true} }
+		case "T":
+			// Topic directory from frontmatter tags and registry metadata
+			return m, func() tea.Msg { return openCategoriesMsg{} }
+		case "K":
+			// Curated collections (kits) from the registries
+			return m, func() tea.Msg { return openCollectionsMsg{} }
+		case "W":
+			// Switch workspace
+			m.workspaces = listWorkspaces()
+			m.spaceIdx = 0
+			for i, name := range m.workspaces {
+				if name == workspaceName() {
+					m.spaceIdx = i
+				}
+			}
+			m.pickingSpace = true
+			return m, nil
 		case "r":
-			// Refresh
+			// Refresh, re-reading every provider instead of the store index
 			m.loading = true
-			return m, loadProviders
+			return m, func() tea.Msg {
+				resetStoreIndex()
+				return loadProviders()
+			}
+		case "x":
+			// Repair dangling symlinks of the selected provider
+			if len(m.providers) > 0 && len(m.providers[m.selectedIdx].Broken) > 0 {
+				p := m.providers[m.selectedIdx]
+				m.loading = true
+				return m, runTask("Repairing "+p.Name, func() tea.Msg {
+					store := skill.NewStore(getSkillsPath())
+					relinked, removed, err := repairBrokenLinks(store, p)
+					o := syncOutcome{Action: "repair", Added: len(relinked), Removed: len(removed)}
+					if err != nil {
+						o.Errors = []string{err.Error()}
+					}
+					recordSyncOutcomes(store, map[string]syncOutcome{p.Name: o})
+					if err != nil {
+						return errMsg{err: err}
+					}
+					return loadProviders()
+				})
+			}
 		}
 	}
 
@@ -196,77 +370,70 @@ func (m statusModel) View() string {
 	b.WriteString("\n")
 
 	if m.loading {
-		b.WriteString(spinnerStyle.Render("Loading..."))
+		b.WriteString(spinnerStyle.Render(withSpinner("Loading...")))
 		return b.String()
 	}
 
 	if m.err != nil {
 		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString(renderErrorHint(m.err))
+		return b.String()
+	}
+
+	if m.pickingSpace {
+		b.WriteString(m.workspacePickerView())
+		return b.String()
+	}
+
+	if m.pickingClone {
+		b.WriteString(m.clonePickerView())
+		return b.String()
+	}
+
+	if m.explaining != "" {
+		b.WriteString(m.explainView())
 		return b.String()
 	}
 
 	// Section header
 	b.WriteString("\n")
 	b.WriteString(subtitleStyle.Render("Provider Status"))
+	if activeWorkspace != "" {
+		b.WriteString(statusMutedStyle.Render("  workspace: " + activeWorkspace))
+	}
 	b.WriteString("\n")
 
-	// Table header - use dynamic widths based on terminal width
-	providerW := 20
-	skillsW := 10
-	statusW := w - providerW - skillsW - 10 // Use remaining width for status
-
-	header := fmt.Sprintf("  %-*s  %*s  %*s", providerW, "Provider", skillsW, "Skills", statusW, "Status")
-	b.WriteString(getTableHeaderStyle(w).Render(header))
+	// Table header - the status column takes the remaining width
+	t := providerTable()
+	widths := t.layout(w - 4)
+	b.WriteString(getTableHeaderStyle(w).Render(t.header(widths)))
 	b.WriteString("\n")
 
 	// Provider rows
+	now := time.Now()
 	for i, p := range m.providers {
 		skillCount := "-"
+		agentCount := "-"
 		if p.Configured {
 			skillCount = fmt.Sprintf("%d", p.SkillCount)
-		}
-
-		statusText := "not configured"
-		if p.Configured {
-			if p.Synced {
-				statusText = "✓ synced"
-			} else {
-				statusText = "⚠ out of sync"
+			if providerAssetPath(p, provider.AssetAgents) != "" {
+				agentCount = fmt.Sprintf("%d", p.AgentCount)
 			}
 		}
 
+		statusText, statusStyle := providerStatus(p, now)
+
 		if i == m.selectedIdx {
 			// Selected row: plain icon (no color) so background shows through
 			icon := "●"
 			if !p.Configured {
 				icon = "○"
 			}
-			// Calculate padding for right-alignment (same as non-selected)
-			statusTextLen := len(statusText)
-			padding := statusW - statusTextLen
-			if padding < 0 {
-				padding = 0
-			}
-			row := fmt.Sprintf("%s %-*s  %*s  %*s%s", icon, providerW, p.Name, skillsW, skillCount, padding, "", statusText)
+			row := t.row(widths, icon, p.Name, skillCount, agentCount, statusText)
 			b.WriteString(getSelectedRowStyle(w).Render(row))
 		} else {
-			// Non-selected: colored icon, right-aligned status
-			icon := renderProviderIcon(p.Configured)
-			var statusStyled string
-			if p.Configured && p.Synced {
-				statusStyled = statusOkStyle.Render("✓ synced")
-			} else if p.Configured {
-				statusStyled = statusWarnStyle.Render("⚠ out of sync")
-			} else {
-				statusStyled = statusMutedStyle.Render("not configured")
-			}
-			// Calculate padding for right-alignment
-			statusTextLen := len(statusText) // Use plain text length
-			padding := statusW - statusTextLen
-			if padding < 0 {
-				padding = 0
-			}
-			row := fmt.Sprintf("%s %-*s  %*s  %*s%s", icon, providerW, p.Name, skillsW, skillCount, padding, "", statusStyled)
+			// Non-selected: colored icon and status
+			row := t.row(widths, renderProviderIcon(p.Configured), p.Name, skillCount, agentCount, statusStyle.Render(statusText))
 			b.WriteString(tableRowStyle.Render(row))
 		}
 		b.WriteString("\n")
@@ -274,14 +441,66 @@ func (m statusModel) View() string {
 
 	// Summary
 	b.WriteString("\n")
-	b.WriteString(fmt.Sprintf("  Total: %d skills in ~/.agents/skills/\n", m.totalSkills))
+	b.WriteString(fmt.Sprintf("  Total: %d skills in %s\n", m.totalSkills, displayPath(getSkillsPath())))
+	if extra := formatAssetCounts(m.assetCounts); extra != "" {
+		b.WriteString(statusMutedStyle.Render("  Also stored: "+extra) + "\n")
+	}
+	if health := registryHealth(); health != "" {
+		b.WriteString(statusMutedStyle.Render("  Registries: "+health) + "\n")
+	}
 
 	// Help - show context-aware help
-	if len(m.providers) > 0 && m.providers[m.selectedIdx].Configured {
-		b.WriteString(renderHelpBar(m.width, []string{"[s] search", "[m/enter] manage", "[c] config", "[r] refresh", "[q] quit"}))
+	if len(m.providers) > 0 && len(m.providers[m.selectedIdx].Broken) > 0 {
+		b.WriteString(renderHelpBar(m.width, []string{"[s] search", "[m/enter] manage", "[x] fix broken links", "[e] explain", "[c] config", "[i] installed", "[R] recent", "[K] collections", "[T] topics", "[W] workspace", "[r] refresh", "[q] quit"}))
+	} else if len(m.providers) > 0 && m.providers[m.selectedIdx].Configured {
+		b.WriteString(renderHelpBar(m.width, []string{"[s] search", "[m/enter] manage", "[e] explain", "[c] config", "[C] clone", "[P] parity", "[R] recent", "[K] collections", "[T] topics", "[W] workspace", "[r] refresh", "[q] quit"}))
 	} else {
-		b.WriteString(renderHelpBar(m.width, []string{"[s] search", "[c] configure", "[r] refresh", "[q] quit"}))
+		b.WriteString(renderHelpBar(m.width, []string{"[s] search", "[c] configure", "[i] installed", "[R] recent", "[K] collections", "[T] topics", "[W] workspace", "[r] refresh", "[q] quit"}))
 	}
 
 	return b.String()
 }
+
+// providerStatus describes a provider's state from its links and the sync
+// journal, with the style to show it in.
+func providerStatus(p Provider, now time.Time) (string, lipgloss.Style) {
+	switch {
+	case !p.Configured:
+		return "not configured", statusMutedStyle
+	case p.Frozen:
+		return "• frozen", statusMutedStyle
+	case len(p.Broken) > 0:
+		return fmt.Sprintf("✗ %d broken links", len(p.Broken)), errorStyle
+	case !p.Synced:
+		return "⚠ out of sync", statusWarnStyle
+	case len(p.LastResult.Errors) > 0:
+		return "✗ sync failed " + formatAgo(p.LastResult.At, now), errorStyle
+	case p.LastSync.IsZero():
+		return "⚠ never synced", statusWarnStyle
+	}
+	return "✓ synced " + formatAgo(p.LastSync, now), statusOkStyle
+}
+
+// providerTable lays out the provider status table. On narrow terminals
+// the agent and skill counts are dropped before the status.
+func providerTable() table {
+	return table{gap: 2, columns: []tableColumn{
+		{Width: 1},
+		{Title: "Provider", Width: 20},
+		{Title: "Skills", Width: 6, Right: true, Priority: 2},
+		{Title: "Agents", Width: 6, Right: true, Priority: 1},
+		{Title: "Status", Min: 21, Right: true},
+	}}
+}
+
+// formatAssetCounts renders non-zero non-skill asset counts, e.g. "3 commands, 1 agents".
+func formatAssetCounts(counts map[provider.AssetType]int) string {
+	var parts []string
+	for _, t := range provider.AssetTypes() {
+		if t == provider.AssetSkills || counts[t] == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", counts[t], t))
+	}
+	return strings.Join(parts, ", ")
+}
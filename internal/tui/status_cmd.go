@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// RunStatusPlain prints the provider status without starting the TUI,
+// with the latest sync journal entry of each configured provider.
+func RunStatusPlain() error {
+	now := time.Now()
+	fmt.Println("Provider Status")
+	fmt.Println("===============")
+	for _, p := range detectProviders() {
+		text, _ := providerStatus(p, now)
+		if !p.Configured {
+			fmt.Printf("  ○ %s %s\n", padRight(p.Name, 20), text)
+			continue
+		}
+		fmt.Printf("  ● %s %s  %s\n", padRight(p.Name, 20), padRight(fmt.Sprintf("%d skills", p.SkillCount), 10), text)
+		if o := p.LastResult; !o.At.IsZero() {
+			fmt.Printf("      last %s %s: %s\n", o.Action, formatAgo(o.At, now), o.summary())
+			for _, e := range o.Errors {
+				fmt.Printf("        ✗ %s\n", e)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// statusWatchInterval is how often the status view checks the store and
+// provider directories for changes made elsewhere. Shortened in tests.
+//
+// The view polls, as the dev watcher does, instead of using fsnotify: the
+// watched paths include provider folders that may not exist yet and files
+// that are replaced by rename (config.json, the sync journal), which an
+// inotify watch loses track of, and a dozen stats every two seconds cost
+// nothing next to a redraw.
+var statusWatchInterval = 2 * time.Second
+
+// statusWatchTickMsg asks the status view to check for changes. gen ties it
+// to the providers load that started the watch; older ticks are dropped.
+type statusWatchTickMsg struct {
+	gen int
+}
+
+// statusWatchMsg carries the signature of the watched paths.
+type statusWatchMsg struct {
+	gen int
+	sig string
+}
+
+// watchedPaths lists what the status view reflects: the store and its
+// asset folders, the configured providers' directories, config.json and
+// the sync journal.
+func watchedPaths(providers []Provider) []string {
+	store := skill.NewStore(getSkillsPath())
+	paths := []string{configFilePath(), syncStateFilePath()}
+	for _, t := range provider.AssetTypes() {
+		paths = append(paths, store.AssetDir(t))
+	}
+	for _, p := range providers {
+		if p.Configured && p.Hook == "" {
+			paths = append(paths, p.Path)
+		}
+	}
+	return paths
+}
+
+// watchSignature fingerprints paths by modification time and size. Adding
+// or removing an entry changes a directory's modification time, so any
+// link, install or removal changes the signature.
+func watchSignature(paths []string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&b, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+		} else {
+			fmt.Fprintf(&b, "%s:-;", path)
+		}
+	}
+	return b.String()
+}
+
+// watchTick schedules the next check.
+func (m statusModel) watchTick() tea.Cmd {
+	gen := m.watchGen
+	return tea.Tick(statusWatchInterval, func(time.Time) tea.Msg {
+		return statusWatchTickMsg{gen: gen}
+	})
+}
+
+// checkWatched computes the signature of the paths the view shows.
+func (m statusModel) checkWatched() tea.Cmd {
+	gen, providers := m.watchGen, m.providers
+	return func() tea.Msg {
+		return statusWatchMsg{gen: gen, sig: watchSignature(watchedPaths(providers))}
+	}
+}
+
+// updateWatch reloads the providers when the watched paths changed since
+// the last load. The reload restarts the watch; otherwise the next check
+// is scheduled. Checks stop while another view is open, as their messages
+// are not delivered, and resume when the status view reloads.
+func (m statusModel) updateWatch(msg tea.Msg) (statusModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case statusWatchTickMsg:
+		if msg.gen != m.watchGen {
+			return m, nil
+		}
+		return m, m.checkWatched()
+	case statusWatchMsg:
+		if msg.gen != m.watchGen {
+			return m, nil
+		}
+		// Leave the list alone while a picker or an action is in progress
+		if msg.sig != m.watchSig && !m.loading && !m.pickingSpace && !m.pickingClone {
+			return m, loadProviders
+		}
+		return m, m.watchTick()
+	}
+	return m, nil
+}
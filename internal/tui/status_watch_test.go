@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchSignatureChangesWithProviderLinks(t *testing.T) {
+	setTestHome(t)
+	p := Provider{Name: "claude", Path: t.TempDir(), Configured: true}
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(p.Path, old, old)
+
+	paths := watchedPaths([]Provider{p})
+	before := watchSignature(paths)
+	if watchSignature(paths) != before {
+		t.Fatal("signature changed with nothing changed")
+	}
+	os.Symlink(filepath.Join(getSkillsPath(), "lint"), filepath.Join(p.Path, "lint"))
+	if watchSignature(paths) == before {
+		t.Error("signature did not change after linking a skill")
+	}
+
+	// Unconfigured and hook providers are not watched
+	paths = watchedPaths([]Provider{{Name: "cursor", Path: p.Path}, {Name: "hooked", Path: p.Path, Hook: "x", Configured: true}})
+	for _, path := range paths {
+		if path == p.Path {
+			t.Errorf("watched %s of an unconfigured or hook provider", path)
+		}
+	}
+}
+
+func TestStatusWatchReloadsOnChange(t *testing.T) {
+	setTestHome(t)
+	old := statusWatchInterval
+	statusWatchInterval = time.Millisecond
+	t.Cleanup(func() { statusWatchInterval = old })
+	m := newStatusModel()
+	m, cmd := m.Update(providersLoadedMsg{watchSig: "a"})
+	if cmd == nil || m.watchGen != 1 {
+		t.Fatal("loading providers did not start the watch")
+	}
+
+	// Ticks of an earlier load are dropped
+	if _, cmd := m.Update(statusWatchTickMsg{gen: 0}); cmd != nil {
+		t.Error("stale tick was handled")
+	}
+	if _, cmd := m.Update(statusWatchTickMsg{gen: 1}); cmd == nil {
+		t.Error("tick did not check the watched paths")
+	}
+
+	// Unchanged paths schedule the next check; changed ones reload
+	if _, cmd := m.Update(statusWatchMsg{gen: 1, sig: "a"}); cmd == nil {
+		t.Error("no next check scheduled")
+	}
+	_, cmd = m.Update(statusWatchMsg{gen: 1, sig: "b"})
+	if cmd == nil {
+		t.Fatal("change did not reload")
+	}
+	if _, ok := cmd().(providersLoadedMsg); !ok {
+		t.Error("change did not reload the providers")
+	}
+
+	// ...but not while a picker is open
+	m.pickingSpace = true
+	_, cmd = m.Update(statusWatchMsg{gen: 1, sig: "b"})
+	if _, ok := cmd().(providersLoadedMsg); ok {
+		t.Error("reloaded under an open picker")
+	}
+}
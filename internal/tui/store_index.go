@@ -0,0 +1,200 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/config"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+const (
+	// storeIndexVersion is bumped when indexed fields change meaning, which
+	// discards older index files.
+	storeIndexVersion = 1
+	// racyWindow is how long after a change entries are re-derived anyway:
+	// file times are coarse, so a second change right after the first can
+	// leave them unchanged.
+	racyWindow = 2 * time.Second
+)
+
+// settled reports whether t is old enough for an unchanged time to mean
+// nothing changed.
+func settled(t time.Time) bool {
+	return time.Since(t) > racyWindow
+}
+
+// indexedSkill is what the views derive from a skill folder's SKILL.md. It
+// is reused while the file's modification time and size are unchanged.
+type indexedSkill struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Tokens  int       `json:"tokens"`
+	Tags    []string  `json:"tags,omitempty"`
+	Hash    string    `json:"hash,omitempty"` // folder hash from the lock file
+}
+
+// indexedLinks is the link state of a provider directory, reused while
+// neither it nor the store directory has changed.
+type indexedLinks struct {
+	ModTime      time.Time `json:"mod_time"`
+	StoreModTime time.Time `json:"store_mod_time"`
+	Names        []string  `json:"names"`
+	Broken       []string  `json:"broken,omitempty"`
+}
+
+// storeIndex caches per-skill and per-provider state so the status, manage
+// and installed views do not re-read every SKILL.md and provider entry on
+// each load. Entries are keyed by directory and validated against
+// modification times, so changes made outside efx-skills are picked up too.
+//
+// The index is a JSON file rather than SQLite or bbolt: it is a cache
+// rewritten whole in one atomic write, so a database would only add a
+// dependency (cgo for SQLite) and a file lock every process has to share.
+type storeIndex struct {
+	Version int                     `json:"version"`
+	Skills  map[string]indexedSkill `json:"skills"`
+	Links   map[string]indexedLinks `json:"links"`
+
+	path  string
+	dirty bool
+}
+
+// storeIdx is the index of the running process, loaded on first use.
+var storeIdx struct {
+	sync.Mutex
+	index *storeIndex
+}
+
+func storeIndexFilePath() string {
+	return filepath.Join(filepath.Dir(configFilePath()), "store-index.json")
+}
+
+// loadStoreIndex reads the index. A missing, unreadable or outdated file is
+// an empty index.
+func loadStoreIndex() *storeIndex {
+	x := &storeIndex{path: storeIndexFilePath()}
+	if data, err := os.ReadFile(x.path); err == nil {
+		json.Unmarshal(data, x)
+	}
+	if x.Version != storeIndexVersion {
+		x.Skills, x.Links = nil, nil
+	}
+	x.Version = storeIndexVersion
+	if x.Skills == nil {
+		x.Skills = make(map[string]indexedSkill)
+	}
+	if x.Links == nil {
+		x.Links = make(map[string]indexedLinks)
+	}
+	return x
+}
+
+// openStoreIndex returns the process's index, locked for a batch of
+// lookups until release.
+func openStoreIndex() *storeIndex {
+	storeIdx.Lock()
+	if storeIdx.index == nil || storeIdx.index.path != storeIndexFilePath() {
+		storeIdx.index = loadStoreIndex()
+	}
+	return storeIdx.index
+}
+
+// release saves the index when the lookups changed it and unlocks it.
+func (x *storeIndex) release() {
+	if x.dirty {
+		x.save()
+	}
+	storeIdx.Unlock()
+}
+
+// resetStoreIndex empties the index, so the next loads re-read everything.
+func resetStoreIndex() {
+	x := openStoreIndex()
+	x.Skills = make(map[string]indexedSkill)
+	x.Links = make(map[string]indexedLinks)
+	x.dirty = true
+	x.release()
+}
+
+// save writes the index, dropping entries whose directory is gone.
+func (x *storeIndex) save() error {
+	for dir := range x.Skills {
+		if _, err := os.Stat(dir); err != nil {
+			delete(x.Skills, dir)
+		}
+	}
+	for dir := range x.Links {
+		if _, err := os.Stat(dir); err != nil {
+			delete(x.Links, dir)
+		}
+	}
+	x.dirty = false
+	return config.WriteCache(x.path, x)
+}
+
+// skill returns the indexed state of the skill folder dir, re-deriving it
+// when SKILL.md changed. ok is false when the folder has no SKILL.md.
+func (x *storeIndex) skill(dir, hash string) (s indexedSkill, ok bool) {
+	info, err := os.Stat(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		if _, known := x.Skills[dir]; known {
+			delete(x.Skills, dir)
+			x.dirty = true
+		}
+		return indexedSkill{}, false
+	}
+	s, ok = x.Skills[dir]
+	if ok && s.ModTime.Equal(info.ModTime()) && s.Size == info.Size() && settled(s.ModTime) {
+		if s.Hash != hash {
+			s.Hash = hash
+			x.Skills[dir] = s
+			x.dirty = true
+		}
+		return s, true
+	}
+	s = indexedSkill{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Tokens:  skill.SkillTokens(dir),
+		Tags:    skill.Tags(dir),
+		Hash:    hash,
+	}
+	x.Skills[dir] = s
+	x.dirty = true
+	return s, true
+}
+
+// links returns the skills in a provider directory and those that are
+// dangling symlinks, re-reading the directory when it or the store changed.
+// Hook providers have no directory to index and are always asked.
+func (x *storeIndex) links(p Provider, storeDir string) (names, broken []string) {
+	if p.Hook != "" {
+		return listProviderSkills(p), brokenProviderLinks(p)
+	}
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return nil, nil
+	}
+	var storeMod time.Time
+	if st, err := os.Stat(storeDir); err == nil {
+		storeMod = st.ModTime()
+	}
+	l, ok := x.Links[p.Path]
+	if ok && l.ModTime.Equal(info.ModTime()) && l.StoreModTime.Equal(storeMod) && settled(l.ModTime) && settled(l.StoreModTime) {
+		return slices.Clone(l.Names), slices.Clone(l.Broken)
+	}
+	l = indexedLinks{
+		ModTime:      info.ModTime(),
+		StoreModTime: storeMod,
+		Names:        listProviderSkills(p),
+		Broken:       brokenProviderLinks(p),
+	}
+	x.Links[p.Path] = l
+	x.dirty = true
+	return slices.Clone(l.Names), slices.Clone(l.Broken)
+}
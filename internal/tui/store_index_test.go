@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// age moves the modification time of path back, past the racy window.
+func age(t *testing.T, path string) {
+	t.Helper()
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoreIndexReusesUnchangedSkills(t *testing.T) {
+	setTestHome(t)
+	dir := filepath.Join(t.TempDir(), "lint")
+	os.MkdirAll(dir, 0755)
+	skillFile := filepath.Join(dir, "SKILL.md")
+	os.WriteFile(skillFile, []byte("---\nname: lint\ntags: [go]\n---\nLint Go code."), 0644)
+	age(t, skillFile)
+
+	x := openStoreIndex()
+	s, ok := x.skill(dir, "abc")
+	x.release()
+	if !ok || s.Tokens == 0 || len(s.Tags) != 1 || s.Tags[0] != "go" || s.Hash != "abc" {
+		t.Fatalf("indexed = %+v, %v", s, ok)
+	}
+
+	// The saved index is reused while SKILL.md is unchanged
+	storeIdx.index = nil
+	x = openStoreIndex()
+	cached := x.Skills[dir]
+	cached.Tokens = 12345
+	x.Skills[dir] = cached
+	s, _ = x.skill(dir, "abc")
+	x.release()
+	if s.Tokens != 12345 {
+		t.Errorf("tokens = %d, want the indexed value", s.Tokens)
+	}
+
+	// ...and re-derived once it changes
+	os.WriteFile(skillFile, []byte("---\nname: lint\n---\nLint Go code, now without tags."), 0644)
+	x = openStoreIndex()
+	s, _ = x.skill(dir, "abc")
+	x.release()
+	if s.Tokens == 12345 || len(s.Tags) != 0 {
+		t.Errorf("indexed = %+v, want it re-derived from the new SKILL.md", s)
+	}
+}
+
+func TestStoreIndexTracksProviderLinks(t *testing.T) {
+	setTestHome(t)
+	store := t.TempDir()
+	os.MkdirAll(filepath.Join(store, "lint"), 0755)
+	p := Provider{Name: "claude", Path: t.TempDir()}
+	os.Symlink(filepath.Join(store, "lint"), filepath.Join(p.Path, "lint"))
+	os.Symlink(filepath.Join(store, "gone"), filepath.Join(p.Path, "gone"))
+	age(t, p.Path)
+	age(t, store)
+
+	x := openStoreIndex()
+	names, broken := x.links(p, store)
+	x.release()
+	if len(names) != 2 || len(broken) != 1 || broken[0] != "gone" {
+		t.Fatalf("names = %v, broken = %v", names, broken)
+	}
+
+	// Linking another skill changes the provider directory
+	os.MkdirAll(filepath.Join(store, "fmt"), 0755)
+	os.Symlink(filepath.Join(store, "fmt"), filepath.Join(p.Path, "fmt"))
+	x = openStoreIndex()
+	names, _ = x.links(p, store)
+	x.release()
+	if len(names) != 3 {
+		t.Errorf("names = %v, want the new link", names)
+	}
+
+	resetStoreIndex()
+	if x := openStoreIndex(); len(x.Links) != 0 {
+		x.release()
+		t.Error("reset kept the provider links")
+	} else {
+		x.release()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lmarques/efx-skills/internal/errs"
 )
 
 // Colors
@@ -165,6 +166,16 @@ func renderProviderIcon(configured bool) string {
 	return statusMutedStyle.Render("○")
 }
 
+// renderErrorHint renders how to fix err on a line of its own, or "" when
+// the kind of failure is unknown.
+func renderErrorHint(err error) string {
+	hint := errs.Hint(err)
+	if hint == "" {
+		return ""
+	}
+	return "\n" + statusMutedStyle.Render("  Hint: "+hint)
+}
+
 // getSelectedRowStyle returns a selectedRowStyle with dynamic width
 func getSelectedRowStyle(width int) lipgloss.Style {
 	if width <= 0 {
@@ -197,7 +208,7 @@ func renderHelpBar(width int, items []string) string {
 		} else {
 			candidate = item
 		}
-		if len(candidate) > maxLineW && currentLine != "" {
+		if lipgloss.Width(candidate) > maxLineW && currentLine != "" {
 			lines = append(lines, "  "+currentLine)
 			currentLine = item
 		} else {
@@ -0,0 +1,360 @@
+package tui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/lmarques/efx-skills/internal/mcp"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// syncAction is a single change sync would make to bring a provider in line
+// with the central store.
+type syncAction struct {
+	Provider  string
+	AssetType provider.AssetType
+	Name      string
+	Stale     bool // a copied skill that differs from the store, rather than a missing one
+	Convert   bool // a skill linked in another form than the provider's link mode
+	Remove    bool // a dangling link to a skill that is no longer stored
+}
+
+// verb names what applying a does, as in apply reports.
+func (a syncAction) verb() string {
+	switch {
+	case a.Remove:
+		return "unlink"
+	case a.Convert:
+		return "convert"
+	case a.Stale:
+		return "refresh"
+	case a.AssetType == provider.AssetMCP:
+		return "merge"
+	}
+	return "link"
+}
+
+// symbol marks a in plans: + adds, ~ converts in place, - removes.
+func (a syncAction) symbol() string {
+	switch {
+	case a.Remove:
+		return "-"
+	case a.Convert, a.Stale:
+		return "~"
+	}
+	return "+"
+}
+
+// planSync lists the stored skills, commands, agents and MCP servers missing
+// from each configured provider that supports them, and the copies of
+// skills that no longer match the store. Skills a link rule or the
+// provider's exclusions keep out of a provider are left alone, and frozen
+// providers are skipped.
+func planSync(store *skill.Store, providers []Provider) []syncAction {
+	rules := linkRules()
+	tags := make(map[string][]string)
+	neverLinked := func(name, providerName string) bool {
+		if len(rules) == 0 {
+			return false
+		}
+		if _, ok := tags[name]; !ok {
+			tags[name] = skill.Tags(filepath.Join(store.BaseDir, name))
+		}
+		_, never := ruleVerdict(rules, name, tags[name], providerName)
+		return never
+	}
+
+	var actions []syncAction
+
+	for _, p := range providers {
+		if !p.Configured || p.Frozen {
+			continue
+		}
+		for _, t := range manageableAssetTypes(p) {
+			stored, _ := store.ListAssets(t)
+
+			present := make(map[string]bool)
+			if t == provider.AssetSkills {
+				for _, name := range listProviderSkills(p) {
+					present[name] = true
+				}
+				for _, name := range brokenProviderLinks(p) {
+					present[name] = false
+				}
+			} else {
+				for _, name := range linkedAssetNames(providerAssetPath(p, t)) {
+					present[name] = true
+				}
+			}
+
+			for _, name := range stored {
+				switch {
+				case t == provider.AssetSkills && (neverLinked(name, p.Name) || p.excludes(name)):
+				case !present[name]:
+					actions = append(actions, syncAction{Provider: p.Name, AssetType: t, Name: name})
+				case t == provider.AssetSkills && p.Hook == "" && linkFormDiffers(store, p, name):
+					actions = append(actions, syncAction{Provider: p.Name, AssetType: t, Name: name, Convert: true})
+				case t == provider.AssetSkills && p.Hook == "" && p.LinkMode.Copied() &&
+					treeDigest(filepath.Join(store.BaseDir, name)) != treeDigest(filepath.Join(p.Path, name)):
+					actions = append(actions, syncAction{Provider: p.Name, AssetType: t, Name: name, Stale: true})
+				}
+			}
+		}
+
+		if path := providerAssetPath(p, provider.AssetMCP); path != "" {
+			want, _ := mcp.LoadServers(store.AssetDir(provider.AssetMCP))
+			have, _ := mcp.ReadProviderServers(path)
+			for _, name := range mcp.Missing(want, have) {
+				actions = append(actions, syncAction{Provider: p.Name, AssetType: provider.AssetMCP, Name: name})
+			}
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].Provider != actions[j].Provider {
+			return actions[i].Provider < actions[j].Provider
+		}
+		return actions[i].AssetType < actions[j].AssetType
+	})
+	return actions
+}
+
+// linkFormDiffers reports whether a skill present in p is placed in
+// another form than p's link mode: a symlink where copies are made, or a
+// copy where skills are symlinked. Copies that differ from the store are
+// left alone, as they may hold local edits.
+func linkFormDiffers(store *skill.Store, p Provider, name string) bool {
+	linked := isSymlink(filepath.Join(p.Path, name))
+	if p.LinkMode.Copied() {
+		return linked
+	}
+	return !linked && treeDigest(filepath.Join(store.BaseDir, name)) == treeDigest(filepath.Join(p.Path, name))
+}
+
+// planDanglingRemovals lists the dangling links of each configured provider
+// to skills that are no longer stored. Dangling links to stored skills are
+// re-made by the actions of planSync instead. Frozen providers keep theirs.
+func planDanglingRemovals(store *skill.Store, providers []Provider) []syncAction {
+	var actions []syncAction
+	for _, p := range providers {
+		if !p.Configured || p.Frozen {
+			continue
+		}
+		for _, name := range brokenProviderLinks(p) {
+			if !store.IsInstalled(name) {
+				actions = append(actions, syncAction{Provider: p.Name, AssetType: provider.AssetSkills, Name: name, Remove: true})
+			}
+		}
+	}
+	return actions
+}
+
+// planSyncChanges lists every change sync makes, grouped by provider:
+// the actions of planSync and the removal of dangling links.
+func planSyncChanges(store *skill.Store, providers []Provider) []syncAction {
+	actions := append(planDanglingRemovals(store, providers), planSync(store, providers)...)
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Provider < actions[j].Provider
+	})
+	return actions
+}
+
+// treeDigest hashes the relative paths and contents of the files below dir,
+// following dir itself when it is a symlink (dev skills). Copies and the
+// stored folder have equal digests when their files match.
+func treeDigest(dir string) uint64 {
+	h := fnv.New64a()
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return 0
+	}
+	filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, p)
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(rel), len(data))
+		h.Write(data)
+		return nil
+	})
+	return h.Sum64()
+}
+
+// applySyncAction performs one planned link, removal or config merge for
+// MCP servers.
+func applySyncAction(store *skill.Store, p Provider, a syncAction) error {
+	if a.Remove {
+		return os.Remove(filepath.Join(p.Path, a.Name))
+	}
+	if a.Convert && !p.LinkMode.Copied() {
+		// Symlinking only replaces links, so the copy goes first
+		if err := os.RemoveAll(filepath.Join(p.Path, a.Name)); err != nil {
+			return err
+		}
+	}
+	switch a.AssetType {
+	case provider.AssetSkills:
+		return linkSkillToProvider(store, p, a.Name)
+	case provider.AssetMCP:
+		servers, err := mcp.LoadServers(store.AssetDir(provider.AssetMCP))
+		if err != nil {
+			return err
+		}
+		s, ok := servers[a.Name]
+		if !ok {
+			return fmt.Errorf("MCP server %s not found", a.Name)
+		}
+		return mcp.MergeIntoProvider(providerAssetPath(p, provider.AssetMCP), map[string]mcp.Server{a.Name: s})
+	}
+	return store.LinkAsset(a.AssetType, a.Name, providerAssetPath(p, a.AssetType))
+}
+
+// RunSync links every stored skill, command and agent into each configured
+// provider that supports it, merges stored MCP servers into their configs,
+// removes dangling links and regenerates composed single-file targets whose
+// sources changed. In a terminal the plan is shown first, to deselect
+// actions and confirm, unless autoApprove is set.
+func RunSync(autoApprove bool) error {
+	store := skill.NewStore(getSkillsPath())
+	store, unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	providers := detectProviders()
+	byName := make(map[string]Provider)
+	for _, p := range providers {
+		byName[p.Name] = p
+	}
+
+	actions := planSyncChanges(store, providers)
+	if len(actions) > 0 && !autoApprove && interactive() {
+		if actions, err = confirmSyncPlan(actions); err != nil {
+			return err
+		}
+		if actions == nil {
+			fmt.Println("Sync cancelled; nothing was changed.")
+			return nil
+		}
+	}
+
+	outcomes := make(map[string]syncOutcome)
+	for _, p := range providers {
+		if p.Configured {
+			outcomes[p.Name] = syncOutcome{Action: "sync"}
+		}
+	}
+	defer func() {
+		if err := recordSyncOutcomes(store, outcomes); err != nil {
+			fmt.Printf("  ✗ recording sync state: %v\n", err)
+		}
+	}()
+
+	composed, composeErr := regenerateComposed(store, composeTargets())
+	for _, line := range composed {
+		fmt.Println(line)
+	}
+	if len(actions) == 0 {
+		fmt.Println("All providers are in sync.")
+		printBudgetWarnings(providers)
+		return composeErr
+	}
+
+	fmt.Println("Syncing skills across all providers...")
+	ops := make(map[string][]applyOp)
+	for _, a := range actions {
+		p := byName[a.Provider]
+		ops[p.Name] = append(ops[p.Name], func() applyResult {
+			asset := assetNoun(a.AssetType) + " " + a.Name
+			if a.Remove {
+				asset = "dangling link " + a.Name
+			}
+			return applyResult{
+				Provider: p.Name,
+				Action:   a.verb(),
+				Asset:    asset,
+				Err:      applySyncAction(store, p, a),
+			}
+		})
+	}
+	report := applyConcurrently(ops)
+	for _, line := range report.lines() {
+		fmt.Println("  " + line)
+	}
+
+	for _, res := range report {
+		o := outcomes[res.Provider]
+		o.add(res)
+		outcomes[res.Provider] = o
+	}
+
+	done, skipped, failed := report.counts()
+	fmt.Printf("\n%d done, %d skipped, %d failed\n", done, skipped, failed)
+	printBudgetWarnings(providers)
+	if err := report.err(); err != nil {
+		return partialFailure(done, fmt.Errorf("sync finished with %w", err))
+	}
+	return composeErr
+}
+
+// RunSyncCheck lists what sync would change without changing anything. It
+// fails with ExitOutOfSync when a provider is missing something, holds a
+// stale copy or has broken links, or a composed file is out of date.
+func RunSyncCheck() error {
+	store := skill.NewStore(getSkillsPath())
+	providers := detectProviders()
+
+	var problems int
+	for _, p := range providers {
+		if !p.Configured || p.Frozen {
+			continue
+		}
+		for _, name := range p.Broken {
+			fmt.Printf("  ✗ %s: broken link %s\n", p.Name, name)
+			problems++
+		}
+	}
+	for _, a := range planSync(store, providers) {
+		if a.Stale {
+			fmt.Printf("  ✗ %s: stale copy of %s %s\n", a.Provider, assetNoun(a.AssetType), a.Name)
+		} else if a.Convert {
+			fmt.Printf("  ✗ %s: %s %s does not match the provider's link mode\n", a.Provider, assetNoun(a.AssetType), a.Name)
+		} else {
+			fmt.Printf("  ✗ %s: missing %s %s\n", a.Provider, assetNoun(a.AssetType), a.Name)
+		}
+		problems++
+	}
+	for _, t := range composeTargets() {
+		output := expandPath(t.Output)
+		if current, err := store.ComposedCurrent(output, t.Skills); err != nil {
+			fmt.Printf("  ✗ %s: %v\n", t.Name, err)
+			problems++
+		} else if !current {
+			fmt.Printf("  ✗ %s: %s is out of date\n", t.Name, output)
+			problems++
+		}
+	}
+
+	if problems > 0 {
+		return outOfSync("%d change(s) needed; run efx-skills sync", problems)
+	}
+	fmt.Println("All providers are in sync.")
+	return nil
+}
+
+// printBudgetWarnings reports providers whose linked skills exceed the
+// budget set in config.
+func printBudgetWarnings(providers []Provider) {
+	for _, w := range budgetWarnings(providers) {
+		fmt.Println(w)
+	}
+}
@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// interactive reports whether standard input and the terminal the screens
+// draw on are terminals, so a screen can be shown and answered. Under
+// --ascii that is the terminal behind the filtered standard output; under
+// --quiet nothing is asked.
+func interactive() bool {
+	if quietMode {
+		return false
+	}
+	out := os.Stdout
+	if terminalOut != nil {
+		out = terminalOut
+	}
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(out.Fd()))
+}
+
+// syncPlanModel shows what sync would change, grouped per provider, and
+// lets individual actions be deselected before applying, in the spirit of
+// terraform plan.
+type syncPlanModel struct {
+	actions   []syncAction
+	selected  []bool
+	cursor    int
+	height    int
+	confirmed bool
+	cancelled bool
+}
+
+func newSyncPlanModel(actions []syncAction) syncPlanModel {
+	selected := make([]bool, len(actions))
+	for i := range selected {
+		selected[i] = true
+	}
+	return syncPlanModel{actions: actions, selected: selected}
+}
+
+// chosen returns the selected actions.
+func (m syncPlanModel) chosen() []syncAction {
+	var chosen []syncAction
+	for i, a := range m.actions {
+		if m.selected[i] {
+			chosen = append(chosen, a)
+		}
+	}
+	return chosen
+}
+
+func (m syncPlanModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m syncPlanModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.actions)-1 {
+				m.cursor++
+			}
+		case " ", "x":
+			m.selected[m.cursor] = !m.selected[m.cursor]
+		case "a":
+			// Select everything, or nothing when everything is selected
+			all := len(m.chosen()) == len(m.actions)
+			for i := range m.selected {
+				m.selected[i] = !all
+			}
+		case "p":
+			// Toggle every action of the provider under the cursor
+			provider := m.actions[m.cursor].Provider
+			on := !m.selected[m.cursor]
+			for i, a := range m.actions {
+				if a.Provider == provider {
+					m.selected[i] = on
+				}
+			}
+		case "enter", "y":
+			m.confirmed = true
+			return m, tea.Quit
+		case "esc", "n", "q", "ctrl+c":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// planCounts counts the additions, conversions and removals among actions.
+func planCounts(actions []syncAction) (add, change, remove int) {
+	for _, a := range actions {
+		switch a.symbol() {
+		case "+":
+			add++
+		case "~":
+			change++
+		case "-":
+			remove++
+		}
+	}
+	return add, change, remove
+}
+
+// planLine renders one action of the plan.
+func planLine(a syncAction) string {
+	switch {
+	case a.Remove:
+		return "remove dangling link " + a.Name
+	case a.Convert:
+		return "convert " + assetNoun(a.AssetType) + " " + a.Name + " to the provider's link mode"
+	case a.Stale:
+		return "refresh " + assetNoun(a.AssetType) + " " + a.Name + " (the copy differs from the store)"
+	}
+	return a.verb() + " " + assetNoun(a.AssetType) + " " + a.Name
+}
+
+func (m syncPlanModel) View() string {
+	var lines []string
+	cursorLine := 0
+	for i, a := range m.actions {
+		if i == 0 || a.Provider != m.actions[i-1].Provider {
+			var group []syncAction
+			for _, b := range m.actions[i:] {
+				if b.Provider != a.Provider {
+					break
+				}
+				group = append(group, b)
+			}
+			add, change, remove := planCounts(group)
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, subtitleStyle.Render(a.Provider)+"  "+
+				statusMutedStyle.Render(fmt.Sprintf("+%d ~%d -%d", add, change, remove)))
+		}
+
+		box := "[ ]"
+		if m.selected[i] {
+			box = "[x]"
+		}
+		symbol := a.symbol()
+		switch symbol {
+		case "+":
+			symbol = statusOkStyle.Render(symbol)
+		case "~":
+			symbol = statusWarnStyle.Render(symbol)
+		case "-":
+			symbol = errorStyle.Render(symbol)
+		}
+		line := fmt.Sprintf("  %s %s %s", box, symbol, planLine(a))
+		if !m.selected[i] {
+			line = fmt.Sprintf("  %s %s %s", box, symbol, statusMutedStyle.Render(planLine(a)))
+		}
+		if i == m.cursor {
+			cursorLine = len(lines)
+			line = "›" + line[1:]
+		}
+		lines = append(lines, line)
+	}
+
+	// Keep the cursor on screen below the title and above the footer
+	if rows := m.height - 8; rows > 0 && len(lines) > rows {
+		start := max(0, cursorLine-rows/2)
+		start = min(start, len(lines)-rows)
+		lines = lines[start : start+rows]
+	}
+
+	var b strings.Builder
+	b.WriteString(renderTitleBox("Sync plan"))
+	b.WriteString("\n")
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n\n")
+	add, change, remove := planCounts(m.chosen())
+	b.WriteString(fmt.Sprintf("Plan: %d to add, %d to change, %d to remove.", add, change, remove))
+	if skipped := len(m.actions) - len(m.chosen()); skipped > 0 {
+		b.WriteString(statusMutedStyle.Render(fmt.Sprintf(" %d deselected.", skipped)))
+	}
+	b.WriteString("\n")
+	b.WriteString(statusMutedStyle.Render("[space] toggle  [p] toggle provider  [a] toggle all  [enter] apply  [esc] cancel"))
+	b.WriteString("\n")
+	return asciiSafe(b.String())
+}
+
+// confirmSyncPlan shows the plan and returns the actions chosen, nil when
+// the plan was cancelled or everything deselected.
+func confirmSyncPlan(actions []syncAction) ([]syncAction, error) {
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if terminalOut != nil {
+		opts = append(opts, tea.WithOutput(terminalOut))
+	}
+	final, err := tea.NewProgram(newSyncPlanModel(actions), opts...).Run()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := final.(syncPlanModel)
+	if !ok || !m.confirmed {
+		return nil, nil
+	}
+	return m.chosen(), nil
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lmarques/efx-skills/internal/fsutil"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// syncHistorySize caps the outcomes kept per provider.
+const syncHistorySize = 10
+
+// syncState is the sync journal: the outcome of every sync, manage-view
+// apply, broken link repair and clone, per provider. It is kept apart from the lock
+// file, which only describes the store.
+type syncState struct {
+	Providers map[string]providerSyncState `json:"providers"`
+}
+
+// providerSyncState is the journal of one provider.
+type providerSyncState struct {
+	LastSuccess time.Time     `json:"last_success,omitempty"`
+	History     []syncOutcome `json:"history"` // oldest first
+}
+
+// syncOutcome is the result of bringing one provider in line with the store.
+type syncOutcome struct {
+	At      time.Time `json:"at"`
+	Action  string    `json:"action"` // "sync", "apply", "repair" or "clone"
+	Added   int       `json:"added"`
+	Removed int       `json:"removed"`
+	Errors  []string  `json:"errors,omitempty"`
+}
+
+// last returns the latest outcome, if any.
+func (s providerSyncState) last() (syncOutcome, bool) {
+	if len(s.History) == 0 {
+		return syncOutcome{}, false
+	}
+	return s.History[len(s.History)-1], true
+}
+
+// summary renders the changes of an outcome, e.g. "+3 -1, 2 errors".
+func (o syncOutcome) summary() string {
+	s := fmt.Sprintf("+%d -%d", o.Added, o.Removed)
+	if len(o.Errors) > 0 {
+		s += fmt.Sprintf(", %d error(s)", len(o.Errors))
+	}
+	return s
+}
+
+func syncStateFilePath() string {
+	return filepath.Join(filepath.Dir(configFilePath()), "sync-state.json")
+}
+
+// loadSyncState reads the journal. A missing or unreadable file is an empty
+// journal.
+func loadSyncState() syncState {
+	var st syncState
+	if data, err := os.ReadFile(syncStateFilePath()); err == nil {
+		json.Unmarshal(data, &st)
+	}
+	if st.Providers == nil {
+		st.Providers = make(map[string]providerSyncState)
+	}
+	return st
+}
+
+// recordSyncOutcomes appends an outcome per provider, stamped now. It holds
+// the lock of store, which callers already holding it pass in, so runs in
+// other terminals don't drop each other's outcomes.
+func recordSyncOutcomes(store *skill.Store, outcomes map[string]syncOutcome) error {
+	if len(outcomes) == 0 {
+		return nil
+	}
+	_, unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	st := loadSyncState()
+	now := time.Now().UTC()
+	for name, o := range outcomes {
+		o.At = now
+		ps := st.Providers[name]
+		ps.History = append(ps.History, o)
+		if len(ps.History) > syncHistorySize {
+			ps.History = ps.History[len(ps.History)-syncHistorySize:]
+		}
+		if len(o.Errors) == 0 {
+			ps.LastSuccess = now
+		}
+		st.Providers[name] = ps
+	}
+
+	path := syncStateFilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.Replace(path, data, 0644)
+}
+
+// add counts one applied operation into the outcome.
+func (o *syncOutcome) add(res applyResult) {
+	switch {
+	case res.Err != nil:
+		o.Errors = append(o.Errors, fmt.Sprintf("%s %s: %v", res.Action, res.Asset, res.Err))
+	case res.Skipped != "":
+	case res.Action == "unlink":
+		o.Removed++
+	default:
+		o.Added++
+	}
+}
+
+// reportOutcomes builds one outcome per provider in an apply report.
+func reportOutcomes(action string, r applyReport) map[string]syncOutcome {
+	outcomes := make(map[string]syncOutcome)
+	for _, res := range r {
+		o := outcomes[res.Provider]
+		o.Action = action
+		o.add(res)
+		outcomes[res.Provider] = o
+	}
+	return outcomes
+}
+
+// formatAgo renders how long before now t was, e.g. "2h ago".
+func formatAgo(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
+	return t.Local().Format("2006-01-02")
+}
@@ -0,0 +1,379 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/mcp"
+	"github.com/lmarques/efx-skills/internal/provider"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestPlanAndApplySyncLinksAgents(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	if err := store.AddAsset(provider.AssetAgents, "reviewer", []byte("# Reviewer")); err != nil {
+		t.Fatalf("AddAsset error: %v", err)
+	}
+
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills"), Configured: true}
+	qoder := Provider{Name: "qoder", Path: filepath.Join(home, ".qoder", "skills"), Configured: true}
+	actions := planSync(store, []Provider{claude, qoder})
+	if len(actions) != 1 || actions[0].Provider != "claude" || actions[0].AssetType != provider.AssetAgents {
+		t.Fatalf("planSync = %+v, want one claude agents action", actions)
+	}
+
+	if err := applySyncAction(store, claude, actions[0]); err != nil {
+		t.Fatalf("applySyncAction error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(home, ".claude", "agents", "reviewer.md")); err != nil {
+		t.Fatalf("agent not linked: %v", err)
+	}
+	if again := planSync(store, []Provider{claude}); len(again) != 0 {
+		t.Fatalf("planSync after apply = %+v, want none", again)
+	}
+}
+
+func TestPlanSyncMergesMCPServers(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	if err := mcp.SaveServer(store.AssetDir(provider.AssetMCP), "fs", mcp.Server{Command: "mcp-fs"}); err != nil {
+		t.Fatalf("SaveServer error: %v", err)
+	}
+
+	cursor := Provider{Name: "cursor", Path: filepath.Join(home, ".cursor", "skills"), Configured: true}
+	actions := planSync(store, []Provider{cursor})
+	if len(actions) != 1 || actions[0].AssetType != provider.AssetMCP || actions[0].Name != "fs" {
+		t.Fatalf("planSync = %+v, want one cursor mcp action", actions)
+	}
+	if err := applySyncAction(store, cursor, actions[0]); err != nil {
+		t.Fatalf("applySyncAction error: %v", err)
+	}
+
+	have, err := mcp.ReadProviderServers(filepath.Join(home, ".cursor", "mcp.json"))
+	if err != nil || have["fs"].Command != "mcp-fs" {
+		t.Fatalf("cursor mcp.json servers = %+v, err %v", have, err)
+	}
+}
+
+func TestRegenerateComposedExpandsHome(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "style"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "style", "SKILL.md"), []byte("# Style"), 0644)
+
+	targets := []ComposeTarget{{Name: "aider", Output: "~/CONVENTIONS.md", Skills: []string{"style"}}}
+	if _, err := regenerateComposed(store, targets); err != nil {
+		t.Fatalf("regenerateComposed error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, "CONVENTIONS.md")); err != nil {
+		t.Fatalf("composed file not written under HOME: %v", err)
+	}
+
+	targets[0].Skills = []string{"missing"}
+	if _, err := regenerateComposed(store, targets); err == nil {
+		t.Fatal("regenerateComposed should fail for a missing skill")
+	}
+}
+
+func TestRepairBrokenLinks(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "kept"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "kept", "SKILL.md"), []byte("# Kept"), 0644)
+
+	claudeDir := filepath.Join(home, ".claude", "skills")
+	os.MkdirAll(claudeDir, 0755)
+	os.Symlink(filepath.Join(home, "old-store", "kept"), filepath.Join(claudeDir, "kept"))
+	os.Symlink(filepath.Join(home, "old-store", "gone"), filepath.Join(claudeDir, "gone"))
+
+	claude := Provider{Name: "claude", Path: claudeDir, Configured: true}
+	if broken := brokenProviderLinks(claude); len(broken) != 2 {
+		t.Fatalf("brokenProviderLinks = %v, want 2 entries", broken)
+	}
+
+	relinked, removed, err := repairBrokenLinks(store, claude)
+	if err != nil {
+		t.Fatalf("repairBrokenLinks error: %v", err)
+	}
+	if len(relinked) != 1 || relinked[0] != "kept" || len(removed) != 1 || removed[0] != "gone" {
+		t.Fatalf("repairBrokenLinks = %v relinked, %v removed", relinked, removed)
+	}
+	if broken := brokenProviderLinks(claude); len(broken) != 0 {
+		t.Fatalf("broken links remain after repair: %v", broken)
+	}
+}
+
+func TestCopyLinkModeIsRefreshedAfterUpdate(t *testing.T) {
+	home := setTestHome(t)
+	if err := saveConfigData(&ConfigData{
+		Providers:       []string{"claude"},
+		CustomProviders: []CustomProvider{{Name: "claude", LinkMode: "copy"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	store := skill.NewStore(getSkillsPath())
+	src := filepath.Join(store.BaseDir, "demo")
+	os.MkdirAll(src, 0755)
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("v1"), 0644)
+
+	var claude Provider
+	for _, p := range detectProviders() {
+		if p.Name == "claude" {
+			claude = p
+		}
+	}
+	if claude.LinkMode != skill.LinkCopy {
+		t.Fatalf("claude link mode = %q, want copy", claude.LinkMode)
+	}
+	if err := linkSkillToProvider(store, claude, "demo"); err != nil {
+		t.Fatalf("linkSkillToProvider error: %v", err)
+	}
+	target := filepath.Join(home, ".claude", "skills", "demo")
+	if info, err := os.Lstat(target); err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Fatalf("expected a copied directory, got %v", err)
+	}
+
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("v2"), 0644)
+	if err := refreshCopies(store, "demo"); err != nil {
+		t.Fatalf("refreshCopies error: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(target, "SKILL.md")); string(data) != "v2" {
+		t.Errorf("copied SKILL.md = %q, want the updated content", data)
+	}
+}
+
+func TestPlanSyncFindsStaleCopies(t *testing.T) {
+	setTestHome(t)
+	if err := saveConfigData(&ConfigData{
+		Providers:       []string{"claude"},
+		CustomProviders: []CustomProvider{{Name: "claude", LinkMode: "copy"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	store := skill.NewStore(getSkillsPath())
+	src := filepath.Join(store.BaseDir, "demo")
+	os.MkdirAll(src, 0755)
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("v1"), 0644)
+
+	providers := detectProviders()
+	for _, a := range planSync(store, providers) {
+		if err := applySyncAction(store, byProviderName(providers)[a.Provider], a); err != nil {
+			t.Fatalf("applySyncAction error: %v", err)
+		}
+	}
+	if actions := planSync(store, detectProviders()); len(actions) != 0 {
+		t.Fatalf("planSync after sync = %+v, want nothing", actions)
+	}
+
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("v2"), 0644)
+	actions := planSync(store, detectProviders())
+	if len(actions) != 1 || actions[0].Name != "demo" || !actions[0].Stale {
+		t.Fatalf("planSync after edit = %+v, want a stale demo copy", actions)
+	}
+	if err := RunSyncCheck(); ExitCode(err) != ExitOutOfSync {
+		t.Errorf("RunSyncCheck exit code = %d (%v), want %d", ExitCode(err), err, ExitOutOfSync)
+	}
+}
+
+func byProviderName(providers []Provider) map[string]Provider {
+	m := make(map[string]Provider)
+	for _, p := range providers {
+		m[p.Name] = p
+	}
+	return m
+}
+
+func TestSyncJournalShowsInDetectProviders(t *testing.T) {
+	home := setTestHome(t)
+	if err := os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0755); err != nil {
+		t.Fatalf("create provider dir: %v", err)
+	}
+
+	for _, p := range detectProviders() {
+		if p.Name == "claude" && !p.LastSync.IsZero() {
+			t.Fatalf("claude LastSync = %v before any sync, want zero", p.LastSync)
+		}
+	}
+
+	if err := recordSyncOutcomes(skill.NewStore(getSkillsPath()), map[string]syncOutcome{"claude": {Action: "sync", Added: 2}}); err != nil {
+		t.Fatalf("recordSyncOutcomes error: %v", err)
+	}
+	failed := syncOutcome{Action: "apply", Removed: 1, Errors: []string{"unlink skill demo: denied"}}
+	if err := recordSyncOutcomes(skill.NewStore(getSkillsPath()), map[string]syncOutcome{"claude": failed}); err != nil {
+		t.Fatalf("recordSyncOutcomes error: %v", err)
+	}
+
+	for _, p := range detectProviders() {
+		switch p.Name {
+		case "claude":
+			if time.Since(p.LastSync) > time.Minute {
+				t.Errorf("claude LastSync = %v, want the first, clean sync", p.LastSync)
+			}
+			if p.LastResult.Action != "apply" || p.LastResult.summary() != "+0 -1, 1 error(s)" {
+				t.Errorf("claude LastResult = %+v, want the failed apply", p.LastResult)
+			}
+			if text, _ := providerStatus(p, time.Now()); text != "✗ sync failed just now" {
+				t.Errorf("providerStatus = %q, want the failure", text)
+			}
+		case "codex":
+			if !p.LastSync.IsZero() {
+				t.Errorf("codex LastSync = %v, want zero", p.LastSync)
+			}
+		}
+	}
+}
+
+func TestSyncJournalKeepsRecentHistory(t *testing.T) {
+	setTestHome(t)
+	for i := 0; i < syncHistorySize+3; i++ {
+		if err := recordSyncOutcomes(skill.NewStore(getSkillsPath()), map[string]syncOutcome{"claude": {Action: "sync", Added: i}}); err != nil {
+			t.Fatalf("recordSyncOutcomes error: %v", err)
+		}
+	}
+	history := loadSyncState().Providers["claude"].History
+	if len(history) != syncHistorySize || history[len(history)-1].Added != syncHistorySize+2 {
+		t.Fatalf("history = %+v, want the last %d outcomes", history, syncHistorySize)
+	}
+}
+
+func TestReportOutcomes(t *testing.T) {
+	report := applyReport{
+		{Provider: "claude", Action: "link", Asset: "skill a"},
+		{Provider: "claude", Action: "unlink", Asset: "skill b"},
+		{Provider: "claude", Action: "link", Asset: "skill c", Skipped: "not in the store"},
+		{Provider: "cursor", Action: "link", Asset: "skill a", Err: os.ErrPermission},
+	}
+	got := reportOutcomes("apply", report)
+	if o := got["claude"]; o.Added != 1 || o.Removed != 1 || len(o.Errors) != 0 {
+		t.Errorf("claude outcome = %+v, want +1 -1", o)
+	}
+	if o := got["cursor"]; len(o.Errors) != 1 || o.Added != 0 {
+		t.Errorf("cursor outcome = %+v, want one error", o)
+	}
+}
+
+func TestFormatAgo(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{10 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{2*time.Hour + 30*time.Minute, "2h ago"},
+		{3 * 24 * time.Hour, "3d ago"},
+	}
+	for _, tt := range tests {
+		if got := formatAgo(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("formatAgo(%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+	if got := formatAgo(now.Add(-90*24*time.Hour), now); len(got) != len("2006-01-02") {
+		t.Errorf("formatAgo(90d) = %q, want a date", got)
+	}
+}
+
+func TestPlanSyncConvertsLinkForm(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	os.MkdirAll(filepath.Join(store.BaseDir, "lint"), 0755)
+	os.WriteFile(filepath.Join(store.BaseDir, "lint", "SKILL.md"), []byte("# Lint"), 0644)
+
+	// A symlink where the provider wants copies
+	copier := Provider{Name: "opencode", Path: filepath.Join(home, ".opencode", "skills"), Configured: true, LinkMode: skill.LinkCopy}
+	if err := store.LinkToProvider("lint", copier.Path); err != nil {
+		t.Fatal(err)
+	}
+	actions := planSync(store, []Provider{copier})
+	if len(actions) != 1 || !actions[0].Convert {
+		t.Fatalf("planSync = %+v, want one conversion", actions)
+	}
+	if err := applySyncAction(store, copier, actions[0]); err != nil {
+		t.Fatalf("applySyncAction error: %v", err)
+	}
+	if isSymlink(filepath.Join(copier.Path, "lint")) {
+		t.Error("conversion left a symlink in a copy provider")
+	}
+
+	// A matching copy where the provider wants symlinks
+	linker := copier
+	linker.LinkMode = skill.LinkSymlink
+	actions = planSync(store, []Provider{linker})
+	if len(actions) != 1 || !actions[0].Convert {
+		t.Fatalf("planSync = %+v, want one conversion", actions)
+	}
+	if err := applySyncAction(store, linker, actions[0]); err != nil {
+		t.Fatalf("applySyncAction error: %v", err)
+	}
+	if !isSymlink(filepath.Join(linker.Path, "lint")) {
+		t.Error("conversion did not symlink the skill")
+	}
+
+	// A copy with local edits is left alone
+	os.Remove(filepath.Join(linker.Path, "lint"))
+	os.MkdirAll(filepath.Join(linker.Path, "lint"), 0755)
+	os.WriteFile(filepath.Join(linker.Path, "lint", "SKILL.md"), []byte("# Lint, edited"), 0644)
+	if actions := planSync(store, []Provider{linker}); len(actions) != 0 {
+		t.Errorf("planSync = %+v, want an edited copy left alone", actions)
+	}
+}
+
+func TestPlanSyncChangesRemovesDanglingLinks(t *testing.T) {
+	home := setTestHome(t)
+	store := skill.NewStore(filepath.Join(home, ".agents", "skills"))
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills"), Configured: true}
+	os.MkdirAll(claude.Path, 0755)
+	os.Symlink(filepath.Join(store.BaseDir, "gone"), filepath.Join(claude.Path, "gone"))
+
+	actions := planSyncChanges(store, []Provider{claude})
+	if len(actions) != 1 || !actions[0].Remove || actions[0].Name != "gone" || actions[0].verb() != "unlink" {
+		t.Fatalf("planSyncChanges = %+v, want the dangling link removed", actions)
+	}
+	if err := applySyncAction(store, claude, actions[0]); err != nil {
+		t.Fatalf("applySyncAction error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(claude.Path, "gone")); !os.IsNotExist(err) {
+		t.Errorf("dangling link still there: %v", err)
+	}
+}
+
+func TestSyncPlanDeselectsActions(t *testing.T) {
+	m := newSyncPlanModel([]syncAction{
+		{Provider: "claude", AssetType: provider.AssetSkills, Name: "lint"},
+		{Provider: "claude", AssetType: provider.AssetSkills, Name: "gone", Remove: true},
+		{Provider: "cursor", AssetType: provider.AssetSkills, Name: "pdf", Stale: true},
+	})
+	key := func(m syncPlanModel, k string) syncPlanModel {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return next.(syncPlanModel)
+	}
+
+	view := m.View()
+	for _, want := range []string{"claude", "+1 ~0 -1", "cursor", "Plan: 1 to add, 1 to change, 1 to remove."} {
+		if !strings.Contains(view, want) {
+			t.Errorf("plan should show %q:\n%s", want, view)
+		}
+	}
+
+	m = key(key(m, "j"), " ")
+	if got := m.chosen(); len(got) != 2 || got[0].Name != "lint" || got[1].Name != "pdf" {
+		t.Errorf("chosen after deselecting gone = %+v", got)
+	}
+	m = key(key(m, "j"), "p")
+	if got := m.chosen(); len(got) != 1 || got[0].Name != "lint" {
+		t.Errorf("chosen after toggling cursor = %+v", got)
+	}
+	if !strings.Contains(m.View(), "2 deselected") {
+		t.Errorf("plan should count deselected actions:\n%s", m.View())
+	}
+
+	m = key(m, "y")
+	if !m.confirmed {
+		t.Error("y should confirm the plan")
+	}
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// tableColumn describes one column of a table.
+type tableColumn struct {
+	Title    string
+	Width    int  // fixed width, or 0 for a flexible column
+	Weight   int  // share of the leftover width taken by a flexible column (1 when unset)
+	Min      int  // narrowest a flexible column may get before the table counts as too narrow
+	Priority int  // when the table does not fit, the lowest priorities are dropped first; 0 is never dropped
+	Right    bool // right-align cells
+}
+
+// table lays out rows of cells that may contain styled (ANSI) text. Widths
+// are measured in terminal cells, so styles and wide runes never break
+// the alignment the way fmt padding does.
+type table struct {
+	columns []tableColumn
+	gap     int // spaces between columns
+}
+
+func (c tableColumn) weight() int {
+	if c.Weight <= 0 {
+		return 1
+	}
+	return c.Weight
+}
+
+// layout returns the width of every column for a table width cells wide.
+// Dropped columns get a width of 0.
+func (t table) layout(width int) []int {
+	visible := make([]bool, len(t.columns))
+	for i := range visible {
+		visible[i] = true
+	}
+
+	for {
+		need, shown := 0, 0
+		for i, c := range t.columns {
+			if !visible[i] {
+				continue
+			}
+			shown++
+			if c.Width > 0 {
+				need += c.Width
+			} else {
+				need += c.Min
+			}
+		}
+		if shown > 1 {
+			need += t.gap * (shown - 1)
+		}
+		if need <= width {
+			break
+		}
+
+		// Drop the lowest priority column, the rightmost among equals
+		drop := -1
+		for i, c := range t.columns {
+			if visible[i] && c.Priority > 0 && (drop < 0 || c.Priority <= t.columns[drop].Priority) {
+				drop = i
+			}
+		}
+		if drop < 0 {
+			break
+		}
+		visible[drop] = false
+	}
+
+	widths := make([]int, len(t.columns))
+	free, totalWeight, shown, lastFlex := width, 0, 0, -1
+	for i, c := range t.columns {
+		if !visible[i] {
+			continue
+		}
+		shown++
+		if c.Width > 0 {
+			widths[i] = c.Width
+			free -= c.Width
+		} else {
+			totalWeight += c.weight()
+			lastFlex = i
+		}
+	}
+	if shown > 1 {
+		free -= t.gap * (shown - 1)
+	}
+	if free < 0 {
+		free = 0
+	}
+
+	left := free
+	for i, c := range t.columns {
+		if !visible[i] || c.Width > 0 {
+			continue
+		}
+		if i == lastFlex {
+			widths[i] = left
+			break
+		}
+		widths[i] = free * c.weight() / totalWeight
+		left -= widths[i]
+	}
+	return widths
+}
+
+// row renders one line of cells with the widths from layout, skipping
+// dropped columns.
+func (t table) row(widths []int, cells ...string) string {
+	var parts []string
+	for i, c := range t.columns {
+		if widths[i] <= 0 {
+			continue
+		}
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		parts = append(parts, fitCell(cell, widths[i], c.Right))
+	}
+	return strings.Join(parts, strings.Repeat(" ", t.gap))
+}
+
+// header renders the column titles.
+func (t table) header(widths []int) string {
+	titles := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		titles[i] = c.Title
+	}
+	return t.row(widths, titles...)
+}
+
+// fitCell pads or truncates s, which may be styled, to exactly width cells.
+func fitCell(s string, width int, right bool) string {
+	if ansi.StringWidth(s) > width {
+		tail := "..."
+		if width <= len(tail) {
+			tail = ""
+		}
+		s = ansi.Truncate(s, width, tail)
+	}
+	pad := strings.Repeat(" ", width-ansi.StringWidth(s))
+	if right {
+		return pad + s
+	}
+	return s + pad
+}
+
+// padRight pads s, which may be styled, with spaces to at least width cells.
+// Unlike fmt's %-*s it measures display width, so emoji and CJK names line
+// up; longer values are kept whole.
+func padRight(s string, width int) string {
+	if w := ansi.StringWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTableLayoutDropsLowestPriority(t *testing.T) {
+	tbl := table{gap: 2, columns: []tableColumn{
+		{Title: "Name", Min: 10},
+		{Title: "Count", Width: 6, Priority: 2},
+		{Title: "Extra", Width: 8, Priority: 1},
+	}}
+
+	if got := tbl.layout(40); !reflect.DeepEqual(got, []int{22, 6, 8}) {
+		t.Errorf("layout(40) = %v, want [22 6 8]", got)
+	}
+	if got := tbl.layout(20); !reflect.DeepEqual(got, []int{12, 6, 0}) {
+		t.Errorf("layout(20) = %v, want extra dropped", got)
+	}
+	if got := tbl.layout(5); !reflect.DeepEqual(got, []int{5, 0, 0}) {
+		t.Errorf("layout(5) = %v, want only the never-dropped column", got)
+	}
+}
+
+func TestTableLayoutSplitsByWeight(t *testing.T) {
+	tbl := table{gap: 1, columns: []tableColumn{
+		{Title: "A", Weight: 1},
+		{Title: "B", Weight: 2},
+	}}
+	got := tbl.layout(31)
+	if got[0]+got[1]+1 != 31 {
+		t.Errorf("layout(31) = %v, does not fill the width", got)
+	}
+	if got[0] != 10 || got[1] != 20 {
+		t.Errorf("layout(31) = %v, want [10 20]", got)
+	}
+}
+
+func TestTableRowKeepsAlignmentWithStyles(t *testing.T) {
+	tbl := table{gap: 1, columns: []tableColumn{
+		{Title: "Name", Width: 8},
+		{Title: "N", Width: 4, Right: true},
+	}}
+	widths := tbl.layout(13)
+	styled := lipgloss.NewStyle().Bold(true).Render("abc")
+
+	plain := tbl.row(widths, "abc", "7")
+	bold := tbl.row(widths, styled, "7")
+	if ansi.StringWidth(plain) != 13 || ansi.StringWidth(bold) != 13 {
+		t.Errorf("row widths = %d and %d, want 13", ansi.StringWidth(plain), ansi.StringWidth(bold))
+	}
+	if !strings.HasSuffix(plain, "    7") {
+		t.Errorf("row = %q, want right-aligned count", plain)
+	}
+}
+
+func TestFitCell(t *testing.T) {
+	if got := fitCell("abcdefghij", 6, false); got != "abc..." {
+		t.Errorf("fitCell truncated = %q, want %q", got, "abc...")
+	}
+	if got := fitCell("abcdef", 3, false); got != "abc" {
+		t.Errorf("fitCell tiny = %q, want %q", got, "abc")
+	}
+	if got := fitCell("ab", 4, true); got != "  ab" {
+		t.Errorf("fitCell right = %q, want %q", got, "  ab")
+	}
+	styled := lipgloss.NewStyle().Foreground(lipgloss.Color("5")).Render("a long styled value")
+	if got := fitCell(styled, 10, false); ansi.StringWidth(got) != 10 {
+		t.Errorf("fitCell styled width = %d, want 10", ansi.StringWidth(got))
+	}
+}
+
+func TestWidthsCountDisplayCells(t *testing.T) {
+	if got := padRight("技能", 6); got != "技能  " {
+		t.Errorf("padRight CJK = %q, want two spaces of padding", got)
+	}
+	if got := padRight("✓ ok", 6); got != "✓ ok  " {
+		t.Errorf("padRight glyph = %q, want %q", got, "✓ ok  ")
+	}
+	if got := padRight("toolong", 3); got != "toolong" {
+		t.Errorf("padRight long = %q, want it kept whole", got)
+	}
+	if got := fitCell("技能技能技能", 7, false); ansi.StringWidth(got) != 7 {
+		t.Errorf("fitCell CJK = %q (width %d), want width 7", got, ansi.StringWidth(got))
+	}
+}
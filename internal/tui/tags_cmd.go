@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// hasTag reports whether tags holds tag, ignoring case.
+func hasTag(tags []string, tag string) bool {
+	tag = strings.ToLower(tag)
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// storedSkillsTagged lists the stored skills whose frontmatter carries tag.
+func storedSkillsTagged(store *skill.Store, tag string) []string {
+	names, _ := store.ListInstalled()
+	var tagged []string
+	for _, name := range names {
+		if hasTag(skill.Tags(filepath.Join(store.BaseDir, name)), tag) {
+			tagged = append(tagged, name)
+		}
+	}
+	return tagged
+}
+
+// RunInstallTagged installs every skill tagged tag from each owner/repo,
+// with the options of RunInstallBatch. Each SKILL.md is fetched to read
+// its tags before anything is installed.
+func RunInstallTagged(repos []string, tag string, providerNames []string, branch string) error {
+	var refs []string
+	for _, repo := range repos {
+		src, err := skill.ParseSource(repo)
+		if err != nil || src.Kind != skill.SourceGitHub || src.Path != "" {
+			return fmt.Errorf("--tag installs from a repository: expected owner/repo, got %s", repo)
+		}
+		repo = src.Repository()
+		found, err := skill.DiscoverSkills(src.Owner, src.Repo, "")
+		if err != nil {
+			return err
+		}
+		for _, rs := range found {
+			content, err := fetchSkillContent(skillRef(Skill{Name: rs.Name, Source: repo, Path: rs.Path}))
+			if err != nil {
+				continue
+			}
+			if hasTag(skill.ParseTags(content), tag) {
+				refs = append(refs, repo+"/"+rs.Name)
+			}
+		}
+	}
+	if len(refs) == 0 {
+		return fmt.Errorf("no skills tagged %q in %s", tag, strings.Join(repos, ", "))
+	}
+	fmt.Printf("Found %d skill(s) tagged %s\n", len(refs), tag)
+	return RunInstallBatch(refs, providerNames, false, branch)
+}
+
+// RunEnable links the named stored skills, plus every stored skill tagged
+// tag, into the named providers (every configured provider when none are
+// given). With enable false the skills are unlinked instead.
+func RunEnable(names []string, tag string, providerNames []string, enable bool) error {
+	store := skill.NewStore(getSkillsPath())
+	if tag != "" {
+		tagged := storedSkillsTagged(store, tag)
+		if len(tagged) == 0 && len(names) == 0 {
+			return fmt.Errorf("no stored skills tagged %q", tag)
+		}
+		seen := make(map[string]bool)
+		for _, name := range names {
+			seen[name] = true
+		}
+		for _, name := range tagged {
+			if !seen[name] {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("name the skills to change or pass --tag")
+	}
+	targets, err := linkTargets(providerNames)
+	if err != nil {
+		return err
+	}
+
+	store, unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	ops := make(map[string][]applyOp)
+	for _, p := range targets {
+		present := make(map[string]bool)
+		for _, name := range listProviderSkills(p) {
+			present[name] = true
+		}
+		for _, name := range names {
+			switch {
+			case enable && !present[name]:
+				ops[p.Name] = append(ops[p.Name], linkSkillOp(store, p, name))
+			case !enable && present[name]:
+				ops[p.Name] = append(ops[p.Name], unlinkSkillOp(p, name))
+			}
+		}
+	}
+	if len(ops) == 0 {
+		fmt.Println("Nothing to change.")
+		return nil
+	}
+
+	report := applyConcurrently(ops)
+	recordSyncOutcomes(store, reportOutcomes("apply", report))
+	for _, line := range report.lines() {
+		fmt.Println("  " + line)
+	}
+	done, skipped, failed := report.counts()
+	fmt.Printf("\n%d done, %d skipped, %d failed\n", done, skipped, failed)
+	return partialFailure(done, report.err())
+}
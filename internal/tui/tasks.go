@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// activitySpinner animates loading messages and the activity line.
+var activitySpinner = spinner.Dot
+
+// spinnerFrame is the current frame of activitySpinner. Frames follow the
+// clock, so every view shows the same one while the app redraws on ticks.
+func spinnerFrame() string {
+	frames := activitySpinner.Frames
+	return frames[int(time.Now().UnixNano()/int64(activitySpinner.FPS))%len(frames)]
+}
+
+// withSpinner prefixes a loading message with the spinner.
+func withSpinner(s string) string {
+	return strings.TrimSpace(spinnerFrame()) + " " + s
+}
+
+// taskID identifies a background task.
+type taskID int
+
+// backgroundTask is a long-running action, such as an install, update or
+// registry check, running while the user keeps navigating.
+type backgroundTask struct {
+	id      taskID
+	label   string
+	origin  viewState // view the result is delivered to
+	gen     int       // generation of the origin view when started
+	started time.Time
+}
+
+// startTaskMsg asks the app to run cmd as a tracked background task.
+type startTaskMsg struct {
+	label string
+	cmd   tea.Cmd
+}
+
+// taskDoneMsg carries the result of a finished task.
+type taskDoneMsg struct {
+	id     taskID
+	result tea.Msg
+}
+
+// runTask runs cmd as a background task shown as label in the activity
+// line. Its result goes back to the view that started it, even when the
+// user has moved to another one since.
+func runTask(label string, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return startTaskMsg{label: label, cmd: cmd}
+	}
+}
+
+// taskManager tracks the running background tasks and drives the spinner.
+type taskManager struct {
+	next    taskID
+	running []backgroundTask
+	spinner spinner.Model
+	ticking bool
+}
+
+func newTaskManager() taskManager {
+	return taskManager{spinner: spinner.New(spinner.WithSpinner(activitySpinner))}
+}
+
+// start registers a task and returns the command running it.
+func (t *taskManager) start(label string, origin viewState, gen int, cmd tea.Cmd) tea.Cmd {
+	t.next++
+	id := t.next
+	t.running = append(t.running, backgroundTask{id: id, label: label, origin: origin, gen: gen, started: time.Now()})
+	return func() tea.Msg {
+		return taskDoneMsg{id: id, result: cmd()}
+	}
+}
+
+// finish removes a task, returning it.
+func (t *taskManager) finish(id taskID) (backgroundTask, bool) {
+	for i, task := range t.running {
+		if task.id == id {
+			t.running = append(t.running[:i:i], t.running[i+1:]...)
+			return task, true
+		}
+	}
+	return backgroundTask{}, false
+}
+
+// tick keeps the spinner running while busy. It returns the command that
+// starts ticking when the app becomes busy; ticks stop by themselves once
+// it is idle.
+func (t *taskManager) tick(busy bool) tea.Cmd {
+	if !busy || t.ticking {
+		return nil
+	}
+	t.ticking = true
+	return t.spinner.Tick
+}
+
+// View renders the activity line, e.g. "⣾ Installing lint · Updating go".
+func (t taskManager) View() string {
+	if len(t.running) == 0 {
+		return ""
+	}
+	labels := make([]string, len(t.running))
+	for i, task := range t.running {
+		labels[i] = task.label
+	}
+	return "\n" + spinnerStyle.Render(withSpinner(strings.Join(labels, " · ")))
+}
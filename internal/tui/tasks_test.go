@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runTaskCmd runs cmd, running the task it starts in place of the app.
+func runTaskCmd(cmd tea.Cmd) tea.Msg {
+	msg := cmd()
+	if start, ok := msg.(startTaskMsg); ok {
+		return start.cmd()
+	}
+	return msg
+}
+
+// startTask starts a task from the current view and returns the command
+// running it.
+func startTask(t *testing.T, app *model, label string, result tea.Msg) tea.Cmd {
+	t.Helper()
+	next, cmd := app.Update(runTask(label, func() tea.Msg { return result })())
+	*app = next.(model)
+	if cmd == nil {
+		t.Fatal("starting a task returned no command")
+	}
+	return func() tea.Msg {
+		// The command is batched with the spinner tick; run the task only
+		if batch, ok := cmd().(tea.BatchMsg); ok {
+			for _, c := range batch {
+				if done, ok := c().(taskDoneMsg); ok {
+					return done
+				}
+			}
+		}
+		return cmd()
+	}
+}
+
+func TestTaskResultReachesTheViewThatStartedIt(t *testing.T) {
+	setTestHome(t)
+	app := initialModel()
+	next, _ := app.Update(openManageMsg{provider: Provider{Name: "claude"}})
+	app = next.(model)
+
+	run := startTask(t, &app, "Updating demo", updateSkillMsg{skillName: "demo"})
+	if !strings.Contains(app.View(), "Updating demo") {
+		t.Error("the activity line does not show the running task")
+	}
+	if !app.busy() {
+		t.Error("app is not busy while a task runs")
+	}
+
+	// Move on to another view before the task finishes
+	next, _ = app.Update(openConfigMsg{})
+	app = next.(model)
+	next, _ = app.Update(run())
+	app = next.(model)
+
+	if len(app.tasks.running) != 0 {
+		t.Errorf("finished task still tracked: %+v", app.tasks.running)
+	}
+	if app.state != viewConfig {
+		t.Errorf("state = %v, want config", app.state)
+	}
+	if app.manageModel.statusMsg != "Updated demo successfully" {
+		t.Errorf("manage status = %q, want the update result", app.manageModel.statusMsg)
+	}
+	if strings.Contains(app.View(), "Updating demo") {
+		t.Error("the activity line still shows the finished task")
+	}
+}
+
+func TestTaskResultDroppedWhenItsViewWasReplaced(t *testing.T) {
+	setTestHome(t)
+	app := initialModel()
+	next, _ := app.Update(openManageMsg{provider: Provider{Name: "claude"}})
+	app = next.(model)
+	run := startTask(t, &app, "Updating demo", updateSkillMsg{skillName: "demo"})
+
+	// Reopening manage for another provider replaces its model
+	next, _ = app.Update(openManageMsg{provider: Provider{Name: "cursor"}})
+	app = next.(model)
+	next, _ = app.Update(run())
+	app = next.(model)
+
+	if app.manageModel.statusMsg != "" {
+		t.Errorf("result of a task from the replaced view was applied: %q", app.manageModel.statusMsg)
+	}
+	if len(app.tasks.running) != 0 {
+		t.Errorf("finished task still tracked: %+v", app.tasks.running)
+	}
+}
+
+func TestSpinnerStopsWhenIdle(t *testing.T) {
+	setTestHome(t)
+	app := initialModel()
+	app.statusModel.loading = false
+	app.tasks.ticking = true
+
+	next, cmd := app.Update(spinner.TickMsg{})
+	app = next.(model)
+	if cmd != nil || app.tasks.ticking {
+		t.Error("spinner kept ticking with nothing running")
+	}
+
+	run := startTask(t, &app, "Repairing claude", nil)
+	if !app.tasks.ticking {
+		t.Error("starting a task did not start the spinner")
+	}
+	next, _ = app.Update(run())
+	app = next.(model)
+	if app.busy() {
+		t.Error("app still busy after its only task finished")
+	}
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"path/filepath"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// configTemplateValues returns the placeholder values saved in config.
+func configTemplateValues() map[string]string {
+	values := make(map[string]string)
+	if cfg := loadConfigFromFile(); cfg != nil {
+		for k, v := range cfg.Variables {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+// rememberTemplateValues stores prompted placeholder values in config so
+// later installs reuse them.
+func rememberTemplateValues(values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	cfg := loadOrDefaultConfig()
+	if cfg.Variables == nil {
+		cfg.Variables = make(map[string]string)
+	}
+	for k, v := range values {
+		cfg.Variables[k] = v
+	}
+	return saveConfigData(cfg)
+}
+
+// missingTemplateVars lists the placeholders used under path that have no
+// value in values.
+func missingTemplateVars(path string, values map[string]string) []string {
+	vars, err := skill.TemplateVars(path)
+	if err != nil {
+		return nil
+	}
+	var missing []string
+	for _, name := range vars {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// renderSkill fills placeholders in a stored skill with values and records
+// the rendered folder as the baseline update compares local edits against.
+func renderSkill(store *skill.Store, name string, values map[string]string) error {
+	if err := skill.RenderTemplate(filepath.Join(store.BaseDir, name), values); err != nil {
+		return err
+	}
+	return store.RecordFolderHash(name)
+}
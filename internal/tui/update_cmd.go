@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// RunUpdate updates the named skills, or every skill with an upstream change
+// when all is set. Each skill follows its tracked branch; branch switches
+// the named skills to a new one first. Held skills are skipped. Skills edited in the store are
+// resolved with strategy, asked for per skill on a terminal when it is
+// empty, and otherwise left untouched and reported.
+func RunUpdate(names []string, all bool, branch, strategy string) error {
+	if len(names) == 0 && !all {
+		return fmt.Errorf("name the skills to update or pass --all")
+	}
+	if branch != "" && len(names) == 0 {
+		return fmt.Errorf("--branch needs the skills to switch")
+	}
+	conflict, err := skill.ParseConflictStrategy(strategy)
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(getSkillsPath())
+	values := configTemplateValues()
+
+	if len(names) == 0 {
+		updated, err := store.UpdateAllSkillsWith(conflict)
+		failed := 0
+		for _, name := range updated {
+			if err := finishUpdate(store, name, values); err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", name, err)
+				continue
+			}
+			fmt.Printf("✓ Updated %s\n", name)
+		}
+		if len(updated) == 0 && err == nil {
+			fmt.Println("All skills are up to date.")
+		}
+		if err == nil && failed > 0 {
+			err = fmt.Errorf("%d skill(s) failed to update", failed)
+		}
+		return err
+	}
+
+	lock, err := store.ReadLockFile()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, name := range names {
+		entry, ok := lock.Skills[name]
+		if !ok {
+			failed++
+			fmt.Printf("✗ %s: not in the lock file\n", name)
+			continue
+		}
+		if entry.Held {
+			fmt.Printf("• %s is held (efx-skills unhold %s to resume updates)\n", name, name)
+			continue
+		}
+
+		if branch == "" {
+			hasUpdate, _, _, err := store.CheckForUpdate(name)
+			if err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", name, err)
+				continue
+			}
+			if !hasUpdate {
+				fmt.Printf("• %s is up to date (%s)\n", name, entry.Channel())
+				continue
+			}
+		}
+
+		modified, err := store.LocallyModified(name)
+		if err != nil {
+			failed++
+			fmt.Printf("✗ %s: %v\n", name, err)
+			continue
+		}
+		choice := conflict
+		if modified && choice == skill.StrategyRefuse && interactive() {
+			choice = promptConflict(name, os.Stdin)
+		}
+		if modified && choice == skill.KeepLocal {
+			fmt.Printf("• %s kept with its local changes\n", name)
+			continue
+		}
+
+		if branch != "" {
+			err = switchBranch(store, name, entry, branch, choice)
+		} else {
+			err = store.UpdateSkillWith(name, choice)
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("✗ %s: %v\n", name, err)
+			if hint := errs.Hint(err); hint != "" && errs.KindOf(err) == errs.Conflict {
+				fmt.Printf("  %s\n", hint)
+			}
+			continue
+		}
+		if modified && choice == skill.SaveLocal {
+			fmt.Printf("• Saved the local changes of %s as %s\n", name, name+skill.LocalCopySuffix)
+		}
+
+		if err := finishUpdate(store, name, values); err != nil {
+			failed++
+			fmt.Printf("✗ %s: %v\n", name, err)
+			continue
+		}
+		fmt.Printf("✓ Updated %s\n", name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d skill(s) failed to update", failed)
+	}
+	return nil
+}
+
+// finishUpdate re-renders the template values of an updated skill and
+// refreshes the providers holding a copy of it.
+func finishUpdate(store *skill.Store, name string, values map[string]string) error {
+	if err := renderSkill(store, name, values); err != nil {
+		return fmt.Errorf("rendering: %w", err)
+	}
+	if err := refreshCopies(store, name); err != nil {
+		return fmt.Errorf("refreshing copies: %w", err)
+	}
+	return nil
+}
+
+// promptConflict asks what to do with the local changes of a skill
+// upstream has also changed. Anything but a known answer keeps them.
+func promptConflict(name string, in io.Reader) skill.ConflictStrategy {
+	fmt.Printf("%s has local changes and upstream has changed too.\n", name)
+	fmt.Printf("[k]eep local, take [u]pstream, or [s]ave local as %s and update? [k]: ", name+skill.LocalCopySuffix)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "u", "upstream":
+		return skill.TakeUpstream
+	case "s", "save", "save-local":
+		return skill.SaveLocal
+	}
+	return skill.KeepLocal
+}
+
+// conflictKey returns the strategy picked with a key in the local-changes
+// prompt of the manage and installed views.
+func conflictKey(key string) (skill.ConflictStrategy, bool) {
+	switch key {
+	case "k":
+		return skill.KeepLocal, true
+	case "u":
+		return skill.TakeUpstream, true
+	case "s":
+		return skill.SaveLocal, true
+	}
+	return "", false
+}
+
+// conflictQuestion is the local-changes prompt of the manage and installed
+// views.
+func conflictQuestion(names []string) string {
+	return fmt.Sprintf("%s: local changes. [k] keep them [u] take upstream [s] save as <skill>%s, then update [n] cancel",
+		strings.Join(names, ", "), skill.LocalCopySuffix)
+}
+
+// switchBranch reinstalls a skill from branch and makes it the tracked one.
+func switchBranch(store *skill.Store, name string, entry skill.LockEntry, branch string, strategy skill.ConflictStrategy) error {
+	if entry.SourceType == skill.SourceTypeLocal {
+		return fmt.Errorf("local skills have no upstream branch")
+	}
+	if modified, _ := store.LocallyModified(name); modified && strategy == skill.StrategyRefuse {
+		// Refuse before the branch is recorded, so the skill stays as it was
+		return skill.LocalChangesError(name)
+	}
+	if err := store.SetBranch(name, branch); err != nil {
+		return err
+	}
+	return store.UpdateSkillWith(name, strategy)
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestPromptConflict(t *testing.T) {
+	cases := map[string]skill.ConflictStrategy{
+		"u\n":          skill.TakeUpstream,
+		"save-local\n": skill.SaveLocal,
+		"s\n":          skill.SaveLocal,
+		"k\n":          skill.KeepLocal,
+		"\n":           skill.KeepLocal,
+		"what\n":       skill.KeepLocal,
+	}
+	for answer, want := range cases {
+		if got := promptConflict("mine", strings.NewReader(answer)); got != want {
+			t.Errorf("promptConflict(%q) = %q, want %q", answer, got, want)
+		}
+	}
+}
+
+func TestRunUpdateRejectsUnknownStrategy(t *testing.T) {
+	setTestHome(t)
+	if err := RunUpdate([]string{"mine"}, false, "", "merge"); err == nil {
+		t.Fatal("RunUpdate with an unknown strategy should fail")
+	}
+}
+
+// editedSkill installs "mine" in the store with a recorded hash, then edits
+// it there.
+func editedSkill(t *testing.T) *skill.Store {
+	t.Helper()
+	setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	dir := filepath.Join(store.BaseDir, "mine")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# Mine"), 0644)
+	if err := store.AddToLock("mine", "owner/repo", "abc"); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# Mine, edited"), 0644)
+	return store
+}
+
+func TestManageUpdateAsksAboutLocalChanges(t *testing.T) {
+	editedSkill(t)
+	m := newManageModel(Provider{Name: "claude"})
+	m.loading = false
+
+	msg := runTaskCmd(updateSkillTask("mine", skill.StrategyRefuse))
+	if u, ok := msg.(updateSkillMsg); !ok || !u.conflict {
+		t.Fatalf("update of an edited skill = %#v, want a conflict", msg)
+	}
+	m, _ = m.Update(msg)
+	if !m.capturesKeys() || !strings.Contains(m.View(), "[k] keep them") {
+		t.Fatalf("no local-changes prompt:\n%s", m.View())
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if cmd != nil || m.capturesKeys() || !strings.Contains(m.statusMsg, "kept") {
+		t.Errorf("keep answer: status %q, command %v", m.statusMsg, cmd != nil)
+	}
+}
+
+func TestInstalledUpdateAsksAboutLocalChanges(t *testing.T) {
+	editedSkill(t)
+	msg := updateSkillsCmd([]string{"mine"}, skill.StrategyRefuse)().(installedActionMsg)
+	if len(msg.conflicts) != 1 || len(msg.done)+len(msg.failed) != 0 {
+		t.Fatalf("update of an edited skill = %+v, want a conflict", msg)
+	}
+
+	m := newInstalledModel()
+	m, _ = m.Update(msg)
+	if !m.capturesKeys() {
+		t.Fatal("no local-changes prompt")
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if cmd == nil || m.capturesKeys() {
+		t.Error("taking upstream did not start the update")
+	}
+}
+
+func TestFinishUpdateRefreshesCopies(t *testing.T) {
+	store, claude := providerFixture(t, CustomProvider{LinkMode: "copy"}, "go-test")
+	if err := linkSkillToProvider(store, claude, "go-test"); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(filepath.Join(claude.Path, "go-test")); err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Fatalf("claude should hold a copy: %v", err)
+	}
+	os.WriteFile(filepath.Join(store.BaseDir, "go-test", "SKILL.md"), []byte("# go-test v2"), 0644)
+
+	if err := finishUpdate(store, "go-test", nil); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(claude.Path, "go-test", "SKILL.md")); string(data) != "# go-test v2" {
+		t.Errorf("provider copy = %q, want the updated skill", data)
+	}
+}
@@ -0,0 +1,332 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lmarques/efx-skills/internal/fsutil"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+// urlScheme is the scheme of one-click install links, as in
+// efx-skills://install?source=owner/repo&skill=name.
+const urlScheme = "efx-skills"
+
+// installLink is an install requested through an efx-skills:// link.
+type installLink struct {
+	Source  string // owner/repo, a git URL or an archive URL
+	Skill   string
+	Version string
+	Branch  string
+}
+
+// parseInstallLink reads an efx-skills://install link. The link names
+// what to install, never where: skills go to the configured providers.
+func parseInstallLink(raw string) (installLink, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Scheme != urlScheme {
+		return installLink{}, fmt.Errorf("not an %s:// link: %s", urlScheme, raw)
+	}
+	// efx-skills://install parses the action as the host, efx-skills:install
+	// as the opaque part
+	action := u.Host
+	if action == "" {
+		action = strings.Trim(u.Opaque+u.Path, "/")
+	}
+	if action != "install" {
+		return installLink{}, fmt.Errorf("unsupported link action %q (expected install)", action)
+	}
+
+	q := u.Query()
+	link := installLink{
+		Source:  q.Get("source"),
+		Skill:   q.Get("skill"),
+		Version: q.Get("version"),
+		Branch:  q.Get("branch"),
+	}
+	if link.Source == "" {
+		return installLink{}, fmt.Errorf("link names no source")
+	}
+	if link.Version != "" && link.Branch != "" {
+		return installLink{}, fmt.Errorf("a skill follows either a branch or a pinned version, not both")
+	}
+	src, err := skill.ParseSource(link.Source)
+	if err != nil {
+		return installLink{}, err
+	}
+	switch src.Kind {
+	case skill.SourceLocal, skill.SourceRegistry:
+		return installLink{}, fmt.Errorf("links install from a repository, a git URL or an archive, not %s", link.Source)
+	}
+	// Links can come from anyone: the skill they name must be a single
+	// folder of the store before the user is even asked
+	_, name, _, err := parseSkillRef(link.ref())
+	if err != nil {
+		return installLink{}, err
+	}
+	if link.Skill != "" {
+		name = link.Skill
+	}
+	if err := fsutil.ValidName(name); err != nil {
+		return installLink{}, fmt.Errorf("link names an invalid skill: %w", err)
+	}
+	return link, nil
+}
+
+// ref returns the reference RunInstall installs the link with.
+func (l installLink) ref() string {
+	ref := l.Source
+	if l.Skill != "" && !skill.IsArchiveURL(l.Source) {
+		ref = strings.TrimSuffix(ref, "/") + "/" + l.Skill
+	}
+	if l.Version != "" {
+		ref += "@" + l.Version
+	}
+	return ref
+}
+
+// linkPreviewMsg carries what the confirmation shows about the linked
+// skill once fetched.
+type linkPreviewMsg struct {
+	description string
+	license     string
+	err         error
+}
+
+// confirmLinkModel is the interstitial shown before installing from a
+// link, listing exactly what would be installed and where.
+type confirmLinkModel struct {
+	link        installLink
+	source      string
+	name        string
+	targets     []string
+	installed   string // the source of the installed skill it replaces, if any
+	description string
+	license     string
+	loading     bool
+	err         error
+	confirmed   bool
+}
+
+func newConfirmLinkModel(link installLink) (confirmLinkModel, error) {
+	source, name, _, err := parseSkillRef(link.ref())
+	if err != nil {
+		return confirmLinkModel{}, err
+	}
+	targets, err := linkTargets(nil)
+	if err != nil {
+		return confirmLinkModel{}, err
+	}
+	m := confirmLinkModel{link: link, source: source, name: name, loading: true}
+	for _, p := range targets {
+		m.targets = append(m.targets, p.Name)
+	}
+	if lock, err := skill.NewStore(getSkillsPath()).ReadLockFile(); err == nil {
+		if e, ok := lockEntry(lock, name); ok {
+			m.installed = e.Source
+		}
+	}
+	return m, nil
+}
+
+func (m confirmLinkModel) Init() tea.Cmd {
+	source, name := m.source, m.name
+	return func() tea.Msg {
+		content, err := fetchSkillContent(source + "/" + name)
+		if err != nil {
+			return linkPreviewMsg{err: err}
+		}
+		fm, _ := skill.ParseFrontmatter(content)
+		license := fm["license"]
+		if src, err := skill.ParseSource(source); err == nil && license == "" && src.Kind == skill.SourceGitHub {
+			license, _ = fetchRepoLicense(src.Owner, src.Repo)
+		}
+		return linkPreviewMsg{description: fm["description"], license: license}
+	}
+}
+
+func (m confirmLinkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case linkPreviewMsg:
+		m.loading = false
+		m.description, m.license, m.err = msg.description, msg.license, msg.err
+	case tea.KeyMsg:
+		switch msg.String() {
+		// Only y installs: the link opened this prompt, so a stray Enter
+		// must not confirm it
+		case "y":
+			m.confirmed = true
+			return m, tea.Quit
+		case "n", "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m confirmLinkModel) View() string {
+	var b strings.Builder
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s %s\n", statusMutedStyle.Render(padRight(label+":", 12)), value)
+		}
+	}
+	b.WriteString(titleStyle.Render("Install from a link") + "\n\n")
+	row("Skill", m.name)
+	row("Source", m.source)
+	if !skill.IsGitURL(m.source) && !skill.IsArchiveURL(m.source) {
+		row("Page", skill.WebURL(m.source))
+	}
+	switch {
+	case m.link.Version != "":
+		row("Version", m.link.Version)
+	case m.link.Branch != "":
+		row("Branch", m.link.Branch+" (followed by updates)")
+	default:
+		row("Version", "latest from the default branch")
+	}
+	switch {
+	case m.loading:
+		row("About", "fetching SKILL.md...")
+	case m.err != nil:
+		row("About", statusWarnStyle.Render("SKILL.md not found: "+m.err.Error()))
+	default:
+		row("About", m.description)
+		license := m.license
+		if license == "" {
+			license = "unknown"
+		}
+		row("License", license)
+		if warning, err := licensePolicy().check(m.name, m.license); err != nil {
+			row("Policy", statusWarnStyle.Render(err.Error()))
+		} else if warning != "" {
+			row("Policy", statusWarnStyle.Render(warning))
+		}
+	}
+	if len(m.targets) > 0 {
+		row("Links to", strings.Join(m.targets, ", "))
+	} else {
+		row("Links to", statusWarnStyle.Render("no provider is configured; stored only"))
+	}
+	if m.installed != "" {
+		row("Replaces", fmt.Sprintf("the installed %s from %s", m.name, m.installed))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(warning).
+		Padding(1, 2)
+	footer := statusMutedStyle.Render("[y] install  [n] cancel")
+	return "\n" + box.Render(b.String()+"\n"+footer) + "\n"
+}
+
+// desktopEntry is the freedesktop entry registering the efx-skills://
+// handler on Linux. Links open in a terminal, where the confirmation runs.
+const desktopEntry = `[Desktop Entry]
+Type=Application
+Name=efx-skills
+Comment=Install AI agent skills from efx-skills:// links
+Exec=%s open-url %%u
+Terminal=true
+NoDisplay=true
+MimeType=x-scheme-handler/efx-skills;
+`
+
+// desktopExecArg quotes arg for the Exec key of a desktop entry, so paths
+// with spaces or shell characters stay one argument. Backslashes are
+// escaped twice: once for the quoting, once for the string value.
+func desktopExecArg(arg string) string {
+	escaped := strings.NewReplacer(`\`, `\\\\`, `"`, `\\"`, "`", "\\\\`", `$`, `\\$`, `%`, `%%`).Replace(arg)
+	return `"` + escaped + `"`
+}
+
+// desktopEntryName is the file name of the entry, and the name xdg-mime
+// knows the handler by.
+const desktopEntryName = "efx-skills-url.desktop"
+
+// runURLCommand is a variable so tests can stub xdg-mime and reg.
+var runURLCommand = func(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// desktopEntryPath returns where the Linux handler entry is written.
+func desktopEntryPath() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "applications", desktopEntryName), nil
+}
+
+// windowsSchemeKey is the per-user registry key of the handler.
+const windowsSchemeKey = `HKCU\Software\Classes\efx-skills`
+
+// registerURLHandler makes exe the handler of efx-skills:// links for the
+// current user and returns where it was registered.
+func registerURLHandler(exe string) (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		path, err := desktopEntryPath()
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(path, []byte(fmt.Sprintf(desktopEntry, desktopExecArg(exe))), 0644); err != nil {
+			return "", err
+		}
+		if err := runURLCommand("xdg-mime", "default", desktopEntryName, "x-scheme-handler/"+urlScheme); err != nil {
+			return "", err
+		}
+		return path, nil
+	case "windows":
+		command := fmt.Sprintf(`"%s" open-url "%%1"`, exe)
+		for _, args := range [][]string{
+			{"add", windowsSchemeKey, "/ve", "/d", "URL:efx-skills", "/f"},
+			{"add", windowsSchemeKey, "/v", "URL Protocol", "/d", "", "/f"},
+			{"add", windowsSchemeKey + `\shell\open\command`, "/ve", "/d", command, "/f"},
+		} {
+			if err := runURLCommand("reg", args...); err != nil {
+				return "", err
+			}
+		}
+		return windowsSchemeKey, nil
+	case "darwin":
+		return "", fmt.Errorf("macOS only routes URL schemes to app bundles; open links with: efx-skills open-url '<link>'")
+	}
+	return "", fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+}
+
+// unregisterURLHandler removes what registerURLHandler added.
+func unregisterURLHandler() error {
+	switch runtime.GOOS {
+	case "linux":
+		path, err := desktopEntryPath()
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	case "windows":
+		return runURLCommand("reg", "delete", windowsSchemeKey, "/f")
+	}
+	return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RunOpenURL handles an efx-skills://install link: it shows what the link
+// would install and where, and installs it once confirmed. The terminal
+// the handler opened stays up until Enter is pressed, so the result can be
+// read.
+func RunOpenURL(raw string) error {
+	link, err := parseInstallLink(raw)
+	if err != nil {
+		return err
+	}
+	m, err := newConfirmLinkModel(link)
+	if err != nil {
+		return err
+	}
+	opts := []tea.ProgramOption{}
+	if terminalOut != nil {
+		opts = append(opts, tea.WithOutput(terminalOut))
+	}
+	final, err := tea.NewProgram(m, opts...).Run()
+	if err != nil {
+		return err
+	}
+	if fm, ok := final.(confirmLinkModel); !ok || !fm.confirmed {
+		fmt.Println("Cancelled; nothing was installed.")
+		return nil
+	}
+
+	err = RunInstall(link.ref(), nil, false, link.Branch)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+	}
+	fmt.Print("\nPress Enter to close.")
+	bufio.NewReader(os.Stdin).ReadString('\n')
+	return err
+}
+
+// RunURLHandlerRegister makes this binary the handler of efx-skills://
+// links for the current user.
+func RunURLHandlerRegister() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	where, err := registerURLHandler(exe)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ efx-skills:// links open %s (registered in %s)\n", exe, displayPath(where))
+	return nil
+}
+
+// RunURLHandlerUnregister removes the efx-skills:// handler.
+func RunURLHandlerUnregister() error {
+	if err := unregisterURLHandler(); err != nil {
+		return err
+	}
+	fmt.Println("✓ efx-skills:// links are no longer handled")
+	return nil
+}
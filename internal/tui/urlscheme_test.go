@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func TestParseInstallLink(t *testing.T) {
+	tests := map[string]string{
+		"efx-skills://install?source=owner/repo&skill=lint":              "owner/repo/lint",
+		"efx-skills://install?source=owner/repo&skill=lint&version=v1.2": "owner/repo/lint@v1.2",
+		"efx-skills:install?source=owner/repo/lint":                      "owner/repo/lint",
+		"efx-skills://install?source=https://example.com/dl/lint.zip":    "https://example.com/dl/lint.zip",
+	}
+	for raw, want := range tests {
+		link, err := parseInstallLink(raw)
+		if err != nil || link.ref() != want {
+			t.Errorf("parseInstallLink(%q) = %q, %v; want %q", raw, link.ref(), err, want)
+		}
+	}
+
+	for _, bad := range []string{
+		"https://example.com/install?source=owner/repo&skill=lint",
+		"efx-skills://remove?source=owner/repo&skill=lint",
+		"efx-skills://install?skill=lint",
+		"efx-skills://install?source=owner/repo",
+		"efx-skills://install?source=/etc&skill=passwd",
+		"efx-skills://install?source=owner/repo&skill=lint&version=v1&branch=next",
+		"efx-skills://install?source=owner/repo&skill=..",
+		"efx-skills://install?source=owner/repo/.",
+		"efx-skills://install?source=git@github.com:org/skills.git&skill=..",
+		"efx-skills://install?source=https://example.com/dl/lint.zip&skill=..",
+		"efx-skills://install?source=https://example.com/dl/.zip",
+	} {
+		if _, err := parseInstallLink(bad); err == nil {
+			t.Errorf("parseInstallLink(%q) should fail", bad)
+		}
+	}
+}
+
+func TestConfirmLinkShowsWhatWouldBeInstalled(t *testing.T) {
+	setTestHome(t)
+	link, err := parseInstallLink("efx-skills://install?source=owner/repo&skill=lint&branch=next")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := newConfirmLinkModel(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, _ := m.Update(linkPreviewMsg{description: "Lints Go code", license: "MIT"})
+	view := next.View()
+	for _, want := range []string{"lint", "owner/repo", "next", "Lints Go code", "MIT"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("confirmation should show %q:\n%s", want, view)
+		}
+	}
+
+	next, cmd := next.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if next.(confirmLinkModel).confirmed || cmd == nil {
+		t.Error("n should cancel without installing")
+	}
+	next, _ = next.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if next.(confirmLinkModel).confirmed {
+		t.Error("enter should not confirm the install")
+	}
+	next, _ = next.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if !next.(confirmLinkModel).confirmed {
+		t.Error("y should confirm the install")
+	}
+}
+
+func TestRegisterURLHandlerWritesDesktopEntry(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the desktop entry is Linux only")
+	}
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	var ran []string
+	old := runURLCommand
+	defer func() { runURLCommand = old }()
+	runURLCommand = func(name string, args ...string) error {
+		ran = append(ran, name+" "+strings.Join(args, " "))
+		return nil
+	}
+
+	where, err := registerURLHandler("/usr/local/bin/efx-skills")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(where)
+	if err != nil || !strings.Contains(string(data), `Exec="/usr/local/bin/efx-skills" open-url %u`) || !strings.Contains(string(data), "x-scheme-handler/efx-skills") {
+		t.Errorf("desktop entry = %q, %v", data, err)
+	}
+	if len(ran) != 1 || ran[0] != "xdg-mime default efx-skills-url.desktop x-scheme-handler/efx-skills" {
+		t.Errorf("ran %v", ran)
+	}
+
+	if err := unregisterURLHandler(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dataHome, "applications", desktopEntryName)); !os.IsNotExist(err) {
+		t.Errorf("unregister left the entry: %v", err)
+	}
+}
+
+func TestDesktopExecArg(t *testing.T) {
+	for arg, want := range map[string]string{
+		"/usr/local/bin/efx-skills":     `"/usr/local/bin/efx-skills"`,
+		"/home/me/My Apps/efx-skills":   `"/home/me/My Apps/efx-skills"`,
+		`/opt/$x/"odd"/100%/efx-skills`: `"/opt/\\$x/\\"odd\\"/100%%/efx-skills"`,
+		`/opt/back\slash/efx-skills`:    `"/opt/back\\\\slash/efx-skills"`,
+	} {
+		if got := desktopExecArg(arg); got != want {
+			t.Errorf("desktopExecArg(%q) = %s, want %s", arg, got, want)
+		}
+	}
+}
+
+func TestConfirmLinkNotesTheSkillItReplaces(t *testing.T) {
+	setTestHome(t)
+	store := skill.NewStore(getSkillsPath())
+	os.MkdirAll(filepath.Join(store.BaseDir, "lint"), 0755)
+	if err := store.AddToLock("lint", "fork/repo", "abc123"); err != nil {
+		t.Fatal(err)
+	}
+	link, _ := parseInstallLink("efx-skills://install?source=owner/repo&skill=lint")
+	m, err := newConfirmLinkModel(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(m.View(), "fork/repo") {
+		t.Errorf("confirmation should name the installed source it replaces:\n%s", m.View())
+	}
+}
@@ -0,0 +1,79 @@
+package tui
+
+import "strconv"
+
+// vimNav adds vim-style motions to list views: count prefixes ("5j"),
+// gg/G, and ctrl+d/ctrl+u half-page jumps. Views keep one in their model and
+// offer it each key before their own bindings.
+type vimNav struct {
+	count    int  // pending count prefix, 0 when none
+	pendingG bool // first "g" of "gg" was typed
+}
+
+// handle applies key to a list of n rows with the cursor at idx; page is
+// the number of visible rows. It returns the new cursor and whether the key
+// was consumed. Plain j/k are left to the view so its own handling applies.
+func (v *vimNav) handle(key string, idx, n, page int) (int, bool) {
+	if d, err := strconv.Atoi(key); err == nil && len(key) == 1 && (d > 0 || v.count > 0) {
+		if v.count < 1000 {
+			v.count = v.count*10 + d
+		}
+		v.pendingG = false
+		return idx, true
+	}
+
+	count := v.count
+	if key == "g" {
+		if !v.pendingG {
+			v.pendingG = true
+			return idx, true
+		}
+		v.count, v.pendingG = 0, false
+		if count > 0 {
+			return clampIndex(count-1, n), true
+		}
+		return clampIndex(0, n), true
+	}
+	v.count, v.pendingG = 0, false
+
+	half := page / 2
+	if half < 1 {
+		half = 1
+	}
+	repeat := count
+	if repeat < 1 {
+		repeat = 1
+	}
+
+	switch key {
+	case "G":
+		if count > 0 {
+			return clampIndex(count-1, n), true
+		}
+		return clampIndex(n-1, n), true
+	case "ctrl+d":
+		return clampIndex(idx+half*repeat, n), true
+	case "ctrl+u":
+		return clampIndex(idx-half*repeat, n), true
+	case "j", "down":
+		if count > 0 {
+			return clampIndex(idx+count, n), true
+		}
+	case "k", "up":
+		if count > 0 {
+			return clampIndex(idx-count, n), true
+		}
+	}
+	return idx, false
+}
+
+// clampIndex keeps i within a list of n rows.
+func clampIndex(i, n int) int {
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
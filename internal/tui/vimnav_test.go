@@ -0,0 +1,57 @@
+package tui
+
+import "testing"
+
+func TestVimNavMotions(t *testing.T) {
+	// feed sends keys in order and returns the final cursor.
+	feed := func(idx int, keys ...string) int {
+		var v vimNav
+		for _, k := range keys {
+			idx, _ = v.handle(k, idx, 50, 10)
+		}
+		return idx
+	}
+
+	cases := []struct {
+		name  string
+		start int
+		keys  []string
+		want  int
+	}{
+		{"gg", 20, []string{"g", "g"}, 0},
+		{"G", 3, []string{"G"}, 49},
+		{"count G", 3, []string{"1", "2", "G"}, 11},
+		{"count gg", 30, []string{"7", "g", "g"}, 6},
+		{"count j", 5, []string{"4", "j"}, 9},
+		{"count k clamps", 2, []string{"9", "k"}, 0},
+		{"ctrl+d", 0, []string{"ctrl+d"}, 5},
+		{"count ctrl+d", 0, []string{"3", "ctrl+d"}, 15},
+		{"ctrl+u clamps", 2, []string{"ctrl+u"}, 0},
+		{"G past end", 0, []string{"9", "9", "G"}, 49},
+	}
+	for _, c := range cases {
+		if got := feed(c.start, c.keys...); got != c.want {
+			t.Errorf("%s: cursor = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestVimNavLeavesOtherKeys(t *testing.T) {
+	var v vimNav
+	if _, ok := v.handle("j", 0, 10, 5); ok {
+		t.Error("plain j should be left to the view")
+	}
+	if _, ok := v.handle("0", 0, 10, 5); ok {
+		t.Error("a leading 0 is not a count")
+	}
+
+	// A pending g or count is dropped by an unrelated key
+	v.handle("g", 0, 10, 5)
+	v.handle("3", 0, 10, 5)
+	if _, ok := v.handle("x", 0, 10, 5); ok {
+		t.Error("x should not be consumed")
+	}
+	if idx, _ := v.handle("G", 0, 10, 5); idx != 9 {
+		t.Errorf("G after reset = %d, want 9", idx)
+	}
+}
@@ -0,0 +1,311 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lmarques/efx-skills/internal/errs"
+)
+
+// Workspaces are separate setups, e.g. "work" and "personal", each with its
+// own config.json (enabled providers, tracked skills) and store with its
+// own lock file. The default workspace is the original
+// ~/.config/efx-skills/config.json and ~/.agents/skills.
+const (
+	defaultWorkspace = "default"
+	workspaceEnv     = "EFX_SKILLS_WORKSPACE"
+)
+
+// activeWorkspace is the workspace every command works on; "" is the
+// default one.
+var activeWorkspace string
+
+var workspaceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// configDir is where efx-skills keeps its settings.
+func configDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".config", "efx-skills")
+}
+
+// workspaceDir holds the settings of a named workspace.
+func workspaceDir(name string) string {
+	return filepath.Join(configDir(), "workspaces", name)
+}
+
+// workspaceConfigPath returns the config.json of a workspace.
+func workspaceConfigPath(name string) string {
+	if name == "" || name == defaultWorkspace {
+		return filepath.Join(configDir(), "config.json")
+	}
+	return filepath.Join(workspaceDir(name), "config.json")
+}
+
+// workspaceStorePath is the store a new workspace starts with.
+func workspaceStorePath(name string) string {
+	if name == "" || name == defaultWorkspace {
+		return filepath.Join(os.Getenv("HOME"), ".agents", "skills")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".agents", "workspaces", name, "skills")
+}
+
+// currentWorkspacePath records the workspace last chosen with
+// `workspace use` or the TUI switcher.
+func currentWorkspacePath() string {
+	return filepath.Join(configDir(), "workspace")
+}
+
+// workspaceName returns the display name of the active workspace.
+func workspaceName() string {
+	if activeWorkspace == "" {
+		return defaultWorkspace
+	}
+	return activeWorkspace
+}
+
+// listWorkspaces returns "default" followed by the named workspaces.
+func listWorkspaces() []string {
+	names := []string{defaultWorkspace}
+	entries, err := os.ReadDir(filepath.Join(configDir(), "workspaces"))
+	if err != nil {
+		return names
+	}
+	var named []string
+	for _, e := range entries {
+		if _, err := os.Stat(workspaceConfigPath(e.Name())); e.IsDir() && err == nil {
+			named = append(named, e.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(names, named...)
+}
+
+func workspaceExists(name string) bool {
+	if name == defaultWorkspace {
+		return true
+	}
+	_, err := os.Stat(workspaceConfigPath(name))
+	return err == nil
+}
+
+// switchWorkspace makes name the active workspace for this process.
+func switchWorkspace(name string) error {
+	if !workspaceExists(name) {
+		return fmt.Errorf("unknown workspace %s (create it with: efx-skills workspace create %s)", name, name)
+	}
+	activeWorkspace = name
+	if name == defaultWorkspace {
+		activeWorkspace = ""
+	}
+	return nil
+}
+
+// useWorkspace switches to name and remembers it for later runs.
+func useWorkspace(name string) error {
+	if err := switchWorkspace(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(configDir(), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(currentWorkspacePath(), []byte(workspaceName()+"\n"), 0644)
+}
+
+// createWorkspace sets up a named workspace with its own store, which
+// defaults to ~/.agents/workspaces/<name>/skills. No providers are enabled,
+// so nothing is linked until the workspace is configured.
+func createWorkspace(name, storePath string) error {
+	if !workspaceNamePattern.MatchString(name) || name == defaultWorkspace {
+		return fmt.Errorf("invalid workspace name %q (use lowercase letters, digits, - and _)", name)
+	}
+	if workspaceExists(name) {
+		return errs.WithHint(errs.Conflict, "pick another name, or switch to it with efx-skills workspace use "+name, "workspace %s already exists", name)
+	}
+	if storePath == "" {
+		storePath = workspaceStorePath(name)
+	}
+	storePath = expandHome(storePath)
+	if err := os.MkdirAll(storePath, 0755); err != nil {
+		return err
+	}
+
+	prev := activeWorkspace
+	activeWorkspace = name
+	defer func() { activeWorkspace = prev }()
+	return saveConfigData(&ConfigData{
+		Registries: defaultRegistries(),
+		Repos:      defaultRepos(),
+		Providers:  []string{},
+		SkillsPath: storePath,
+	})
+}
+
+// LoadWorkspace selects the workspace for this run: flag when set, else
+// $EFX_SKILLS_WORKSPACE, else the one last chosen with `workspace use`.
+// A remembered workspace that was deleted since falls back to the default
+// one with a warning, so `workspace use` can still pick another.
+func LoadWorkspace(flag string) error {
+	name := flag
+	if name == "" {
+		name = os.Getenv(workspaceEnv)
+	}
+	if name != "" {
+		return switchWorkspace(name)
+	}
+	activeWorkspace = ""
+	data, err := os.ReadFile(currentWorkspacePath())
+	if err != nil {
+		return nil
+	}
+	if name = strings.TrimSpace(string(data)); name == "" {
+		return nil
+	}
+	if err := switchWorkspace(name); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: workspace %s no longer exists, using %s (pick another with: efx-skills workspace use <name>)\n",
+			name, defaultWorkspace)
+	}
+	return nil
+}
+
+// workspaceSkillsPath returns the store of a workspace, as getSkillsPath
+// does for the active one.
+func workspaceSkillsPath(name string) string {
+	var cfg struct {
+		SkillsPath string `json:"skills-path"`
+	}
+	if data, err := os.ReadFile(workspaceConfigPath(name)); err == nil {
+		if json.Unmarshal(data, &cfg) == nil && cfg.SkillsPath != "" {
+			return cfg.SkillsPath
+		}
+	}
+	return workspaceStorePath(name)
+}
+
+// otherWorkspaceStores returns the stores of every workspace but the
+// active one. Their links in shared provider folders are not ours to prune.
+func otherWorkspaceStores() []string {
+	var stores []string
+	current := getSkillsPath()
+	for _, name := range listWorkspaces() {
+		if path := workspaceSkillsPath(name); path != current {
+			stores = append(stores, path)
+		}
+	}
+	return stores
+}
+
+// linksIntoAny reports whether linkPath is a symlink into one of stores.
+func linksIntoAny(linkPath string, stores []string) bool {
+	dest, err := os.Readlink(linkPath)
+	if err != nil {
+		return false
+	}
+	if !filepath.IsAbs(dest) {
+		dest = filepath.Join(filepath.Dir(linkPath), dest)
+	}
+	dest = filepath.Clean(dest)
+	for _, store := range stores {
+		if strings.HasPrefix(dest, filepath.Clean(store)+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// displayPath shortens paths below $HOME to "~/...".
+func displayPath(path string) string {
+	home := os.Getenv("HOME")
+	if home != "" && strings.HasPrefix(path, home+string(filepath.Separator)) {
+		return "~" + path[len(home):]
+	}
+	return path
+}
+
+// updateWorkspacePicker handles keys while the status view lists workspaces.
+func (m statusModel) updateWorkspacePicker(msg tea.KeyMsg) (statusModel, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.spaceIdx > 0 {
+			m.spaceIdx--
+		}
+	case "down", "j":
+		if m.spaceIdx < len(m.workspaces)-1 {
+			m.spaceIdx++
+		}
+	case "enter":
+		m.pickingSpace = false
+		if err := useWorkspace(m.workspaces[m.spaceIdx]); err != nil {
+			m.err = err
+			return m, nil
+		}
+		LoadIgnoreRules()
+		m.loading = true
+		return m, loadProviders
+	case "esc", "q", "W":
+		m.pickingSpace = false
+	}
+	return m, nil
+}
+
+// workspacePickerView lists the workspaces with their stores.
+func (m statusModel) workspacePickerView() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(subtitleStyle.Render("Switch Workspace"))
+	b.WriteString("\n\n")
+	for i, name := range m.workspaces {
+		line := padRight(name, 20) + " " + displayPath(workspaceSkillsPath(name))
+		if name == workspaceName() {
+			line += "  (current)"
+		}
+		if i == m.spaceIdx {
+			b.WriteString(getSelectedRowStyle(m.width).Render("  " + line))
+		} else {
+			b.WriteString(tableRowStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(statusMutedStyle.Render("  Create more with: efx-skills workspace create <name>"))
+	b.WriteString("\n")
+	b.WriteString(renderHelpBar(m.width, []string{"[↑/↓] select", "[enter] switch", "[esc] cancel"}))
+	return b.String()
+}
+
+// RunWorkspaceList prints the workspaces, marking the active one.
+func RunWorkspaceList() error {
+	for _, name := range listWorkspaces() {
+		marker := " "
+		if name == workspaceName() {
+			marker = "*"
+		}
+		fmt.Printf("%s %s %s\n", marker, padRight(name, 20), statusMutedStyle.Render(workspaceSkillsPath(name)))
+	}
+	return nil
+}
+
+// RunWorkspaceCreate creates a workspace, optionally with its store at
+// storePath.
+func RunWorkspaceCreate(name, storePath string) error {
+	if err := createWorkspace(name, storePath); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Created workspace %s\n", name)
+	fmt.Printf("  Switch to it with: efx-skills workspace use %s\n", name)
+	return nil
+}
+
+// RunWorkspaceUse makes name the workspace used by later runs.
+func RunWorkspaceUse(name string) error {
+	if err := useWorkspace(name); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Using workspace %s (store: %s)\n", workspaceName(), getSkillsPath())
+	return nil
+}
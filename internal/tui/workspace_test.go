@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lmarques/efx-skills/internal/skill"
+)
+
+func useTestWorkspace(t *testing.T) string {
+	t.Helper()
+	home := setTestHome(t)
+	t.Setenv(workspaceEnv, "")
+	t.Cleanup(func() { activeWorkspace = "" })
+	return home
+}
+
+func TestWorkspacesKeepSeparateConfigAndStore(t *testing.T) {
+	home := useTestWorkspace(t)
+	if err := saveConfigData(&ConfigData{Providers: []string{"claude"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := createWorkspace("work", ""); err != nil {
+		t.Fatalf("createWorkspace error: %v", err)
+	}
+	if err := createWorkspace("work", ""); err == nil {
+		t.Error("expected an error creating an existing workspace")
+	}
+	if err := createWorkspace("Bad Name", ""); err == nil {
+		t.Error("expected an error for an invalid name")
+	}
+
+	if err := LoadWorkspace("work"); err != nil {
+		t.Fatalf("LoadWorkspace error: %v", err)
+	}
+	wantStore := filepath.Join(home, ".agents", "workspaces", "work", "skills")
+	if got := getSkillsPath(); got != wantStore {
+		t.Errorf("store = %q, want %q", got, wantStore)
+	}
+	store := skill.NewStore(getSkillsPath())
+	if want := filepath.Join(home, ".agents", "workspaces", "work", ".skill-lock.json"); store.LockFile != want {
+		t.Errorf("lock file = %q, want %q", store.LockFile, want)
+	}
+	if cfg := loadConfigFromFile(); cfg == nil || len(cfg.Providers) != 0 {
+		t.Errorf("work config = %+v, want no enabled providers", cfg)
+	}
+
+	if err := LoadWorkspace("default"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := loadConfigFromFile(); cfg == nil || len(cfg.Providers) != 1 {
+		t.Errorf("default config = %+v, want claude enabled", cfg)
+	}
+}
+
+func TestLoadWorkspaceRemembersLastChoice(t *testing.T) {
+	useTestWorkspace(t)
+	if err := createWorkspace("personal", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := useWorkspace("personal"); err != nil {
+		t.Fatal(err)
+	}
+	activeWorkspace = ""
+
+	if err := LoadWorkspace(""); err != nil || workspaceName() != "personal" {
+		t.Errorf("LoadWorkspace = %v, workspace %s; want personal", err, workspaceName())
+	}
+	if err := LoadWorkspace("missing"); err == nil {
+		t.Error("expected an error for an unknown workspace")
+	}
+}
+
+func TestLoadWorkspaceFallsBackFromDeletedChoice(t *testing.T) {
+	useTestWorkspace(t)
+	if err := createWorkspace("personal", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := useWorkspace("personal"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(workspaceDir("personal")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadWorkspace(""); err != nil || workspaceName() != defaultWorkspace {
+		t.Errorf("LoadWorkspace = %v, workspace %s; want the default one", err, workspaceName())
+	}
+}
+
+func TestPruneKeepsLinksOfOtherWorkspaces(t *testing.T) {
+	home := useTestWorkspace(t)
+	if err := createWorkspace("work", ""); err != nil {
+		t.Fatal(err)
+	}
+	workStore := skill.NewStore(workspaceSkillsPath("work"))
+	os.MkdirAll(filepath.Join(workStore.BaseDir, "client-skill"), 0755)
+	os.WriteFile(filepath.Join(workStore.BaseDir, "client-skill", "SKILL.md"), []byte("x"), 0644)
+
+	claude := Provider{Name: "claude", Path: filepath.Join(home, ".claude", "skills"), Configured: true}
+	if err := workStore.LinkToProvider("client-skill", claude.Path); err != nil {
+		t.Fatal(err)
+	}
+
+	// From the default workspace the link is not ours, but not unmanaged either
+	store := skill.NewStore(getSkillsPath())
+	if got := findPruneCandidates(store, []Provider{claude}); len(got) != 0 {
+		t.Errorf("prune candidates = %+v, want none", got)
+	}
+}
+
+func TestStatusWorkspacePicker(t *testing.T) {
+	useTestWorkspace(t)
+	if err := createWorkspace("work", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	m := statusModel{}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	if !m.pickingSpace || len(m.workspaces) != 2 {
+		t.Fatalf("picker = %v with %v, want default and work", m.pickingSpace, m.workspaces)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if workspaceName() != "work" || cmd == nil || m.pickingSpace {
+		t.Errorf("after enter workspace = %s, want work with providers reloading", workspaceName())
+	}
+}